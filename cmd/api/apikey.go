@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/apikey"
+)
+
+const apiKeyUsage = `usage:
+  apikey issue -name <name> -scopes <scope>[,<scope>...]
+  apikey list
+  apikey revoke <id>`
+
+// runAPIKeyCommand manages the API keys from the command line, as in
+//
+//	server apikey issue -name partner -scopes markets:read,markets:write
+//
+// The key issued is written to out only, it can not be shown again.
+func runAPIKeyCommand(ctx context.Context, manager *apikey.APIKeyManager, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(apiKeyUsage)
+	}
+
+	switch args[0] {
+	case "issue":
+		return issueAPIKey(ctx, manager, args[1:], out)
+	case "list":
+		return listAPIKeys(ctx, manager, out)
+	case "revoke":
+		if len(args) != 2 {
+			return errors.New(apiKeyUsage)
+		}
+		if err := manager.Revoke(ctx, domain.APIKeyID(args[1])); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "API key %s revoked\n", args[1])
+		return nil
+	default:
+		return errors.New(apiKeyUsage)
+	}
+}
+
+func issueAPIKey(ctx context.Context, manager *apikey.APIKeyManager, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("issue", flag.ContinueOnError)
+	name := fs.String("name", "", "who the key is for")
+	scopes := fs.String("scopes", "", "comma separated scopes of the key")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	inp := domain.APIKeyInput{Name: *name}
+	for _, s := range strings.Split(*scopes, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			inp.Scopes = append(inp.Scopes, domain.Scope(s))
+		}
+	}
+
+	k, token, err := manager.Issue(ctx, inp)
+	if err != nil {
+		return commandError(err)
+	}
+
+	fmt.Fprintf(out, "id:  %s\nkey: %s\n\nSend the key in the X-API-Key header. It is not shown again.\n", k.ID, token)
+
+	return nil
+}
+
+func listAPIKeys(ctx context.Context, manager *apikey.APIKeyManager, out io.Writer) error {
+	ks, err := manager.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSCOPES\tCREATED\tREVOKED")
+	for _, k := range ks {
+		scopes := make([]string, 0, len(k.Scopes))
+		for _, s := range k.Scopes {
+			scopes = append(scopes, string(s))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, strings.Join(scopes, ","), when(k.CreatedAt), when(k.RevokedAt))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// commandError lists the invalid fields of err, which the message of input
+// errors leaves out.
+func commandError(err *domain.Error) error {
+	if len(err.Fields) == 0 {
+		return err
+	}
+
+	msgs := []string{}
+	for _, f := range err.Fields {
+		msgs = append(msgs, f.Msg)
+	}
+
+	return fmt.Errorf("%w: %s", err, strings.Join(msgs, "; "))
+}
+
+func when(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -1,21 +1,47 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
 
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/graphqlhandler"
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/grpcapi/streetmarketv1"
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/grpchandler"
 	"github.com/Danielsilveira98/unicoAPITest/internal/app/httphandler"
 	"github.com/Danielsilveira98/unicoAPITest/internal/app/middleware"
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/openapi"
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/router"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/apikey"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/changefeed"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/ical"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/jwt"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/loadshed"
 	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/logger"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/outbox"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/ratelimit"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/reference"
 	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/repository"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/schedule"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/snapshot"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/stall"
 	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/streetmarket"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/webhook"
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
 	"github.com/pressly/goose"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -30,6 +56,16 @@ func main() {
 		panic(err)
 	}
 
+	apiKeyRepository := repository.NewAPIKeyRepository(db)
+	apiKeyManager := apikey.NewManager(apiKeyRepository, uuid.NewString, apikey.RandomSecret)
+
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if err := runAPIKeyCommand(context.Background(), apiKeyManager, os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ioWriter, err := os.OpenFile(os.Getenv("LOG_FILE_PATH"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
 	if err != nil {
 		panic(err)
@@ -37,35 +73,438 @@ func main() {
 
 	logger := logger.NewLogger(ioWriter, true)
 
+	scheduleLoc, err := time.LoadLocation(domain.ScheduleTimeZone)
+	if err != nil {
+		panic(err)
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	tokenVerifier, err := setupTokenVerifier()
+	if err != nil {
+		panic(err)
+	}
+
+	corsConfig, err := setupCORS()
+	if err != nil {
+		panic(err)
+	}
+
+	ipLimit, err := ratelimit.ParseLimit(os.Getenv("RATE_LIMIT_IP"))
+	if err != nil {
+		panic(err)
+	}
+
+	readLimit, err := ratelimit.ParseLimit(os.Getenv("RATE_LIMIT_READ"))
+	if err != nil {
+		panic(err)
+	}
+
+	writeLimit, err := ratelimit.ParseLimit(os.Getenv("RATE_LIMIT_WRITE"))
+	if err != nil {
+		panic(err)
+	}
+
+	webhookMaxAttempts, err := strconv.Atoi(os.Getenv("WEBHOOK_MAX_ATTEMPTS"))
+	if err != nil {
+		panic(err)
+	}
+
+	outboxMaxAttempts, err := strconv.Atoi(os.Getenv("OUTBOX_MAX_ATTEMPTS"))
+	if err != nil {
+		panic(err)
+	}
+
 	streetMarketRepository := repository.NewStreetMarketRepository(db)
+	snapshotRepository := repository.NewSnapshotRepository(db)
+	scheduleRepository := repository.NewScheduleRepository(db)
+	stallRepository := repository.NewStallRepository(db)
+	referenceRepository := repository.NewReferenceRepository(db)
+	webhookRepository := repository.NewWebhookRepository(db)
+	outboxRepository := repository.NewOutboxRepository(db)
+
+	changeListener, err := repository.NewChangeListener(connInfo())
+	if err != nil {
+		panic(err)
+	}
 
-	writer := streetmarket.NewWriter(streetMarketRepository, uuid.NewString)
-	eraser := streetmarket.NewEraser(streetMarketRepository)
-	reader := streetmarket.NewReader(streetMarketRepository)
+	webhookPublisher := webhook.NewPublisher(webhookRepository)
+	outboxRelay := outbox.NewRelay(
+		outboxRepository,
+		outboxPublishers(webhookPublisher),
+		outbox.Retry{MaxAttempts: outboxMaxAttempts, Backoff: 5 * time.Second, MaxBackoff: 10 * time.Minute},
+		time.Now,
+		logger,
+	)
+	webhookManager := webhook.NewManager(webhookRepository, uuid.NewString)
+	webhookDispatcher := webhook.NewDispatcher(
+		webhookRepository,
+		&http.Client{Timeout: 10 * time.Second},
+		webhook.Retry{MaxAttempts: webhookMaxAttempts, Backoff: 30 * time.Second, MaxBackoff: 6 * time.Hour},
+		time.Now,
+		logger,
+	)
+	writer := streetmarket.NewWriter(streetMarketRepository, referenceRepository, uuid.NewString)
+	eraser := streetmarket.NewEraser(streetMarketRepository, uuid.NewString)
+	changeFeed := changefeed.NewFeed(outboxRepository, logger)
+	reader := streetmarket.NewReader(streetMarketRepository, scheduleLoc)
+	syncer := streetmarket.NewSyncer(streetMarketRepository)
+	counter := streetmarket.NewCounter(streetMarketRepository, snapshotRepository, scheduleLoc)
+	scheduleReader := schedule.NewReader(scheduleRepository)
+	scheduleWriter := schedule.NewWriter(scheduleRepository, streetMarketRepository)
+	calendarReader := schedule.NewCalendarReader(streetMarketRepository, scheduleRepository)
+	calendarEncoder := ical.NewEncoder(scheduleLoc, time.Now)
+	differ := snapshot.NewDiffer(snapshotRepository)
+	stallReader := stall.NewReader(stallRepository)
+	stallWriter := stall.NewWriter(stallRepository, streetMarketRepository, uuid.NewString)
+	stallEraser := stall.NewEraser(stallRepository, streetMarketRepository)
+	referenceReader := reference.NewReader(referenceRepository)
+	apiKeyAuthenticator := apikey.NewAuthenticator(apiKeyRepository)
+	ipLimiter := ratelimit.NewLimiter(ipLimit, time.Now)
+	readLimiter := ratelimit.NewLimiter(readLimit, time.Now)
+	writeLimiter := ratelimit.NewLimiter(writeLimit, time.Now)
+	// The limit starts well under the connections of the pool and backs off when
+	// queries take long to be served.
+	loadShedLimiter := loadshed.NewLimiter(loadshed.Config{
+		InitialLimit:  20,
+		MinLimit:      5,
+		MaxLimit:      100,
+		TargetLatency: 500 * time.Millisecond,
+		Backoff:       0.9,
+		MaxQueue:      50,
+		MaxWait:       100 * time.Millisecond,
+	}, time.Now)
 
 	pingHandler := httphandler.NewPingHandler()
+	metricsHandler := httphandler.NewMetricsHandler(loadShedLimiter)
+	openAPIHandler := httphandler.NewOpenAPIHandler(openapi.Document())
+	graphQLHandler := graphqlhandler.NewGraphQLHandler(
+		graphqlhandler.NewResolver(reader, writer, eraser, referenceReader),
+		graphqlhandler.Limits{Depth: 6, Complexity: 3000},
+		logger,
+	)
 	streetMarketEditHandler := httphandler.NewStreetMarketEditHandler(writer, logger)
 	streetMarketCreateHandler := httphandler.NewStreetMarketCreateHandler(writer, logger)
 	streetMarketDeleteHandler := httphandler.NewStreetMarketDeleteHandler(eraser, logger)
 	streetMarketListHandler := httphandler.NewStreetMarketListHandler(reader, logger)
+	streetMarketStatsHandler := httphandler.NewStreetMarketStatsHandler(counter, logger)
+	streetMarketSyncHandler := httphandler.NewStreetMarketSyncHandler(syncer, logger)
+	streetMarketChangesHandler := httphandler.NewStreetMarketChangesHandler(changeFeed, 15*time.Second, logger)
+	snapshotDiffHandler := httphandler.NewSnapshotDiffHandler(differ, logger)
+	scheduleGetHandler := httphandler.NewStreetMarketScheduleGetHandler(scheduleReader, logger)
+	scheduleReplaceHandler := httphandler.NewStreetMarketScheduleReplaceHandler(scheduleWriter, logger)
+	calendarHandler := httphandler.NewStreetMarketCalendarHandler(calendarReader, calendarEncoder, logger)
+	calendarListHandler := httphandler.NewStreetMarketCalendarListHandler(calendarReader, calendarEncoder, logger)
+	stallListHandler := httphandler.NewStallListHandler(stallReader, logger)
+	stallGetHandler := httphandler.NewStallGetHandler(stallReader, logger)
+	stallCreateHandler := httphandler.NewStallCreateHandler(stallWriter, logger)
+	stallEditHandler := httphandler.NewStallEditHandler(stallWriter, logger)
+	stallDeleteHandler := httphandler.NewStallDeleteHandler(stallEraser, logger)
+	districtListHandler := httphandler.NewDistrictListHandler(referenceReader, logger)
+	subTownHallListHandler := httphandler.NewSubTownHallListHandler(referenceReader, logger)
+	subTownHallDistrictListHandler := httphandler.NewSubTownHallDistrictListHandler(referenceReader, logger)
+	regionListHandler := httphandler.NewRegionListHandler(referenceReader, logger)
+	webhookCreateHandler := httphandler.NewWebhookCreateHandler(webhookManager, logger)
+	webhookListHandler := httphandler.NewWebhookListHandler(webhookManager, logger)
+	webhookGetHandler := httphandler.NewWebhookGetHandler(webhookManager, logger)
+	webhookDeleteHandler := httphandler.NewWebhookDeleteHandler(webhookManager, logger)
+	webhookDeliveryListHandler := httphandler.NewWebhookDeliveryListHandler(webhookManager, logger)
 
 	tcIdMidd := middleware.NewTraceIDMiddleware(uuid.NewString)
 	logReqMidd := middleware.NewLogRequestMiddleware(logger)
+	apiKeyMidd := middleware.NewAPIKeyMiddleware(apiKeyAuthenticator, httphandler.RespondError, logger)
+	accessMidd := middleware.NewAccessMiddleware(httphandler.RespondError)
+	ipRateLimitMidd := middleware.NewIPRateLimitMiddleware(ipLimiter, httphandler.RespondError)
+	rateLimitMidd := middleware.NewRateLimitMiddleware(readLimiter, writeLimiter, httphandler.RespondError)
+	loadShedMidd := middleware.NewLoadShedMiddleware(loadShedLimiter, httphandler.RespondError)
 
 	r := mux.NewRouter()
 	r.Use(tcIdMidd.Middleware())
+	r.Use(ipRateLimitMidd.Middleware())
+	r.Use(apiKeyMidd.Middleware())
+	if tokenVerifier != nil {
+		r.Use(middleware.NewBearerTokenMiddleware(tokenVerifier, httphandler.RespondError).Middleware())
+	}
 	r.Use(logReqMidd.Middleware())
+	r.Use(rateLimitMidd.Middleware())
+	if os.Getenv("VALIDATE_REQUESTS") == "true" {
+		validationMidd := middleware.NewRequestValidationMiddleware(spec, httphandler.RespondError, httphandler.MaxBodyBytes)
+		r.Use(validationMidd.Middleware())
+	}
+	// Router middlewares only run on matched routes.
+	r.NotFoundHandler = tcIdMidd.Middleware()(http.HandlerFunc(httphandler.NotFound))
+	r.MethodNotAllowedHandler = tcIdMidd.Middleware()(http.HandlerFunc(httphandler.MethodNotAllowed))
 	r.HandleFunc("/ping", pingHandler.Handle).Methods(http.MethodGet)
-	r.HandleFunc("/street_market", streetMarketListHandler.Handle).Methods(http.MethodGet)
-	r.HandleFunc("/street_market", streetMarketCreateHandler.Handle).Methods(http.MethodPost)
-	r.HandleFunc("/street_market/{street-market-id}", streetMarketDeleteHandler.Handle).Methods(http.MethodDelete)
-	r.HandleFunc("/street_market/{street-market-id}", streetMarketEditHandler.Handle).Methods(http.MethodPatch)
+	r.HandleFunc("/metrics", metricsHandler.Handle).Methods(http.MethodGet)
+	r.HandleFunc("/openapi.json", openAPIHandler.Handle).Methods(http.MethodGet)
+	// Mutations check the scopes and roles they need themselves.
+	r.Handle(
+		"/graphql",
+		accessMidd.Require(domain.ScopeMarketsRead, domain.RoleViewer)(http.HandlerFunc(graphQLHandler.Handle)),
+	).Methods(http.MethodGet, http.MethodPost)
+
+	// The street market routes query the database, all but the stream of the
+	// changes, which holds its connection open.
+	v1 := router.Version{Prefix: "/v1", Guard: accessMidd.Require, Shed: loadShedMidd.Middleware(), Routes: []router.Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Shed:    true,
+			Handler: streetMarketListHandler.Handle,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/street_market",
+			Scope:   domain.ScopeMarketsWrite,
+			Role:    domain.RoleEditor,
+			Shed:    true,
+			Handler: streetMarketCreateHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/stats",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Shed:    true,
+			Handler: streetMarketStatsHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/changes",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Handler: streetMarketChangesHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/sync",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Shed:    true,
+			Handler: streetMarketSyncHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/calendar.ics",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Shed:    true,
+			Handler: calendarListHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/{street-market-id}/calendar.ics",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Shed:    true,
+			Handler: calendarHandler.Handle,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/street_market/{street-market-id}",
+			Scope:   domain.ScopeMarketsDelete,
+			Role:    domain.RoleAdmin,
+			Shed:    true,
+			Handler: streetMarketDeleteHandler.Handle,
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/street_market/{street-market-id}",
+			Scope:   domain.ScopeMarketsWrite,
+			Role:    domain.RoleEditor,
+			Shed:    true,
+			Handler: streetMarketEditHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/{street-market-id}/schedule",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Shed:    true,
+			Handler: scheduleGetHandler.Handle,
+		},
+		{
+			Method:  http.MethodPut,
+			Path:    "/street_market/{street-market-id}/schedule",
+			Scope:   domain.ScopeMarketsWrite,
+			Role:    domain.RoleEditor,
+			Shed:    true,
+			Handler: scheduleReplaceHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/{street-market-id}/stalls",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Shed:    true,
+			Handler: stallListHandler.Handle,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/street_market/{street-market-id}/stalls",
+			Scope:   domain.ScopeMarketsWrite,
+			Role:    domain.RoleEditor,
+			Shed:    true,
+			Handler: stallCreateHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/{street-market-id}/stalls/{stall-id}",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Shed:    true,
+			Handler: stallGetHandler.Handle,
+		},
+		{
+			Method:  http.MethodPatch,
+			Path:    "/street_market/{street-market-id}/stalls/{stall-id}",
+			Scope:   domain.ScopeMarketsWrite,
+			Role:    domain.RoleEditor,
+			Shed:    true,
+			Handler: stallEditHandler.Handle,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/street_market/{street-market-id}/stalls/{stall-id}",
+			Scope:   domain.ScopeMarketsDelete,
+			Role:    domain.RoleAdmin,
+			Shed:    true,
+			Handler: stallDeleteHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/snapshots/diff",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Handler: snapshotDiffHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/districts",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Handler: districtListHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/subtownhalls",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Handler: subTownHallListHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/subtownhalls/{subtownhall-id}/districts",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Handler: subTownHallDistrictListHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/regions",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Handler: regionListHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/webhooks",
+			Scope:   domain.ScopeWebhooks,
+			Role:    domain.RoleAdmin,
+			Handler: webhookListHandler.Handle,
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/webhooks",
+			Scope:   domain.ScopeWebhooks,
+			Role:    domain.RoleAdmin,
+			Handler: webhookCreateHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/webhooks/{webhook-id}",
+			Scope:   domain.ScopeWebhooks,
+			Role:    domain.RoleAdmin,
+			Handler: webhookGetHandler.Handle,
+		},
+		{
+			Method:  http.MethodDelete,
+			Path:    "/webhooks/{webhook-id}",
+			Scope:   domain.ScopeWebhooks,
+			Role:    domain.RoleAdmin,
+			Handler: webhookDeleteHandler.Handle,
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/webhooks/{webhook-id}/deliveries",
+			Scope:   domain.ScopeWebhooks,
+			Role:    domain.RoleAdmin,
+			Handler: webhookDeliveryListHandler.Handle,
+		},
+	}}
+	router.Mount(r, v1)
 
-	log.Fatal(http.ListenAndServe(":8000", r))
+	// The unversioned paths were the only ones before /v1 and are kept as its
+	// aliases until the sunset.
+	aliasMidd := middleware.NewDeprecatedAliasMiddleware(
+		v1.Prefix,
+		v1.Roots(),
+		time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC),
+	)
+
+	// The gRPC API takes the same credentials as the HTTP one and guards its
+	// methods as the routes of the same operations.
+	tcIDIntc := grpchandler.NewTraceIDInterceptor(uuid.NewString)
+	apiKeyIntc := grpchandler.NewAPIKeyInterceptor(apiKeyAuthenticator, logger)
+	accessIntc := grpchandler.NewAccessInterceptor(grpchandler.StreetMarketServiceAccess())
+	unaryIntcs := []grpc.UnaryServerInterceptor{tcIDIntc.Unary(), apiKeyIntc.Unary()}
+	streamIntcs := []grpc.StreamServerInterceptor{tcIDIntc.Stream(), apiKeyIntc.Stream()}
+	if tokenVerifier != nil {
+		bearerIntc := grpchandler.NewBearerTokenInterceptor(tokenVerifier)
+		unaryIntcs = append(unaryIntcs, bearerIntc.Unary())
+		streamIntcs = append(streamIntcs, bearerIntc.Stream())
+	}
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(append(unaryIntcs, accessIntc.Unary())...),
+		grpc.ChainStreamInterceptor(append(streamIntcs, accessIntc.Stream())...),
+	)
+	streetmarketv1.RegisterStreetMarketServiceServer(
+		grpcServer,
+		grpchandler.NewStreetMarketServer(reader, writer, eraser, logger),
+	)
+
+	changeIDs := make(chan string)
+	go changeListener.Changes(context.Background(), changeIDs)
+	go changeFeed.Run(context.Background(), changeIDs)
+	go outboxRelay.Run(context.Background(), time.Second)
+	go webhookDispatcher.Run(context.Background(), 5*time.Second)
+	go ipLimiter.Run(context.Background(), time.Minute)
+	go readLimiter.Run(context.Background(), time.Minute)
+	go writeLimiter.Run(context.Background(), time.Minute)
+
+	lis, err := net.Listen("tcp", ":9000")
+	if err != nil {
+		panic(err)
+	}
+	go func() {
+		log.Fatal(grpcServer.Serve(lis))
+	}()
+
+	var h http.Handler = aliasMidd.Middleware()(r)
+	if corsConfig != nil {
+		h = middleware.NewCORSMiddleware(*corsConfig).Middleware()(h)
+	}
+	log.Fatal(http.ListenAndServe(":8000", h))
 }
 
-func setupDB() (*sql.DB, error) {
-	psqlInfo := fmt.Sprintf(
+func connInfo() string {
+	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		os.Getenv("DB_HOST"),
 		os.Getenv("DB_PORT"),
@@ -73,14 +512,94 @@ func setupDB() (*sql.DB, error) {
 		os.Getenv("DB_PASS"),
 		os.Getenv("DB_NAME"),
 	)
+}
 
-	db, err := sql.Open(os.Getenv("DB_DIALECT"), psqlInfo)
+func setupDB() (*sql.DB, error) {
+	db, err := sql.Open(os.Getenv("DB_DIALECT"), connInfo())
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
 	}
 	return db, nil
 }
 
+// setupTokenVerifier verifies the tokens of the SSO against the keys of the
+// JWKS file at JWKS_PATH. Bearer tokens are not accepted when it is not set.
+func setupTokenVerifier() (*jwt.Verifier, error) {
+	path := os.Getenv("JWKS_PATH")
+	if path == "" {
+		return nil, nil
+	}
+
+	keys, err := jwt.LoadKeySet(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	roles, err := jwt.ParseRoles(os.Getenv("JWT_ROLES"))
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	audience := os.Getenv("JWT_AUDIENCE")
+	if audience == "" {
+		return nil, errors.New("JWT_AUDIENCE is required with JWKS_PATH")
+	}
+
+	return jwt.NewVerifier(keys, audience, roles, time.Now), nil
+}
+
+// setupCORS reads the CORS configuration, nil when CORS_ALLOWED_ORIGINS is empty
+// and browsers on other origins are not allowed.
+func setupCORS() (*middleware.CORSConfig, error) {
+	origins := splitList(os.Getenv("CORS_ALLOWED_ORIGINS"))
+	if len(origins) == 0 {
+		return nil, nil
+	}
+
+	cfg := middleware.CORSConfig{
+		AllowedOrigins: origins,
+		AllowedMethods: splitList(os.Getenv("CORS_ALLOWED_METHODS")),
+		AllowedHeaders: splitList(os.Getenv("CORS_ALLOWED_HEADERS")),
+		ExposedHeaders: splitList(os.Getenv("CORS_EXPOSED_HEADERS")),
+	}
+
+	if v := os.Getenv("CORS_ALLOW_CREDENTIALS"); v != "" {
+		credentials, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS_ALLOW_CREDENTIALS: %w", err)
+		}
+		cfg.AllowCredentials = credentials
+	}
+
+	for _, o := range origins {
+		if o == middleware.AnyOrigin && cfg.AllowCredentials {
+			return nil, errors.New("CORS_ALLOW_CREDENTIALS requires the origins to be listed, not *")
+		}
+	}
+
+	if v := os.Getenv("CORS_MAX_AGE"); v != "" {
+		maxAge, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS_MAX_AGE: %w", err)
+		}
+		cfg.MaxAge = maxAge
+	}
+
+	return &cfg, nil
+}
+
+// splitList splits a comma separated list, as in GET, POST, skipping blanks.
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+
+	return list
+}
+
 func runMigrations(db *sql.DB) error {
 	if err := goose.SetDialect(os.Getenv("DB_DIALECT")); err != nil {
 		return fmt.Errorf("%w", err)
@@ -92,3 +611,22 @@ func runMigrations(db *sql.DB) error {
 
 	return nil
 }
+
+// outboxPublishers are the webhooks plus the publisher named by
+// OUTBOX_PUBLISHER, log or http, if any.
+func outboxPublishers(webhooks outbox.Publisher) outbox.Publishers {
+	ps := outbox.Publishers{webhooks}
+
+	switch p := os.Getenv("OUTBOX_PUBLISHER"); p {
+	case "":
+	case "log":
+		ps = append(ps, outbox.NewLogPublisher(os.Stdout))
+	case "http":
+		client := &http.Client{Timeout: 10 * time.Second}
+		ps = append(ps, outbox.NewHTTPPublisher(client, os.Getenv("OUTBOX_HTTP_URL")))
+	default:
+		panic(fmt.Sprintf("unknown outbox publisher %s", p))
+	}
+
+	return ps
+}
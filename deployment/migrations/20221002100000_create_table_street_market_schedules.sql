@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists street_market_schedule (
+  streetmarketid uuid NOT NULL REFERENCES street_market (id) ON DELETE CASCADE,
+  weekday int NOT NULL,
+  startminute int NOT NULL,
+  endminute int NOT NULL
+);
+
+create index if not exists street_market_schedule_weekday_idx on street_market_schedule (weekday, streetmarketid);
+
+create table if not exists street_market_schedule_exception (
+  streetmarketid uuid NOT NULL REFERENCES street_market (id) ON DELETE CASCADE,
+  date DATE NOT NULL,
+  closed BOOLEAN NOT NULL DEFAULT FALSE,
+  startminute int NOT NULL DEFAULT 0,
+  endminute int NOT NULL DEFAULT 0,
+  note VARCHAR(250) NOT NULL DEFAULT '',
+  PRIMARY KEY (streetmarketid, date)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table street_market_schedule_exception;
+drop table street_market_schedule;
+
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists stall (
+  id uuid primary key not null,
+  streetmarketid uuid NOT NULL REFERENCES street_market (id) ON DELETE CASCADE,
+  number int NOT NULL,
+  vendorname VARCHAR(100) NOT NULL,
+  licensenumber VARCHAR(50) NOT NULL,
+  createdat TIMESTAMP NOT NULL DEFAULT NOW(),
+  UNIQUE (streetmarketid, number)
+);
+
+create table if not exists stall_category (
+  stallid uuid NOT NULL REFERENCES stall (id) ON DELETE CASCADE,
+  category VARCHAR(30) NOT NULL,
+  PRIMARY KEY (stallid, category)
+);
+
+create index if not exists stall_category_category_idx on stall_category (category, stallid);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table stall_category;
+drop table stall;
+
+-- +goose StatementEnd
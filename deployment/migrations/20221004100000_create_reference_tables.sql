@@ -0,0 +1,199 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists region5 (
+  name VARCHAR(50) primary key not null
+);
+
+create table if not exists region8 (
+  name VARCHAR(50) primary key not null,
+  region5 VARCHAR(50) NOT NULL REFERENCES region5 (name)
+);
+
+create table if not exists subtownhall (
+  id VARCHAR(50) primary key not null,
+  name VARCHAR(50) NOT NULL,
+  region8 VARCHAR(50) NOT NULL REFERENCES region8 (name)
+);
+
+create table if not exists district (
+  id VARCHAR(50) primary key not null,
+  name VARCHAR(50) NOT NULL,
+  subtownhallid VARCHAR(50) NOT NULL REFERENCES subtownhall (id)
+);
+
+create index if not exists district_subtownhallid_idx on district (subtownhallid);
+
+-- Seeded from the DEINFO_AB_FEIRASLIVRES_2014 edition.
+insert into region5 (name) values
+  ('Centro'),
+  ('Leste'),
+  ('Norte'),
+  ('Oeste'),
+  ('Sul')
+on conflict do nothing;
+
+insert into region8 (name, region5) values
+  ('Centro', 'Centro'),
+  ('Leste 1', 'Leste'),
+  ('Leste 2', 'Leste'),
+  ('Norte 1', 'Norte'),
+  ('Norte 2', 'Norte'),
+  ('Oeste', 'Oeste'),
+  ('Sul 1', 'Sul'),
+  ('Sul 2', 'Sul')
+on conflict do nothing;
+
+insert into subtownhall (id, name, region8) values
+  ('1', 'PERUS', 'Norte 1'),
+  ('2', 'PIRITUBA', 'Norte 1'),
+  ('3', 'FREGUESIA-BRASILANDIA', 'Norte 1'),
+  ('4', 'CASA VERDE-CACHOEIRINHA', 'Norte 1'),
+  ('5', 'SANTANA-TUCURUVI', 'Norte 2'),
+  ('6', 'JACANA-TREMEMBE', 'Norte 2'),
+  ('7', 'VILA MARIA-VILA GUILHERME', 'Norte 2'),
+  ('8', 'LAPA', 'Oeste'),
+  ('9', 'SE', 'Centro'),
+  ('10', 'BUTANTA', 'Oeste'),
+  ('11', 'PINHEIROS', 'Oeste'),
+  ('12', 'VILA MARIANA', 'Sul 1'),
+  ('13', 'IPIRANGA', 'Sul 1'),
+  ('14', 'SANTO AMARO', 'Sul 2'),
+  ('15', 'JABAQUARA', 'Sul 1'),
+  ('16', 'CIDADE ADEMAR', 'Sul 2'),
+  ('17', 'CAMPO LIMPO', 'Sul 2'),
+  ('18', 'M''BOI MIRIM', 'Sul 2'),
+  ('19', 'CAPELA DO SOCORRO', 'Sul 2'),
+  ('20', 'PARELHEIROS', 'Sul 2'),
+  ('21', 'PENHA', 'Leste 1'),
+  ('22', 'ERMELINO MATARAZZO', 'Leste 2'),
+  ('23', 'SAO MIGUEL', 'Leste 2'),
+  ('24', 'ITAIM PAULISTA', 'Leste 2'),
+  ('25', 'MOOCA', 'Leste 1'),
+  ('26', 'ARICANDUVA-FORMOSA-CARRAO', 'Leste 1'),
+  ('27', 'ITAQUERA', 'Leste 2'),
+  ('28', 'GUAIANASES', 'Leste 2'),
+  ('29', 'VILA PRUDENTE', 'Leste 1'),
+  ('30', 'SAO MATEUS', 'Leste 2'),
+  ('31', 'CIDADE TIRADENTES', 'Leste 2')
+on conflict do nothing;
+
+insert into district (id, name, subtownhallid) values
+  ('01', 'AGUA RASA', '25'),
+  ('02', 'ALTO DE PINHEIROS', '11'),
+  ('03', 'ANHANGUERA', '1'),
+  ('04', 'ARICANDUVA', '26'),
+  ('05', 'ARTUR ALVIM', '21'),
+  ('06', 'BARRA FUNDA', '8'),
+  ('07', 'BELA VISTA', '9'),
+  ('08', 'BELEM', '25'),
+  ('09', 'BOM RETIRO', '9'),
+  ('10', 'BRAS', '25'),
+  ('11', 'BRASILANDIA', '3'),
+  ('12', 'BUTANTA', '10'),
+  ('13', 'CACHOEIRINHA', '4'),
+  ('14', 'CAMBUCI', '9'),
+  ('15', 'CAMPO BELO', '14'),
+  ('16', 'CAMPO GRANDE', '14'),
+  ('17', 'CAMPO LIMPO', '17'),
+  ('18', 'CANGAIBA', '21'),
+  ('19', 'CAPAO REDONDO', '17'),
+  ('20', 'CARRAO', '26'),
+  ('21', 'CASA VERDE', '4'),
+  ('22', 'CIDADE ADEMAR', '16'),
+  ('23', 'CIDADE DUTRA', '19'),
+  ('24', 'CIDADE LIDER', '27'),
+  ('25', 'CIDADE TIRADENTES', '31'),
+  ('26', 'CONSOLACAO', '9'),
+  ('27', 'CURSINO', '13'),
+  ('28', 'ERMELINO MATARAZZO', '22'),
+  ('29', 'FREGUESIA DO O', '3'),
+  ('30', 'GRAJAU', '19'),
+  ('31', 'GUAIANASES', '28'),
+  ('32', 'IGUATEMI', '30'),
+  ('33', 'IPIRANGA', '13'),
+  ('34', 'ITAIM BIBI', '11'),
+  ('35', 'ITAIM PAULISTA', '24'),
+  ('36', 'ITAQUERA', '27'),
+  ('37', 'JABAQUARA', '15'),
+  ('38', 'JACANA', '6'),
+  ('39', 'JAGUARA', '8'),
+  ('40', 'JAGUARE', '8'),
+  ('41', 'JARAGUA', '2'),
+  ('42', 'JARDIM ANGELA', '18'),
+  ('43', 'JARDIM HELENA', '23'),
+  ('44', 'JARDIM PAULISTA', '11'),
+  ('45', 'JARDIM SAO LUIS', '18'),
+  ('46', 'JOSE BONIFACIO', '27'),
+  ('47', 'LAJEADO', '28'),
+  ('48', 'LAPA', '8'),
+  ('49', 'LIBERDADE', '9'),
+  ('50', 'LIMAO', '4'),
+  ('51', 'MANDAQUI', '5'),
+  ('53', 'MOEMA', '12'),
+  ('54', 'MOOCA', '25'),
+  ('55', 'MORUMBI', '10'),
+  ('56', 'PARELHEIROS', '20'),
+  ('57', 'PARI', '25'),
+  ('58', 'PARQUE DO CARMO', '27'),
+  ('59', 'PEDREIRA', '16'),
+  ('60', 'PENHA', '21'),
+  ('61', 'PERDIZES', '8'),
+  ('62', 'PERUS', '1'),
+  ('63', 'PINHEIROS', '11'),
+  ('64', 'PIRITUBA', '2'),
+  ('65', 'PONTE RASA', '22'),
+  ('66', 'RAPOSO TAVARES', '10'),
+  ('67', 'REPUBLICA', '9'),
+  ('68', 'RIO PEQUENO', '10'),
+  ('69', 'SACOMA', '13'),
+  ('70', 'SANTA CECILIA', '9'),
+  ('71', 'SANTANA', '5'),
+  ('72', 'SANTO AMARO', '14'),
+  ('73', 'SAO DOMINGOS', '2'),
+  ('74', 'SAO LUCAS', '29'),
+  ('75', 'SAO MATEUS', '30'),
+  ('76', 'SAO MIGUEL', '23'),
+  ('77', 'SAO RAFAEL', '30'),
+  ('78', 'SAPOPEMBA', '29'),
+  ('79', 'SAUDE', '12'),
+  ('80', 'SE', '9'),
+  ('81', 'SOCORRO', '19'),
+  ('82', 'TATUAPE', '25'),
+  ('83', 'TREMEMBE', '6'),
+  ('84', 'TUCURUVI', '5'),
+  ('85', 'VILA ANDRADE', '17'),
+  ('86', 'VILA CURUCA', '24'),
+  ('87', 'VILA FORMOSA', '26'),
+  ('88', 'VILA GUILHERME', '7'),
+  ('89', 'VILA JACUI', '23'),
+  ('90', 'VILA LEOPOLDINA', '8'),
+  ('91', 'VILA MARIA', '7'),
+  ('92', 'VILA MARIANA', '12'),
+  ('93', 'VILA MATILDE', '21'),
+  ('94', 'VILA MEDEIROS', '7'),
+  ('95', 'VILA PRUDENTE', '29'),
+  ('96', 'VILA SONIA', '10')
+on conflict do nothing;
+
+-- Rows loaded before this migration are not checked, only new writes are.
+alter table street_market
+  add constraint street_market_iddist_fkey foreign key (iddist) references district (id) not valid,
+  add constraint street_market_idsubth_fkey foreign key (idsubth) references subtownhall (id) not valid,
+  add constraint street_market_region5_fkey foreign key (region5) references region5 (name) not valid,
+  add constraint street_market_region8_fkey foreign key (region8) references region8 (name) not valid;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table street_market
+  drop constraint street_market_iddist_fkey,
+  drop constraint street_market_idsubth_fkey,
+  drop constraint street_market_region5_fkey,
+  drop constraint street_market_region8_fkey;
+
+drop table district;
+drop table subtownhall;
+drop table region8;
+drop table region5;
+
+-- +goose StatementEnd
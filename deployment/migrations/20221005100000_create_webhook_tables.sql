@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists webhook_subscription (
+  id uuid primary key not null,
+  url VARCHAR(2048) NOT NULL,
+  secret VARCHAR(250) NOT NULL,
+  events VARCHAR(30)[] NOT NULL,
+  iddist VARCHAR(50) NOT NULL DEFAULT '',
+  region5 VARCHAR(50) NOT NULL DEFAULT '',
+  createdat TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+create table if not exists webhook_delivery (
+  id uuid primary key not null,
+  subscriptionid uuid NOT NULL REFERENCES webhook_subscription (id) ON DELETE CASCADE,
+  event VARCHAR(30) NOT NULL,
+  payload jsonb NOT NULL,
+  status VARCHAR(10) NOT NULL DEFAULT 'pending',
+  attempts int NOT NULL DEFAULT 0,
+  nextattemptat TIMESTAMP NOT NULL DEFAULT NOW(),
+  createdat TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+create index if not exists webhook_delivery_due_idx on webhook_delivery (nextattemptat) where status = 'pending';
+create index if not exists webhook_delivery_subscription_idx on webhook_delivery (subscriptionid, createdat);
+
+create table if not exists webhook_delivery_attempt (
+  deliveryid uuid NOT NULL REFERENCES webhook_delivery (id) ON DELETE CASCADE,
+  number int NOT NULL,
+  statuscode int NOT NULL,
+  error TEXT NOT NULL,
+  durationms int NOT NULL,
+  attemptedat TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (deliveryid, number)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table webhook_delivery_attempt;
+drop table webhook_delivery;
+drop table webhook_subscription;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists outbox (
+  id uuid primary key not null,
+  event VARCHAR(30) NOT NULL,
+  aggregateid uuid NOT NULL,
+  before jsonb,
+  after jsonb,
+  occurredat TIMESTAMP NOT NULL DEFAULT NOW(),
+  publishedat TIMESTAMP,
+  attempts int NOT NULL DEFAULT 0,
+  nextattemptat TIMESTAMP NOT NULL DEFAULT NOW(),
+  lasterror TEXT NOT NULL DEFAULT ''
+);
+
+create index if not exists outbox_due_idx on outbox (nextattemptat) where publishedat is null;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table outbox;
+
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+create or replace function notify_street_market_change() returns trigger as $$
+begin
+  perform pg_notify('street_market_changes', NEW.id::text);
+  return NEW;
+end;
+$$ language plpgsql;
+
+create trigger outbox_notify after insert on outbox
+  for each row execute procedure notify_street_market_change();
+
+create index if not exists outbox_occurredat_idx on outbox (occurredat, id);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists outbox_occurredat_idx;
+drop trigger if exists outbox_notify on outbox;
+drop function if exists notify_street_market_change();
+
+-- +goose StatementEnd
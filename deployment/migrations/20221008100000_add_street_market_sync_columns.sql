@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table street_market
+  add column updatedat TIMESTAMP,
+  add column deletedat TIMESTAMP,
+  add column changetxid bigint NOT NULL DEFAULT txid_current();
+
+update street_market set updatedat = createdat;
+
+alter table street_market
+  alter column updatedat set NOT NULL,
+  alter column updatedat set DEFAULT NOW();
+
+create or replace function touch_street_market() returns trigger as $$
+begin
+  NEW.updatedat := NOW();
+  NEW.changetxid := txid_current();
+  return NEW;
+end;
+$$ language plpgsql;
+
+create trigger street_market_touch before update on street_market
+  for each row execute procedure touch_street_market();
+
+create index if not exists street_market_changetxid_idx on street_market (changetxid);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists street_market_changetxid_idx;
+drop trigger if exists street_market_touch on street_market;
+drop function if exists touch_street_market();
+
+delete from street_market where deletedat is not null;
+
+alter table street_market
+  drop column changetxid,
+  drop column deletedat,
+  drop column updatedat;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists api_key (
+  id uuid primary key not null,
+  name VARCHAR(100) NOT NULL,
+  keyhash CHAR(64) NOT NULL,
+  scopes VARCHAR(30)[] NOT NULL,
+  createdat TIMESTAMP NOT NULL DEFAULT NOW(),
+  revokedat TIMESTAMP
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop table api_key;
+
+-- +goose StatementEnd
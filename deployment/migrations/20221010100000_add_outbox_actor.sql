@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table outbox
+  add column actor VARCHAR(255) NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table outbox
+  drop column actor;
+
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+create index if not exists outbox_pending_aggregate_idx on outbox (aggregateid, occurredat, id) where publishedat is null;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists outbox_pending_aggregate_idx;
+
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+create index if not exists street_market_changetxid_id_idx on street_market (changetxid, id);
+drop index if exists street_market_changetxid_idx;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+create index if not exists street_market_changetxid_idx on street_market (changetxid);
+drop index if exists street_market_changetxid_id_idx;
+
+-- +goose StatementEnd
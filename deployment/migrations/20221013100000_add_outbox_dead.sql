@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table outbox
+  add column deadat TIMESTAMP;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+alter table outbox
+  drop column deadat;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+delete from street_market_snapshot s
+  using street_market_snapshot d
+  where s.edition = d.edition and s.register = d.register and s.ctid > d.ctid;
+
+drop index if exists street_market_snapshot_edition_idx;
+
+create unique index if not exists street_market_snapshot_edition_register_key on street_market_snapshot (edition, register);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists street_market_snapshot_edition_register_key;
+
+create index if not exists street_market_snapshot_edition_idx on street_market_snapshot (edition, register);
+
+-- +goose StatementEnd
@@ -0,0 +1,70 @@
+package graphqlhandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+// DepthLimit rejects operations nesting selections deeper than Max, before
+// they run. Introspection is left out, as the queries of the GraphQL tools are
+// deep but cheap.
+type DepthLimit struct {
+	Max int
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+} = DepthLimit{}
+
+func (DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (DepthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if depth := selectionDepth(rc.Operation.SelectionSet); depth > d.Max {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.Max)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+
+	return nil
+}
+
+// selectionDepth is the depth of the deepest field of set. Fragments add no
+// depth of their own, and fragment cycles are rejected by the validation that
+// runs before.
+func selectionDepth(set ast.SelectionSet) int {
+	max := 0
+	for _, s := range set {
+		d := 0
+		switch s := s.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			d = 1 + selectionDepth(s.SelectionSet)
+		case *ast.InlineFragment:
+			d = selectionDepth(s.SelectionSet)
+		case *ast.FragmentSpread:
+			d = selectionDepth(s.Definition.SelectionSet)
+		}
+
+		if d > max {
+			max = d
+		}
+	}
+
+	return max
+}
@@ -0,0 +1,153 @@
+package graphqlhandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Cost of the fields that read lists, as multipliers of the cost of their
+// selections.
+const (
+	streetMarketsPerPage    = 100
+	districtsCost           = 100
+	subTownHallsCost        = 40
+	districtsPerSubTownHall = 10
+	// District.subTownHall reads the sub-town halls.
+	subTownHallLookupCost = 10
+)
+
+// Limits bound the operations the server accepts. Depth counts nested
+// selections, as streetMarkets { district { name } } has depth 3, and
+// complexity the fields an operation may resolve.
+type Limits struct {
+	Depth      int
+	Complexity int
+}
+
+type errorLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type GraphQLHandler struct {
+	srv    *handler.Server
+	logger errorLogger
+}
+
+func NewGraphQLHandler(resolver *Resolver, limits Limits, logger errorLogger) *GraphQLHandler {
+	h := &GraphQLHandler{logger: logger}
+
+	h.srv = handler.New(NewExecutableSchema(Config{Resolvers: resolver, Complexity: complexity()}))
+	h.srv.AddTransport(transport.GET{})
+	h.srv.AddTransport(transport.POST{})
+	h.srv.SetQueryCache(lru.New(1000))
+	h.srv.Use(extension.Introspection{})
+	h.srv.Use(DepthLimit{limits.Depth})
+	h.srv.Use(extension.FixedComplexityLimit(limits.Complexity))
+	h.srv.SetErrorPresenter(h.presentError)
+
+	return h
+}
+
+func (h *GraphQLHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	h.srv.ServeHTTP(w, r)
+}
+
+func complexity() ComplexityRoot {
+	c := ComplexityRoot{}
+
+	c.Query.StreetMarkets = func(childComplexity int, _ *StreetMarketFilter, _ int) int {
+		return streetMarketsPerPage * childComplexity
+	}
+	c.Query.Districts = func(childComplexity int) int {
+		return districtsCost * childComplexity
+	}
+	c.Query.SubTownHalls = func(childComplexity int) int {
+		return subTownHallsCost * childComplexity
+	}
+	c.SubTownHall.Districts = func(childComplexity int) int {
+		return districtsPerSubTownHall * childComplexity
+	}
+	c.District.SubTownHall = func(childComplexity int) int {
+		return subTownHallLookupCost + childComplexity
+	}
+
+	return c
+}
+
+// presentError adds the error code of the HTTP API, as STREET_MARKET_NOT_FOUND,
+// and the invalid input fields to the extensions of domain errors. As in the
+// HTTP API, the message of unexpected errors is kept out of the response.
+func (h *GraphQLHandler) presentError(ctx context.Context, err error) *gqlerror.Error {
+	gErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var dErr *domain.Error
+	if !errors.As(err, &dErr) {
+		return gErr
+	}
+
+	if !clientError(dErr.Kind) {
+		h.logger.Error(ctx, *dErr)
+		gErr.Message = "Unexpected error"
+	}
+
+	gErr.Extensions = map[string]interface{}{"code": dErr.Kind}
+	if traceID, ok := ctx.Value(domain.TraceIDCtxKey).(string); ok {
+		gErr.Extensions["trace_id"] = traceID
+	}
+
+	if len(dErr.Fields) > 0 {
+		fields := []map[string]string{}
+		for _, f := range dErr.Fields {
+			fields = append(fields, map[string]string{
+				"field":   "input." + inputKey(f.Field),
+				"code":    string(f.Code),
+				"message": f.Msg,
+			})
+		}
+		gErr.Extensions["errors"] = fields
+	}
+
+	return gErr
+}
+
+// clientError tells the kinds the HTTP API responds with a 4xx status.
+func clientError(kind domain.KindError) bool {
+	switch kind {
+	case domain.InpValidationErrKd,
+		domain.SMNotFoundErrKd,
+		domain.SnapNotFoundErrKd,
+		domain.StallNotFoundErrKd,
+		domain.SubTHNotFoundErrKd,
+		domain.StallDupErrKd,
+		domain.UnauthenticatedErrKd,
+		domain.ForbiddenErrKd:
+		return true
+	default:
+		return false
+	}
+}
+
+// inputKey returns the input field of the street market inputs matching the
+// input struct field name, as idDist for IDdist, or name itself when there is
+// no such field.
+func inputKey(name string) string {
+	f, ok := reflect.TypeOf(StreetMarketCreateInput{}).FieldByNameFunc(func(n string) bool {
+		return strings.EqualFold(n, name)
+	})
+	if !ok {
+		return name
+	}
+
+	return strings.Split(f.Tag.Get("json"), ",")[0]
+}
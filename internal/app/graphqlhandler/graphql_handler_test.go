@@ -0,0 +1,193 @@
+package graphqlhandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubLogger struct {
+	errors []domain.Error
+}
+
+func (s *stubLogger) Error(ctx context.Context, err domain.Error) {
+	s.errors = append(s.errors, err)
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func post(t *testing.T, h *GraphQLHandler, query string) graphQLResponse {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	ctx := context.WithValue(req.Context(), domain.TraceIDCtxKey, "trace")
+	req = req.WithContext(domain.WithAPIKey(ctx, domain.APIKey{Scopes: domain.AllScopes()}))
+
+	rr := httptest.NewRecorder()
+	h.Handle(rr, req)
+
+	var res graphQLResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &res); err != nil {
+		t.Fatalf("%v: %s", err, rr.Body.String())
+	}
+
+	return res
+}
+
+func newTestHandler(logger *stubLogger) *GraphQLHandler {
+	reader := &stubStreetMarketReader{
+		list: func() ([]domain.StreetMarket, *domain.Error) {
+			return []domain.StreetMarket{{
+				ID:          "1",
+				Name:        "RAPOSO TAVARES",
+				IDdist:      "87",
+				District:    "VILA FORMOSA",
+				IDSubTH:     "26",
+				SubTownHall: "ARICANDUVA",
+				Region8:     "Leste 1",
+			}}, nil
+		},
+		get: func() (domain.StreetMarket, *domain.Error) {
+			return domain.StreetMarket{}, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "pq: connection refused"}
+		},
+	}
+	writer := &stubStreetMarketWriter{create: func() (string, *domain.Error) {
+		return "", &domain.Error{
+			Kind:   domain.InpValidationErrKd,
+			Msg:    "Invalid input",
+			Fields: []domain.FieldError{{Field: "IDdist", Code: domain.ConflictFieldCd, Msg: "IDdist conflicts"}},
+		}
+	}}
+	ref := &stubReferenceReader{
+		subTownHalls: func() ([]domain.SubTownHall, *domain.Error) {
+			return []domain.SubTownHall{{ID: "26", Name: "ARICANDUVA", Region8: "Leste 1"}}, nil
+		},
+		subTownHallDistricts: func(string) ([]domain.District, *domain.Error) {
+			return []domain.District{{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}}, nil
+		},
+	}
+
+	return NewGraphQLHandler(NewResolver(reader, writer, nil, ref), Limits{Depth: 4, Complexity: 3000}, logger)
+}
+
+func TestGraphQLHandler_Handle(t *testing.T) {
+	res := post(t, newTestHandler(&stubLogger{}), `{
+		streetMarkets(filter: {district: "VILA FORMOSA"}) {
+			name
+			district { id name subTownHall { name } }
+			subTownHall { id region8 districts { name } }
+		}
+	}`)
+
+	if len(res.Errors) > 0 {
+		t.Fatalf("expect no errors, got %v", res.Errors)
+	}
+
+	want := `{"streetMarkets":[{"name":"RAPOSO TAVARES",` +
+		`"district":{"id":"87","name":"VILA FORMOSA","subTownHall":{"name":"ARICANDUVA"}},` +
+		`"subTownHall":{"id":"26","region8":"Leste 1","districts":[{"name":"VILA FORMOSA"}]}}]}`
+	if diff := cmp.Diff(want, string(res.Data)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGraphQLHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		query       string
+		wantMessage string
+		wantExt     map[string]interface{}
+		wantLogged  int
+	}{
+		"When input is invalid": {
+			query: `mutation {
+				createStreetMarket(input: {
+					long: 1, lat: 1, sectCens: "a", area: "a", idDist: "1", district: "a", idSubTH: "1",
+					subTownHall: "a", region5: "a", region8: "a", name: "a", register: "a", street: "a",
+					number: "a", neighborhood: "a", addrExtraInfo: "a"
+				}) { id }
+			}`,
+			wantMessage: "Invalid input",
+			wantExt: map[string]interface{}{
+				"code":     "INPUT_IS_INVALID",
+				"trace_id": "trace",
+				"errors": []interface{}{
+					map[string]interface{}{"field": "input.idDist", "code": "CONFLICT", "message": "IDdist conflicts"},
+				},
+			},
+		},
+		"When an unexpected error occurs": {
+			query:       `{ streetMarket(id: "1") { id } }`,
+			wantMessage: "Unexpected error",
+			wantExt:     map[string]interface{}{"code": "UNEXPECTED", "trace_id": "trace"},
+			wantLogged:  1,
+		},
+		"When operation is too deep": {
+			query:       `{ subTownHalls { districts { subTownHall { districts { name } } } } }`,
+			wantMessage: "operation has depth 5, which exceeds the limit of 4",
+			wantExt:     map[string]interface{}{"code": "DEPTH_LIMIT_EXCEEDED"},
+		},
+		"When operation is too deep through fragments": {
+			query: `{ ...Halls }
+				fragment Halls on Query { subTownHalls { districts { ... on District { subTownHall { ...Names } } } } }
+				fragment Names on SubTownHall { districts { name } }`,
+			wantMessage: "operation has depth 5, which exceeds the limit of 4",
+			wantExt:     map[string]interface{}{"code": "DEPTH_LIMIT_EXCEEDED"},
+		},
+		"When operation is too complex": {
+			query:       `{ streetMarkets { subTownHall { districts { id name } } district { subTownHall { id name } } } }`,
+			wantMessage: "operation has complexity 3400, which exceeds the limit of 3000",
+			wantExt:     map[string]interface{}{"code": "COMPLEXITY_LIMIT_EXCEEDED"},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			logger := &stubLogger{}
+			res := post(t, newTestHandler(logger), tc.query)
+
+			if len(res.Errors) != 1 {
+				t.Fatalf("expect 1 error, got %v", res.Errors)
+			}
+
+			if diff := cmp.Diff(tc.wantMessage, res.Errors[0].Message); diff != "" {
+				t.Errorf("message mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantExt, res.Errors[0].Extensions); diff != "" {
+				t.Errorf("extensions mismatch (-want +got):\n%s", diff)
+			}
+
+			if len(logger.errors) != tc.wantLogged {
+				t.Errorf("expect %d logged errors, got %v", tc.wantLogged, logger.errors)
+			}
+		})
+	}
+}
+
+func TestGraphQLHandler_Handle_Introspection(t *testing.T) {
+	res := post(t, newTestHandler(&stubLogger{}), `{
+		__schema { types { name fields { name type { name ofType { name ofType { name } } } } } }
+	}`)
+
+	if len(res.Errors) > 0 {
+		t.Errorf("expect introspection out of the depth limit, got %v", res.Errors)
+	}
+}
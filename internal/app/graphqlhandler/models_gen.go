@@ -0,0 +1,50 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graphqlhandler
+
+type StreetMarketCreateInput struct {
+	Long          float64 `json:"long"`
+	Lat           float64 `json:"lat"`
+	SectCens      string  `json:"sectCens"`
+	Area          string  `json:"area"`
+	IDDist        string  `json:"idDist"`
+	District      string  `json:"district"`
+	IDSubTh       string  `json:"idSubTH"`
+	SubTownHall   string  `json:"subTownHall"`
+	Region5       string  `json:"region5"`
+	Region8       string  `json:"region8"`
+	Name          string  `json:"name"`
+	Register      string  `json:"register"`
+	Street        string  `json:"street"`
+	Number        string  `json:"number"`
+	Neighborhood  string  `json:"neighborhood"`
+	AddrExtraInfo string  `json:"addrExtraInfo"`
+}
+
+type StreetMarketEditInput struct {
+	Long          *float64 `json:"long"`
+	Lat           *float64 `json:"lat"`
+	SectCens      *string  `json:"sectCens"`
+	Area          *string  `json:"area"`
+	IDDist        *string  `json:"idDist"`
+	District      *string  `json:"district"`
+	IDSubTh       *string  `json:"idSubTH"`
+	SubTownHall   *string  `json:"subTownHall"`
+	Region5       *string  `json:"region5"`
+	Region8       *string  `json:"region8"`
+	Name          *string  `json:"name"`
+	Register      *string  `json:"register"`
+	Street        *string  `json:"street"`
+	Number        *string  `json:"number"`
+	Neighborhood  *string  `json:"neighborhood"`
+	AddrExtraInfo *string  `json:"addrExtraInfo"`
+}
+
+type StreetMarketFilter struct {
+	District     *string `json:"district"`
+	Region5      *string `json:"region5"`
+	Name         *string `json:"name"`
+	Neighborhood *string `json:"neighborhood"`
+	// A product category, as fish.
+	Sells *string `json:"sells"`
+}
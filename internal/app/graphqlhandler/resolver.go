@@ -0,0 +1,273 @@
+// Package graphqlhandler serves the GraphQL API of schema.graphqls. The
+// generated.go and models_gen.go files are generated by gqlgen from the schema
+// and gqlgen.yml, run go generate after changing them.
+package graphqlhandler
+
+//go:generate go run github.com/99designs/gqlgen generate --config gqlgen.yml
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type streetMarketReader interface {
+	Get(context.Context, domain.SMID) (domain.StreetMarket, *domain.Error)
+	List(context.Context, int, domain.StreetMarketFilter) ([]domain.StreetMarket, *domain.Error)
+}
+
+type streetMarketWriter interface {
+	Create(context.Context, domain.StreetMarketCreateInput) (string, *domain.Error)
+	Edit(context.Context, domain.SMID, domain.StreetMarketEditInput) *domain.Error
+}
+
+type streetMarketEraser interface {
+	Delete(context.Context, domain.SMID) *domain.Error
+}
+
+type referenceReader interface {
+	Districts(context.Context) ([]domain.District, *domain.Error)
+	SubTownHalls(context.Context) ([]domain.SubTownHall, *domain.Error)
+	SubTownHallDistricts(context.Context, string) ([]domain.District, *domain.Error)
+}
+
+// Resolver is the ResolverRoot of the schema.
+type Resolver struct {
+	reader    streetMarketReader
+	writer    streetMarketWriter
+	eraser    streetMarketEraser
+	refReader referenceReader
+}
+
+func NewResolver(
+	reader streetMarketReader,
+	writer streetMarketWriter,
+	eraser streetMarketEraser,
+	refReader referenceReader,
+) *Resolver {
+	return &Resolver{reader, writer, eraser, refReader}
+}
+
+func (r *Resolver) Query() QueryResolver               { return &queryResolver{r} }
+func (r *Resolver) Mutation() MutationResolver         { return &mutationResolver{r} }
+func (r *Resolver) StreetMarket() StreetMarketResolver { return &streetMarketResolver{r} }
+func (r *Resolver) District() DistrictResolver         { return &districtResolver{r} }
+func (r *Resolver) SubTownHall() SubTownHallResolver   { return &subTownHallResolver{r} }
+
+type queryResolver struct{ *Resolver }
+
+// StreetMarket resolves to null when the street market does not exist.
+func (r *queryResolver) StreetMarket(ctx context.Context, id string) (*domain.StreetMarket, error) {
+	sm, err := r.reader.Get(ctx, domain.SMID(id))
+	if err != nil {
+		if err.Kind == domain.SMNotFoundErrKd {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &sm, nil
+}
+
+func (r *queryResolver) StreetMarkets(
+	ctx context.Context,
+	filter *StreetMarketFilter,
+	page int,
+) ([]domain.StreetMarket, error) {
+	f := domain.StreetMarketFilter{}
+	if filter != nil {
+		f.District = str(filter.District)
+		f.Region5 = str(filter.Region5)
+		f.Name = str(filter.Name)
+		f.Neighborhood = str(filter.Neighborhood)
+
+		if sells := str(filter.Sells); sells != "" {
+			pc := domain.ProductCategory(sells)
+			if err := pc.Validate(); err != nil {
+				return nil, &domain.Error{
+					Kind:     domain.InpValidationErrKd,
+					Msg:      "sells must be a product category",
+					Previous: err,
+				}
+			}
+			f.Sells = pc
+		}
+	}
+
+	ls, err := r.reader.List(ctx, page, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return ls, nil
+}
+
+func (r *queryResolver) Districts(ctx context.Context) ([]domain.District, error) {
+	ds, err := r.refReader.Districts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+func (r *queryResolver) SubTownHalls(ctx context.Context) ([]domain.SubTownHall, error) {
+	sths, err := r.refReader.SubTownHalls(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sths, nil
+}
+
+type mutationResolver struct{ *Resolver }
+
+func (r *mutationResolver) CreateStreetMarket(
+	ctx context.Context,
+	input StreetMarketCreateInput,
+) (*domain.StreetMarket, error) {
+	if err := domain.Authorize(ctx, domain.ScopeMarketsWrite, domain.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	id, err := r.writer.Create(ctx, domain.StreetMarketCreateInput{
+		Long:          input.Long,
+		Lat:           input.Lat,
+		SectCens:      input.SectCens,
+		Area:          input.Area,
+		IDdist:        input.IDDist,
+		District:      input.District,
+		IDSubTH:       input.IDSubTh,
+		SubTownHall:   input.SubTownHall,
+		Region5:       input.Region5,
+		Region8:       input.Region8,
+		Name:          input.Name,
+		Register:      input.Register,
+		Street:        input.Street,
+		Number:        input.Number,
+		Neighborhood:  input.Neighborhood,
+		AddrExtraInfo: input.AddrExtraInfo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.get(ctx, domain.SMID(id))
+}
+
+func (r *mutationResolver) EditStreetMarket(
+	ctx context.Context,
+	id string,
+	input StreetMarketEditInput,
+) (*domain.StreetMarket, error) {
+	if err := domain.Authorize(ctx, domain.ScopeMarketsWrite, domain.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	err := r.writer.Edit(ctx, domain.SMID(id), domain.StreetMarketEditInput{
+		Long:          float(input.Long),
+		Lat:           float(input.Lat),
+		SectCens:      str(input.SectCens),
+		Area:          str(input.Area),
+		IDdist:        str(input.IDDist),
+		District:      str(input.District),
+		IDSubTH:       str(input.IDSubTh),
+		SubTownHall:   str(input.SubTownHall),
+		Region5:       str(input.Region5),
+		Region8:       str(input.Region8),
+		Name:          str(input.Name),
+		Register:      str(input.Register),
+		Street:        str(input.Street),
+		Number:        str(input.Number),
+		Neighborhood:  str(input.Neighborhood),
+		AddrExtraInfo: str(input.AddrExtraInfo),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.get(ctx, domain.SMID(id))
+}
+
+func (r *mutationResolver) DeleteStreetMarket(ctx context.Context, id string) (string, error) {
+	if err := domain.Authorize(ctx, domain.ScopeMarketsDelete, domain.RoleAdmin); err != nil {
+		return "", err
+	}
+
+	if err := r.eraser.Delete(ctx, domain.SMID(id)); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// get reads the street market a mutation has just written.
+func (r *mutationResolver) get(ctx context.Context, id domain.SMID) (*domain.StreetMarket, error) {
+	sm, err := r.reader.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sm, nil
+}
+
+// The reference data of a street market is denormalized in it, so it resolves
+// without reading the reference tables.
+type streetMarketResolver struct{ *Resolver }
+
+func (r *streetMarketResolver) District(ctx context.Context, obj *domain.StreetMarket) (*domain.District, error) {
+	return &domain.District{ID: obj.IDdist, Name: obj.District, SubTownHallID: obj.IDSubTH}, nil
+}
+
+func (r *streetMarketResolver) SubTownHall(
+	ctx context.Context,
+	obj *domain.StreetMarket,
+) (*domain.SubTownHall, error) {
+	return &domain.SubTownHall{ID: obj.IDSubTH, Name: obj.SubTownHall, Region8: obj.Region8}, nil
+}
+
+type districtResolver struct{ *Resolver }
+
+func (r *districtResolver) SubTownHall(ctx context.Context, obj *domain.District) (*domain.SubTownHall, error) {
+	sths, err := r.refReader.SubTownHalls(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sth := range sths {
+		if sth.ID == obj.SubTownHallID {
+			return &sth, nil
+		}
+	}
+
+	return nil, &domain.Error{Kind: domain.SubTHNotFoundErrKd, Msg: "Entity not exists"}
+}
+
+type subTownHallResolver struct{ *Resolver }
+
+func (r *subTownHallResolver) Districts(ctx context.Context, obj *domain.SubTownHall) ([]domain.District, error) {
+	ds, err := r.refReader.SubTownHallDistricts(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// str and float dereference optional arguments, nil being the zero value.
+func str(v *string) string {
+	if v == nil {
+		return ""
+	}
+
+	return *v
+}
+
+func float(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+
+	return *v
+}
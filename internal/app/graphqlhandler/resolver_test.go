@@ -0,0 +1,254 @@
+package graphqlhandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+)
+
+type stubStreetMarketReader struct {
+	getInp   domain.SMID
+	get      func() (domain.StreetMarket, *domain.Error)
+	listPage int
+	listInp  domain.StreetMarketFilter
+	list     func() ([]domain.StreetMarket, *domain.Error)
+}
+
+func (s *stubStreetMarketReader) Get(ctx context.Context, ID domain.SMID) (domain.StreetMarket, *domain.Error) {
+	s.getInp = ID
+	return s.get()
+}
+
+func (s *stubStreetMarketReader) List(
+	ctx context.Context,
+	page int,
+	f domain.StreetMarketFilter,
+) ([]domain.StreetMarket, *domain.Error) {
+	s.listPage = page
+	s.listInp = f
+	return s.list()
+}
+
+type stubStreetMarketWriter struct {
+	createInp domain.StreetMarketCreateInput
+	create    func() (string, *domain.Error)
+	editID    domain.SMID
+	editInp   domain.StreetMarketEditInput
+	edit      func() *domain.Error
+}
+
+func (s *stubStreetMarketWriter) Create(
+	ctx context.Context,
+	inp domain.StreetMarketCreateInput,
+) (string, *domain.Error) {
+	s.createInp = inp
+	return s.create()
+}
+
+func (s *stubStreetMarketWriter) Edit(
+	ctx context.Context,
+	ID domain.SMID,
+	inp domain.StreetMarketEditInput,
+) *domain.Error {
+	s.editID = ID
+	s.editInp = inp
+	return s.edit()
+}
+
+type stubStreetMarketEraser struct {
+	deleteInp domain.SMID
+	delete    func() *domain.Error
+}
+
+func (s *stubStreetMarketEraser) Delete(ctx context.Context, ID domain.SMID) *domain.Error {
+	s.deleteInp = ID
+	return s.delete()
+}
+
+type stubReferenceReader struct {
+	districts            func() ([]domain.District, *domain.Error)
+	subTownHalls         func() ([]domain.SubTownHall, *domain.Error)
+	subTownHallDistricts func(string) ([]domain.District, *domain.Error)
+}
+
+func (s *stubReferenceReader) Districts(context.Context) ([]domain.District, *domain.Error) {
+	return s.districts()
+}
+
+func (s *stubReferenceReader) SubTownHalls(context.Context) ([]domain.SubTownHall, *domain.Error) {
+	return s.subTownHalls()
+}
+
+func (s *stubReferenceReader) SubTownHallDistricts(ctx context.Context, ID string) ([]domain.District, *domain.Error) {
+	return s.subTownHallDistricts(ID)
+}
+
+func TestQueryResolver_StreetMarket(t *testing.T) {
+	testCases := map[string]struct {
+		err     *domain.Error
+		want    *domain.StreetMarket
+		wantErr domain.KindError
+	}{
+		"When street market exists": {
+			want: &domain.StreetMarket{ID: "id", Name: "RAPOSO TAVARES"},
+		},
+		"When street market does not exist": {
+			err: &domain.Error{Kind: domain.SMNotFoundErrKd},
+		},
+		"When an unexpected error occurs": {
+			err:     &domain.Error{Kind: domain.UnexpectedErrKd},
+			wantErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			reader := &stubStreetMarketReader{get: func() (domain.StreetMarket, *domain.Error) {
+				if tc.want == nil {
+					return domain.StreetMarket{}, tc.err
+				}
+				return *tc.want, nil
+			}}
+
+			got, err := NewResolver(reader, nil, nil, nil).Query().StreetMarket(context.TODO(), "id")
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.wantErr == "" && err != nil {
+				t.Errorf("expect nil, got %v", err)
+			}
+			if tc.wantErr != "" && (err == nil || err.(*domain.Error).Kind != tc.wantErr) {
+				t.Errorf("expect error kind %s, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestQueryResolver_StreetMarkets(t *testing.T) {
+	reader := &stubStreetMarketReader{list: func() ([]domain.StreetMarket, *domain.Error) {
+		return []domain.StreetMarket{{ID: "id"}}, nil
+	}}
+
+	district, sells := "VILA FORMOSA", "fish"
+	_, err := NewResolver(reader, nil, nil, nil).Query().StreetMarkets(
+		context.TODO(),
+		&StreetMarketFilter{District: &district, Sells: &sells},
+		2,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := domain.StreetMarketFilter{District: district, Sells: domain.ProductCategory(sells)}
+	if diff := cmp.Diff(want, reader.listInp); diff != "" {
+		t.Errorf("unexpected filter (-want +got):\n%s", diff)
+	}
+
+	if reader.listPage != 2 {
+		t.Errorf("expect page 2, got %d", reader.listPage)
+	}
+
+	cars := "cars"
+	_, err = NewResolver(reader, nil, nil, nil).Query().StreetMarkets(
+		context.TODO(),
+		&StreetMarketFilter{Sells: &cars},
+		1,
+	)
+	if err == nil || err.(*domain.Error).Kind != domain.InpValidationErrKd {
+		t.Errorf("expect error kind %s, got %v", domain.InpValidationErrKd, err)
+	}
+}
+
+func TestMutationResolver_EditStreetMarket(t *testing.T) {
+	id := uuid.NewString()
+	reader := &stubStreetMarketReader{get: func() (domain.StreetMarket, *domain.Error) {
+		return domain.StreetMarket{ID: id, Name: "RAPOSO TAVARES", Lat: -23}, nil
+	}}
+	writer := &stubStreetMarketWriter{edit: func() *domain.Error { return nil }}
+
+	name, lat := "RAPOSO TAVARES", -23.0
+	got, err := NewResolver(reader, writer, nil, nil).Mutation().EditStreetMarket(
+		domain.WithAPIKey(context.TODO(), domain.APIKey{Scopes: []domain.Scope{domain.ScopeMarketsWrite}}),
+		id,
+		StreetMarketEditInput{Name: &name, Lat: &lat},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(domain.StreetMarketEditInput{Name: name, Lat: lat}, writer.editInp); diff != "" {
+		t.Errorf("unexpected input (-want +got):\n%s", diff)
+	}
+
+	if writer.editID != domain.SMID(id) || reader.getInp != domain.SMID(id) {
+		t.Errorf("expect edit and get %s, got %s and %s", id, writer.editID, reader.getInp)
+	}
+
+	if got.ID != id {
+		t.Errorf("expect edited street market, got %v", got)
+	}
+}
+
+func TestMutationResolver_Access(t *testing.T) {
+	writer := &stubStreetMarketWriter{edit: func() *domain.Error { return nil }}
+	eraser := &stubStreetMarketEraser{delete: func() *domain.Error { return nil }}
+	resolver := NewResolver(nil, writer, eraser, nil).Mutation()
+
+	testCases := map[string]struct {
+		ctx  context.Context
+		wErr domain.KindError
+	}{
+		"When there is no key": {
+			ctx:  context.TODO(),
+			wErr: domain.UnauthenticatedErrKd,
+		},
+		"When the key lacks the scope": {
+			ctx:  domain.WithAPIKey(context.TODO(), domain.APIKey{Scopes: []domain.Scope{domain.ScopeMarketsWrite}}),
+			wErr: domain.ForbiddenErrKd,
+		},
+		"When the user lacks the role": {
+			ctx:  domain.WithUser(context.TODO(), domain.User{Subject: "maria", Role: domain.RoleEditor}),
+			wErr: domain.ForbiddenErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			_, err := resolver.DeleteStreetMarket(tc.ctx, uuid.NewString())
+			if err == nil || err.(*domain.Error).Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, err)
+			}
+
+			if eraser.deleteInp != "" {
+				t.Errorf("expect nothing deleted, got %s", eraser.deleteInp)
+			}
+		})
+	}
+}
+
+func TestDistrictResolver_SubTownHall(t *testing.T) {
+	ref := &stubReferenceReader{subTownHalls: func() ([]domain.SubTownHall, *domain.Error) {
+		return []domain.SubTownHall{{ID: "25", Name: "ARICANDUVA"}, {ID: "26", Name: "PENHA"}}, nil
+	}}
+
+	resolver := NewResolver(nil, nil, nil, ref).District()
+
+	got, err := resolver.SubTownHall(context.TODO(), &domain.District{SubTownHallID: "26"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(&domain.SubTownHall{ID: "26", Name: "PENHA"}, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = resolver.SubTownHall(context.TODO(), &domain.District{SubTownHallID: "99"})
+	if err == nil || err.(*domain.Error).Kind != domain.SubTHNotFoundErrKd {
+		t.Errorf("expect error kind %s, got %v", domain.SubTHNotFoundErrKd, err)
+	}
+}
@@ -0,0 +1,1083 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: streetmarket/v1/street_market.proto
+
+package streetmarketv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type StreetMarket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Long          float64 `protobuf:"fixed64,2,opt,name=long,proto3" json:"long,omitempty"`
+	Lat           float64 `protobuf:"fixed64,3,opt,name=lat,proto3" json:"lat,omitempty"`
+	SectCens      string  `protobuf:"bytes,4,opt,name=sect_cens,json=sectCens,proto3" json:"sect_cens,omitempty"`
+	Area          string  `protobuf:"bytes,5,opt,name=area,proto3" json:"area,omitempty"`
+	IdDist        string  `protobuf:"bytes,6,opt,name=id_dist,json=idDist,proto3" json:"id_dist,omitempty"`
+	District      string  `protobuf:"bytes,7,opt,name=district,proto3" json:"district,omitempty"`
+	IdSubTh       string  `protobuf:"bytes,8,opt,name=id_sub_th,json=idSubTh,proto3" json:"id_sub_th,omitempty"`
+	Subtownhall   string  `protobuf:"bytes,9,opt,name=subtownhall,proto3" json:"subtownhall,omitempty"`
+	Region_5      string  `protobuf:"bytes,10,opt,name=region_5,json=region5,proto3" json:"region_5,omitempty"`
+	Region_8      string  `protobuf:"bytes,11,opt,name=region_8,json=region8,proto3" json:"region_8,omitempty"`
+	Name          string  `protobuf:"bytes,12,opt,name=name,proto3" json:"name,omitempty"`
+	Register      string  `protobuf:"bytes,13,opt,name=register,proto3" json:"register,omitempty"`
+	Street        string  `protobuf:"bytes,14,opt,name=street,proto3" json:"street,omitempty"`
+	Number        string  `protobuf:"bytes,15,opt,name=number,proto3" json:"number,omitempty"`
+	Neighborhood  string  `protobuf:"bytes,16,opt,name=neighborhood,proto3" json:"neighborhood,omitempty"`
+	AddrExtraInfo string  `protobuf:"bytes,17,opt,name=addr_extra_info,json=addrExtraInfo,proto3" json:"addr_extra_info,omitempty"`
+}
+
+func (x *StreetMarket) Reset() {
+	*x = StreetMarket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreetMarket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreetMarket) ProtoMessage() {}
+
+func (x *StreetMarket) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreetMarket.ProtoReflect.Descriptor instead.
+func (*StreetMarket) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreetMarket) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetLong() float64 {
+	if x != nil {
+		return x.Long
+	}
+	return 0
+}
+
+func (x *StreetMarket) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *StreetMarket) GetSectCens() string {
+	if x != nil {
+		return x.SectCens
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetIdDist() string {
+	if x != nil {
+		return x.IdDist
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetDistrict() string {
+	if x != nil {
+		return x.District
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetIdSubTh() string {
+	if x != nil {
+		return x.IdSubTh
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetSubtownhall() string {
+	if x != nil {
+		return x.Subtownhall
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetRegion_5() string {
+	if x != nil {
+		return x.Region_5
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetRegion_8() string {
+	if x != nil {
+		return x.Region_8
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetNeighborhood() string {
+	if x != nil {
+		return x.Neighborhood
+	}
+	return ""
+}
+
+func (x *StreetMarket) GetAddrExtraInfo() string {
+	if x != nil {
+		return x.AddrExtraInfo
+	}
+	return ""
+}
+
+// StreetMarketInput is a street market without its id.
+type StreetMarketInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Long          float64 `protobuf:"fixed64,1,opt,name=long,proto3" json:"long,omitempty"`
+	Lat           float64 `protobuf:"fixed64,2,opt,name=lat,proto3" json:"lat,omitempty"`
+	SectCens      string  `protobuf:"bytes,3,opt,name=sect_cens,json=sectCens,proto3" json:"sect_cens,omitempty"`
+	Area          string  `protobuf:"bytes,4,opt,name=area,proto3" json:"area,omitempty"`
+	IdDist        string  `protobuf:"bytes,5,opt,name=id_dist,json=idDist,proto3" json:"id_dist,omitempty"`
+	District      string  `protobuf:"bytes,6,opt,name=district,proto3" json:"district,omitempty"`
+	IdSubTh       string  `protobuf:"bytes,7,opt,name=id_sub_th,json=idSubTh,proto3" json:"id_sub_th,omitempty"`
+	Subtownhall   string  `protobuf:"bytes,8,opt,name=subtownhall,proto3" json:"subtownhall,omitempty"`
+	Region_5      string  `protobuf:"bytes,9,opt,name=region_5,json=region5,proto3" json:"region_5,omitempty"`
+	Region_8      string  `protobuf:"bytes,10,opt,name=region_8,json=region8,proto3" json:"region_8,omitempty"`
+	Name          string  `protobuf:"bytes,11,opt,name=name,proto3" json:"name,omitempty"`
+	Register      string  `protobuf:"bytes,12,opt,name=register,proto3" json:"register,omitempty"`
+	Street        string  `protobuf:"bytes,13,opt,name=street,proto3" json:"street,omitempty"`
+	Number        string  `protobuf:"bytes,14,opt,name=number,proto3" json:"number,omitempty"`
+	Neighborhood  string  `protobuf:"bytes,15,opt,name=neighborhood,proto3" json:"neighborhood,omitempty"`
+	AddrExtraInfo string  `protobuf:"bytes,16,opt,name=addr_extra_info,json=addrExtraInfo,proto3" json:"addr_extra_info,omitempty"`
+}
+
+func (x *StreetMarketInput) Reset() {
+	*x = StreetMarketInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreetMarketInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreetMarketInput) ProtoMessage() {}
+
+func (x *StreetMarketInput) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreetMarketInput.ProtoReflect.Descriptor instead.
+func (*StreetMarketInput) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StreetMarketInput) GetLong() float64 {
+	if x != nil {
+		return x.Long
+	}
+	return 0
+}
+
+func (x *StreetMarketInput) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *StreetMarketInput) GetSectCens() string {
+	if x != nil {
+		return x.SectCens
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetIdDist() string {
+	if x != nil {
+		return x.IdDist
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetDistrict() string {
+	if x != nil {
+		return x.District
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetIdSubTh() string {
+	if x != nil {
+		return x.IdSubTh
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetSubtownhall() string {
+	if x != nil {
+		return x.Subtownhall
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetRegion_5() string {
+	if x != nil {
+		return x.Region_5
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetRegion_8() string {
+	if x != nil {
+		return x.Region_8
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetRegister() string {
+	if x != nil {
+		return x.Register
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetNeighborhood() string {
+	if x != nil {
+		return x.Neighborhood
+	}
+	return ""
+}
+
+func (x *StreetMarketInput) GetAddrExtraInfo() string {
+	if x != nil {
+		return x.AddrExtraInfo
+	}
+	return ""
+}
+
+type GetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetRequest) Reset() {
+	*x = GetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRequest) ProtoMessage() {}
+
+func (x *GetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
+func (*GetRequest) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ListRequest filters as the query params of GET /v1/street_market, every
+// field being optional.
+type ListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	District     string `protobuf:"bytes,1,opt,name=district,proto3" json:"district,omitempty"`
+	Region5      string `protobuf:"bytes,2,opt,name=region5,proto3" json:"region5,omitempty"`
+	Name         string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Neighborhood string `protobuf:"bytes,4,opt,name=neighborhood,proto3" json:"neighborhood,omitempty"`
+	// A product category, as fish.
+	Sells string `protobuf:"bytes,5,opt,name=sells,proto3" json:"sells,omitempty"`
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListRequest) GetDistrict() string {
+	if x != nil {
+		return x.District
+	}
+	return ""
+}
+
+func (x *ListRequest) GetRegion5() string {
+	if x != nil {
+		return x.Region5
+	}
+	return ""
+}
+
+func (x *ListRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListRequest) GetNeighborhood() string {
+	if x != nil {
+		return x.Neighborhood
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSells() string {
+	if x != nil {
+		return x.Sells
+	}
+	return ""
+}
+
+type CreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StreetMarket *StreetMarketInput `protobuf:"bytes,1,opt,name=street_market,json=streetMarket,proto3" json:"street_market,omitempty"`
+}
+
+func (x *CreateRequest) Reset() {
+	*x = CreateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRequest) ProtoMessage() {}
+
+func (x *CreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRequest) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateRequest) GetStreetMarket() *StreetMarketInput {
+	if x != nil {
+		return x.StreetMarket
+	}
+	return nil
+}
+
+type CreateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CreateResponse) Reset() {
+	*x = CreateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateResponse) ProtoMessage() {}
+
+func (x *CreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateResponse.ProtoReflect.Descriptor instead.
+func (*CreateResponse) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StreetMarket *StreetMarketInput `protobuf:"bytes,2,opt,name=street_market,json=streetMarket,proto3" json:"street_market,omitempty"`
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetStreetMarket() *StreetMarketInput {
+	if x != nil {
+		return x.StreetMarket
+	}
+	return nil
+}
+
+type UpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateResponse) Reset() {
+	*x = UpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateResponse) ProtoMessage() {}
+
+func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{7}
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_streetmarket_v1_street_market_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_streetmarket_v1_street_market_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_streetmarket_v1_street_market_proto_rawDescGZIP(), []int{9}
+}
+
+var File_streetmarket_v1_street_market_proto protoreflect.FileDescriptor
+
+var file_streetmarket_v1_street_market_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2f, 0x76,
+	0x31, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x5f, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x22, 0xca, 0x03, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x65,
+	0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x6e, 0x67, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6c,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x43, 0x65, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72,
+	0x65, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x65, 0x61, 0x12, 0x17,
+	0x0a, 0x07, 0x69, 0x64, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x69, 0x64, 0x44, 0x69, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x72,
+	0x69, 0x63, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x72,
+	0x69, 0x63, 0x74, 0x12, 0x1a, 0x0a, 0x09, 0x69, 0x64, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x74, 0x68,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x64, 0x53, 0x75, 0x62, 0x54, 0x68, 0x12,
+	0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x74, 0x6f, 0x77, 0x6e, 0x68, 0x61, 0x6c, 0x6c, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x74, 0x6f, 0x77, 0x6e, 0x68, 0x61, 0x6c,
+	0x6c, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x5f, 0x35, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x35, 0x12, 0x19, 0x0a, 0x08,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x5f, 0x38, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x38, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x65,
+	0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x65, 0x69, 0x67, 0x68,
+	0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e,
+	0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x61,
+	0x64, 0x64, 0x72, 0x5f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x64, 0x64, 0x72, 0x45, 0x78, 0x74, 0x72, 0x61, 0x49,
+	0x6e, 0x66, 0x6f, 0x22, 0xbf, 0x03, 0x0a, 0x11, 0x53, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x6e,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x6e, 0x67, 0x12, 0x10, 0x0a,
+	0x03, 0x6c, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x43, 0x65, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x72, 0x65, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x65, 0x61,
+	0x12, 0x17, 0x0a, 0x07, 0x69, 0x64, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x69, 0x64, 0x44, 0x69, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73,
+	0x74, 0x72, 0x69, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73,
+	0x74, 0x72, 0x69, 0x63, 0x74, 0x12, 0x1a, 0x0a, 0x09, 0x69, 0x64, 0x5f, 0x73, 0x75, 0x62, 0x5f,
+	0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x64, 0x53, 0x75, 0x62, 0x54,
+	0x68, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x75, 0x62, 0x74, 0x6f, 0x77, 0x6e, 0x68, 0x61, 0x6c, 0x6c,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x74, 0x6f, 0x77, 0x6e, 0x68,
+	0x61, 0x6c, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x5f, 0x35, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x35, 0x12, 0x19,
+	0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x5f, 0x38, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x38, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72,
+	0x65, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x65,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x6e, 0x65, 0x69,
+	0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x12, 0x26, 0x0a,
+	0x0f, 0x61, 0x64, 0x64, 0x72, 0x5f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x64, 0x64, 0x72, 0x45, 0x78, 0x74, 0x72,
+	0x61, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x1c, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x35, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x35, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a,
+	0x0c, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x73, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x58, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x47, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65,
+	0x65, 0x74, 0x5f, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x49, 0x6e,
+	0x70, 0x75, 0x74, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x22, 0x20, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x68, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x47, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x5f, 0x6d,
+	0x61, 0x72, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x74,
+	0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52,
+	0x0c, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x22, 0x10, 0x0a,
+	0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x1f, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x10, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x32, 0x80, 0x03, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x03, 0x47, 0x65,
+	0x74, 0x12, 0x1b, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x45, 0x0a,
+	0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x65, 0x74, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x30, 0x01, 0x12, 0x49, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1e,
+	0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x49, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x73, 0x74, 0x72, 0x65,
+	0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x73, 0x74, 0x72, 0x65,
+	0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x06, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x12, 0x1e, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x44, 0x61, 0x6e, 0x69, 0x65, 0x6c, 0x73, 0x69, 0x6c, 0x76, 0x65, 0x69,
+	0x72, 0x61, 0x39, 0x38, 0x2f, 0x75, 0x6e, 0x69, 0x63, 0x6f, 0x41, 0x50, 0x49, 0x54, 0x65, 0x73,
+	0x74, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x70, 0x2f, 0x67,
+	0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x76, 0x31, 0x3b, 0x73, 0x74, 0x72, 0x65, 0x65, 0x74, 0x6d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_streetmarket_v1_street_market_proto_rawDescOnce sync.Once
+	file_streetmarket_v1_street_market_proto_rawDescData = file_streetmarket_v1_street_market_proto_rawDesc
+)
+
+func file_streetmarket_v1_street_market_proto_rawDescGZIP() []byte {
+	file_streetmarket_v1_street_market_proto_rawDescOnce.Do(func() {
+		file_streetmarket_v1_street_market_proto_rawDescData = protoimpl.X.CompressGZIP(file_streetmarket_v1_street_market_proto_rawDescData)
+	})
+	return file_streetmarket_v1_street_market_proto_rawDescData
+}
+
+var file_streetmarket_v1_street_market_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_streetmarket_v1_street_market_proto_goTypes = []interface{}{
+	(*StreetMarket)(nil),      // 0: streetmarket.v1.StreetMarket
+	(*StreetMarketInput)(nil), // 1: streetmarket.v1.StreetMarketInput
+	(*GetRequest)(nil),        // 2: streetmarket.v1.GetRequest
+	(*ListRequest)(nil),       // 3: streetmarket.v1.ListRequest
+	(*CreateRequest)(nil),     // 4: streetmarket.v1.CreateRequest
+	(*CreateResponse)(nil),    // 5: streetmarket.v1.CreateResponse
+	(*UpdateRequest)(nil),     // 6: streetmarket.v1.UpdateRequest
+	(*UpdateResponse)(nil),    // 7: streetmarket.v1.UpdateResponse
+	(*DeleteRequest)(nil),     // 8: streetmarket.v1.DeleteRequest
+	(*DeleteResponse)(nil),    // 9: streetmarket.v1.DeleteResponse
+}
+var file_streetmarket_v1_street_market_proto_depIdxs = []int32{
+	1, // 0: streetmarket.v1.CreateRequest.street_market:type_name -> streetmarket.v1.StreetMarketInput
+	1, // 1: streetmarket.v1.UpdateRequest.street_market:type_name -> streetmarket.v1.StreetMarketInput
+	2, // 2: streetmarket.v1.StreetMarketService.Get:input_type -> streetmarket.v1.GetRequest
+	3, // 3: streetmarket.v1.StreetMarketService.List:input_type -> streetmarket.v1.ListRequest
+	4, // 4: streetmarket.v1.StreetMarketService.Create:input_type -> streetmarket.v1.CreateRequest
+	6, // 5: streetmarket.v1.StreetMarketService.Update:input_type -> streetmarket.v1.UpdateRequest
+	8, // 6: streetmarket.v1.StreetMarketService.Delete:input_type -> streetmarket.v1.DeleteRequest
+	0, // 7: streetmarket.v1.StreetMarketService.Get:output_type -> streetmarket.v1.StreetMarket
+	0, // 8: streetmarket.v1.StreetMarketService.List:output_type -> streetmarket.v1.StreetMarket
+	5, // 9: streetmarket.v1.StreetMarketService.Create:output_type -> streetmarket.v1.CreateResponse
+	7, // 10: streetmarket.v1.StreetMarketService.Update:output_type -> streetmarket.v1.UpdateResponse
+	9, // 11: streetmarket.v1.StreetMarketService.Delete:output_type -> streetmarket.v1.DeleteResponse
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_streetmarket_v1_street_market_proto_init() }
+func file_streetmarket_v1_street_market_proto_init() {
+	if File_streetmarket_v1_street_market_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_streetmarket_v1_street_market_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreetMarket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreetMarketInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_streetmarket_v1_street_market_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_streetmarket_v1_street_market_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_streetmarket_v1_street_market_proto_goTypes,
+		DependencyIndexes: file_streetmarket_v1_street_market_proto_depIdxs,
+		MessageInfos:      file_streetmarket_v1_street_market_proto_msgTypes,
+	}.Build()
+	File_streetmarket_v1_street_market_proto = out.File
+	file_streetmarket_v1_street_market_proto_rawDesc = nil
+	file_streetmarket_v1_street_market_proto_goTypes = nil
+	file_streetmarket_v1_street_market_proto_depIdxs = nil
+}
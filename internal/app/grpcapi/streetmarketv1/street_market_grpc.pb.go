@@ -0,0 +1,281 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: streetmarket/v1/street_market.proto
+
+package streetmarketv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// StreetMarketServiceClient is the client API for StreetMarketService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type StreetMarketServiceClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*StreetMarket, error)
+	// List streams every street market matching the filter, page by page.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (StreetMarketService_ListClient, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	// Update changes the non-empty fields of the street market.
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+}
+
+type streetMarketServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStreetMarketServiceClient(cc grpc.ClientConnInterface) StreetMarketServiceClient {
+	return &streetMarketServiceClient{cc}
+}
+
+func (c *streetMarketServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*StreetMarket, error) {
+	out := new(StreetMarket)
+	err := c.cc.Invoke(ctx, "/streetmarket.v1.StreetMarketService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streetMarketServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (StreetMarketService_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StreetMarketService_ServiceDesc.Streams[0], "/streetmarket.v1.StreetMarketService/List", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streetMarketServiceListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StreetMarketService_ListClient interface {
+	Recv() (*StreetMarket, error)
+	grpc.ClientStream
+}
+
+type streetMarketServiceListClient struct {
+	grpc.ClientStream
+}
+
+func (x *streetMarketServiceListClient) Recv() (*StreetMarket, error) {
+	m := new(StreetMarket)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streetMarketServiceClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/streetmarket.v1.StreetMarketService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streetMarketServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/streetmarket.v1.StreetMarketService/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *streetMarketServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/streetmarket.v1.StreetMarketService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StreetMarketServiceServer is the server API for StreetMarketService service.
+// All implementations must embed UnimplementedStreetMarketServiceServer
+// for forward compatibility
+type StreetMarketServiceServer interface {
+	Get(context.Context, *GetRequest) (*StreetMarket, error)
+	// List streams every street market matching the filter, page by page.
+	List(*ListRequest, StreetMarketService_ListServer) error
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	// Update changes the non-empty fields of the street market.
+	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	mustEmbedUnimplementedStreetMarketServiceServer()
+}
+
+// UnimplementedStreetMarketServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedStreetMarketServiceServer struct {
+}
+
+func (UnimplementedStreetMarketServiceServer) Get(context.Context, *GetRequest) (*StreetMarket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedStreetMarketServiceServer) List(*ListRequest, StreetMarketService_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedStreetMarketServiceServer) Create(context.Context, *CreateRequest) (*CreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedStreetMarketServiceServer) Update(context.Context, *UpdateRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedStreetMarketServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedStreetMarketServiceServer) mustEmbedUnimplementedStreetMarketServiceServer() {}
+
+// UnsafeStreetMarketServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StreetMarketServiceServer will
+// result in compilation errors.
+type UnsafeStreetMarketServiceServer interface {
+	mustEmbedUnimplementedStreetMarketServiceServer()
+}
+
+func RegisterStreetMarketServiceServer(s grpc.ServiceRegistrar, srv StreetMarketServiceServer) {
+	s.RegisterService(&StreetMarketService_ServiceDesc, srv)
+}
+
+func _StreetMarketService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetMarketServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/streetmarket.v1.StreetMarketService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetMarketServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreetMarketService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StreetMarketServiceServer).List(m, &streetMarketServiceListServer{stream})
+}
+
+type StreetMarketService_ListServer interface {
+	Send(*StreetMarket) error
+	grpc.ServerStream
+}
+
+type streetMarketServiceListServer struct {
+	grpc.ServerStream
+}
+
+func (x *streetMarketServiceListServer) Send(m *StreetMarket) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StreetMarketService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetMarketServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/streetmarket.v1.StreetMarketService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetMarketServiceServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreetMarketService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetMarketServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/streetmarket.v1.StreetMarketService/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetMarketServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StreetMarketService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StreetMarketServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/streetmarket.v1.StreetMarketService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StreetMarketServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StreetMarketService_ServiceDesc is the grpc.ServiceDesc for StreetMarketService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StreetMarketService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "streetmarket.v1.StreetMarketService",
+	HandlerType: (*StreetMarketServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _StreetMarketService_Get_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _StreetMarketService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _StreetMarketService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _StreetMarketService_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "List",
+			Handler:       _StreetMarketService_List_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "streetmarket/v1/street_market.proto",
+}
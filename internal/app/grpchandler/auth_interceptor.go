@@ -0,0 +1,198 @@
+package grpchandler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/grpcapi/streetmarketv1"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// APIKeyMetadata is the metadata key clients send their API key in, the
+// X-API-Key header of the HTTP API.
+const APIKeyMetadata = "x-api-key"
+
+type apiKeyAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (domain.APIKey, *domain.Error)
+}
+
+type tokenVerifier interface {
+	Verify(ctx context.Context, token string) (domain.User, *domain.Error)
+}
+
+// authenticate returns the context the call goes on with, or why it is refused.
+type authenticate func(ctx context.Context, method string) (context.Context, *domain.Error)
+
+func unaryAuth(fn authenticate) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := fn(ctx, info.FullMethod)
+		if err != nil {
+			return nil, statusError(err, nil, "")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(fn authenticate) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := fn(ss.Context(), info.FullMethod)
+		if err != nil {
+			return statusError(err, nil, "")
+		}
+
+		return handler(srv, &contextStream{ss, ctx})
+	}
+}
+
+// firstMetadata is the first value of key in the metadata of the call.
+func firstMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+// APIKeyInterceptor is the counterpart of the API key middleware of the HTTP
+// API: it puts the key sent in the x-api-key metadata key in the context.
+// Calls without one go on anonymously, AccessInterceptor refusing them where
+// one is needed, while an invalid key is refused right away.
+type APIKeyInterceptor struct {
+	authenticator apiKeyAuthenticator
+	logger        errorLogger
+}
+
+func NewAPIKeyInterceptor(authenticator apiKeyAuthenticator, logger errorLogger) *APIKeyInterceptor {
+	return &APIKeyInterceptor{authenticator, logger}
+}
+
+func (i *APIKeyInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return unaryAuth(i.authenticate)
+}
+
+func (i *APIKeyInterceptor) Stream() grpc.StreamServerInterceptor {
+	return streamAuth(i.authenticate)
+}
+
+func (i *APIKeyInterceptor) authenticate(ctx context.Context, _ string) (context.Context, *domain.Error) {
+	token := firstMetadata(ctx, APIKeyMetadata)
+	if token == "" {
+		return ctx, nil
+	}
+
+	k, err := i.authenticator.Authenticate(ctx, token)
+	if err != nil {
+		logUnexpected(ctx, i.logger, err)
+		return nil, err
+	}
+
+	return domain.WithAPIKey(ctx, k), nil
+}
+
+// BearerTokenInterceptor is the counterpart of the bearer token middleware of
+// the HTTP API: it puts the user of the token sent in the authorization
+// metadata key, as Bearer <token>, in the context. Calls without a token go on
+// anonymously, while an invalid one is refused right away.
+type BearerTokenInterceptor struct {
+	verifier tokenVerifier
+}
+
+func NewBearerTokenInterceptor(verifier tokenVerifier) *BearerTokenInterceptor {
+	return &BearerTokenInterceptor{verifier}
+}
+
+func (i *BearerTokenInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return unaryAuth(i.authenticate)
+}
+
+func (i *BearerTokenInterceptor) Stream() grpc.StreamServerInterceptor {
+	return streamAuth(i.authenticate)
+}
+
+func (i *BearerTokenInterceptor) authenticate(ctx context.Context, _ string) (context.Context, *domain.Error) {
+	scheme, token, ok := strings.Cut(firstMetadata(ctx, "authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ctx, nil
+	}
+
+	u, err := i.verifier.Verify(ctx, strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.WithUser(ctx, u), nil
+}
+
+// Access is what the caller of a method must be allowed to do: Scope, when
+// it calls with an API key, or Role, when it calls as a user.
+type Access struct {
+	Scope domain.Scope
+	Role  domain.Role
+}
+
+// StreetMarketServiceAccess is the access of each method of the street market
+// service, the same as of its routes in the HTTP API.
+func StreetMarketServiceAccess() map[string]Access {
+	method := func(name string) string {
+		return fmt.Sprintf("/%s/%s", streetmarketv1.StreetMarketService_ServiceDesc.ServiceName, name)
+	}
+
+	return map[string]Access{
+		method("Get"):    {domain.ScopeMarketsRead, domain.RoleViewer},
+		method("List"):   {domain.ScopeMarketsRead, domain.RoleViewer},
+		method("Create"): {domain.ScopeMarketsWrite, domain.RoleEditor},
+		method("Update"): {domain.ScopeMarketsWrite, domain.RoleEditor},
+		method("Delete"): {domain.ScopeMarketsDelete, domain.RoleAdmin},
+	}
+}
+
+// AccessInterceptor is the counterpart of the access middleware of the HTTP
+// API: it lets through the calls whose caller, identified by APIKeyInterceptor
+// or BearerTokenInterceptor, has the access of the method. Methods without an
+// access are refused to everyone.
+type AccessInterceptor struct {
+	access map[string]Access
+}
+
+func NewAccessInterceptor(access map[string]Access) *AccessInterceptor {
+	return &AccessInterceptor{access}
+}
+
+func (i *AccessInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return unaryAuth(i.authorize)
+}
+
+func (i *AccessInterceptor) Stream() grpc.StreamServerInterceptor {
+	return streamAuth(i.authorize)
+}
+
+func (i *AccessInterceptor) authorize(ctx context.Context, method string) (context.Context, *domain.Error) {
+	a, ok := i.access[method]
+	if !ok {
+		return nil, &domain.Error{
+			Kind: domain.ForbiddenErrKd,
+			Msg:  fmt.Sprintf("The method %s is not open to anyone", method),
+		}
+	}
+
+	if err := domain.Authorize(ctx, a.Scope, a.Role); err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
@@ -0,0 +1,210 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/grpcapi/streetmarketv1"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// stubAPIKeyAuthenticator knows the keys by their token.
+type stubAPIKeyAuthenticator map[string]domain.APIKey
+
+func (s stubAPIKeyAuthenticator) Authenticate(_ context.Context, token string) (domain.APIKey, *domain.Error) {
+	k, ok := s[token]
+	if !ok {
+		return domain.APIKey{}, &domain.Error{Kind: domain.UnauthenticatedErrKd, Msg: "The API key is not valid"}
+	}
+
+	return k, nil
+}
+
+// stubTokenVerifier knows the users by their token.
+type stubTokenVerifier map[string]domain.User
+
+func (s stubTokenVerifier) Verify(_ context.Context, token string) (domain.User, *domain.Error) {
+	u, ok := s[token]
+	if !ok {
+		return domain.User{}, &domain.Error{Kind: domain.UnauthenticatedErrKd, Msg: "The token is not valid"}
+	}
+
+	return u, nil
+}
+
+// dialAuth serves a street market server whose calls all succeed behind the
+// authentication and access interceptors.
+func dialAuth(t *testing.T) streetmarketv1.StreetMarketServiceClient {
+	t.Helper()
+
+	reader := &stubStreetMarketReader{
+		get:  func() (domain.StreetMarket, *domain.Error) { return domain.StreetMarket{}, nil },
+		walk: func(fn func(domain.StreetMarket) error) *domain.Error { return nil },
+	}
+	writer := &stubStreetMarketWriter{
+		create: func() (string, *domain.Error) { return "1966d99f-20e8-4e5e-8f68-eb88ca67f95f", nil },
+		edit:   func() *domain.Error { return nil },
+	}
+	eraser := &stubStreetMarketEraser{delete: func() *domain.Error { return nil }}
+
+	keys := stubAPIKeyAuthenticator{
+		"reader.s3cr3t": {ID: "reader", Scopes: []domain.Scope{domain.ScopeMarketsRead}},
+		"writer.s3cr3t": {ID: "writer", Scopes: []domain.Scope{domain.ScopeMarketsRead, domain.ScopeMarketsWrite}},
+	}
+	users := stubTokenVerifier{
+		"viewer-token": {Subject: "viewer", Role: domain.RoleViewer},
+		"editor-token": {Subject: "editor", Role: domain.RoleEditor},
+	}
+
+	apiKeyIntc := NewAPIKeyInterceptor(keys, &stubLogger{})
+	bearerIntc := NewBearerTokenInterceptor(users)
+	accessIntc := NewAccessInterceptor(StreetMarketServiceAccess())
+
+	return dial(
+		t,
+		NewStreetMarketServer(reader, writer, eraser, &stubLogger{}),
+		func() string { return "generated-id" },
+		grpc.ChainUnaryInterceptor(apiKeyIntc.Unary(), bearerIntc.Unary(), accessIntc.Unary()),
+		grpc.ChainStreamInterceptor(apiKeyIntc.Stream(), bearerIntc.Stream(), accessIntc.Stream()),
+	)
+}
+
+func TestAuthInterceptors_Unary(t *testing.T) {
+	create := func(ctx context.Context, c streetmarketv1.StreetMarketServiceClient) error {
+		_, err := c.Create(ctx, &streetmarketv1.CreateRequest{})
+		return err
+	}
+	get := func(ctx context.Context, c streetmarketv1.StreetMarketServiceClient) error {
+		_, err := c.Get(ctx, &streetmarketv1.GetRequest{})
+		return err
+	}
+	remove := func(ctx context.Context, c streetmarketv1.StreetMarketServiceClient) error {
+		_, err := c.Delete(ctx, &streetmarketv1.DeleteRequest{})
+		return err
+	}
+
+	testCases := map[string]struct {
+		md   metadata.MD
+		call func(context.Context, streetmarketv1.StreetMarketServiceClient) error
+		want codes.Code
+	}{
+		"When a write is called without credentials": {
+			md:   metadata.MD{},
+			call: create,
+			want: codes.Unauthenticated,
+		},
+		"When a read is called without credentials": {
+			md:   metadata.MD{},
+			call: get,
+			want: codes.Unauthenticated,
+		},
+		"When the API key is not valid": {
+			md:   metadata.Pairs(APIKeyMetadata, "reader.guess"),
+			call: get,
+			want: codes.Unauthenticated,
+		},
+		"When the bearer token is not valid": {
+			md:   metadata.Pairs("authorization", "Bearer guess"),
+			call: get,
+			want: codes.Unauthenticated,
+		},
+		"When the API key may read": {
+			md:   metadata.Pairs(APIKeyMetadata, "reader.s3cr3t"),
+			call: get,
+			want: codes.OK,
+		},
+		"When the API key may not write": {
+			md:   metadata.Pairs(APIKeyMetadata, "reader.s3cr3t"),
+			call: create,
+			want: codes.PermissionDenied,
+		},
+		"When the API key may write": {
+			md:   metadata.Pairs(APIKeyMetadata, "writer.s3cr3t"),
+			call: create,
+			want: codes.OK,
+		},
+		"When the API key may not delete": {
+			md:   metadata.Pairs(APIKeyMetadata, "writer.s3cr3t"),
+			call: remove,
+			want: codes.PermissionDenied,
+		},
+		"When a viewer writes": {
+			md:   metadata.Pairs("authorization", "Bearer viewer-token"),
+			call: create,
+			want: codes.PermissionDenied,
+		},
+		"When an editor writes": {
+			md:   metadata.Pairs("authorization", "Bearer editor-token"),
+			call: create,
+			want: codes.OK,
+		},
+		"When an editor deletes": {
+			md:   metadata.Pairs("authorization", "Bearer editor-token"),
+			call: remove,
+			want: codes.PermissionDenied,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			client := dialAuth(t)
+
+			err := tc.call(metadata.NewOutgoingContext(context.Background(), tc.md), client)
+			if got := status.Code(err); got != tc.want {
+				t.Errorf("expect code %s, got %s (%v)", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptors_Stream(t *testing.T) {
+	testCases := map[string]struct {
+		md   metadata.MD
+		want codes.Code
+	}{
+		"When it is called without credentials": {
+			md:   metadata.MD{},
+			want: codes.Unauthenticated,
+		},
+		"When the caller may read": {
+			md:   metadata.Pairs("authorization", "Bearer viewer-token"),
+			want: codes.OK,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			client := dialAuth(t)
+
+			stream, err := client.List(metadata.NewOutgoingContext(context.Background(), tc.md), &streetmarketv1.ListRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = stream.Recv()
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			if got := status.Code(err); got != tc.want {
+				t.Errorf("expect code %s, got %s (%v)", tc.want, got, err)
+			}
+		})
+	}
+}
+
+func TestAccessInterceptor_UnknownMethod(t *testing.T) {
+	ctx := domain.WithUser(context.Background(), domain.User{Subject: "admin", Role: domain.RoleAdmin})
+
+	i := NewAccessInterceptor(StreetMarketServiceAccess())
+
+	_, err := i.authorize(ctx, "/streetmarket.v1.StreetMarketService/Purge")
+	if err == nil || err.Kind != domain.ForbiddenErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.ForbiddenErrKd, err)
+	}
+}
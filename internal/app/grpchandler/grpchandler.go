@@ -0,0 +1,92 @@
+// Package grpchandler serves the gRPC API, the counterpart of httphandler for
+// the services defined in the proto directory.
+package grpchandler
+
+import (
+	"context"
+	"strings"
+
+	apistatus "github.com/Danielsilveira98/unicoAPITest/internal/app/status"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// errorDomain is the domain of the google.rpc.ErrorInfo details.
+const errorDomain = "unicoapitest"
+
+// grpcCode is the status code every service responds an error kind with, as
+// apistatus.Of tells along with its HTTP status.
+func grpcCode(kind domain.KindError) codes.Code {
+	return apistatus.Of(kind).GRPC
+}
+
+type errorLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+// logUnexpected logs err when it is not the client's fault.
+func logUnexpected(ctx context.Context, logger errorLogger, err *domain.Error) {
+	if grpcCode(err.Kind) == codes.Internal {
+		logger.Error(ctx, *err)
+	}
+}
+
+// statusError converts err to a status error. As in the HTTP API, the message
+// of unexpected errors is kept out of the response. Field names are translated
+// to the fields of msg, the message the client sent, prefixed by parent.
+func statusError(err *domain.Error, msg protoreflect.ProtoMessage, parent string) error {
+	code := grpcCode(err.Kind)
+
+	detail := err.Error()
+	if code == codes.Internal {
+		detail = "Unexpected error"
+	}
+
+	st := status.New(code, detail)
+
+	br := &errdetails.BadRequest{}
+	for _, f := range err.Fields {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       protoField(msg, parent, f.Field),
+			Description: f.Msg,
+		})
+	}
+
+	info := &errdetails.ErrorInfo{Reason: string(err.Kind), Domain: errorDomain}
+
+	withDetails, dErr := st.WithDetails(info)
+	if len(br.FieldViolations) > 0 {
+		withDetails, dErr = st.WithDetails(info, br)
+	}
+	if dErr != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
+
+// protoField returns the field name of msg matching the input struct field
+// name, as street_market.id_dist for IDdist, or name itself when msg has no
+// such field.
+func protoField(msg protoreflect.ProtoMessage, parent, name string) string {
+	if msg == nil {
+		return name
+	}
+
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fn := string(fields.Get(i).Name())
+		if strings.EqualFold(strings.ReplaceAll(fn, "_", ""), name) {
+			if parent == "" {
+				return fn
+			}
+
+			return parent + "." + fn
+		}
+	}
+
+	return name
+}
@@ -0,0 +1,156 @@
+package grpchandler
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/grpcapi/streetmarketv1"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+type stubLogger struct {
+	errors []domain.Error
+}
+
+func (s *stubLogger) Error(ctx context.Context, err domain.Error) {
+	s.errors = append(s.errors, err)
+}
+
+// dial serves srv in memory, behind the trace id interceptor and then the
+// ones of opts, and returns a client of it.
+func dial(
+	t *testing.T,
+	srv streetmarketv1.StreetMarketServiceServer,
+	idGen idGen,
+	opts ...grpc.ServerOption,
+) streetmarketv1.StreetMarketServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+
+	tcIDIntc := NewTraceIDInterceptor(idGen)
+	s := grpc.NewServer(append(
+		[]grpc.ServerOption{grpc.ChainUnaryInterceptor(tcIDIntc.Unary()), grpc.ChainStreamInterceptor(tcIDIntc.Stream())},
+		opts...,
+	)...)
+	streetmarketv1.RegisterStreetMarketServiceServer(s, srv)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return streetmarketv1.NewStreetMarketServiceClient(conn)
+}
+
+func TestGRPCCode(t *testing.T) {
+	testCases := map[domain.KindError]codes.Code{
+		domain.InpValidationErrKd:        codes.InvalidArgument,
+		domain.MissingRefErrKd:           codes.InvalidArgument,
+		domain.SMNotFoundErrKd:           codes.NotFound,
+		domain.SnapNotFoundErrKd:         codes.NotFound,
+		domain.StallNotFoundErrKd:        codes.NotFound,
+		domain.SubTHNotFoundErrKd:        codes.NotFound,
+		domain.RouteNotFoundErrKd:        codes.NotFound,
+		domain.NothingFoundErrKd:         codes.NotFound,
+		domain.MethodNotAllowedErrKd:     codes.Unimplemented,
+		domain.DuplicatedErrKd:           codes.AlreadyExists,
+		domain.StallDupErrKd:             codes.AlreadyExists,
+		domain.UnauthenticatedErrKd:      codes.Unauthenticated,
+		domain.ForbiddenErrKd:            codes.PermissionDenied,
+		domain.RateLimitedErrKd:          codes.ResourceExhausted,
+		domain.OverloadedErrKd:           codes.Unavailable,
+		domain.BodyTooLargeErrKd:         codes.ResourceExhausted,
+		domain.UnsupportedMediaTypeErrKd: codes.InvalidArgument,
+		domain.UnexpectedErrKd:           codes.Internal,
+		domain.NothingCreatedErrKd:       codes.Internal,
+	}
+
+	for kind, want := range testCases {
+		t.Run(string(kind), func(t *testing.T) {
+			if got := status.Code(statusError(&domain.Error{Kind: kind}, nil, "")); got != want {
+				t.Errorf("expect %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	testCases := map[string]struct {
+		err         *domain.Error
+		wantCode    codes.Code
+		wantMsg     string
+		wantDetails []interface{}
+	}{
+		"When error has fields": {
+			err: &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Invalid input",
+				Fields: []domain.FieldError{
+					{Field: "IDdist", Code: domain.RequiredFieldCd, Msg: "IDdist is required"},
+					{Field: "Region5", Code: domain.RequiredFieldCd, Msg: "Region5 is required"},
+					{Field: "Unknown", Code: domain.RequiredFieldCd, Msg: "Unknown is required"},
+				},
+			},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "Invalid input",
+			wantDetails: []interface{}{
+				&errdetails.ErrorInfo{Reason: string(domain.InpValidationErrKd), Domain: errorDomain},
+				&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+					{Field: "street_market.id_dist", Description: "IDdist is required"},
+					{Field: "street_market.region_5", Description: "Region5 is required"},
+					{Field: "Unknown", Description: "Unknown is required"},
+				}},
+			},
+		},
+		"When error is unexpected": {
+			err:      &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "pq: connection refused"},
+			wantCode: codes.Internal,
+			wantMsg:  "Unexpected error",
+			wantDetails: []interface{}{
+				&errdetails.ErrorInfo{Reason: string(domain.UnexpectedErrKd), Domain: errorDomain},
+			},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			st := status.Convert(statusError(tc.err, &streetmarketv1.StreetMarketInput{}, "street_market"))
+
+			if st.Code() != tc.wantCode {
+				t.Errorf("expect code %v, got %v", tc.wantCode, st.Code())
+			}
+
+			if st.Message() != tc.wantMsg {
+				t.Errorf("expect message %q, got %q", tc.wantMsg, st.Message())
+			}
+
+			if diff := cmp.Diff(tc.wantDetails, st.Details(), protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected details (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/grpcapi/streetmarketv1"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"google.golang.org/grpc/status"
+)
+
+type streetMarketReader interface {
+	Get(context.Context, domain.SMID) (domain.StreetMarket, *domain.Error)
+	Walk(context.Context, domain.StreetMarketFilter, func(domain.StreetMarket) error) *domain.Error
+}
+
+type streetMarketWriter interface {
+	Create(context.Context, domain.StreetMarketCreateInput) (string, *domain.Error)
+	Edit(context.Context, domain.SMID, domain.StreetMarketEditInput) *domain.Error
+}
+
+type streetMarketEraser interface {
+	Delete(context.Context, domain.SMID) *domain.Error
+}
+
+type StreetMarketServer struct {
+	streetmarketv1.UnimplementedStreetMarketServiceServer
+	reader streetMarketReader
+	writer streetMarketWriter
+	eraser streetMarketEraser
+	logger errorLogger
+}
+
+func NewStreetMarketServer(
+	reader streetMarketReader,
+	writer streetMarketWriter,
+	eraser streetMarketEraser,
+	logger errorLogger,
+) *StreetMarketServer {
+	return &StreetMarketServer{reader: reader, writer: writer, eraser: eraser, logger: logger}
+}
+
+func (s *StreetMarketServer) Get(
+	ctx context.Context,
+	req *streetmarketv1.GetRequest,
+) (*streetmarketv1.StreetMarket, error) {
+	sm, dErr := s.reader.Get(ctx, domain.SMID(req.GetId()))
+	if dErr != nil {
+		logUnexpected(ctx, s.logger, dErr)
+		return nil, statusError(dErr, req, "")
+	}
+
+	return toStreetMarket(sm), nil
+}
+
+func (s *StreetMarketServer) List(
+	req *streetmarketv1.ListRequest,
+	stream streetmarketv1.StreetMarketService_ListServer,
+) error {
+	ctx := stream.Context()
+
+	f := domain.StreetMarketFilter{
+		District:     req.GetDistrict(),
+		Region5:      req.GetRegion5(),
+		Name:         req.GetName(),
+		Neighborhood: req.GetNeighborhood(),
+	}
+
+	if sells := req.GetSells(); sells != "" {
+		pc := domain.ProductCategory(sells)
+		if err := pc.Validate(); err != nil {
+			dErr := &domain.Error{Kind: domain.InpValidationErrKd, Msg: "sells must be a product category", Previous: err}
+			return statusError(dErr, req, "")
+		}
+		f.Sells = pc
+	}
+
+	dErr := s.reader.Walk(ctx, f, func(sm domain.StreetMarket) error {
+		return stream.Send(toStreetMarket(sm))
+	})
+	if dErr != nil {
+		// The client is gone, there is nobody to respond to.
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+
+		logUnexpected(ctx, s.logger, dErr)
+		return statusError(dErr, req, "")
+	}
+
+	return nil
+}
+
+func (s *StreetMarketServer) Create(
+	ctx context.Context,
+	req *streetmarketv1.CreateRequest,
+) (*streetmarketv1.CreateResponse, error) {
+	in := req.GetStreetMarket()
+
+	id, dErr := s.writer.Create(ctx, domain.StreetMarketCreateInput{
+		Long:          in.GetLong(),
+		Lat:           in.GetLat(),
+		SectCens:      in.GetSectCens(),
+		Area:          in.GetArea(),
+		IDdist:        in.GetIdDist(),
+		District:      in.GetDistrict(),
+		IDSubTH:       in.GetIdSubTh(),
+		SubTownHall:   in.GetSubtownhall(),
+		Region5:       in.GetRegion_5(),
+		Region8:       in.GetRegion_8(),
+		Name:          in.GetName(),
+		Register:      in.GetRegister(),
+		Street:        in.GetStreet(),
+		Number:        in.GetNumber(),
+		Neighborhood:  in.GetNeighborhood(),
+		AddrExtraInfo: in.GetAddrExtraInfo(),
+	})
+	if dErr != nil {
+		logUnexpected(ctx, s.logger, dErr)
+		return nil, statusError(dErr, &streetmarketv1.StreetMarketInput{}, "street_market")
+	}
+
+	return &streetmarketv1.CreateResponse{Id: id}, nil
+}
+
+func (s *StreetMarketServer) Update(
+	ctx context.Context,
+	req *streetmarketv1.UpdateRequest,
+) (*streetmarketv1.UpdateResponse, error) {
+	in := req.GetStreetMarket()
+
+	dErr := s.writer.Edit(ctx, domain.SMID(req.GetId()), domain.StreetMarketEditInput{
+		Long:          in.GetLong(),
+		Lat:           in.GetLat(),
+		SectCens:      in.GetSectCens(),
+		Area:          in.GetArea(),
+		IDdist:        in.GetIdDist(),
+		District:      in.GetDistrict(),
+		IDSubTH:       in.GetIdSubTh(),
+		SubTownHall:   in.GetSubtownhall(),
+		Region5:       in.GetRegion_5(),
+		Region8:       in.GetRegion_8(),
+		Name:          in.GetName(),
+		Register:      in.GetRegister(),
+		Street:        in.GetStreet(),
+		Number:        in.GetNumber(),
+		Neighborhood:  in.GetNeighborhood(),
+		AddrExtraInfo: in.GetAddrExtraInfo(),
+	})
+	if dErr != nil {
+		logUnexpected(ctx, s.logger, dErr)
+		return nil, statusError(dErr, &streetmarketv1.StreetMarketInput{}, "street_market")
+	}
+
+	return &streetmarketv1.UpdateResponse{}, nil
+}
+
+func (s *StreetMarketServer) Delete(
+	ctx context.Context,
+	req *streetmarketv1.DeleteRequest,
+) (*streetmarketv1.DeleteResponse, error) {
+	if dErr := s.eraser.Delete(ctx, domain.SMID(req.GetId())); dErr != nil {
+		logUnexpected(ctx, s.logger, dErr)
+		return nil, statusError(dErr, req, "")
+	}
+
+	return &streetmarketv1.DeleteResponse{}, nil
+}
+
+func toStreetMarket(sm domain.StreetMarket) *streetmarketv1.StreetMarket {
+	return &streetmarketv1.StreetMarket{
+		Id:            sm.ID,
+		Long:          sm.Long,
+		Lat:           sm.Lat,
+		SectCens:      sm.SectCens,
+		Area:          sm.Area,
+		IdDist:        sm.IDdist,
+		District:      sm.District,
+		IdSubTh:       sm.IDSubTH,
+		Subtownhall:   sm.SubTownHall,
+		Region_5:      sm.Region5,
+		Region_8:      sm.Region8,
+		Name:          sm.Name,
+		Register:      sm.Register,
+		Street:        sm.Street,
+		Number:        sm.Number,
+		Neighborhood:  sm.Neighborhood,
+		AddrExtraInfo: sm.AddrExtraInfo,
+	}
+}
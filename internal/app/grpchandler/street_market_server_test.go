@@ -0,0 +1,393 @@
+package grpchandler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/grpcapi/streetmarketv1"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+type stubStreetMarketReader struct {
+	getInp  domain.SMID
+	get     func() (domain.StreetMarket, *domain.Error)
+	walkInp domain.StreetMarketFilter
+	walk    func(fn func(domain.StreetMarket) error) *domain.Error
+}
+
+func (s *stubStreetMarketReader) Get(ctx context.Context, ID domain.SMID) (domain.StreetMarket, *domain.Error) {
+	s.getInp = ID
+	return s.get()
+}
+
+func (s *stubStreetMarketReader) Walk(
+	ctx context.Context,
+	f domain.StreetMarketFilter,
+	fn func(domain.StreetMarket) error,
+) *domain.Error {
+	s.walkInp = f
+	return s.walk(fn)
+}
+
+type stubStreetMarketWriter struct {
+	createInp domain.StreetMarketCreateInput
+	create    func() (string, *domain.Error)
+	editID    domain.SMID
+	editInp   domain.StreetMarketEditInput
+	edit      func() *domain.Error
+}
+
+func (s *stubStreetMarketWriter) Create(
+	ctx context.Context,
+	inp domain.StreetMarketCreateInput,
+) (string, *domain.Error) {
+	s.createInp = inp
+	return s.create()
+}
+
+func (s *stubStreetMarketWriter) Edit(
+	ctx context.Context,
+	ID domain.SMID,
+	inp domain.StreetMarketEditInput,
+) *domain.Error {
+	s.editID = ID
+	s.editInp = inp
+	return s.edit()
+}
+
+type stubStreetMarketEraser struct {
+	deleteInp domain.SMID
+	delete    func() *domain.Error
+}
+
+func (s *stubStreetMarketEraser) Delete(ctx context.Context, ID domain.SMID) *domain.Error {
+	s.deleteInp = ID
+	return s.delete()
+}
+
+func streetMarket() domain.StreetMarket {
+	return domain.StreetMarket{
+		ID:            uuid.NewString(),
+		Long:          -46548146,
+		Lat:           -23568390,
+		SectCens:      "355030885000019",
+		Area:          "3550308005040",
+		IDdist:        "87",
+		District:      "VILA FORMOSA",
+		IDSubTH:       "26",
+		SubTownHall:   "ARICANDUVA",
+		Region5:       "Leste",
+		Region8:       "Leste 1",
+		Name:          "RAPOSO TAVARES",
+		Register:      "1129-0",
+		Street:        "Rua dos Bobos",
+		Number:        "500",
+		Neighborhood:  "JARDIM SARAH",
+		AddrExtraInfo: "Loren ipsum",
+	}
+}
+
+func TestStreetMarketServer_Get(t *testing.T) {
+	sm := streetMarket()
+	reader := &stubStreetMarketReader{get: func() (domain.StreetMarket, *domain.Error) { return sm, nil }}
+
+	client := dial(t, NewStreetMarketServer(reader, nil, nil, &stubLogger{}), uuid.NewString)
+
+	got, err := client.Get(context.Background(), &streetmarketv1.GetRequest{Id: sm.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(toStreetMarket(sm), got, protocmp.Transform()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if reader.getInp != domain.SMID(sm.ID) {
+		t.Errorf("expect get %s, got %s", sm.ID, reader.getInp)
+	}
+}
+
+func TestStreetMarketServer_Get_Error(t *testing.T) {
+	testCases := map[string]struct {
+		err      *domain.Error
+		wantCode codes.Code
+		wantLog  bool
+	}{
+		"When street market is not found": {
+			err:      &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists"},
+			wantCode: codes.NotFound,
+		},
+		"When ID is invalid": {
+			err:      &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Invalid ID"},
+			wantCode: codes.InvalidArgument,
+		},
+		"When an unexpected error occurs": {
+			err:      &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when get"},
+			wantCode: codes.Internal,
+			wantLog:  true,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			reader := &stubStreetMarketReader{get: func() (domain.StreetMarket, *domain.Error) {
+				return domain.StreetMarket{}, tc.err
+			}}
+			logger := &stubLogger{}
+
+			client := dial(t, NewStreetMarketServer(reader, nil, nil, logger), uuid.NewString)
+
+			_, err := client.Get(context.Background(), &streetmarketv1.GetRequest{Id: "id"})
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("expect code %v, got %v", tc.wantCode, got)
+			}
+
+			if got := len(logger.errors) == 1; got != tc.wantLog {
+				t.Errorf("expect logged %v, got %v", tc.wantLog, logger.errors)
+			}
+		})
+	}
+}
+
+func TestStreetMarketServer_List(t *testing.T) {
+	want := []domain.StreetMarket{streetMarket(), streetMarket()}
+	reader := &stubStreetMarketReader{walk: func(fn func(domain.StreetMarket) error) *domain.Error {
+		for _, sm := range want {
+			if err := fn(sm); err != nil {
+				return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+			}
+		}
+		return nil
+	}}
+
+	client := dial(t, NewStreetMarketServer(reader, nil, nil, &stubLogger{}), uuid.NewString)
+
+	stream, err := client.List(context.Background(), &streetmarketv1.ListRequest{
+		District: "VILA FORMOSA",
+		Region5:  "Leste",
+		Sells:    "fish",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []*streetmarketv1.StreetMarket{}
+	for {
+		sm, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, sm)
+	}
+
+	wantPb := []*streetmarketv1.StreetMarket{toStreetMarket(want[0]), toStreetMarket(want[1])}
+	if diff := cmp.Diff(wantPb, got, protocmp.Transform()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	wantF := domain.StreetMarketFilter{District: "VILA FORMOSA", Region5: "Leste", Sells: domain.ProductCategory("fish")}
+	if diff := cmp.Diff(wantF, reader.walkInp); diff != "" {
+		t.Errorf("unexpected filter (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreetMarketServer_List_Error(t *testing.T) {
+	testCases := map[string]struct {
+		req      *streetmarketv1.ListRequest
+		err      *domain.Error
+		wantCode codes.Code
+	}{
+		"When sells is not a product category": {
+			req:      &streetmarketv1.ListRequest{Sells: "cars"},
+			wantCode: codes.InvalidArgument,
+		},
+		"When an unexpected error occurs": {
+			req:      &streetmarketv1.ListRequest{},
+			err:      &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing"},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			reader := &stubStreetMarketReader{walk: func(fn func(domain.StreetMarket) error) *domain.Error {
+				return tc.err
+			}}
+
+			client := dial(t, NewStreetMarketServer(reader, nil, nil, &stubLogger{}), uuid.NewString)
+
+			stream, err := client.List(context.Background(), tc.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = stream.Recv()
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("expect code %v, got %v", tc.wantCode, got)
+			}
+		})
+	}
+}
+
+func TestStreetMarketServer_Create(t *testing.T) {
+	sm := streetMarket()
+	writer := &stubStreetMarketWriter{create: func() (string, *domain.Error) { return sm.ID, nil }}
+
+	client := dial(t, NewStreetMarketServer(nil, writer, nil, &stubLogger{}), uuid.NewString)
+
+	got, err := client.Create(context.Background(), &streetmarketv1.CreateRequest{
+		StreetMarket: &streetmarketv1.StreetMarketInput{
+			Long:          sm.Long,
+			Lat:           sm.Lat,
+			SectCens:      sm.SectCens,
+			Area:          sm.Area,
+			IdDist:        sm.IDdist,
+			District:      sm.District,
+			IdSubTh:       sm.IDSubTH,
+			Subtownhall:   sm.SubTownHall,
+			Region_5:      sm.Region5,
+			Region_8:      sm.Region8,
+			Name:          sm.Name,
+			Register:      sm.Register,
+			Street:        sm.Street,
+			Number:        sm.Number,
+			Neighborhood:  sm.Neighborhood,
+			AddrExtraInfo: sm.AddrExtraInfo,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.GetId() != sm.ID {
+		t.Errorf("expect id %s, got %s", sm.ID, got.GetId())
+	}
+
+	want := domain.StreetMarketCreateInput{
+		Long:          sm.Long,
+		Lat:           sm.Lat,
+		SectCens:      sm.SectCens,
+		Area:          sm.Area,
+		IDdist:        sm.IDdist,
+		District:      sm.District,
+		IDSubTH:       sm.IDSubTH,
+		SubTownHall:   sm.SubTownHall,
+		Region5:       sm.Region5,
+		Region8:       sm.Region8,
+		Name:          sm.Name,
+		Register:      sm.Register,
+		Street:        sm.Street,
+		Number:        sm.Number,
+		Neighborhood:  sm.Neighborhood,
+		AddrExtraInfo: sm.AddrExtraInfo,
+	}
+	if diff := cmp.Diff(want, writer.createInp); diff != "" {
+		t.Errorf("unexpected input (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreetMarketServer_Create_Error(t *testing.T) {
+	writer := &stubStreetMarketWriter{create: func() (string, *domain.Error) {
+		return "", &domain.Error{
+			Kind:   domain.InpValidationErrKd,
+			Msg:    "Invalid input",
+			Fields: []domain.FieldError{{Field: "SubTownHall", Code: domain.RequiredFieldCd, Msg: "SubTownHall is required"}},
+		}
+	}}
+
+	client := dial(t, NewStreetMarketServer(nil, writer, nil, &stubLogger{}), uuid.NewString)
+
+	_, err := client.Create(context.Background(), &streetmarketv1.CreateRequest{})
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("expect code %v, got %v", codes.InvalidArgument, st.Code())
+	}
+
+	if len(st.Details()) != 2 {
+		t.Fatalf("expect error info and bad request details, got %v", st.Details())
+	}
+}
+
+func TestStreetMarketServer_Update(t *testing.T) {
+	testCases := map[string]struct {
+		err      *domain.Error
+		wantCode codes.Code
+	}{
+		"When street market is edited": {
+			wantCode: codes.OK,
+		},
+		"When street market is not found": {
+			err:      &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists"},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			writer := &stubStreetMarketWriter{edit: func() *domain.Error { return tc.err }}
+
+			client := dial(t, NewStreetMarketServer(nil, writer, nil, &stubLogger{}), uuid.NewString)
+
+			id := uuid.NewString()
+			_, err := client.Update(context.Background(), &streetmarketv1.UpdateRequest{
+				Id:           id,
+				StreetMarket: &streetmarketv1.StreetMarketInput{Name: "RAPOSO TAVARES"},
+			})
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("expect code %v, got %v", tc.wantCode, got)
+			}
+
+			if writer.editID != domain.SMID(id) {
+				t.Errorf("expect edit %s, got %s", id, writer.editID)
+			}
+
+			if diff := cmp.Diff(domain.StreetMarketEditInput{Name: "RAPOSO TAVARES"}, writer.editInp); diff != "" {
+				t.Errorf("unexpected input (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStreetMarketServer_Delete(t *testing.T) {
+	testCases := map[string]struct {
+		err      *domain.Error
+		wantCode codes.Code
+	}{
+		"When street market is deleted": {
+			wantCode: codes.OK,
+		},
+		"When street market is not found": {
+			err:      &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists"},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			eraser := &stubStreetMarketEraser{delete: func() *domain.Error { return tc.err }}
+
+			client := dial(t, NewStreetMarketServer(nil, nil, eraser, &stubLogger{}), uuid.NewString)
+
+			id := uuid.NewString()
+			_, err := client.Delete(context.Background(), &streetmarketv1.DeleteRequest{Id: id})
+			if got := status.Code(err); got != tc.wantCode {
+				t.Errorf("expect code %v, got %v", tc.wantCode, got)
+			}
+
+			if eraser.deleteInp != domain.SMID(id) {
+				t.Errorf("expect delete %s, got %s", id, eraser.deleteInp)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package grpchandler
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type idGen func() string
+
+// TraceIDInterceptor is the counterpart of the trace id middleware of the HTTP
+// API: it reads the trace-id metadata key, generating the id when missing,
+// puts it in the context and sends it back in the response header.
+type TraceIDInterceptor struct {
+	idGen idGen
+}
+
+func NewTraceIDInterceptor(idGen idGen) *TraceIDInterceptor {
+	return &TraceIDInterceptor{idGen}
+}
+
+func (i *TraceIDInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, md := i.traceID(ctx)
+		if err := grpc.SetHeader(ctx, md); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (i *TraceIDInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, md := i.traceID(ss.Context())
+		if err := ss.SetHeader(md); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return handler(srv, &contextStream{ss, ctx})
+	}
+}
+
+func (i *TraceIDInterceptor) traceID(ctx context.Context) (context.Context, metadata.MD) {
+	key := string(domain.TraceIDCtxKey)
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	traceID := i.idGen()
+	if v := md.Get(key); len(v) > 0 && v[0] != "" {
+		traceID = v[0]
+	}
+
+	return context.WithValue(ctx, domain.TraceIDCtxKey, traceID), metadata.Pairs(key, traceID)
+}
+
+// contextStream is a server stream whose context is ctx, as the interceptors
+// put what they learn of the call in it.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
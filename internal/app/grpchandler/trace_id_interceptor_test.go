@@ -0,0 +1,99 @@
+package grpchandler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/grpcapi/streetmarketv1"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTraceIDInterceptor(t *testing.T) {
+	testCases := map[string]struct {
+		md   metadata.MD
+		want string
+	}{
+		"When trace id is sent": {
+			md:   metadata.Pairs("trace-id", "sent-id"),
+			want: "sent-id",
+		},
+		"When trace id is missing": {
+			md:   metadata.MD{},
+			want: "generated-id",
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			var gotCtx []string
+			reader := &stubStreetMarketReader{}
+			reader.get = func() (domain.StreetMarket, *domain.Error) {
+				return domain.StreetMarket{}, nil
+			}
+			reader.walk = func(fn func(domain.StreetMarket) error) *domain.Error {
+				return nil
+			}
+
+			srv := &traceRecorder{StreetMarketServer: NewStreetMarketServer(reader, nil, nil, &stubLogger{}), got: &gotCtx}
+			client := dial(t, srv, func() string { return "generated-id" })
+
+			ctx := metadata.NewOutgoingContext(context.Background(), tc.md)
+
+			var header metadata.MD
+			if _, err := client.Get(ctx, &streetmarketv1.GetRequest{}, grpc.Header(&header)); err != nil {
+				t.Fatal(err)
+			}
+			if got := header.Get("trace-id"); len(got) != 1 || got[0] != tc.want {
+				t.Errorf("unary: expect header trace id %s, got %v", tc.want, got)
+			}
+
+			stream, err := client.List(ctx, &streetmarketv1.ListRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			header, err = stream.Header()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := header.Get("trace-id"); len(got) != 1 || got[0] != tc.want {
+				t.Errorf("stream: expect header trace id %s, got %v", tc.want, got)
+			}
+			_, _ = stream.Recv()
+
+			for _, got := range gotCtx {
+				if got != tc.want {
+					t.Errorf("expect context trace id %s, got %s", tc.want, got)
+				}
+			}
+			if len(gotCtx) != 2 {
+				t.Errorf("expect both calls recorded, got %v", gotCtx)
+			}
+		})
+	}
+}
+
+// traceRecorder records the trace id of the context of every call.
+type traceRecorder struct {
+	*StreetMarketServer
+	got *[]string
+}
+
+func (r *traceRecorder) Get(ctx context.Context, req *streetmarketv1.GetRequest) (*streetmarketv1.StreetMarket, error) {
+	r.record(ctx)
+	return r.StreetMarketServer.Get(ctx, req)
+}
+
+func (r *traceRecorder) List(
+	req *streetmarketv1.ListRequest,
+	stream streetmarketv1.StreetMarketService_ListServer,
+) error {
+	r.record(stream.Context())
+	return r.StreetMarketServer.List(req, stream)
+}
+
+func (r *traceRecorder) record(ctx context.Context) {
+	id, _ := ctx.Value(domain.TraceIDCtxKey).(string)
+	*r.got = append(*r.got, id)
+}
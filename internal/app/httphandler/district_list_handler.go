@@ -0,0 +1,58 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type districtLister interface {
+	Districts(context.Context) ([]domain.District, *domain.Error)
+}
+
+type districtListHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type districtResponse struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	SubTownHallID string `json:"subtownhall_id"`
+}
+
+type listDistrictResponse map[string][]districtResponse
+
+type DistrictListHandler struct {
+	lister districtLister
+	logger districtListHandlerLogger
+}
+
+func NewDistrictListHandler(
+	lister districtLister,
+	logger districtListHandlerLogger,
+) *DistrictListHandler {
+	return &DistrictListHandler{lister, logger}
+}
+
+func (h *DistrictListHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ds, err := h.lister.Districts(ctx)
+	if err != nil {
+		h.logger.Error(ctx, *err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, listDistrictResponse{"data": toDistrictResponses(ds)})
+}
+
+func toDistrictResponses(ds []domain.District) []districtResponse {
+	res := []districtResponse{}
+	for _, d := range ds {
+		res = append(res, districtResponse{ID: d.ID, Name: d.Name, SubTownHallID: d.SubTownHallID})
+	}
+
+	return res
+}
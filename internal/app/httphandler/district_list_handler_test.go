@@ -0,0 +1,84 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubDistrictLister struct {
+	districts func(context.Context) ([]domain.District, *domain.Error)
+}
+
+func (s *stubDistrictLister) Districts(ctx context.Context) ([]domain.District, *domain.Error) {
+	return s.districts(ctx)
+}
+
+func TestDistrictListHandler_Handle(t *testing.T) {
+	listerMock := &stubDistrictLister{
+		districts: func(context.Context) ([]domain.District, *domain.Error) {
+			return []domain.District{{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/districts", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewDistrictListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got listDistrictResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := listDistrictResponse{"data": {{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}}}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
+
+func TestDistrictListHandler_Handle_Error(t *testing.T) {
+	listerMock := &stubDistrictLister{
+		districts: func(context.Context) ([]domain.District, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"}
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/districts", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewDistrictListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusInternalServerError {
+		t.Errorf("expect status code %v, got %v", http.StatusInternalServerError, status)
+	}
+
+	var got ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
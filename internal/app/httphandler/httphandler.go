@@ -1,12 +1,31 @@
 package httphandler
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/status"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 )
 
-type ErrorResponse map[string]interface{}
+const problemContentType = "application/problem+json"
+
+// ErrorResponse is a RFC 7807 problem detail. Code is the stable error code
+// clients should match on, detail is meant for humans.
+type ErrorResponse struct {
+	Type    string               `json:"type"`
+	Title   string               `json:"title"`
+	Status  int                  `json:"status"`
+	Detail  string               `json:"detail"`
+	Code    domain.KindError     `json:"code"`
+	TraceID string               `json:"trace_id,omitempty"`
+	Errors  []fieldErrorResponse `json:"errors,omitempty"`
+}
 
 func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	response, err := json.Marshal(payload)
@@ -24,8 +43,129 @@ func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	}
 }
 
-func respondError(w http.ResponseWriter, code int, message string) {
-	respondJSON(w, code, ErrorResponse{"error": message})
+func respondBytes(w http.ResponseWriter, status int, contentType string, payload []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if _, err := w.Write(payload); err != nil {
+		fmt.Println(err) // TODO log here
+	}
+}
+
+// respondError responds err as a problem detail with the status of its kind.
+// The message of unexpected errors is kept out of the response, as it may be
+// the raw database error, and is found in the logs through the trace id.
+func respondError(w http.ResponseWriter, r *http.Request, err *domain.Error) {
+	respondProblem(w, newProblem(r, httpStatus(err.Kind), err))
+}
+
+// RespondError is respondError for the middlewares, which respond errors before
+// any handler runs. Field names are responded as they are.
+func RespondError(w http.ResponseWriter, r *http.Request, err *domain.Error) {
+	respondError(w, r, err)
+}
+
+// httpStatus is the status every handler responds an error kind with, as
+// status.Of tells along with its gRPC code.
+func httpStatus(kind domain.KindError) int {
+	return status.Of(kind).HTTP
+}
+
+type errorLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+// logUnexpected logs err when it is not the client's fault.
+func logUnexpected(ctx context.Context, logger errorLogger, err *domain.Error) {
+	if httpStatus(err.Kind) >= http.StatusInternalServerError {
+		logger.Error(ctx, *err)
+	}
+}
+
+type fieldErrorResponse struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// respondInvalidInput responds 400 listing the field errors of err. Field names
+// are translated to the json keys of body, the struct the client sent.
+func respondInvalidInput(w http.ResponseWriter, r *http.Request, err *domain.Error, body interface{}) {
+	p := newProblem(r, http.StatusBadRequest, err)
+	p.Errors = nil
+	for _, f := range err.Fields {
+		p.Errors = append(p.Errors, fieldErrorResponse{Field: jsonKey(body, f.Field), Code: string(f.Code), Message: f.Msg})
+	}
+
+	respondProblem(w, p)
+}
+
+// NotFound and MethodNotAllowed replace the plain text responses of the router.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	respondError(w, r, &domain.Error{
+		Kind: domain.RouteNotFoundErrKd,
+		Msg:  fmt.Sprintf("%s does not exist", r.URL.Path),
+	})
+}
+
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	respondError(w, r, &domain.Error{
+		Kind: domain.MethodNotAllowedErrKd,
+		Msg:  fmt.Sprintf("%s is not allowed on %s", r.Method, r.URL.Path),
+	})
+}
+
+func newProblem(r *http.Request, status int, err *domain.Error) ErrorResponse {
+	detail := err.Error()
+	if status == http.StatusInternalServerError {
+		detail = "Unexpected error"
+	}
+
+	traceID, _ := r.Context().Value(domain.TraceIDCtxKey).(string)
+
+	p := ErrorResponse{
+		Type:    problemType(err.Kind),
+		Title:   http.StatusText(status),
+		Status:  status,
+		Detail:  detail,
+		Code:    err.Kind,
+		TraceID: traceID,
+	}
+	for _, f := range err.Fields {
+		p.Errors = append(p.Errors, fieldErrorResponse{Field: f.Field, Code: string(f.Code), Message: f.Msg})
+	}
+
+	return p
+}
+
+// problemType is the problem type URI of an error code, as in
+// /problems/street-market-not-found.
+func problemType(kind domain.KindError) string {
+	return "/problems/" + strings.ToLower(strings.ReplaceAll(string(kind), "_", "-"))
+}
+
+func respondProblem(w http.ResponseWriter, p ErrorResponse) {
+	response, err := json.Marshal(p)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	respondBytes(w, p.Status, problemContentType, response)
+}
+
+// jsonKey returns the json key of the field name of body, or name itself when
+// body has no such field.
+func jsonKey(body interface{}, name string) string {
+	f, ok := reflect.TypeOf(body).FieldByName(name)
+	if !ok {
+		return name
+	}
+
+	key := strings.Split(f.Tag.Get("json"), ",")[0]
+	if key == "" {
+		return name
+	}
+
+	return key
 }
 
 type streetMarketBody struct {
@@ -48,21 +188,45 @@ type streetMarketBody struct {
 }
 
 type streetMarketResponse struct {
-	ID            string  `json:"id,omitempty"`
-	Long          float64 `json:"long,omitempty"`
-	Lat           float64 `json:"lat,omitempty"`
-	SectCens      string  `json:"sect_cens,omitempty"`
-	Area          string  `json:"area,omitempty"`
-	IDdist        string  `json:"id_dist,omitempty"`
-	District      string  `json:"district,omitempty"`
-	IDSubTH       string  `json:"id_sub_th,omitempty"`
-	SubTownHall   string  `json:"subtownhall,omitempty"`
-	Region5       string  `json:"region_5,omitempty"`
-	Region8       string  `json:"region_8,omitempty"`
-	Name          string  `json:"name,omitempty"`
-	Register      string  `json:"register,omitempty"`
-	Street        string  `json:"street,omitempty"`
-	Number        string  `json:"number,omitempty"`
-	Neighborhood  string  `json:"neighborhood,omitempty"`
-	AddrExtraInfo string  `json:"addr_extra_info,omitempty"`
+	ID            string     `json:"id,omitempty"`
+	Long          float64    `json:"long,omitempty"`
+	Lat           float64    `json:"lat,omitempty"`
+	SectCens      string     `json:"sect_cens,omitempty"`
+	Area          string     `json:"area,omitempty"`
+	IDdist        string     `json:"id_dist,omitempty"`
+	District      string     `json:"district,omitempty"`
+	IDSubTH       string     `json:"id_sub_th,omitempty"`
+	SubTownHall   string     `json:"subtownhall,omitempty"`
+	Region5       string     `json:"region_5,omitempty"`
+	Region8       string     `json:"region_8,omitempty"`
+	Name          string     `json:"name,omitempty"`
+	Register      string     `json:"register,omitempty"`
+	Street        string     `json:"street,omitempty"`
+	Number        string     `json:"number,omitempty"`
+	Neighborhood  string     `json:"neighborhood,omitempty"`
+	AddrExtraInfo string     `json:"addr_extra_info,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+func newStreetMarketResponse(sm domain.StreetMarket) streetMarketResponse {
+	return streetMarketResponse{
+		ID:            sm.ID,
+		Long:          sm.Long,
+		Lat:           sm.Lat,
+		SectCens:      sm.SectCens,
+		Area:          sm.Area,
+		IDdist:        sm.IDdist,
+		District:      sm.District,
+		IDSubTH:       sm.IDSubTH,
+		SubTownHall:   sm.SubTownHall,
+		Region5:       sm.Region5,
+		Region8:       sm.Region8,
+		Name:          sm.Name,
+		Register:      sm.Register,
+		Street:        sm.Street,
+		Number:        sm.Number,
+		Neighborhood:  sm.Neighborhood,
+		AddrExtraInfo: sm.AddrExtraInfo,
+		UpdatedAt:     sm.UpdatedAt,
+	}
 }
@@ -0,0 +1,189 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/gorilla/mux"
+)
+
+// ignoreProblemMeta ignores the problem fields derived from the status and the
+// code, the handler tests check those through the status code.
+func ignoreProblemMeta() cmp.Option {
+	return cmpopts.IgnoreFields(ErrorResponse{}, "Type", "Title", "Status")
+}
+
+func TestRespondError(t *testing.T) {
+	testCases := map[string]struct {
+		err  *domain.Error
+		want ErrorResponse
+	}{
+		"When is a client error": {
+			err: &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists"},
+			want: ErrorResponse{
+				Type:    "/problems/street-market-not-found",
+				Title:   "Not Found",
+				Status:  http.StatusNotFound,
+				Detail:  "Entity not exists",
+				Code:    domain.SMNotFoundErrKd,
+				TraceID: "0ca5d1ab-ee21-4d80-a6e1-4f1d3a4e1aa0",
+			},
+		},
+		"When is an unexpected error": {
+			err: &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "pq: relation \"street_market\" does not exist"},
+			want: ErrorResponse{
+				Type:    "/problems/unexpected",
+				Title:   "Internal Server Error",
+				Status:  http.StatusInternalServerError,
+				Detail:  "Unexpected error",
+				Code:    domain.UnexpectedErrKd,
+				TraceID: "0ca5d1ab-ee21-4d80-a6e1-4f1d3a4e1aa0",
+			},
+		},
+		"When the API is overloaded": {
+			err: &domain.Error{Kind: domain.OverloadedErrKd, Msg: "The API is overloaded, retry in 1 seconds"},
+			want: ErrorResponse{
+				Type:    "/problems/overloaded",
+				Title:   "Service Unavailable",
+				Status:  http.StatusServiceUnavailable,
+				Detail:  "The API is overloaded, retry in 1 seconds",
+				Code:    domain.OverloadedErrKd,
+				TraceID: "0ca5d1ab-ee21-4d80-a6e1-4f1d3a4e1aa0",
+			},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/street_market", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.WithValue(req.Context(), domain.TraceIDCtxKey, "0ca5d1ab-ee21-4d80-a6e1-4f1d3a4e1aa0")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			respondError(rr, req, tc.err)
+
+			if status := rr.Code; status != tc.want.Status {
+				t.Errorf("expect status code %v, got %v", tc.want.Status, status)
+			}
+
+			if ct := rr.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("expect content type application/problem+json, got %s", ct)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	testCases := map[domain.KindError]int{
+		domain.InpValidationErrKd:        http.StatusBadRequest,
+		domain.MissingRefErrKd:           http.StatusBadRequest,
+		domain.SMNotFoundErrKd:           http.StatusNotFound,
+		domain.SnapNotFoundErrKd:         http.StatusNotFound,
+		domain.StallNotFoundErrKd:        http.StatusNotFound,
+		domain.SubTHNotFoundErrKd:        http.StatusNotFound,
+		domain.WebhookNotFoundErrKd:      http.StatusNotFound,
+		domain.RouteNotFoundErrKd:        http.StatusNotFound,
+		domain.APIKeyNotFoundErrKd:       http.StatusNotFound,
+		domain.NothingFoundErrKd:         http.StatusNotFound,
+		domain.UnauthenticatedErrKd:      http.StatusUnauthorized,
+		domain.ForbiddenErrKd:            http.StatusForbidden,
+		domain.MethodNotAllowedErrKd:     http.StatusMethodNotAllowed,
+		domain.DuplicatedErrKd:           http.StatusConflict,
+		domain.StallDupErrKd:             http.StatusConflict,
+		domain.RateLimitedErrKd:          http.StatusTooManyRequests,
+		domain.OverloadedErrKd:           http.StatusServiceUnavailable,
+		domain.BodyTooLargeErrKd:         http.StatusRequestEntityTooLarge,
+		domain.UnsupportedMediaTypeErrKd: http.StatusUnsupportedMediaType,
+		domain.UnexpectedErrKd:           http.StatusInternalServerError,
+		domain.NothingCreatedErrKd:       http.StatusInternalServerError,
+	}
+
+	for kind, want := range testCases {
+		t.Run(string(kind), func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			respondError(rr, httptest.NewRequest(http.MethodGet, "/street_market", nil), &domain.Error{Kind: kind})
+
+			if rr.Code != want {
+				t.Errorf("expect status %v, got %v", want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestRouterErrors(t *testing.T) {
+	testCases := map[string]struct {
+		method string
+		path   string
+		want   ErrorResponse
+	}{
+		"When route not exists": {
+			method: http.MethodGet,
+			path:   "/markets",
+			want: ErrorResponse{
+				Type:   "/problems/route-not-found",
+				Title:  "Not Found",
+				Status: http.StatusNotFound,
+				Detail: "/markets does not exist",
+				Code:   domain.RouteNotFoundErrKd,
+			},
+		},
+		"When method is not allowed": {
+			method: http.MethodPut,
+			path:   "/ping",
+			want: ErrorResponse{
+				Type:   "/problems/method-not-allowed",
+				Title:  "Method Not Allowed",
+				Status: http.StatusMethodNotAllowed,
+				Detail: "PUT is not allowed on /ping",
+				Code:   domain.MethodNotAllowedErrKd,
+			},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tc.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.NotFoundHandler = http.HandlerFunc(NotFound)
+			r.MethodNotAllowedHandler = http.HandlerFunc(MethodNotAllowed)
+			r.HandleFunc("/ping", NewPingHandler().Handle).Methods(http.MethodGet)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.want.Status {
+				t.Errorf("expect status code %v, got %v", tc.want.Status, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
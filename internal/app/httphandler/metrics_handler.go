@@ -0,0 +1,66 @@
+package httphandler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type metricsSource interface {
+	Metrics() []domain.Metric
+}
+
+// MetricsHandler responds the metrics of its sources in the text format of
+// Prometheus.
+type MetricsHandler struct {
+	sources []metricsSource
+}
+
+func NewMetricsHandler(sources ...metricsSource) *MetricsHandler {
+	return &MetricsHandler{sources}
+}
+
+func (h *MetricsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	described := map[string]bool{}
+
+	for _, s := range h.sources {
+		for _, m := range s.Metrics() {
+			if !described[m.Name] {
+				described[m.Name] = true
+				fmt.Fprintf(&buf, "# HELP %s %s\n", m.Name, m.Help)
+				fmt.Fprintf(&buf, "# TYPE %s %s\n", m.Name, m.Kind)
+			}
+			fmt.Fprintf(&buf, "%s%s %s\n", m.Name, metricLabels(m.Labels), strconv.FormatFloat(m.Value, 'g', -1, 64))
+		}
+	}
+
+	respondBytes(w, http.StatusOK, metricsContentType, buf.Bytes())
+}
+
+// metricLabels is labels as in {priority="critical"}, sorted by name.
+func metricLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for n := range labels {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, n := range names {
+		pairs[i] = fmt.Sprintf("%s=%s", n, strconv.Quote(labels[n]))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
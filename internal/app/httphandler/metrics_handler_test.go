@@ -0,0 +1,67 @@
+package httphandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubMetricsSource struct {
+	metrics []domain.Metric
+}
+
+func (s stubMetricsSource) Metrics() []domain.Metric {
+	return s.metrics
+}
+
+func TestMetricsHandler_Handle(t *testing.T) {
+	limiter := stubMetricsSource{[]domain.Metric{
+		{
+			Name:  "unicoapi_concurrency_limit",
+			Help:  "Requests that may run at once.",
+			Kind:  domain.GaugeMetricKd,
+			Value: 20.5,
+		},
+		{
+			Name:   "unicoapi_requests_shed_total",
+			Help:   "Requests refused.",
+			Kind:   domain.CounterMetricKd,
+			Labels: map[string]string{"priority": "critical", "api": "v1"},
+			Value:  1,
+		},
+		{
+			Name:   "unicoapi_requests_shed_total",
+			Help:   "Requests refused.",
+			Kind:   domain.CounterMetricKd,
+			Labels: map[string]string{"priority": "normal", "api": "v1"},
+			Value:  12,
+		},
+	}}
+	empty := stubMetricsSource{}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rr := httptest.NewRecorder()
+	NewMetricsHandler(limiter, empty).Handle(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expect status %d, got %d", http.StatusOK, rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("expect content type of the text format, got %s", ct)
+	}
+
+	want := `# HELP unicoapi_concurrency_limit Requests that may run at once.
+# TYPE unicoapi_concurrency_limit gauge
+unicoapi_concurrency_limit 20.5
+# HELP unicoapi_requests_shed_total Requests refused.
+# TYPE unicoapi_requests_shed_total counter
+unicoapi_requests_shed_total{api="v1",priority="critical"} 1
+unicoapi_requests_shed_total{api="v1",priority="normal"} 12
+`
+	if diff := cmp.Diff(want, rr.Body.String()); diff != "" {
+		t.Errorf("mismatch of the metrics (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,17 @@
+package httphandler
+
+import (
+	"net/http"
+)
+
+type OpenAPIHandler struct {
+	document []byte
+}
+
+func NewOpenAPIHandler(document []byte) *OpenAPIHandler {
+	return &OpenAPIHandler{document}
+}
+
+func (h *OpenAPIHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	respondBytes(w, http.StatusOK, "application/json", h.document)
+}
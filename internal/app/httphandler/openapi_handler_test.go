@@ -0,0 +1,88 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/app/openapi"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOpenAPIHandler_Handle(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	NewOpenAPIHandler(openapi.Document()).Handle(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("handler returned wrong content type: got %v", ct)
+	}
+
+	if diff := cmp.Diff(string(openapi.Document()), rr.Body.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestOpenAPIDocument_Bodies keeps the request bodies of the document in sync
+// with the structs the handlers decode.
+func TestOpenAPIDocument_Bodies(t *testing.T) {
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(openapi.Document(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]interface{}{
+		"StreetMarketCreate": streetMarketBody{},
+		"StreetMarketEdit":   streetMarketBody{},
+		"StallCreate":        stallBody{},
+		"StallEdit":          stallBody{},
+		"Schedule":           scheduleBody{},
+		"WebhookCreate":      webhookBody{},
+	}
+
+	for schema, body := range testCases {
+		t.Run(schema, func(t *testing.T) {
+			want := jsonKeys(body)
+
+			got := []string{}
+			for k := range doc.Components.Schemas[schema].Properties {
+				got = append(got, k)
+			}
+			sort.Strings(got)
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("schema properties mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func jsonKeys(body interface{}) []string {
+	typ := reflect.TypeOf(body)
+
+	keys := []string{}
+	for i := 0; i < typ.NumField(); i++ {
+		keys = append(keys, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,73 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type regionLister interface {
+	Regions(context.Context) ([]domain.Region5, *domain.Error)
+}
+
+type regionListHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type region8Response struct {
+	Name         string                `json:"name"`
+	SubTownHalls []subTownHallResponse `json:"subtownhalls"`
+}
+
+type region5Response struct {
+	Name    string            `json:"name"`
+	Regions []region8Response `json:"regions"`
+}
+
+type listRegionResponse map[string][]region5Response
+
+// RegionListHandler responds the administrative hierarchy as a tree, from the
+// 5 regions down to the districts.
+type RegionListHandler struct {
+	lister regionLister
+	logger regionListHandlerLogger
+}
+
+func NewRegionListHandler(
+	lister regionLister,
+	logger regionListHandlerLogger,
+) *RegionListHandler {
+	return &RegionListHandler{lister, logger}
+}
+
+func (h *RegionListHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	r5s, err := h.lister.Regions(ctx)
+	if err != nil {
+		h.logger.Error(ctx, *err)
+		respondError(w, r, err)
+		return
+	}
+
+	res := []region5Response{}
+	for _, r5 := range r5s {
+		r5r := region5Response{Name: r5.Name, Regions: []region8Response{}}
+		for _, r8 := range r5.Regions {
+			r8r := region8Response{Name: r8.Name, SubTownHalls: []subTownHallResponse{}}
+			for _, sth := range r8.SubTownHalls {
+				r8r.SubTownHalls = append(r8r.SubTownHalls, subTownHallResponse{
+					ID:        sth.ID,
+					Name:      sth.Name,
+					Region8:   sth.Region8,
+					Districts: toDistrictResponses(sth.Districts),
+				})
+			}
+			r5r.Regions = append(r5r.Regions, r8r)
+		}
+		res = append(res, r5r)
+	}
+
+	respondJSON(w, http.StatusOK, listRegionResponse{"data": res})
+}
@@ -0,0 +1,107 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRegionLister struct {
+	regions func(context.Context) ([]domain.Region5, *domain.Error)
+}
+
+func (s *stubRegionLister) Regions(ctx context.Context) ([]domain.Region5, *domain.Error) {
+	return s.regions(ctx)
+}
+
+func TestRegionListHandler_Handle(t *testing.T) {
+	listerMock := &stubRegionLister{
+		regions: func(context.Context) ([]domain.Region5, *domain.Error) {
+			return []domain.Region5{{
+				Name: "Leste",
+				Regions: []domain.Region8{{
+					Name:    "Leste 1",
+					Region5: "Leste",
+					SubTownHalls: []domain.SubTownHall{{
+						ID:        "26",
+						Name:      "ARICANDUVA-FORMOSA-CARRAO",
+						Region8:   "Leste 1",
+						Districts: []domain.District{{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}},
+					}},
+				}},
+			}}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/regions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewRegionListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got listRegionResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := listRegionResponse{"data": {{
+		Name: "Leste",
+		Regions: []region8Response{{
+			Name: "Leste 1",
+			SubTownHalls: []subTownHallResponse{{
+				ID:        "26",
+				Name:      "ARICANDUVA-FORMOSA-CARRAO",
+				Region8:   "Leste 1",
+				Districts: []districtResponse{{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}},
+			}},
+		}},
+	}}}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
+
+func TestRegionListHandler_Handle_Error(t *testing.T) {
+	listerMock := &stubRegionLister{
+		regions: func(context.Context) ([]domain.Region5, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"}
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/regions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewRegionListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusInternalServerError {
+		t.Errorf("expect status code %v, got %v", http.StatusInternalServerError, status)
+	}
+
+	var got ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
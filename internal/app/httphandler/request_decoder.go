@@ -0,0 +1,205 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// MaxBodyBytes is the largest request body the handlers read.
+const MaxBodyBytes = 1 << 20
+
+const jsonContentType = "application/json"
+
+// decodeJSON decodes the JSON body of r into v. The body must be sent as
+// application/json, have at most MaxBodyBytes and hold a single value with no
+// fields v does not know. Type errors are told with the path of the value, as
+// in schedule[1].open.
+func decodeJSON(r *http.Request, v interface{}) *domain.Error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != jsonContentType {
+		return &domain.Error{
+			Kind: domain.UnsupportedMediaTypeErrKd,
+			Msg:  fmt.Sprintf("The body must be %s, got %q", jsonContentType, r.Header.Get("Content-Type")),
+		}
+	}
+
+	tooLarge := &domain.Error{
+		Kind: domain.BodyTooLargeErrKd,
+		Msg:  fmt.Sprintf("The body must have at most %d bytes", MaxBodyBytes),
+	}
+	if r.ContentLength > MaxBodyBytes {
+		return tooLarge
+	}
+
+	defer r.Body.Close()
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, MaxBodyBytes+1))
+	if err != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+	if len(data) > MaxBodyBytes {
+		return tooLarge
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return decodeError(data, err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return &domain.Error{Kind: domain.InpValidationErrKd, Msg: "malformed body, data after the JSON value"}
+	}
+
+	return nil
+}
+
+func decodeError(data []byte, err error) *domain.Error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		field := jsonPath(data, typeErr.Offset)
+		if field == "" {
+			field = typeErr.Field
+		}
+
+		return invalidField(field, domain.InvalidTypeFieldCd, "%s must be of type %s", name(field), jsonType(typeErr.Type))
+	}
+
+	// The decoder has no error type for unknown fields.
+	if f := strings.TrimPrefix(err.Error(), "json: unknown field "); f != err.Error() {
+		field, uErr := strconv.Unquote(f)
+		if uErr != nil {
+			field = f
+		}
+
+		return invalidField(field, domain.UnexpectedFieldCd, "%s is not a known field", field)
+	}
+
+	return &domain.Error{Kind: domain.InpValidationErrKd, Msg: "malformed body", Cause: err}
+}
+
+func invalidField(field string, code domain.FieldErrorCode, format string, args ...interface{}) *domain.Error {
+	return &domain.Error{
+		Kind:   domain.InpValidationErrKd,
+		Msg:    "Invalid input",
+		Fields: []domain.FieldError{{Field: field, Code: code, Msg: fmt.Sprintf(format, args...)}},
+	}
+}
+
+// pathFrame is an object or array being read, and where in it.
+type pathFrame struct {
+	array     bool
+	index     int
+	key       string
+	expectKey bool
+}
+
+// jsonPath is the path of the value of data read up to offset, as the decoder
+// tells type errors, or "" when it is the whole body.
+func jsonPath(data []byte, offset int64) string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var stack []*pathFrame
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			stack = stack[:len(stack)-1]
+			valueRead(stack)
+			continue
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].expectKey {
+			top := stack[len(stack)-1]
+			top.key, _ = tok.(string)
+			top.expectKey = false
+			continue
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].array {
+			stack[len(stack)-1].index++
+		}
+
+		if dec.InputOffset() >= offset {
+			return pathOf(stack)
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			stack = append(stack, &pathFrame{expectKey: true})
+		case json.Delim('['):
+			stack = append(stack, &pathFrame{array: true, index: -1})
+		default:
+			valueRead(stack)
+		}
+	}
+}
+
+// valueRead moves the object being read to its next key.
+func valueRead(stack []*pathFrame) {
+	if len(stack) > 0 && !stack[len(stack)-1].array {
+		stack[len(stack)-1].expectKey = true
+	}
+}
+
+func pathOf(stack []*pathFrame) string {
+	var b strings.Builder
+	for _, f := range stack {
+		if f.array {
+			fmt.Fprintf(&b, "[%d]", f.index)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(f.key)
+	}
+
+	return b.String()
+}
+
+// name is field as told in messages, body when it is the whole body.
+func name(field string) string {
+	if field == "" {
+		return "body"
+	}
+
+	return field
+}
+
+// jsonType is the JSON type a value of t is decoded from.
+func jsonType(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
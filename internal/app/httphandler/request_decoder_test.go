@@ -0,0 +1,140 @@
+package httphandler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	testCases := map[string]struct {
+		contentType string
+		body        string
+		wantErr     *domain.Error
+		want        scheduleBody
+	}{
+		"When the body is valid": {
+			contentType: "application/json; charset=utf-8",
+			body:        `{"weekdays": [{"weekday": "sunday", "start": "07:00", "end": "13:00"}]}`,
+			want:        scheduleBody{Weekdays: []scheduleSlotBody{{Weekday: "sunday", Start: "07:00", End: "13:00"}}},
+		},
+		"When the content type is not JSON": {
+			contentType: "text/plain",
+			body:        `{}`,
+			wantErr: &domain.Error{
+				Kind: domain.UnsupportedMediaTypeErrKd,
+				Msg:  `The body must be application/json, got "text/plain"`,
+			},
+		},
+		"When there is no content type": {
+			body: `{}`,
+			wantErr: &domain.Error{
+				Kind: domain.UnsupportedMediaTypeErrKd,
+				Msg:  `The body must be application/json, got ""`,
+			},
+		},
+		"When the body is too large": {
+			contentType: "application/json",
+			body:        `{"weekdays": [], "exceptions": [` + strings.Repeat(" ", MaxBodyBytes) + `]}`,
+			wantErr: &domain.Error{
+				Kind: domain.BodyTooLargeErrKd,
+				Msg:  "The body must have at most 1048576 bytes",
+			},
+		},
+		"When the body has an unknown field": {
+			contentType: "application/json",
+			body:        `{"weekdays": [], "holidays": []}`,
+			wantErr: &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Invalid input",
+				Fields: []domain.FieldError{
+					{Field: "holidays", Code: domain.UnexpectedFieldCd, Msg: "holidays is not a known field"},
+				},
+			},
+		},
+		"When a value has the wrong type": {
+			contentType: "application/json",
+			body:        `{"weekdays": [{"weekday": "sunday"}, {"weekday": "monday", "start": 7}]}`,
+			wantErr: &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Invalid input",
+				Fields: []domain.FieldError{
+					{Field: "weekdays[1].start", Code: domain.InvalidTypeFieldCd, Msg: "weekdays[1].start must be of type string"},
+				},
+			},
+		},
+		"When an object has the wrong type": {
+			contentType: "application/json",
+			body:        `{"weekdays": [], "exceptions": {"date": "2022-12-25"}}`,
+			wantErr: &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Invalid input",
+				Fields: []domain.FieldError{
+					{Field: "exceptions", Code: domain.InvalidTypeFieldCd, Msg: "exceptions must be of type array"},
+				},
+			},
+		},
+		"When the body has the wrong type": {
+			contentType: "application/json",
+			body:        `[]`,
+			wantErr: &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Invalid input",
+				Fields: []domain.FieldError{
+					{Code: domain.InvalidTypeFieldCd, Msg: "body must be of type object"},
+				},
+			},
+		},
+		"When the body is malformed": {
+			contentType: "application/json",
+			body:        `{"weekdays": [}`,
+			wantErr:     &domain.Error{Kind: domain.InpValidationErrKd, Msg: "malformed body"},
+		},
+		"When the body is empty": {
+			contentType: "application/json",
+			wantErr:     &domain.Error{Kind: domain.InpValidationErrKd, Msg: "malformed body"},
+		},
+		"When there is data after the body": {
+			contentType: "application/json",
+			body:        `{"weekdays": []} {}`,
+			wantErr:     &domain.Error{Kind: domain.InpValidationErrKd, Msg: "malformed body, data after the JSON value"},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/v1/street_market/id/schedule", strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			var got scheduleBody
+			dErr := decodeJSON(req, &got)
+
+			if diff := cmp.Diff(tc.wantErr, dErr, cmpopts.IgnoreFields(domain.Error{}, "Cause")); diff != "" {
+				t.Errorf("mismatch of the error (-want +got):\n%s", diff)
+			}
+			if tc.wantErr == nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("mismatch of the body (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeJSON_ContentLength(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/street_market", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = MaxBodyBytes + 1
+
+	dErr := decodeJSON(req, &streetMarketBody{})
+	if dErr == nil || dErr.Kind != domain.BodyTooLargeErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.BodyTooLargeErrKd, dErr)
+	}
+}
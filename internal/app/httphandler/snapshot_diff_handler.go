@@ -0,0 +1,152 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type snapshotDiffer interface {
+	Diff(context.Context, domain.SnapshotDiffInput) (domain.SnapshotDiff, *domain.Error)
+}
+
+type snapshotDiffHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type snapshotResponse struct {
+	Edition       int     `json:"edition"`
+	Long          float64 `json:"long,omitempty"`
+	Lat           float64 `json:"lat,omitempty"`
+	SectCens      string  `json:"sect_cens,omitempty"`
+	Area          string  `json:"area,omitempty"`
+	IDdist        string  `json:"id_dist,omitempty"`
+	District      string  `json:"district,omitempty"`
+	IDSubTH       string  `json:"id_sub_th,omitempty"`
+	SubTownHall   string  `json:"subtownhall,omitempty"`
+	Region5       string  `json:"region_5,omitempty"`
+	Region8       string  `json:"region_8,omitempty"`
+	Name          string  `json:"name,omitempty"`
+	Register      string  `json:"register,omitempty"`
+	Street        string  `json:"street,omitempty"`
+	Number        string  `json:"number,omitempty"`
+	Neighborhood  string  `json:"neighborhood,omitempty"`
+	AddrExtraInfo string  `json:"addr_extra_info,omitempty"`
+}
+
+type snapshotChangeResponse struct {
+	Before snapshotResponse `json:"before"`
+	After  snapshotResponse `json:"after"`
+}
+
+type snapshotDiffResponse struct {
+	From      int                      `json:"from"`
+	To        int                      `json:"to"`
+	Added     []snapshotResponse       `json:"added"`
+	Removed   []snapshotResponse       `json:"removed"`
+	Relocated []snapshotChangeResponse `json:"relocated"`
+	Renamed   []snapshotChangeResponse `json:"renamed"`
+}
+
+type SnapshotDiffHandler struct {
+	differ snapshotDiffer
+	logger snapshotDiffHandlerLogger
+}
+
+func NewSnapshotDiffHandler(
+	differ snapshotDiffer,
+	logger snapshotDiffHandlerLogger,
+) *SnapshotDiffHandler {
+	return &SnapshotDiffHandler{differ, logger}
+}
+
+func (h *SnapshotDiffHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	from, err := strconv.Atoi(r.FormValue("from"))
+	if err != nil {
+		h.logger.Error(ctx, domain.Error{
+			Kind: domain.InpValidationErrKd,
+			Msg:  err.Error(),
+		})
+		respondError(w, r, &domain.Error{
+			Kind: domain.InpValidationErrKd,
+			Msg:  "From must be an integer",
+		})
+		return
+	}
+
+	to, err := strconv.Atoi(r.FormValue("to"))
+	if err != nil {
+		h.logger.Error(ctx, domain.Error{
+			Kind: domain.InpValidationErrKd,
+			Msg:  err.Error(),
+		})
+		respondError(w, r, &domain.Error{
+			Kind: domain.InpValidationErrKd,
+			Msg:  "To must be an integer",
+		})
+		return
+	}
+
+	diff, dErr := h.differ.Diff(ctx, domain.SnapshotDiffInput{From: from, To: to})
+	if dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, snapshotDiffResponse{
+		From:      diff.From,
+		To:        diff.To,
+		Added:     toSnapshotsResponse(diff.Added),
+		Removed:   toSnapshotsResponse(diff.Removed),
+		Relocated: toSnapshotChangesResponse(diff.Relocated),
+		Renamed:   toSnapshotChangesResponse(diff.Renamed),
+	})
+}
+
+func toSnapshotsResponse(ls []domain.StreetMarketSnapshot) []snapshotResponse {
+	rs := []snapshotResponse{}
+	for _, ss := range ls {
+		rs = append(rs, toSnapshotResponse(ss))
+	}
+
+	return rs
+}
+
+func toSnapshotChangesResponse(ls []domain.SnapshotChange) []snapshotChangeResponse {
+	rs := []snapshotChangeResponse{}
+	for _, c := range ls {
+		rs = append(rs, snapshotChangeResponse{
+			Before: toSnapshotResponse(c.Before),
+			After:  toSnapshotResponse(c.After),
+		})
+	}
+
+	return rs
+}
+
+func toSnapshotResponse(ss domain.StreetMarketSnapshot) snapshotResponse {
+	return snapshotResponse{
+		Edition:       ss.Edition,
+		Long:          ss.Long,
+		Lat:           ss.Lat,
+		SectCens:      ss.SectCens,
+		Area:          ss.Area,
+		IDdist:        ss.IDdist,
+		District:      ss.District,
+		IDSubTH:       ss.IDSubTH,
+		SubTownHall:   ss.SubTownHall,
+		Region5:       ss.Region5,
+		Region8:       ss.Region8,
+		Name:          ss.Name,
+		Register:      ss.Register,
+		Street:        ss.Street,
+		Number:        ss.Number,
+		Neighborhood:  ss.Neighborhood,
+		AddrExtraInfo: ss.AddrExtraInfo,
+	}
+}
@@ -0,0 +1,155 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubSnapshotDiffer struct {
+	diffInp domain.SnapshotDiffInput
+	diff    func(context.Context, domain.SnapshotDiffInput) (domain.SnapshotDiff, *domain.Error)
+}
+
+func (s *stubSnapshotDiffer) Diff(
+	ctx context.Context,
+	inp domain.SnapshotDiffInput,
+) (domain.SnapshotDiff, *domain.Error) {
+	s.diffInp = inp
+	return s.diff(ctx, inp)
+}
+
+func TestSnapshotDiffHandler_Handle(t *testing.T) {
+	before := domain.StreetMarketSnapshot{Edition: 2003, Register: "4041-0", Name: "VILA FORMOSA", Street: "RUA PRETORIA"}
+	after := domain.StreetMarketSnapshot{Edition: 2014, Register: "4041-0", Name: "VILA FORMOSA", Street: "RUA MARAGOJIPE"}
+	added := domain.StreetMarketSnapshot{Edition: 2014, Register: "4045-2", Name: "PRACA SANTA HELENA"}
+
+	differMock := &stubSnapshotDiffer{
+		diff: func(ctx context.Context, inp domain.SnapshotDiffInput) (domain.SnapshotDiff, *domain.Error) {
+			return domain.SnapshotDiff{
+				From:      2003,
+				To:        2014,
+				Added:     []domain.StreetMarketSnapshot{added},
+				Removed:   []domain.StreetMarketSnapshot{},
+				Relocated: []domain.SnapshotChange{{Before: before, After: after}},
+				Renamed:   []domain.SnapshotChange{},
+			}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/snapshots/diff?from=2003&to=2014", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewSnapshotDiffHandler(differMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	wantInp := domain.SnapshotDiffInput{From: 2003, To: 2014}
+	if diff := cmp.Diff(wantInp, differMock.diffInp); diff != "" {
+		t.Errorf("snapshot differ diff receive a unexpected input (-want +got):\n%s", diff)
+	}
+
+	want := snapshotDiffResponse{
+		From:    2003,
+		To:      2014,
+		Added:   []snapshotResponse{{Edition: 2014, Register: "4045-2", Name: "PRACA SANTA HELENA"}},
+		Removed: []snapshotResponse{},
+		Relocated: []snapshotChangeResponse{{
+			Before: snapshotResponse{Edition: 2003, Register: "4041-0", Name: "VILA FORMOSA", Street: "RUA PRETORIA"},
+			After:  snapshotResponse{Edition: 2014, Register: "4041-0", Name: "VILA FORMOSA", Street: "RUA MARAGOJIPE"},
+		}},
+		Renamed: []snapshotChangeResponse{},
+	}
+
+	var got snapshotDiffResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
+
+func TestSnapshotDiffHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		differErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+		path         string
+	}{
+		"Param from invalid": {
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "From must be an integer", Code: domain.InpValidationErrKd},
+			path:         "/snapshots/diff?from=invalid&to=2014",
+		},
+		"Param to invalid": {
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "To must be an integer", Code: domain.InpValidationErrKd},
+			path:         "/snapshots/diff?from=2003",
+		},
+		"Invalid input": {
+			differErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Invalid input"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Invalid input", Code: domain.InpValidationErrKd},
+			path:         "/snapshots/diff?from=2003&to=2003",
+		},
+		"Edition not found": {
+			differErr:    &domain.Error{Kind: domain.SnapNotFoundErrKd, Msg: "Edition 2011 not exists"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Edition 2011 not exists", Code: domain.SnapNotFoundErrKd},
+			path:         "/snapshots/diff?from=2003&to=2011",
+		},
+		"Unexpected error": {
+			differErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+			path:         "/snapshots/diff?from=2003&to=2014",
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			differMock := &stubSnapshotDiffer{
+				diff: func(ctx context.Context, inp domain.SnapshotDiffInput) (domain.SnapshotDiff, *domain.Error) {
+					return domain.SnapshotDiff{}, tc.differErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewSnapshotDiffHandler(differMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(h.Handle)
+			handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package httphandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type stallCreator interface {
+	Create(context.Context, domain.SMID, domain.StallCreateInput) (string, *domain.Error)
+}
+
+type stallCreateHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type stallBody struct {
+	Number        int                      `json:"number"`
+	VendorName    string                   `json:"vendor_name"`
+	LicenseNumber string                   `json:"license_number"`
+	Categories    []domain.ProductCategory `json:"categories"`
+}
+
+type StallCreateHandler struct {
+	creator stallCreator
+	logger  stallCreateHandlerLogger
+}
+
+func NewStallCreateHandler(
+	creator stallCreator,
+	logger stallCreateHandlerLogger,
+) *StallCreateHandler {
+	return &StallCreateHandler{creator, logger}
+}
+
+func (h *StallCreateHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body stallBody
+
+	if dErr := decodeJSON(r, &body); dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	vars := mux.Vars(r)
+	smID := domain.SMID(vars["street-market-id"])
+
+	input := domain.StallCreateInput{
+		Number:        body.Number,
+		VendorName:    body.VendorName,
+		LicenseNumber: body.LicenseNumber,
+		Categories:    body.Categories,
+	}
+
+	id, dErr := h.creator.Create(ctx, smID, input)
+	if dErr != nil {
+		if dErr.Kind == domain.InpValidationErrKd {
+			respondInvalidInput(w, r, dErr, stallBody{})
+			return
+		}
+
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	location := fmt.Sprintf("/v1/street_market/%s/stalls/%s", smID, id)
+	w.Header().Add("Location", location)
+	respondJSON(w, http.StatusCreated, "")
+}
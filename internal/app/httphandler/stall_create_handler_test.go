@@ -0,0 +1,187 @@
+package httphandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubStallCreator struct {
+	createSMIDInp domain.SMID
+	createInp     domain.StallCreateInput
+	create        func(context.Context, domain.SMID, domain.StallCreateInput) (string, *domain.Error)
+}
+
+func (s *stubStallCreator) Create(
+	ctx context.Context,
+	smID domain.SMID,
+	inp domain.StallCreateInput,
+) (string, *domain.Error) {
+	s.createSMIDInp = smID
+	s.createInp = inp
+	return s.create(ctx, smID, inp)
+}
+
+func TestStallCreateHandler_Handle(t *testing.T) {
+	smID := domain.SMID("cdd2028a-fd0b-4734-97e7-ef2e57e9009b")
+	id := "12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf"
+	wantInp := domain.StallCreateInput{
+		Number:        12,
+		VendorName:    "Maria da Silva",
+		LicenseNumber: "TPU-2022-0012",
+		Categories:    []domain.ProductCategory{domain.FishCategory, domain.SeafoodCategory},
+	}
+
+	creatorMock := &stubStallCreator{
+		create: func(context.Context, domain.SMID, domain.StallCreateInput) (string, *domain.Error) {
+			return id, nil
+		},
+	}
+
+	rBody := `{"number":12,"vendor_name":"Maria da Silva","license_number":"TPU-2022-0012",` +
+		`"categories":["fish","seafood"]}`
+	path := fmt.Sprintf("/street_market/%s/stalls", smID)
+	req, err := http.NewRequest(http.MethodPost, path, strings.NewReader(rBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Host = "localhost"
+
+	h := NewStallCreateHandler(creatorMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/street_market/{street-market-id}/stalls", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("expect status code %v, got %v", http.StatusCreated, status)
+	}
+
+	wantLocation := fmt.Sprintf("/v1/street_market/%s/stalls/%s", smID, id)
+	if got := rr.Header().Get("Location"); got != wantLocation {
+		t.Errorf("expect location %s, got %s", wantLocation, got)
+	}
+
+	if smID != creatorMock.createSMIDInp {
+		t.Errorf("expect stall creator receive street market id %s, got %s", smID, creatorMock.createSMIDInp)
+	}
+
+	if diff := cmp.Diff(wantInp, creatorMock.createInp); diff != "" {
+		t.Errorf("stall creator create receive a unexpected input (-want +got):\n%s", diff)
+	}
+}
+
+func TestStallCreateHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		creatorErr   *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid input": {
+			creatorErr:   &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Invalid fields": {
+			creatorErr: &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Invalid input",
+				Fields: []domain.FieldError{
+					{Field: "VendorName", Code: domain.RequiredFieldCd, Msg: "VendorName is required"},
+					{Field: "LicenseNumber", Code: domain.TooLongFieldCd, Msg: "LicenseNumber must have at most 50 characters"},
+				},
+			},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody: ErrorResponse{
+				Detail: "Invalid input",
+				Code:   domain.InpValidationErrKd,
+				Errors: []fieldErrorResponse{
+					{Field: "vendor_name", Code: "REQUIRED", Message: "VendorName is required"},
+					{Field: "license_number", Code: "TOO_LONG", Message: "LicenseNumber must have at most 50 characters"},
+				},
+			},
+		},
+		"Street market not found": {
+			creatorErr:   &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.SMNotFoundErrKd},
+		},
+		"Stall number duplicated": {
+			creatorErr:   &domain.Error{Kind: domain.StallDupErrKd, Msg: "Duplicated"},
+			wantStatusCd: http.StatusConflict,
+			wantBody:     ErrorResponse{Detail: "Duplicated", Code: domain.StallDupErrKd},
+		},
+		"Unexpected error": {
+			creatorErr:   &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			creatorMock := &stubStallCreator{
+				create: func(context.Context, domain.SMID, domain.StallCreateInput) (string, *domain.Error) {
+					return "", tc.creatorErr
+				},
+			}
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(stallBody{}); err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/street_market/id/stalls", &body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			h := NewStallCreateHandler(creatorMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/stalls", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("malformed body", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/street_market/id/stalls", strings.NewReader("body"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		h := NewStallCreateHandler(&stubStallCreator{}, &stubLogger{})
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(h.Handle)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("expect status code %v, got %v", http.StatusBadRequest, status)
+		}
+	})
+}
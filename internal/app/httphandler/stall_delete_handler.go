@@ -0,0 +1,45 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type stallEraser interface {
+	Delete(context.Context, domain.SMID, domain.StallID) *domain.Error
+}
+
+type stallDeleteHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type StallDeleteHandler struct {
+	eraser stallEraser
+	logger stallDeleteHandlerLogger
+}
+
+func NewStallDeleteHandler(
+	eraser stallEraser,
+	logger stallDeleteHandlerLogger,
+) *StallDeleteHandler {
+	return &StallDeleteHandler{eraser, logger}
+}
+
+func (h *StallDeleteHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	smID := domain.SMID(vars["street-market-id"])
+	id := domain.StallID(vars["stall-id"])
+
+	err := h.eraser.Delete(ctx, smID, id)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, "")
+}
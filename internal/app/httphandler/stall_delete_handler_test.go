@@ -0,0 +1,118 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubStallEraser struct {
+	deleteSMIDInp domain.SMID
+	deleteIDInp   domain.StallID
+	delete        func(context.Context, domain.SMID, domain.StallID) *domain.Error
+}
+
+func (s *stubStallEraser) Delete(ctx context.Context, smID domain.SMID, ID domain.StallID) *domain.Error {
+	s.deleteSMIDInp = smID
+	s.deleteIDInp = ID
+	return s.delete(ctx, smID, ID)
+}
+
+func TestStallDeleteHandler_Handle(t *testing.T) {
+	smID := domain.SMID("cdd2028a-fd0b-4734-97e7-ef2e57e9009b")
+	id := domain.StallID("12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf")
+
+	eraserMock := &stubStallEraser{
+		delete: func(context.Context, domain.SMID, domain.StallID) *domain.Error {
+			return nil
+		},
+	}
+
+	path := fmt.Sprintf("/street_market/%s/stalls/%s", smID, id)
+	req, err := http.NewRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStallDeleteHandler(eraserMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/street_market/{street-market-id}/stalls/{stall-id}", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("expect status code %v, got %v", http.StatusNoContent, status)
+	}
+
+	if smID != eraserMock.deleteSMIDInp || id != eraserMock.deleteIDInp {
+		t.Errorf(
+			"expect stall eraser receive ids %s %s, got %s %s",
+			smID, id, eraserMock.deleteSMIDInp, eraserMock.deleteIDInp,
+		)
+	}
+}
+
+func TestStallDeleteHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		eraserErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid id": {
+			eraserErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Stall not found": {
+			eraserErr:    &domain.Error{Kind: domain.StallNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.StallNotFoundErrKd},
+		},
+		"Unexpected error": {
+			eraserErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			eraserMock := &stubStallEraser{
+				delete: func(context.Context, domain.SMID, domain.StallID) *domain.Error {
+					return tc.eraserErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, "/street_market/id/stalls/id", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewStallDeleteHandler(eraserMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/stalls/{stall-id}", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type stallEditor interface {
+	Edit(context.Context, domain.SMID, domain.StallID, domain.StallEditInput) *domain.Error
+}
+
+type stallEditHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type StallEditHandler struct {
+	editor stallEditor
+	logger stallEditHandlerLogger
+}
+
+func NewStallEditHandler(
+	editor stallEditor,
+	logger stallEditHandlerLogger,
+) *StallEditHandler {
+	return &StallEditHandler{editor, logger}
+}
+
+func (h *StallEditHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body stallBody
+
+	if dErr := decodeJSON(r, &body); dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	vars := mux.Vars(r)
+	smID := domain.SMID(vars["street-market-id"])
+	id := domain.StallID(vars["stall-id"])
+
+	input := domain.StallEditInput{
+		Number:        body.Number,
+		VendorName:    body.VendorName,
+		LicenseNumber: body.LicenseNumber,
+		Categories:    body.Categories,
+	}
+
+	dErr := h.editor.Edit(ctx, smID, id, input)
+	if dErr != nil {
+		if dErr.Kind == domain.InpValidationErrKd {
+			respondInvalidInput(w, r, dErr, stallBody{})
+			return
+		}
+
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, "")
+}
@@ -0,0 +1,175 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubStallEditor struct {
+	editSMIDInp domain.SMID
+	editIDInp   domain.StallID
+	editInp     domain.StallEditInput
+	edit        func(context.Context, domain.SMID, domain.StallID, domain.StallEditInput) *domain.Error
+}
+
+func (s *stubStallEditor) Edit(
+	ctx context.Context,
+	smID domain.SMID,
+	ID domain.StallID,
+	inp domain.StallEditInput,
+) *domain.Error {
+	s.editSMIDInp = smID
+	s.editIDInp = ID
+	s.editInp = inp
+	return s.edit(ctx, smID, ID, inp)
+}
+
+func TestStallEditHandler_Handle(t *testing.T) {
+	smID := domain.SMID("cdd2028a-fd0b-4734-97e7-ef2e57e9009b")
+	id := domain.StallID("12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf")
+
+	testCases := map[string]struct {
+		rBody   string
+		wantInp domain.StallEditInput
+	}{
+		"Categories kept when absent": {
+			rBody:   `{"vendor_name":"Joana"}`,
+			wantInp: domain.StallEditInput{VendorName: "Joana"},
+		},
+		"Categories replaced": {
+			rBody: `{"number":3,"categories":["eggs"]}`,
+			wantInp: domain.StallEditInput{
+				Number:     3,
+				Categories: []domain.ProductCategory{domain.EggsCategory},
+			},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			editorMock := &stubStallEditor{
+				edit: func(context.Context, domain.SMID, domain.StallID, domain.StallEditInput) *domain.Error {
+					return nil
+				},
+			}
+
+			path := fmt.Sprintf("/street_market/%s/stalls/%s", smID, id)
+			req, err := http.NewRequest(http.MethodPatch, path, strings.NewReader(tc.rBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			h := NewStallEditHandler(editorMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/stalls/{stall-id}", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != http.StatusNoContent {
+				t.Errorf("expect status code %v, got %v", http.StatusNoContent, status)
+			}
+
+			if smID != editorMock.editSMIDInp || id != editorMock.editIDInp {
+				t.Errorf(
+					"expect stall editor receive ids %s %s, got %s %s",
+					smID, id, editorMock.editSMIDInp, editorMock.editIDInp,
+				)
+			}
+
+			if diff := cmp.Diff(tc.wantInp, editorMock.editInp); diff != "" {
+				t.Errorf("stall editor edit receive a unexpected input (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStallEditHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		editorErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid input": {
+			editorErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Stall not found": {
+			editorErr:    &domain.Error{Kind: domain.StallNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.StallNotFoundErrKd},
+		},
+		"Stall number duplicated": {
+			editorErr:    &domain.Error{Kind: domain.StallDupErrKd, Msg: "Duplicated"},
+			wantStatusCd: http.StatusConflict,
+			wantBody:     ErrorResponse{Detail: "Duplicated", Code: domain.StallDupErrKd},
+		},
+		"Unexpected error": {
+			editorErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			editorMock := &stubStallEditor{
+				edit: func(context.Context, domain.SMID, domain.StallID, domain.StallEditInput) *domain.Error {
+					return tc.editorErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodPatch, "/street_market/id/stalls/id", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			h := NewStallEditHandler(editorMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/stalls/{stall-id}", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("malformed body", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPatch, "/street_market/id/stalls/id", strings.NewReader("body"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		h := NewStallEditHandler(&stubStallEditor{}, &stubLogger{})
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(h.Handle)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("expect status code %v, got %v", http.StatusBadRequest, status)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type stallGetter interface {
+	Get(context.Context, domain.SMID, domain.StallID) (domain.Stall, *domain.Error)
+}
+
+type stallGetHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type stallResponse struct {
+	ID             string                   `json:"id"`
+	StreetMarketID string                   `json:"street_market_id"`
+	Number         int                      `json:"number"`
+	VendorName     string                   `json:"vendor_name"`
+	LicenseNumber  string                   `json:"license_number"`
+	Categories     []domain.ProductCategory `json:"categories"`
+}
+
+type StallGetHandler struct {
+	getter stallGetter
+	logger stallGetHandlerLogger
+}
+
+func NewStallGetHandler(
+	getter stallGetter,
+	logger stallGetHandlerLogger,
+) *StallGetHandler {
+	return &StallGetHandler{getter, logger}
+}
+
+func (h *StallGetHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	smID := domain.SMID(vars["street-market-id"])
+	id := domain.StallID(vars["stall-id"])
+
+	st, err := h.getter.Get(ctx, smID, id)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toStallResponse(st))
+}
+
+func toStallResponse(st domain.Stall) stallResponse {
+	return stallResponse{
+		ID:             st.ID,
+		StreetMarketID: st.StreetMarketID,
+		Number:         st.Number,
+		VendorName:     st.VendorName,
+		LicenseNumber:  st.LicenseNumber,
+		Categories:     st.Categories,
+	}
+}
@@ -0,0 +1,143 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubStallGetter struct {
+	getSMIDInp domain.SMID
+	getIDInp   domain.StallID
+	get        func(context.Context, domain.SMID, domain.StallID) (domain.Stall, *domain.Error)
+}
+
+func (s *stubStallGetter) Get(ctx context.Context, smID domain.SMID, ID domain.StallID) (domain.Stall, *domain.Error) {
+	s.getSMIDInp = smID
+	s.getIDInp = ID
+	return s.get(ctx, smID, ID)
+}
+
+func TestStallGetHandler_Handle(t *testing.T) {
+	smID := domain.SMID("cdd2028a-fd0b-4734-97e7-ef2e57e9009b")
+	id := domain.StallID("12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf")
+	st := domain.Stall{
+		ID:             string(id),
+		StreetMarketID: string(smID),
+		Number:         12,
+		VendorName:     "Maria da Silva",
+		LicenseNumber:  "TPU-2022-0012",
+		Categories:     []domain.ProductCategory{domain.FishCategory},
+	}
+
+	getterMock := &stubStallGetter{
+		get: func(context.Context, domain.SMID, domain.StallID) (domain.Stall, *domain.Error) {
+			return st, nil
+		},
+	}
+
+	path := fmt.Sprintf("/street_market/%s/stalls/%s", smID, id)
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStallGetHandler(getterMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/street_market/{street-market-id}/stalls/{stall-id}", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got stallResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := stallResponse{
+		ID:             st.ID,
+		StreetMarketID: st.StreetMarketID,
+		Number:         12,
+		VendorName:     "Maria da Silva",
+		LicenseNumber:  "TPU-2022-0012",
+		Categories:     []domain.ProductCategory{domain.FishCategory},
+	}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+
+	if smID != getterMock.getSMIDInp || id != getterMock.getIDInp {
+		t.Errorf(
+			"expect stall getter receive ids %s %s, got %s %s",
+			smID, id, getterMock.getSMIDInp, getterMock.getIDInp,
+		)
+	}
+}
+
+func TestStallGetHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		getterErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid id": {
+			getterErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Stall not found": {
+			getterErr:    &domain.Error{Kind: domain.StallNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.StallNotFoundErrKd},
+		},
+		"Unexpected error": {
+			getterErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			getterMock := &stubStallGetter{
+				get: func(context.Context, domain.SMID, domain.StallID) (domain.Stall, *domain.Error) {
+					return domain.Stall{}, tc.getterErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/street_market/id/stalls/id", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewStallGetHandler(getterMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/stalls/{stall-id}", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type stallLister interface {
+	List(context.Context, domain.SMID) ([]domain.Stall, *domain.Error)
+}
+
+type stallListHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type listStallResponse map[string][]stallResponse
+
+type StallListHandler struct {
+	lister stallLister
+	logger stallListHandlerLogger
+}
+
+func NewStallListHandler(
+	lister stallLister,
+	logger stallListHandlerLogger,
+) *StallListHandler {
+	return &StallListHandler{lister, logger}
+}
+
+func (h *StallListHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	smID := domain.SMID(vars["street-market-id"])
+
+	sts, err := h.lister.List(ctx, smID)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	res := []stallResponse{}
+	for _, st := range sts {
+		res = append(res, toStallResponse(st))
+	}
+
+	respondJSON(w, http.StatusOK, listStallResponse{"data": res})
+}
@@ -0,0 +1,135 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubStallLister struct {
+	listInp domain.SMID
+	list    func(context.Context, domain.SMID) ([]domain.Stall, *domain.Error)
+}
+
+func (s *stubStallLister) List(ctx context.Context, smID domain.SMID) ([]domain.Stall, *domain.Error) {
+	s.listInp = smID
+	return s.list(ctx, smID)
+}
+
+func TestStallListHandler_Handle(t *testing.T) {
+	smID := domain.SMID("cdd2028a-fd0b-4734-97e7-ef2e57e9009b")
+	listerMock := &stubStallLister{
+		list: func(context.Context, domain.SMID) ([]domain.Stall, *domain.Error) {
+			return []domain.Stall{{
+				ID:             "12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf",
+				StreetMarketID: string(smID),
+				Number:         1,
+				VendorName:     "Maria da Silva",
+				LicenseNumber:  "TPU-2022-0001",
+				Categories:     []domain.ProductCategory{domain.PastelCategory},
+			}}, nil
+		},
+	}
+
+	path := fmt.Sprintf("/street_market/%s/stalls", smID)
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStallListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/street_market/{street-market-id}/stalls", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got listStallResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := listStallResponse{"data": {{
+		ID:             "12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf",
+		StreetMarketID: string(smID),
+		Number:         1,
+		VendorName:     "Maria da Silva",
+		LicenseNumber:  "TPU-2022-0001",
+		Categories:     []domain.ProductCategory{domain.PastelCategory},
+	}}}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+
+	if smID != listerMock.listInp {
+		t.Errorf("expect stall lister receive street market id %s, got %s", smID, listerMock.listInp)
+	}
+}
+
+func TestStallListHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		listerErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid id": {
+			listerErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Street market not found": {
+			listerErr:    &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.SMNotFoundErrKd},
+		},
+		"Unexpected error": {
+			listerErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			listerMock := &stubStallLister{
+				list: func(context.Context, domain.SMID) ([]domain.Stall, *domain.Error) {
+					return nil, tc.listerErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/street_market/id/stalls", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewStallListHandler(listerMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/stalls", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+type calendarGetter interface {
+	Get(context.Context, domain.SMID) ([]domain.ScheduledStreetMarket, *domain.Error)
+}
+
+type calendarEncoder interface {
+	Encode([]domain.ScheduledStreetMarket) []byte
+}
+
+type streetMarketCalendarHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type StreetMarketCalendarHandler struct {
+	getter  calendarGetter
+	encoder calendarEncoder
+	logger  streetMarketCalendarHandlerLogger
+}
+
+func NewStreetMarketCalendarHandler(
+	getter calendarGetter,
+	encoder calendarEncoder,
+	logger streetMarketCalendarHandlerLogger,
+) *StreetMarketCalendarHandler {
+	return &StreetMarketCalendarHandler{getter, encoder, logger}
+}
+
+func (h *StreetMarketCalendarHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := domain.SMID(vars["street-market-id"])
+
+	ssms, err := h.getter.Get(ctx, id)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondBytes(w, http.StatusOK, calendarContentType, h.encoder.Encode(ssms))
+}
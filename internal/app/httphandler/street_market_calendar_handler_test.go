@@ -0,0 +1,134 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubCalendarGetter struct {
+	getInp domain.SMID
+	get    func(context.Context, domain.SMID) ([]domain.ScheduledStreetMarket, *domain.Error)
+}
+
+func (s *stubCalendarGetter) Get(ctx context.Context, ID domain.SMID) ([]domain.ScheduledStreetMarket, *domain.Error) {
+	s.getInp = ID
+	return s.get(ctx, ID)
+}
+
+type stubCalendarEncoder struct {
+	encodeInp []domain.ScheduledStreetMarket
+}
+
+func (s *stubCalendarEncoder) Encode(ssms []domain.ScheduledStreetMarket) []byte {
+	s.encodeInp = ssms
+	return []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+}
+
+func TestStreetMarketCalendarHandler_Handle(t *testing.T) {
+	id := "aaa1be24-ddec-4590-839d-b7ae54b9ed78"
+	ssms := []domain.ScheduledStreetMarket{{StreetMarket: domain.StreetMarket{ID: id}}}
+
+	getterMock := &stubCalendarGetter{
+		get: func(ctx context.Context, ID domain.SMID) ([]domain.ScheduledStreetMarket, *domain.Error) {
+			return ssms, nil
+		},
+	}
+	encoderMock := &stubCalendarEncoder{}
+
+	req, err := http.NewRequest(http.MethodGet, "/street_market/"+id+"/calendar.ics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStreetMarketCalendarHandler(getterMock, encoderMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/street_market/{street-market-id}/calendar.ics", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	if ct := rr.Header().Get("Content-Type"); ct != calendarContentType {
+		t.Errorf("expect content type %s, got %s", calendarContentType, ct)
+	}
+
+	if getterMock.getInp != domain.SMID(id) {
+		t.Errorf("expect calendar getter receive id %s, got %s", id, getterMock.getInp)
+	}
+
+	if diff := cmp.Diff(ssms, encoderMock.encodeInp); diff != "" {
+		t.Errorf("calendar encoder receive a unexpected input (-want +got):\n%s", diff)
+	}
+
+	if got := rr.Body.String(); got != "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n" {
+		t.Errorf("unexpected body %q", got)
+	}
+}
+
+func TestStreetMarketCalendarHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		getterErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid id": {
+			getterErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Invalid ID"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Invalid ID", Code: domain.InpValidationErrKd},
+		},
+		"Street Market not founded": {
+			getterErr:    &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "SM not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "SM not found", Code: domain.SMNotFoundErrKd},
+		},
+		"Unexpected error": {
+			getterErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			getterMock := &stubCalendarGetter{
+				get: func(ctx context.Context, ID domain.SMID) ([]domain.ScheduledStreetMarket, *domain.Error) {
+					return nil, tc.getterErr
+				},
+			}
+
+			path := "/street_market/70ec02cb-0e4a-44cc-b0f7-83c040cb83ea/calendar.ics"
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewStreetMarketCalendarHandler(getterMock, &stubCalendarEncoder{}, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/calendar.ics", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
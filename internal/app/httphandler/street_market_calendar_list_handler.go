@@ -0,0 +1,49 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type calendarLister interface {
+	List(context.Context, domain.StreetMarketFilter) ([]domain.ScheduledStreetMarket, *domain.Error)
+}
+
+type streetMarketCalendarListHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type StreetMarketCalendarListHandler struct {
+	lister  calendarLister
+	encoder calendarEncoder
+	logger  streetMarketCalendarListHandlerLogger
+}
+
+func NewStreetMarketCalendarListHandler(
+	lister calendarLister,
+	encoder calendarEncoder,
+	logger streetMarketCalendarListHandlerLogger,
+) *StreetMarketCalendarListHandler {
+	return &StreetMarketCalendarListHandler{lister, encoder, logger}
+}
+
+func (h *StreetMarketCalendarListHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	f := domain.StreetMarketFilter{
+		District:     r.FormValue("district"),
+		Region5:      r.FormValue("region5"),
+		Name:         r.FormValue("name"),
+		Neighborhood: r.FormValue("neighborhood"),
+	}
+
+	ssms, err := h.lister.List(ctx, f)
+	if err != nil {
+		h.logger.Error(ctx, *err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondBytes(w, http.StatusOK, calendarContentType, h.encoder.Encode(ssms))
+}
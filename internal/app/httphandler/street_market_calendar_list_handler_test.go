@@ -0,0 +1,95 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubCalendarLister struct {
+	listInp domain.StreetMarketFilter
+	list    func(context.Context, domain.StreetMarketFilter) ([]domain.ScheduledStreetMarket, *domain.Error)
+}
+
+func (s *stubCalendarLister) List(
+	ctx context.Context,
+	query domain.StreetMarketFilter,
+) ([]domain.ScheduledStreetMarket, *domain.Error) {
+	s.listInp = query
+	return s.list(ctx, query)
+}
+
+func TestStreetMarketCalendarListHandler_Handle(t *testing.T) {
+	ssms := []domain.ScheduledStreetMarket{{StreetMarket: domain.StreetMarket{ID: "aaa1be24-ddec-4590-839d-b7ae54b9ed78"}}}
+
+	listerMock := &stubCalendarLister{
+		list: func(ctx context.Context, query domain.StreetMarketFilter) ([]domain.ScheduledStreetMarket, *domain.Error) {
+			return ssms, nil
+		},
+	}
+	encoderMock := &stubCalendarEncoder{}
+
+	req, err := http.NewRequest(http.MethodGet, "/street_market/calendar.ics?district=VILA%20FORMOSA", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStreetMarketCalendarListHandler(listerMock, encoderMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	if ct := rr.Header().Get("Content-Type"); ct != calendarContentType {
+		t.Errorf("expect content type %s, got %s", calendarContentType, ct)
+	}
+
+	wantInp := domain.StreetMarketFilter{District: "VILA FORMOSA"}
+	if diff := cmp.Diff(wantInp, listerMock.listInp); diff != "" {
+		t.Errorf("calendar lister receive a unexpected input (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(ssms, encoderMock.encodeInp); diff != "" {
+		t.Errorf("calendar encoder receive a unexpected input (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreetMarketCalendarListHandler_Handle_Error(t *testing.T) {
+	listerMock := &stubCalendarLister{
+		list: func(ctx context.Context, query domain.StreetMarketFilter) ([]domain.ScheduledStreetMarket, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"}
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/street_market/calendar.ics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStreetMarketCalendarListHandler(listerMock, &stubCalendarEncoder{}, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusInternalServerError {
+		t.Errorf("expect status code %v, got %v", http.StatusInternalServerError, status)
+	}
+
+	var got ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,131 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+const (
+	eventStreamContentType = "text/event-stream"
+	// reconnectDelay is how long clients wait before reconnecting after the
+	// stream ends, in milliseconds.
+	reconnectDelay = 3000
+)
+
+type streetMarketChangeFollower interface {
+	Follow(context.Context, domain.ChangeFilter, string) (<-chan domain.MarketEvent, *domain.Error)
+}
+
+type streetMarketChangesHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type marketEventResponse struct {
+	ID             string                `json:"id"`
+	Type           domain.EventType      `json:"type"`
+	StreetMarketID string                `json:"street_market_id"`
+	OccurredAt     *time.Time            `json:"occurred_at,omitempty"`
+	Before         *streetMarketResponse `json:"before,omitempty"`
+	After          *streetMarketResponse `json:"after,omitempty"`
+}
+
+type StreetMarketChangesHandler struct {
+	follower  streetMarketChangeFollower
+	heartbeat time.Duration
+	logger    streetMarketChangesHandlerLogger
+}
+
+// NewStreetMarketChangesHandler sends a comment every heartbeat while there
+// are no changes, so proxies do not close an idle stream.
+func NewStreetMarketChangesHandler(
+	follower streetMarketChangeFollower,
+	heartbeat time.Duration,
+	logger streetMarketChangesHandlerLogger,
+) *StreetMarketChangesHandler {
+	return &StreetMarketChangesHandler{follower, heartbeat, logger}
+}
+
+// Handle streams the changes of the street markets as server-sent events, the
+// district and region5 query parameters narrowing them. A client reconnecting
+// with the Last-Event-ID header first gets the changes it missed.
+func (h *StreetMarketChangesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err := &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Response does not support streaming"}
+		h.logger.Error(ctx, *err)
+		respondError(w, r, err)
+		return
+	}
+
+	f := domain.ChangeFilter{District: r.FormValue("district"), Region5: r.FormValue("region5")}
+	evs, err := h.follower.Follow(ctx, f, r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", eventStreamContentType)
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	t := time.NewTicker(h.heartbeat)
+	defer t.Stop()
+
+	for {
+		var wErr error
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			_, wErr = io.WriteString(w, ": keep-alive\n\n")
+		case ev, ok := <-evs:
+			if !ok {
+				return
+			}
+			wErr = writeEvent(w, ev)
+		}
+		if wErr != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes ev as a server-sent event named by its type, its id being
+// what clients resume from.
+func writeEvent(w io.Writer, ev domain.MarketEvent) error {
+	res := marketEventResponse{ID: ev.ID, Type: ev.Type, StreetMarketID: ev.MarketID, OccurredAt: ev.OccurredAt}
+	if ev.Before != nil {
+		smr := newStreetMarketResponse(*ev.Before)
+		res.Before = &smr
+	}
+	if ev.After != nil {
+		smr := newStreetMarketResponse(*ev.After)
+		res.After = &smr
+	}
+
+	data, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
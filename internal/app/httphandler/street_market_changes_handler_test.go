@@ -0,0 +1,207 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubStreetMarketChangeFollower struct {
+	filterInp      domain.ChangeFilter
+	lastEventIDInp string
+	follow         func(context.Context, domain.ChangeFilter, string) (<-chan domain.MarketEvent, *domain.Error)
+}
+
+func (s *stubStreetMarketChangeFollower) Follow(
+	ctx context.Context,
+	f domain.ChangeFilter,
+	lastEventID string,
+) (<-chan domain.MarketEvent, *domain.Error) {
+	s.filterInp = f
+	s.lastEventIDInp = lastEventID
+	return s.follow(ctx, f, lastEventID)
+}
+
+func TestStreetMarketChangesHandler_Handle(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	before := domain.StreetMarket{ID: "2c809e53-6e2e-4a60-bbf4-de8913562970", Name: "VILA FORMOSA", Region5: "Leste"}
+	after := before
+	after.Name = "VILA CARRAO"
+
+	followerMock := &stubStreetMarketChangeFollower{
+		follow: func(context.Context, domain.ChangeFilter, string) (<-chan domain.MarketEvent, *domain.Error) {
+			evs := make(chan domain.MarketEvent, 2)
+			evs <- domain.MarketEvent{
+				ID:         "7d3f5a2e-1b4c-4e8d-9a6f-0c2b1e3d4f5a",
+				Type:       domain.MarketUpdatedEvent,
+				MarketID:   before.ID,
+				Before:     &before,
+				After:      &after,
+				OccurredAt: &at,
+			}
+			evs <- domain.MarketEvent{
+				ID:         "8e4a6b3f-2c5d-4f9e-8b7a-1d3c2f4e5a6b",
+				Type:       domain.MarketDeletedEvent,
+				MarketID:   before.ID,
+				Before:     &after,
+				OccurredAt: &at,
+			}
+			close(evs)
+			return evs, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/street_market/changes?district=VILA+FORMOSA&region5=Leste", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", "0b0e7c1a-3d5e-4f6a-8b9c-1d2e3f4a5b6c")
+
+	h := NewStreetMarketChangesHandler(followerMock, time.Hour, &stubLogger{})
+	rr := httptest.NewRecorder()
+	h.Handle(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != eventStreamContentType {
+		t.Errorf("expect content type %s, got %s", eventStreamContentType, ct)
+	}
+	if !rr.Flushed {
+		t.Error("expect the response flushed")
+	}
+
+	wFilter := domain.ChangeFilter{District: "VILA FORMOSA", Region5: "Leste"}
+	if diff := cmp.Diff(wFilter, followerMock.filterInp); diff != "" {
+		t.Errorf("unexpected filter (-want +got):\n%s", diff)
+	}
+	if followerMock.lastEventIDInp != "0b0e7c1a-3d5e-4f6a-8b9c-1d2e3f4a5b6c" {
+		t.Errorf("expect the last event id from the header, got %q", followerMock.lastEventIDInp)
+	}
+
+	frames := strings.Split(strings.TrimSuffix(rr.Body.String(), "\n\n"), "\n\n")
+	if len(frames) != 3 {
+		t.Fatalf("expect the retry and two events, got %q", rr.Body.String())
+	}
+	if frames[0] != "retry: 3000" {
+		t.Errorf("expect the reconnect delay first, got %q", frames[0])
+	}
+
+	lines := strings.Split(frames[1], "\n")
+	wLines := []string{"id: 7d3f5a2e-1b4c-4e8d-9a6f-0c2b1e3d4f5a", "event: street_market.updated"}
+	if diff := cmp.Diff(wLines, lines[:2]); diff != "" {
+		t.Errorf("unexpected event fields (-want +got):\n%s", diff)
+	}
+
+	var got marketEventResponse
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &got); err != nil {
+		t.Fatal(err)
+	}
+	want := marketEventResponse{
+		ID:             "7d3f5a2e-1b4c-4e8d-9a6f-0c2b1e3d4f5a",
+		Type:           domain.MarketUpdatedEvent,
+		StreetMarketID: before.ID,
+		OccurredAt:     &at,
+		Before:         &streetMarketResponse{ID: before.ID, Name: "VILA FORMOSA", Region5: "Leste"},
+		After:          &streetMarketResponse{ID: before.ID, Name: "VILA CARRAO", Region5: "Leste"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("want data mismatch with got data (-want +got):\n%s", diff)
+	}
+
+	if !strings.HasPrefix(frames[2], "id: 8e4a6b3f-2c5d-4f9e-8b7a-1d3c2f4e5a6b\nevent: street_market.deleted\n") {
+		t.Errorf("unexpected second event %q", frames[2])
+	}
+}
+
+func TestStreetMarketChangesHandler_Handle_Heartbeat(t *testing.T) {
+	followerMock := &stubStreetMarketChangeFollower{
+		follow: func(context.Context, domain.ChangeFilter, string) (<-chan domain.MarketEvent, *domain.Error) {
+			evs := make(chan domain.MarketEvent)
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				close(evs)
+			}()
+			return evs, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/street_market/changes", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStreetMarketChangesHandler(followerMock, 5*time.Millisecond, &stubLogger{})
+	rr := httptest.NewRecorder()
+	h.Handle(rr, req)
+
+	if !strings.Contains(rr.Body.String(), "\n\n: keep-alive\n\n") {
+		t.Errorf("expect keep-alive comments while idle, got %q", rr.Body.String())
+	}
+}
+
+func TestStreetMarketChangesHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		followerErr  *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid input": {
+			followerErr: &domain.Error{
+				Kind:   domain.InpValidationErrKd,
+				Msg:    "Last-Event-ID is not a known event",
+				Fields: []domain.FieldError{{Field: "Last-Event-ID", Code: domain.UnknownFieldCd, Msg: "Unknown"}},
+			},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody: ErrorResponse{
+				Detail: "Last-Event-ID is not a known event",
+				Code:   domain.InpValidationErrKd,
+				Errors: []fieldErrorResponse{{Field: "Last-Event-ID", Code: "UNKNOWN_REFERENCE", Message: "Unknown"}},
+			},
+		},
+		"Unexpected error": {
+			followerErr:  &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			followerMock := &stubStreetMarketChangeFollower{
+				follow: func(context.Context, domain.ChangeFilter, string) (<-chan domain.MarketEvent, *domain.Error) {
+					return nil, tc.followerErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/street_market/changes", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewStreetMarketChangesHandler(followerMock, time.Hour, &stubLogger{})
+			rr := httptest.NewRecorder()
+			h.Handle(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
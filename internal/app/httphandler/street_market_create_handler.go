@@ -2,9 +2,7 @@ package httphandler
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
@@ -34,23 +32,9 @@ func (h *StreetMarketCreateHandler) Handle(w http.ResponseWriter, r *http.Reques
 	ctx := r.Context()
 	var body streetMarketBody
 
-	bb, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		h.logger.Error(ctx, domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
-		})
-		respondError(w, http.StatusInternalServerError, err.Error())
-		return
-	}
-	defer r.Body.Close()
-
-	if err := json.Unmarshal(bb, &body); err != nil {
-		h.logger.Error(ctx, domain.Error{
-			Kind: domain.InpValidationErrKd,
-			Msg:  err.Error(),
-		})
-		respondError(w, http.StatusBadRequest, "malformed body")
+	if dErr := decodeJSON(r, &body); dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
 		return
 	}
 
@@ -76,21 +60,17 @@ func (h *StreetMarketCreateHandler) Handle(w http.ResponseWriter, r *http.Reques
 	id, dErr := h.creator.Create(r.Context(), input)
 
 	if dErr != nil {
-		var status int
-
-		switch dErr.Kind {
-		case domain.InpValidationErrKd:
-			status = http.StatusBadRequest
-		default:
-			h.logger.Error(ctx, *dErr)
-			status = http.StatusInternalServerError
+		if dErr.Kind == domain.InpValidationErrKd {
+			respondInvalidInput(w, r, dErr, streetMarketBody{})
+			return
 		}
 
-		respondError(w, status, dErr.Error())
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
 		return
 	}
 
-	location := fmt.Sprintf("%s/street_market/%s", r.Host, id)
+	location := fmt.Sprintf("/v1/street_market/%s", id)
 	w.Header().Add("Location", location)
 	respondJSON(w, http.StatusCreated, "")
 }
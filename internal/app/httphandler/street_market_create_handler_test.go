@@ -80,6 +80,8 @@ func TestStreetMarketCreateHandler_Handle(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Host = "localhost"
 
 	h := NewStreetMarketCreateHandler(creatorMock, &stubLogger{})
 	rr := httptest.NewRecorder()
@@ -90,6 +92,11 @@ func TestStreetMarketCreateHandler_Handle(t *testing.T) {
 		t.Errorf("expect status code %v, got %v", http.StatusCreated, status)
 	}
 
+	wantLocation := "/v1/street_market/" + id
+	if got := rr.Header().Get("Location"); got != wantLocation {
+		t.Errorf("expect location %s, got %s", wantLocation, got)
+	}
+
 	if diff := cmp.Diff(wantInp, creatorMock.createInp); diff != "" {
 		t.Errorf("street market creator create receive a unexpected input (-want +got):\n%s", diff)
 	}
@@ -106,13 +113,33 @@ func TestStreetMarketCreateHandler_Handle_Error(t *testing.T) {
 			rBody:        streetMarketBody{},
 			creatorErr:   &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
 			wantStatusCd: http.StatusBadRequest,
-			wantBody:     ErrorResponse{"error": "Error"},
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Invalid fields": {
+			rBody: streetMarketBody{},
+			creatorErr: &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Invalid input",
+				Fields: []domain.FieldError{
+					{Field: "IDdist", Code: domain.RequiredFieldCd, Msg: "IDdist is required"},
+					{Field: "AddrExtraInfo", Code: domain.TooLongFieldCd, Msg: "AddrExtraInfo must have at most 250 characters"},
+				},
+			},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody: ErrorResponse{
+				Detail: "Invalid input",
+				Code:   domain.InpValidationErrKd,
+				Errors: []fieldErrorResponse{
+					{Field: "id_dist", Code: "REQUIRED", Message: "IDdist is required"},
+					{Field: "addr_extra_info", Code: "TOO_LONG", Message: "AddrExtraInfo must have at most 250 characters"},
+				},
+			},
 		},
 		"Unexpected error": {
 			rBody:        streetMarketBody{},
 			creatorErr:   &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
 			wantStatusCd: http.StatusInternalServerError,
-			wantBody:     ErrorResponse{"error": "Unexpected"},
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
 		},
 	}
 
@@ -133,6 +160,7 @@ func TestStreetMarketCreateHandler_Handle_Error(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			req.Header.Set("Content-Type", "application/json")
 
 			h := NewStreetMarketCreateHandler(creatorMock, &stubLogger{})
 			rr := httptest.NewRecorder()
@@ -149,7 +177,7 @@ func TestStreetMarketCreateHandler_Handle_Error(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(tc.wantBody, got); diff != "" {
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
 				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
 			}
 		})
@@ -160,6 +188,7 @@ func TestStreetMarketCreateHandler_Handle_Error(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
+		req.Header.Set("Content-Type", "application/json")
 
 		h := NewStreetMarketCreateHandler(&stubStreetMarketCreator{}, &stubLogger{})
 		rr := httptest.NewRecorder()
@@ -175,8 +204,42 @@ func TestStreetMarketCreateHandler_Handle_Error(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		want := ErrorResponse{"error": "malformed body"}
-		if diff := cmp.Diff(want, got); diff != "" {
+		want := ErrorResponse{Detail: "malformed body", Code: domain.InpValidationErrKd}
+		if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+			t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		body := `{"name": "VILA FORMOSA", "neighbourhood": "VL FORMOSA"}`
+		req, err := http.NewRequest(http.MethodPost, "/street_market", strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		h := NewStreetMarketCreateHandler(&stubStreetMarketCreator{}, &stubLogger{})
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(h.Handle)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("expect status code %v, got %v", http.StatusBadRequest, status)
+		}
+		var got ErrorResponse
+		err = json.Unmarshal(rr.Body.Bytes(), &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := ErrorResponse{
+			Detail: "Invalid input",
+			Code:   domain.InpValidationErrKd,
+			Errors: []fieldErrorResponse{
+				{Field: "neighbourhood", Code: "UNEXPECTED_FIELD", Message: "neighbourhood is not a known field"},
+			},
+		}
+		if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
 			t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
 		}
 	})
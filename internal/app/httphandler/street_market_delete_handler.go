@@ -36,19 +36,8 @@ func (h *StreetMarketDeleteHandler) Handle(w http.ResponseWriter, r *http.Reques
 	err := h.eraser.Delete(r.Context(), id)
 
 	if err != nil {
-		var status int
-
-		switch err.Kind {
-		case domain.InpValidationErrKd:
-			status = http.StatusBadRequest
-		case domain.SMNotFoundErrKd:
-			status = http.StatusNotFound
-		default:
-			h.logger.Error(ctx, *err)
-			status = http.StatusInternalServerError
-		}
-
-		respondError(w, status, err.Error())
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
 		return
 	}
 
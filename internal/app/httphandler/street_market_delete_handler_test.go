@@ -68,19 +68,19 @@ func TestStreetMarketDeleteHandler_Handle_Error(t *testing.T) {
 			id:           "5e5e1905-282f-4038-b8c9-bc1719174892",
 			eraserErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
 			wantStatusCd: http.StatusInternalServerError,
-			wantBody:     ErrorResponse{"error": "Unexpected"},
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
 		},
 		"Invalid person id": {
 			id:           "id",
 			eraserErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
 			wantStatusCd: http.StatusBadRequest,
-			wantBody:     ErrorResponse{"error": "Error"},
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
 		},
 		"Street Market not founded": {
 			id:           "id",
 			eraserErr:    &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "SM not found"},
 			wantStatusCd: http.StatusNotFound,
-			wantBody:     ErrorResponse{"error": "SM not found"},
+			wantBody:     ErrorResponse{Detail: "SM not found", Code: domain.SMNotFoundErrKd},
 		},
 	}
 
@@ -114,7 +114,7 @@ func TestStreetMarketDeleteHandler_Handle_Error(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(tc.wantBody, got); diff != "" {
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
 				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
 			}
 		})
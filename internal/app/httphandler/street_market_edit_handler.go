@@ -2,8 +2,6 @@ package httphandler
 
 import (
 	"context"
-	"encoding/json"
-	"io/ioutil"
 	"net/http"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
@@ -34,23 +32,9 @@ func (h *StreetMarketEditHandler) Handle(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 	var body streetMarketBody
 
-	bb, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		h.logger.Error(ctx, domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
-		})
-		respondError(w, http.StatusInternalServerError, err.Error())
-		return
-	}
-	defer r.Body.Close()
-
-	if err := json.Unmarshal(bb, &body); err != nil {
-		h.logger.Error(ctx, domain.Error{
-			Kind: domain.InpValidationErrKd,
-			Msg:  err.Error(),
-		})
-		respondError(w, http.StatusBadRequest, "malformed body")
+	if dErr := decodeJSON(r, &body); dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
 		return
 	}
 
@@ -79,19 +63,13 @@ func (h *StreetMarketEditHandler) Handle(w http.ResponseWriter, r *http.Request)
 	dErr := h.editor.Edit(r.Context(), id, input)
 
 	if dErr != nil {
-		var status int
-
-		switch dErr.Kind {
-		case domain.InpValidationErrKd:
-			status = http.StatusBadRequest
-		case domain.SMNotFoundErrKd:
-			status = http.StatusNotFound
-		default:
-			h.logger.Error(ctx, *dErr)
-			status = http.StatusInternalServerError
+		if dErr.Kind == domain.InpValidationErrKd {
+			respondInvalidInput(w, r, dErr, streetMarketBody{})
+			return
 		}
 
-		respondError(w, status, dErr.Error())
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
 		return
 	}
 
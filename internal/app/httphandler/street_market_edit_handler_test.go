@@ -87,6 +87,7 @@ func TestStreetMarketEditHandler_Handle(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	req.Header.Set("Content-Type", "application/json")
 
 	h := NewStreetMarketEditHandler(editorMock, &stubLogger{})
 	rr := httptest.NewRecorder()
@@ -116,21 +117,42 @@ func TestStreetMarketEditHandler_Handle_Error(t *testing.T) {
 			id:           "invalid",
 			editorErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
 			wantStatusCd: http.StatusBadRequest,
-			wantBody:     ErrorResponse{"error": "Error"},
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Invalid fields": {
+			rBody: streetMarketBody{},
+			id:    "70ec02cb-0e4a-44cc-b0f7-83c040cb83ea",
+			editorErr: &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Invalid input",
+				Fields: []domain.FieldError{
+					{Field: "IDdist", Code: domain.RequiredFieldCd, Msg: "IDdist is required"},
+					{Field: "AddrExtraInfo", Code: domain.TooLongFieldCd, Msg: "AddrExtraInfo must have at most 250 characters"},
+				},
+			},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody: ErrorResponse{
+				Detail: "Invalid input",
+				Code:   domain.InpValidationErrKd,
+				Errors: []fieldErrorResponse{
+					{Field: "id_dist", Code: "REQUIRED", Message: "IDdist is required"},
+					{Field: "addr_extra_info", Code: "TOO_LONG", Message: "AddrExtraInfo must have at most 250 characters"},
+				},
+			},
 		},
 		"Unexpected error": {
 			rBody:        streetMarketBody{},
 			id:           "70ec02cb-0e4a-44cc-b0f7-83c040cb83ea",
 			editorErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
 			wantStatusCd: http.StatusInternalServerError,
-			wantBody:     ErrorResponse{"error": "Unexpected"},
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
 		},
 		"Street Market not founded": {
 			rBody:        streetMarketBody{},
 			id:           "70ec02cb-0e4a-44cc-b0f7-83c040cb83ea",
 			editorErr:    &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "SM not found"},
 			wantStatusCd: http.StatusNotFound,
-			wantBody:     ErrorResponse{"error": "SM not found"},
+			wantBody:     ErrorResponse{Detail: "SM not found", Code: domain.SMNotFoundErrKd},
 		},
 	}
 
@@ -152,6 +174,7 @@ func TestStreetMarketEditHandler_Handle_Error(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			req.Header.Set("Content-Type", "application/json")
 
 			h := NewStreetMarketEditHandler(editorMock, &stubLogger{})
 			rr := httptest.NewRecorder()
@@ -169,7 +192,7 @@ func TestStreetMarketEditHandler_Handle_Error(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(tc.wantBody, got); diff != "" {
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
 				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
 			}
 		})
@@ -182,6 +205,7 @@ func TestStreetMarketEditHandler_Handle_Error(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
+		req.Header.Set("Content-Type", "application/json")
 
 		h := NewStreetMarketEditHandler(&stubStreetMarketEditor{}, &stubLogger{})
 		rr := httptest.NewRecorder()
@@ -198,8 +222,8 @@ func TestStreetMarketEditHandler_Handle_Error(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		want := ErrorResponse{"error": "malformed body"}
-		if diff := cmp.Diff(want, got); diff != "" {
+		want := ErrorResponse{Detail: "malformed body", Code: domain.InpValidationErrKd}
+		if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
 			t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
 		}
 	})
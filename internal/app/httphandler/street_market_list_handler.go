@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 )
@@ -35,12 +36,6 @@ func NewStreetMarketListHandler(
 
 func (h *StreetMarketListHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	f := domain.StreetMarketFilter{
-		District:     r.FormValue("district"),
-		Region5:      r.FormValue("region5"),
-		Name:         r.FormValue("name"),
-		Neighborhood: r.FormValue("neighborhood"),
-	}
 
 	var pgn int
 	var err error
@@ -52,42 +47,80 @@ func (h *StreetMarketListHandler) Handle(w http.ResponseWriter, r *http.Request)
 				Kind: domain.InpValidationErrKd,
 				Msg:  err.Error(),
 			})
-			respondError(w, http.StatusBadRequest, "Page can be integer")
+			respondError(w, r, &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "Page can be integer",
+			})
 			return
 		}
 	}
 
+	f, dErr := parseStreetMarketFilter(r)
+	if dErr != nil {
+		h.logger.Error(ctx, *dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
 	ls, dErr := h.getter.List(ctx, pgn, f)
 	if dErr != nil {
 		h.logger.Error(ctx, *dErr)
-		respondError(w, http.StatusInternalServerError, dErr.Error())
+		respondError(w, r, dErr)
 		return
 	}
 
 	lr := []streetMarketResponse{}
 	for _, sm := range ls {
-		smr := streetMarketResponse{
-			ID:            sm.ID,
-			Long:          sm.Long,
-			Lat:           sm.Lat,
-			SectCens:      sm.SectCens,
-			Area:          sm.Area,
-			IDdist:        sm.IDdist,
-			District:      sm.District,
-			IDSubTH:       sm.IDSubTH,
-			SubTownHall:   sm.SubTownHall,
-			Region5:       sm.Region5,
-			Region8:       sm.Region8,
-			Name:          sm.Name,
-			Register:      sm.Register,
-			Street:        sm.Street,
-			Number:        sm.Number,
-			Neighborhood:  sm.Neighborhood,
-			AddrExtraInfo: sm.AddrExtraInfo,
+		lr = append(lr, newStreetMarketResponse(sm))
+	}
+
+	respondJSON(w, http.StatusOK, listStreetMarketResponse{"data": lr})
+}
+
+// parseStreetMarketFilter reads the street market filter from the query
+// params. The returned error message is meant for the client.
+func parseStreetMarketFilter(r *http.Request) (domain.StreetMarketFilter, *domain.Error) {
+	f := domain.StreetMarketFilter{
+		District:     r.FormValue("district"),
+		Region5:      r.FormValue("region5"),
+		Name:         r.FormValue("name"),
+		Neighborhood: r.FormValue("neighborhood"),
+	}
+
+	if openOn := r.FormValue("open_on"); openOn != "" {
+		wd, err := domain.ParseWeekday(openOn)
+		if err != nil {
+			return f, &domain.Error{Kind: domain.InpValidationErrKd, Msg: "open_on must be a weekday", Previous: err}
+		}
+		f.OpenOn = &wd
+	}
+
+	if openAt := r.FormValue("open_at"); openAt != "" {
+		t, ok := parseOpenAt(openAt)
+		if !ok {
+			return f, &domain.Error{Kind: domain.InpValidationErrKd, Msg: "open_at must be a RFC 3339 date time"}
 		}
+		f.OpenAt = &t
+	}
 
-		lr = append(lr, smr)
+	if sells := r.FormValue("sells"); sells != "" {
+		pc := domain.ProductCategory(sells)
+		if err := pc.Validate(); err != nil {
+			return f, &domain.Error{Kind: domain.InpValidationErrKd, Msg: "sells must be a product category", Previous: err}
+		}
+		f.Sells = pc
 	}
 
-	respondJSON(w, http.StatusOK, listStreetMarketResponse{"data": lr})
+	return f, nil
+}
+
+// parseOpenAt accepts RFC 3339 with or without seconds, as in 2026-10-18T08:00-03:00.
+func parseOpenAt(v string) (time.Time, bool) {
+	for _, l := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+		if t, err := time.Parse(l, v); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
 }
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 	"github.com/google/go-cmp/cmp"
@@ -108,7 +109,7 @@ func TestStreetMarketListHandler_Handle(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if diff := cmp.Diff(want, got); diff != "" {
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
 		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
 	}
 
@@ -121,6 +122,43 @@ func TestStreetMarketListHandler_Handle(t *testing.T) {
 	}
 }
 
+func TestStreetMarketListHandler_Handle_ScheduleFilter(t *testing.T) {
+	listerMock := &stubStreetMarketLister{
+		list: func(ctx context.Context, page int, inp domain.StreetMarketFilter) ([]domain.StreetMarket, *domain.Error) {
+			return []domain.StreetMarket{}, nil
+		},
+	}
+
+	path := "/street_market?open_on=saturday&open_at=2026-10-18T08:00-03:00&sells=fish"
+	req, err := http.NewRequest(http.MethodGet, path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStreetMarketListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	openOn := time.Saturday
+	openAt := time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC)
+
+	got := listerMock.listInp
+	if got.OpenOn == nil || *got.OpenOn != openOn {
+		t.Errorf("expect street market lister receive open on %v, got %v", openOn, got.OpenOn)
+	}
+	if got.OpenAt == nil || !got.OpenAt.Equal(openAt) {
+		t.Errorf("expect street market lister receive open at %v, got %v", openAt, got.OpenAt)
+	}
+	if got.Sells != domain.FishCategory {
+		t.Errorf("expect street market lister receive sells %v, got %v", domain.FishCategory, got.Sells)
+	}
+}
+
 func TestStreetMarketListHandler_Handle_Error(t *testing.T) {
 	testCases := map[string]struct {
 		listerErr    *domain.Error
@@ -131,13 +169,28 @@ func TestStreetMarketListHandler_Handle_Error(t *testing.T) {
 		"Unexpected error": {
 			listerErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Error"},
 			wantStatusCd: http.StatusInternalServerError,
-			wantBody:     ErrorResponse{"error": "Error"},
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
 			path:         "/street_market",
 		},
+		"Param open_on invalid": {
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "open_on must be a weekday", Code: domain.InpValidationErrKd},
+			path:         "/street_market?open_on=someday",
+		},
+		"Param open_at invalid": {
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "open_at must be a RFC 3339 date time", Code: domain.InpValidationErrKd},
+			path:         "/street_market?open_at=tomorrow",
+		},
+		"Param sells invalid": {
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "sells must be a product category", Code: domain.InpValidationErrKd},
+			path:         "/street_market?sells=cars",
+		},
 		"Param page invalid": {
 			listerErr:    &domain.Error{Kind: domain.InpValidationErrKd},
 			wantStatusCd: http.StatusBadRequest,
-			wantBody:     ErrorResponse{"error": "Page can be integer"},
+			wantBody:     ErrorResponse{Detail: "Page can be integer", Code: domain.InpValidationErrKd},
 			path:         "/street_market?page=invalid",
 		},
 	}
@@ -170,7 +223,7 @@ func TestStreetMarketListHandler_Handle_Error(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			if diff := cmp.Diff(tc.wantBody, got); diff != "" {
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
 				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
 			}
 		})
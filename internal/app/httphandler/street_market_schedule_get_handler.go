@@ -0,0 +1,94 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type scheduleGetter interface {
+	Get(context.Context, domain.SMID) (domain.Schedule, *domain.Error)
+}
+
+type streetMarketScheduleGetHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type scheduleSlotBody struct {
+	Weekday string `json:"weekday"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+type scheduleExceptionBody struct {
+	Date   string `json:"date"`
+	Closed bool   `json:"closed"`
+	Start  string `json:"start,omitempty"`
+	End    string `json:"end,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+type scheduleBody struct {
+	Weekdays   []scheduleSlotBody      `json:"weekdays"`
+	Exceptions []scheduleExceptionBody `json:"exceptions"`
+}
+
+type StreetMarketScheduleGetHandler struct {
+	getter scheduleGetter
+	logger streetMarketScheduleGetHandlerLogger
+}
+
+func NewStreetMarketScheduleGetHandler(
+	getter scheduleGetter,
+	logger streetMarketScheduleGetHandlerLogger,
+) *StreetMarketScheduleGetHandler {
+	return &StreetMarketScheduleGetHandler{getter, logger}
+}
+
+func (h *StreetMarketScheduleGetHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := domain.SMID(vars["street-market-id"])
+
+	sch, err := h.getter.Get(ctx, id)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toScheduleBody(sch))
+}
+
+func toScheduleBody(sch domain.Schedule) scheduleBody {
+	body := scheduleBody{
+		Weekdays:   []scheduleSlotBody{},
+		Exceptions: []scheduleExceptionBody{},
+	}
+
+	for _, sl := range sch.Slots {
+		body.Weekdays = append(body.Weekdays, scheduleSlotBody{
+			Weekday: strings.ToLower(sl.Weekday.String()),
+			Start:   sl.Start.String(),
+			End:     sl.End.String(),
+		})
+	}
+
+	for _, ex := range sch.Exceptions {
+		exb := scheduleExceptionBody{
+			Date:   ex.Date.Format(domain.ScheduleDateFmt),
+			Closed: ex.Closed,
+			Note:   ex.Note,
+		}
+		if !ex.Closed {
+			exb.Start = ex.Start.String()
+			exb.End = ex.End.String()
+		}
+		body.Exceptions = append(body.Exceptions, exb)
+	}
+
+	return body
+}
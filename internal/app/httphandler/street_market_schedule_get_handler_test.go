@@ -0,0 +1,131 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubScheduleGetter struct {
+	getInp domain.SMID
+	get    func(context.Context, domain.SMID) (domain.Schedule, *domain.Error)
+}
+
+func (s *stubScheduleGetter) Get(ctx context.Context, ID domain.SMID) (domain.Schedule, *domain.Error) {
+	s.getInp = ID
+	return s.get(ctx, ID)
+}
+
+func TestStreetMarketScheduleGetHandler_Handle(t *testing.T) {
+	id := "aaa1be24-ddec-4590-839d-b7ae54b9ed78"
+	getterMock := &stubScheduleGetter{
+		get: func(ctx context.Context, ID domain.SMID) (domain.Schedule, *domain.Error) {
+			return domain.Schedule{
+				StreetMarketID: id,
+				Slots:          []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+				Exceptions: []domain.ScheduleException{
+					{Date: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), Closed: true, Note: "Natal"},
+				},
+			}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/street_market/%s/schedule", id), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStreetMarketScheduleGetHandler(getterMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/street_market/{street-market-id}/schedule", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	if getterMock.getInp != domain.SMID(id) {
+		t.Errorf("expect schedule getter receive id %s, got %s", id, getterMock.getInp)
+	}
+
+	want := scheduleBody{
+		Weekdays:   []scheduleSlotBody{{Weekday: "saturday", Start: "07:00", End: "13:00"}},
+		Exceptions: []scheduleExceptionBody{{Date: "2026-12-25", Closed: true, Note: "Natal"}},
+	}
+
+	var got scheduleBody
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreetMarketScheduleGetHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		getterErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid id": {
+			getterErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Invalid ID"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Invalid ID", Code: domain.InpValidationErrKd},
+		},
+		"Street Market not founded": {
+			getterErr:    &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "SM not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "SM not found", Code: domain.SMNotFoundErrKd},
+		},
+		"Unexpected error": {
+			getterErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			getterMock := &stubScheduleGetter{
+				get: func(ctx context.Context, ID domain.SMID) (domain.Schedule, *domain.Error) {
+					return domain.Schedule{}, tc.getterErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/street_market/70ec02cb-0e4a-44cc-b0f7-83c040cb83ea/schedule", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewStreetMarketScheduleGetHandler(getterMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/schedule", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package httphandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type scheduleReplacer interface {
+	Replace(context.Context, domain.SMID, domain.Schedule) *domain.Error
+}
+
+type streetMarketScheduleReplaceHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type StreetMarketScheduleReplaceHandler struct {
+	replacer scheduleReplacer
+	logger   streetMarketScheduleReplaceHandlerLogger
+}
+
+func NewStreetMarketScheduleReplaceHandler(
+	replacer scheduleReplacer,
+	logger streetMarketScheduleReplaceHandlerLogger,
+) *StreetMarketScheduleReplaceHandler {
+	return &StreetMarketScheduleReplaceHandler{replacer, logger}
+}
+
+func (h *StreetMarketScheduleReplaceHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body scheduleBody
+
+	if dErr := decodeJSON(r, &body); dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	sch, dErr := fromScheduleBody(body)
+	if dErr != nil {
+		respondError(w, r, dErr)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id := domain.SMID(vars["street-market-id"])
+
+	dErr = h.replacer.Replace(ctx, id, sch)
+	if dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, "")
+}
+
+func fromScheduleBody(body scheduleBody) (domain.Schedule, *domain.Error) {
+	sch := domain.Schedule{
+		Slots:      []domain.ScheduleSlot{},
+		Exceptions: []domain.ScheduleException{},
+	}
+
+	for _, slb := range body.Weekdays {
+		wd, err := domain.ParseWeekday(slb.Weekday)
+		if err != nil {
+			return domain.Schedule{}, err
+		}
+		start, err := domain.ParseClockTime(slb.Start)
+		if err != nil {
+			return domain.Schedule{}, err
+		}
+		end, err := domain.ParseClockTime(slb.End)
+		if err != nil {
+			return domain.Schedule{}, err
+		}
+
+		sch.Slots = append(sch.Slots, domain.ScheduleSlot{Weekday: wd, Start: start, End: end})
+	}
+
+	for _, exb := range body.Exceptions {
+		date, tErr := time.Parse(domain.ScheduleDateFmt, exb.Date)
+		if tErr != nil {
+			return domain.Schedule{}, &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  fmt.Sprintf("%s is not a valid date, use YYYY-MM-DD", exb.Date),
+			}
+		}
+
+		ex := domain.ScheduleException{Date: date, Closed: exb.Closed, Note: exb.Note}
+		if !exb.Closed {
+			var err *domain.Error
+			if ex.Start, err = domain.ParseClockTime(exb.Start); err != nil {
+				return domain.Schedule{}, err
+			}
+			if ex.End, err = domain.ParseClockTime(exb.End); err != nil {
+				return domain.Schedule{}, err
+			}
+		}
+
+		sch.Exceptions = append(sch.Exceptions, ex)
+	}
+
+	return sch, nil
+}
@@ -0,0 +1,164 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubScheduleReplacer struct {
+	replaceIDInp domain.SMID
+	replaceInp   domain.Schedule
+	replace      func(context.Context, domain.SMID, domain.Schedule) *domain.Error
+}
+
+func (s *stubScheduleReplacer) Replace(ctx context.Context, ID domain.SMID, sch domain.Schedule) *domain.Error {
+	s.replaceIDInp = ID
+	s.replaceInp = sch
+	return s.replace(ctx, ID, sch)
+}
+
+func TestStreetMarketScheduleReplaceHandler_Handle(t *testing.T) {
+	id := "aaa1be24-ddec-4590-839d-b7ae54b9ed78"
+	body := `{
+		"weekdays": [{"weekday": "saturday", "start": "07:00", "end": "13:00"}],
+		"exceptions": [
+			{"date": "2026-12-25", "closed": true, "note": "Natal"},
+			{"date": "2026-12-24", "start": "06:00", "end": "11:00"}
+		]
+	}`
+
+	replacerMock := &stubScheduleReplacer{
+		replace: func(ctx context.Context, ID domain.SMID, sch domain.Schedule) *domain.Error {
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("/street_market/%s/schedule", id), strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	h := NewStreetMarketScheduleReplaceHandler(replacerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/street_market/{street-market-id}/schedule", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("expect status code %v, got %v", http.StatusNoContent, status)
+	}
+
+	want := domain.Schedule{
+		Slots: []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+		Exceptions: []domain.ScheduleException{
+			{Date: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), Closed: true, Note: "Natal"},
+			{Date: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), Start: 360, End: 660},
+		},
+	}
+
+	if diff := cmp.Diff(want, replacerMock.replaceInp); diff != "" {
+		t.Errorf("schedule replacer receive a unexpected input (-want +got):\n%s", diff)
+	}
+
+	if replacerMock.replaceIDInp != domain.SMID(id) {
+		t.Errorf("expect schedule replacer receive id %s, got %s", id, replacerMock.replaceIDInp)
+	}
+}
+
+func TestStreetMarketScheduleReplaceHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		body         string
+		replacerErr  *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Malformed body": {
+			body:         "body",
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "malformed body", Code: domain.InpValidationErrKd},
+		},
+		"Invalid weekday": {
+			body:         `{"weekdays": [{"weekday": "someday", "start": "07:00", "end": "13:00"}]}`,
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "someday is not a valid weekday", Code: domain.InpValidationErrKd},
+		},
+		"Invalid time": {
+			body:         `{"weekdays": [{"weekday": "saturday", "start": "7h", "end": "13:00"}]}`,
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "7h is not a valid time, use HH:MM", Code: domain.InpValidationErrKd},
+		},
+		"Invalid date": {
+			body:         `{"exceptions": [{"date": "25/12/2026", "closed": true}]}`,
+			wantStatusCd: http.StatusBadRequest,
+			wantBody: ErrorResponse{
+				Detail: "25/12/2026 is not a valid date, use YYYY-MM-DD",
+				Code:   domain.InpValidationErrKd,
+			},
+		},
+		"Invalid input": {
+			body:         `{}`,
+			replacerErr:  &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Invalid input"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Invalid input", Code: domain.InpValidationErrKd},
+		},
+		"Street Market not founded": {
+			body:         `{}`,
+			replacerErr:  &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "SM not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "SM not found", Code: domain.SMNotFoundErrKd},
+		},
+		"Unexpected error": {
+			body:         `{}`,
+			replacerErr:  &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			replacerMock := &stubScheduleReplacer{
+				replace: func(ctx context.Context, ID domain.SMID, sch domain.Schedule) *domain.Error {
+					return tc.replacerErr
+				},
+			}
+
+			path := "/street_market/70ec02cb-0e4a-44cc-b0f7-83c040cb83ea/schedule"
+			req, err := http.NewRequest(http.MethodPut, path, strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			h := NewStreetMarketScheduleReplaceHandler(replacerMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/street_market/{street-market-id}/schedule", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
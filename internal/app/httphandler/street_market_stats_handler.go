@@ -0,0 +1,92 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type streetMarketCounter interface {
+	Count(context.Context, domain.StreetMarketStatsInput) ([]domain.StreetMarketCount, *domain.Error)
+}
+
+type streetMarketStatsHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type streetMarketCountResponse struct {
+	Group string `json:"group"`
+	Count int    `json:"count"`
+}
+
+type streetMarketStatsResponse struct {
+	GroupBy  string                      `json:"group_by"`
+	Snapshot int                         `json:"snapshot,omitempty"`
+	Total    int                         `json:"total"`
+	Data     []streetMarketCountResponse `json:"data"`
+}
+
+type StreetMarketStatsHandler struct {
+	counter streetMarketCounter
+	logger  streetMarketStatsHandlerLogger
+}
+
+func NewStreetMarketStatsHandler(
+	counter streetMarketCounter,
+	logger streetMarketStatsHandlerLogger,
+) *StreetMarketStatsHandler {
+	return &StreetMarketStatsHandler{counter, logger}
+}
+
+func (h *StreetMarketStatsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	f, dErr := parseStreetMarketFilter(r)
+	if dErr != nil {
+		h.logger.Error(ctx, *dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	inp := domain.StreetMarketStatsInput{
+		GroupBy: domain.StatsGroupBy(r.FormValue("group_by")),
+		Filter:  f,
+	}
+
+	if snapshot := r.FormValue("snapshot"); snapshot != "" {
+		edition, err := strconv.Atoi(snapshot)
+		if err != nil {
+			h.logger.Error(ctx, domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  err.Error(),
+			})
+			respondError(w, r, &domain.Error{
+				Kind: domain.InpValidationErrKd,
+				Msg:  "snapshot must be an edition year",
+			})
+			return
+		}
+		inp.Snapshot = edition
+	}
+
+	cs, dErr := h.counter.Count(ctx, inp)
+	if dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	res := streetMarketStatsResponse{
+		GroupBy:  string(inp.GroupBy),
+		Snapshot: inp.Snapshot,
+		Data:     []streetMarketCountResponse{},
+	}
+	for _, c := range cs {
+		res.Total += c.Count
+		res.Data = append(res.Data, streetMarketCountResponse{Group: c.Group, Count: c.Count})
+	}
+
+	respondJSON(w, http.StatusOK, res)
+}
@@ -0,0 +1,140 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubStreetMarketCounter struct {
+	countInp domain.StreetMarketStatsInput
+	count    func(context.Context, domain.StreetMarketStatsInput) ([]domain.StreetMarketCount, *domain.Error)
+}
+
+func (s *stubStreetMarketCounter) Count(
+	ctx context.Context,
+	inp domain.StreetMarketStatsInput,
+) ([]domain.StreetMarketCount, *domain.Error) {
+	s.countInp = inp
+	return s.count(ctx, inp)
+}
+
+func TestStreetMarketStatsHandler_Handle(t *testing.T) {
+	counterMock := &stubStreetMarketCounter{
+		count: func(context.Context, domain.StreetMarketStatsInput) ([]domain.StreetMarketCount, *domain.Error) {
+			return []domain.StreetMarketCount{{Group: "ARICANDUVA", Count: 7}, {Group: "PENHA", Count: 5}}, nil
+		},
+	}
+
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"/street_market/stats?group_by=subtownhall&region5=Leste&snapshot=2014",
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewStreetMarketStatsHandler(counterMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got streetMarketStatsResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := streetMarketStatsResponse{
+		GroupBy:  "subtownhall",
+		Snapshot: 2014,
+		Total:    12,
+		Data:     []streetMarketCountResponse{{Group: "ARICANDUVA", Count: 7}, {Group: "PENHA", Count: 5}},
+	}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+
+	wantInp := domain.StreetMarketStatsInput{
+		GroupBy:  domain.SubTownHallGroup,
+		Filter:   domain.StreetMarketFilter{Region5: "Leste"},
+		Snapshot: 2014,
+	}
+	if diff := cmp.Diff(wantInp, counterMock.countInp); diff != "" {
+		t.Errorf("street market counter receive a unexpected input (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreetMarketStatsHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		counterErr   *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+		path         string
+	}{
+		"Invalid input": {
+			counterErr:   &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Invalid input"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Invalid input", Code: domain.InpValidationErrKd},
+			path:         "/street_market/stats?group_by=street",
+		},
+		"Unexpected error": {
+			counterErr:   &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+			path:         "/street_market/stats?group_by=district",
+		},
+		"Param snapshot invalid": {
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "snapshot must be an edition year", Code: domain.InpValidationErrKd},
+			path:         "/street_market/stats?group_by=district&snapshot=last",
+		},
+		"Param open_on invalid": {
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "open_on must be a weekday", Code: domain.InpValidationErrKd},
+			path:         "/street_market/stats?group_by=district&open_on=someday",
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			counterMock := &stubStreetMarketCounter{
+				count: func(context.Context, domain.StreetMarketStatsInput) ([]domain.StreetMarketCount, *domain.Error) {
+					return nil, tc.counterErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewStreetMarketStatsHandler(counterMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(h.Handle)
+			handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
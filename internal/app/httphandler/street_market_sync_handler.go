@@ -0,0 +1,70 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type streetMarketSyncer interface {
+	Sync(context.Context, domain.SyncToken) (domain.StreetMarketChanges, *domain.Error)
+}
+
+type streetMarketSyncHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type tombstoneResponse struct {
+	ID        string     `json:"id"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type syncStreetMarketResponse struct {
+	Data    []streetMarketResponse `json:"data"`
+	Deleted []tombstoneResponse    `json:"deleted"`
+	Next    domain.SyncToken       `json:"next"`
+	HasMore bool                   `json:"has_more"`
+}
+
+type StreetMarketSyncHandler struct {
+	syncer streetMarketSyncer
+	logger streetMarketSyncHandlerLogger
+}
+
+func NewStreetMarketSyncHandler(
+	syncer streetMarketSyncer,
+	logger streetMarketSyncHandlerLogger,
+) *StreetMarketSyncHandler {
+	return &StreetMarketSyncHandler{syncer, logger}
+}
+
+// Handle responds a page of the street markets changed since the token in the
+// since query parameter, the deleted ones as tombstones, and the token of the
+// next page or sync. Without since it responds every street market.
+func (h *StreetMarketSyncHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	chs, err := h.syncer.Sync(ctx, domain.SyncToken(r.FormValue("since")))
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	res := syncStreetMarketResponse{
+		Data:    []streetMarketResponse{},
+		Deleted: []tombstoneResponse{},
+		Next:    chs.Next(),
+		HasMore: chs.More(),
+	}
+	for _, sm := range chs.Markets {
+		res.Data = append(res.Data, newStreetMarketResponse(sm))
+	}
+	for _, ts := range chs.Deleted {
+		res.Deleted = append(res.Deleted, tombstoneResponse{ID: ts.ID, DeletedAt: ts.DeletedAt})
+	}
+
+	respondJSON(w, http.StatusOK, res)
+}
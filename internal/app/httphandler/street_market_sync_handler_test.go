@@ -0,0 +1,146 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubStreetMarketSyncer struct {
+	sinceInp domain.SyncToken
+	sync     func(context.Context, domain.SyncToken) (domain.StreetMarketChanges, *domain.Error)
+}
+
+func (s *stubStreetMarketSyncer) Sync(
+	ctx context.Context,
+	since domain.SyncToken,
+) (domain.StreetMarketChanges, *domain.Error) {
+	s.sinceInp = since
+	return s.sync(ctx, since)
+}
+
+func TestStreetMarketSyncHandler_Handle(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	next := domain.SyncCursor{
+		Position:  740100,
+		Next:      740213,
+		AfterTxID: 740160,
+		AfterID:   "84713a81-0e31-4c14-a62f-7e1f67bc526d",
+	}
+
+	syncerMock := &stubStreetMarketSyncer{
+		sync: func(context.Context, domain.SyncToken) (domain.StreetMarketChanges, *domain.Error) {
+			return domain.StreetMarketChanges{
+				Markets: []domain.StreetMarket{{
+					ID:        "1966d99f-20e8-4e5e-8f68-eb88ca67f95f",
+					Name:      "RAPOSO TAVARES",
+					Region5:   "Leste",
+					UpdatedAt: &at,
+				}},
+				Deleted: []domain.Tombstone{{ID: "84713a81-0e31-4c14-a62f-7e1f67bc526d", DeletedAt: &at}},
+				Cursor:  next,
+			}, nil
+		},
+	}
+
+	since := domain.NewSyncToken(740100)
+	req, err := http.NewRequest(http.MethodGet, "/v1/street_market/sync?since="+string(since), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	NewStreetMarketSyncHandler(syncerMock, &stubLogger{}).Handle(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got syncStreetMarketResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := syncStreetMarketResponse{
+		Data: []streetMarketResponse{{
+			ID:        "1966d99f-20e8-4e5e-8f68-eb88ca67f95f",
+			Name:      "RAPOSO TAVARES",
+			Region5:   "Leste",
+			UpdatedAt: &at,
+		}},
+		Deleted: []tombstoneResponse{{ID: "84713a81-0e31-4c14-a62f-7e1f67bc526d", DeletedAt: &at}},
+		Next:    next.Token(),
+		HasMore: true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+
+	if syncerMock.sinceInp != since {
+		t.Errorf("expect syncer receive %s, got %s", since, syncerMock.sinceInp)
+	}
+}
+
+func TestStreetMarketSyncHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		syncerErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid input": {
+			syncerErr: &domain.Error{
+				Kind:   domain.InpValidationErrKd,
+				Msg:    "since is not a sync token",
+				Fields: []domain.FieldError{{Field: "since", Code: domain.InvalidFormatFieldCd, Msg: "Invalid"}},
+			},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody: ErrorResponse{
+				Detail: "since is not a sync token",
+				Code:   domain.InpValidationErrKd,
+				Errors: []fieldErrorResponse{{Field: "since", Code: "INVALID_FORMAT", Message: "Invalid"}},
+			},
+		},
+		"Unexpected error": {
+			syncerErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			syncerMock := &stubStreetMarketSyncer{
+				sync: func(context.Context, domain.SyncToken) (domain.StreetMarketChanges, *domain.Error) {
+					return domain.StreetMarketChanges{}, tc.syncerErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/street_market/sync?since=x", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			NewStreetMarketSyncHandler(syncerMock, &stubLogger{}).Handle(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
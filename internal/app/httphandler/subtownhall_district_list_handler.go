@@ -0,0 +1,44 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type subTownHallDistrictLister interface {
+	SubTownHallDistricts(context.Context, string) ([]domain.District, *domain.Error)
+}
+
+type subTownHallDistrictListHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type SubTownHallDistrictListHandler struct {
+	lister subTownHallDistrictLister
+	logger subTownHallDistrictListHandlerLogger
+}
+
+func NewSubTownHallDistrictListHandler(
+	lister subTownHallDistrictLister,
+	logger subTownHallDistrictListHandlerLogger,
+) *SubTownHallDistrictListHandler {
+	return &SubTownHallDistrictListHandler{lister, logger}
+}
+
+func (h *SubTownHallDistrictListHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := vars["subtownhall-id"]
+
+	ds, err := h.lister.SubTownHallDistricts(ctx, id)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, listDistrictResponse{"data": toDistrictResponses(ds)})
+}
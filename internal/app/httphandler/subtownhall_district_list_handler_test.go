@@ -0,0 +1,116 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubSubTownHallDistrictLister struct {
+	listInp string
+	list    func(context.Context, string) ([]domain.District, *domain.Error)
+}
+
+func (s *stubSubTownHallDistrictLister) SubTownHallDistricts(
+	ctx context.Context,
+	ID string,
+) ([]domain.District, *domain.Error) {
+	s.listInp = ID
+	return s.list(ctx, ID)
+}
+
+func TestSubTownHallDistrictListHandler_Handle(t *testing.T) {
+	listerMock := &stubSubTownHallDistrictLister{
+		list: func(context.Context, string) ([]domain.District, *domain.Error) {
+			return []domain.District{{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/subtownhalls/26/districts", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewSubTownHallDistrictListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/subtownhalls/{subtownhall-id}/districts", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got listDistrictResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := listDistrictResponse{"data": {{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}}}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+
+	if listerMock.listInp != "26" {
+		t.Errorf("expect district lister receive sub-town hall id 26, got %s", listerMock.listInp)
+	}
+}
+
+func TestSubTownHallDistrictListHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		listerErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Sub-town hall not found": {
+			listerErr:    &domain.Error{Kind: domain.SubTHNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.SubTHNotFoundErrKd},
+		},
+		"Unexpected error": {
+			listerErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			listerMock := &stubSubTownHallDistrictLister{
+				list: func(context.Context, string) ([]domain.District, *domain.Error) {
+					return nil, tc.listerErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/subtownhalls/99/districts", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewSubTownHallDistrictListHandler(listerMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/subtownhalls/{subtownhall-id}/districts", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type subTownHallLister interface {
+	SubTownHalls(context.Context) ([]domain.SubTownHall, *domain.Error)
+}
+
+type subTownHallListHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type subTownHallResponse struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Region8   string             `json:"region_8"`
+	Districts []districtResponse `json:"districts,omitempty"`
+}
+
+type listSubTownHallResponse map[string][]subTownHallResponse
+
+type SubTownHallListHandler struct {
+	lister subTownHallLister
+	logger subTownHallListHandlerLogger
+}
+
+func NewSubTownHallListHandler(
+	lister subTownHallLister,
+	logger subTownHallListHandlerLogger,
+) *SubTownHallListHandler {
+	return &SubTownHallListHandler{lister, logger}
+}
+
+func (h *SubTownHallListHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	sths, err := h.lister.SubTownHalls(ctx)
+	if err != nil {
+		h.logger.Error(ctx, *err)
+		respondError(w, r, err)
+		return
+	}
+
+	res := []subTownHallResponse{}
+	for _, sth := range sths {
+		res = append(res, subTownHallResponse{ID: sth.ID, Name: sth.Name, Region8: sth.Region8})
+	}
+
+	respondJSON(w, http.StatusOK, listSubTownHallResponse{"data": res})
+}
@@ -0,0 +1,84 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubSubTownHallLister struct {
+	subTownHalls func(context.Context) ([]domain.SubTownHall, *domain.Error)
+}
+
+func (s *stubSubTownHallLister) SubTownHalls(ctx context.Context) ([]domain.SubTownHall, *domain.Error) {
+	return s.subTownHalls(ctx)
+}
+
+func TestSubTownHallListHandler_Handle(t *testing.T) {
+	listerMock := &stubSubTownHallLister{
+		subTownHalls: func(context.Context) ([]domain.SubTownHall, *domain.Error) {
+			return []domain.SubTownHall{{ID: "26", Name: "ARICANDUVA-FORMOSA-CARRAO", Region8: "Leste 1"}}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/subtownhalls", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewSubTownHallListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got listSubTownHallResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := listSubTownHallResponse{"data": {{ID: "26", Name: "ARICANDUVA-FORMOSA-CARRAO", Region8: "Leste 1"}}}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
+
+func TestSubTownHallListHandler_Handle_Error(t *testing.T) {
+	listerMock := &stubSubTownHallLister{
+		subTownHalls: func(context.Context) ([]domain.SubTownHall, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"}
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/subtownhalls", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewSubTownHallListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusInternalServerError {
+		t.Errorf("expect status code %v, got %v", http.StatusInternalServerError, status)
+	}
+
+	var got ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
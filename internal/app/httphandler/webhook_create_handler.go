@@ -0,0 +1,72 @@
+package httphandler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type webhookCreator interface {
+	Create(context.Context, domain.WebhookSubscriptionInput) (string, *domain.Error)
+}
+
+type webhookCreateHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type webhookBody struct {
+	URL     string             `json:"url"`
+	Secret  string             `json:"secret"`
+	Events  []domain.EventType `json:"events"`
+	IDdist  string             `json:"id_dist"`
+	Region5 string             `json:"region_5"`
+}
+
+type WebhookCreateHandler struct {
+	creator webhookCreator
+	logger  webhookCreateHandlerLogger
+}
+
+func NewWebhookCreateHandler(
+	creator webhookCreator,
+	logger webhookCreateHandlerLogger,
+) *WebhookCreateHandler {
+	return &WebhookCreateHandler{creator, logger}
+}
+
+func (h *WebhookCreateHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body webhookBody
+
+	if dErr := decodeJSON(r, &body); dErr != nil {
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	input := domain.WebhookSubscriptionInput{
+		URL:     body.URL,
+		Secret:  body.Secret,
+		Events:  body.Events,
+		IDdist:  body.IDdist,
+		Region5: body.Region5,
+	}
+
+	id, dErr := h.creator.Create(ctx, input)
+	if dErr != nil {
+		if dErr.Kind == domain.InpValidationErrKd {
+			respondInvalidInput(w, r, dErr, webhookBody{})
+			return
+		}
+
+		logUnexpected(ctx, h.logger, dErr)
+		respondError(w, r, dErr)
+		return
+	}
+
+	location := fmt.Sprintf("/v1/webhooks/%s", id)
+	w.Header().Add("Location", location)
+	respondJSON(w, http.StatusCreated, "")
+}
@@ -0,0 +1,151 @@
+package httphandler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubWebhookCreator struct {
+	createInp domain.WebhookSubscriptionInput
+	create    func(context.Context, domain.WebhookSubscriptionInput) (string, *domain.Error)
+}
+
+func (s *stubWebhookCreator) Create(ctx context.Context, inp domain.WebhookSubscriptionInput) (string, *domain.Error) {
+	s.createInp = inp
+	return s.create(ctx, inp)
+}
+
+func TestWebhookCreateHandler_Handle(t *testing.T) {
+	id := "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"
+	wantInp := domain.WebhookSubscriptionInput{
+		URL:     "https://partner.example.com/hooks",
+		Secret:  "0123456789abcdef",
+		Events:  []domain.EventType{domain.MarketCreatedEvent, domain.MarketDeletedEvent},
+		Region5: "Leste",
+	}
+
+	creatorMock := &stubWebhookCreator{
+		create: func(context.Context, domain.WebhookSubscriptionInput) (string, *domain.Error) {
+			return id, nil
+		},
+	}
+
+	rBody := `{"url":"https://partner.example.com/hooks","secret":"0123456789abcdef",` +
+		`"events":["street_market.created","street_market.deleted"],"region_5":"Leste"}`
+	req, err := http.NewRequest(http.MethodPost, "/v1/webhooks", strings.NewReader(rBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Host = "localhost"
+
+	h := NewWebhookCreateHandler(creatorMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Errorf("expect status code %v, got %v", http.StatusCreated, status)
+	}
+
+	wantLocation := fmt.Sprintf("/v1/webhooks/%s", id)
+	if got := rr.Header().Get("Location"); got != wantLocation {
+		t.Errorf("expect location %s, got %s", wantLocation, got)
+	}
+
+	if diff := cmp.Diff(wantInp, creatorMock.createInp); diff != "" {
+		t.Errorf("webhook creator create receive a unexpected input (-want +got):\n%s", diff)
+	}
+}
+
+func TestWebhookCreateHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		creatorErr   *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid input": {
+			creatorErr: &domain.Error{
+				Kind:   domain.InpValidationErrKd,
+				Msg:    "Invalid input",
+				Fields: []domain.FieldError{{Field: "URL", Code: domain.InvalidFormatFieldCd, Msg: "URL is invalid"}},
+			},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody: ErrorResponse{
+				Detail: "Invalid input",
+				Code:   domain.InpValidationErrKd,
+				Errors: []fieldErrorResponse{{Field: "url", Code: "INVALID_FORMAT", Message: "URL is invalid"}},
+			},
+		},
+		"Unexpected error": {
+			creatorErr:   &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			creatorMock := &stubWebhookCreator{
+				create: func(context.Context, domain.WebhookSubscriptionInput) (string, *domain.Error) {
+					return "", tc.creatorErr
+				},
+			}
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(webhookBody{}); err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/webhooks", &body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+
+			h := NewWebhookCreateHandler(creatorMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			handler := http.HandlerFunc(h.Handle)
+			handler.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("malformed body", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "/v1/webhooks", strings.NewReader("body"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		h := NewWebhookCreateHandler(&stubWebhookCreator{}, &stubLogger{})
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(h.Handle)
+		handler.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusBadRequest {
+			t.Errorf("expect status code %v, got %v", http.StatusBadRequest, status)
+		}
+	})
+}
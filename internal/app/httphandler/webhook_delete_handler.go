@@ -0,0 +1,43 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type webhookEraser interface {
+	Delete(context.Context, domain.WebhookID) *domain.Error
+}
+
+type webhookDeleteHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type WebhookDeleteHandler struct {
+	eraser webhookEraser
+	logger webhookDeleteHandlerLogger
+}
+
+func NewWebhookDeleteHandler(
+	eraser webhookEraser,
+	logger webhookDeleteHandlerLogger,
+) *WebhookDeleteHandler {
+	return &WebhookDeleteHandler{eraser, logger}
+}
+
+func (h *WebhookDeleteHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := domain.WebhookID(vars["webhook-id"])
+
+	if err := h.eraser.Delete(ctx, id); err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusNoContent, "")
+}
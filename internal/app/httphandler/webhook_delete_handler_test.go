@@ -0,0 +1,111 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubWebhookEraser struct {
+	deleteInp domain.WebhookID
+	delete    func(context.Context, domain.WebhookID) *domain.Error
+}
+
+func (s *stubWebhookEraser) Delete(ctx context.Context, ID domain.WebhookID) *domain.Error {
+	s.deleteInp = ID
+	return s.delete(ctx, ID)
+}
+
+func TestWebhookDeleteHandler_Handle(t *testing.T) {
+	id := domain.WebhookID("1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d")
+
+	eraserMock := &stubWebhookEraser{
+		delete: func(context.Context, domain.WebhookID) *domain.Error {
+			return nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/v1/webhooks/%s", id), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewWebhookDeleteHandler(eraserMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/webhooks/{webhook-id}", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNoContent {
+		t.Errorf("expect status code %v, got %v", http.StatusNoContent, status)
+	}
+
+	if id != eraserMock.deleteInp {
+		t.Errorf("expect webhook eraser receive id %s, got %s", id, eraserMock.deleteInp)
+	}
+}
+
+func TestWebhookDeleteHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		eraserErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid id": {
+			eraserErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Webhook not found": {
+			eraserErr:    &domain.Error{Kind: domain.WebhookNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.WebhookNotFoundErrKd},
+		},
+		"Unexpected error": {
+			eraserErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			eraserMock := &stubWebhookEraser{
+				delete: func(context.Context, domain.WebhookID) *domain.Error {
+					return tc.eraserErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodDelete, "/v1/webhooks/id", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewWebhookDeleteHandler(eraserMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/v1/webhooks/{webhook-id}", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type webhookDeliveryLister interface {
+	ListDeliveries(context.Context, domain.WebhookID, domain.DeliveryStatus) ([]domain.WebhookDelivery, *domain.Error)
+}
+
+type webhookDeliveryListHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type webhookDeliveryResponse struct {
+	ID            string                   `json:"id"`
+	Event         domain.EventType         `json:"event"`
+	Status        domain.DeliveryStatus    `json:"status"`
+	Payload       json.RawMessage          `json:"payload"`
+	AttemptCount  int                      `json:"attempt_count"`
+	NextAttemptAt *time.Time               `json:"next_attempt_at,omitempty"`
+	CreatedAt     *time.Time               `json:"created_at,omitempty"`
+	Attempts      []webhookAttemptResponse `json:"attempts"`
+}
+
+type webhookAttemptResponse struct {
+	Number      int        `json:"number"`
+	StatusCode  int        `json:"status_code,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	DurationMs  int64      `json:"duration_ms"`
+	AttemptedAt *time.Time `json:"attempted_at,omitempty"`
+}
+
+type listWebhookDeliveryResponse map[string][]webhookDeliveryResponse
+
+type WebhookDeliveryListHandler struct {
+	lister webhookDeliveryLister
+	logger webhookDeliveryListHandlerLogger
+}
+
+func NewWebhookDeliveryListHandler(
+	lister webhookDeliveryLister,
+	logger webhookDeliveryListHandlerLogger,
+) *WebhookDeliveryListHandler {
+	return &WebhookDeliveryListHandler{lister, logger}
+}
+
+// Handle lists the latest deliveries of a subscription, the status query
+// parameter narrowing them, as status=dead for the dead letters.
+func (h *WebhookDeliveryListHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := domain.WebhookID(vars["webhook-id"])
+	status := domain.DeliveryStatus(r.URL.Query().Get("status"))
+
+	ds, err := h.lister.ListDeliveries(ctx, id, status)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	res := []webhookDeliveryResponse{}
+	for _, d := range ds {
+		attempts := []webhookAttemptResponse{}
+		for _, a := range d.Attempts {
+			attempts = append(attempts, webhookAttemptResponse{
+				Number:      a.Number,
+				StatusCode:  a.StatusCode,
+				Error:       a.Error,
+				DurationMs:  a.Duration.Milliseconds(),
+				AttemptedAt: a.AttemptedAt,
+			})
+		}
+
+		res = append(res, webhookDeliveryResponse{
+			ID:            d.ID,
+			Event:         d.Event,
+			Status:        d.Status,
+			Payload:       d.Payload,
+			AttemptCount:  d.AttemptCount,
+			NextAttemptAt: d.NextAttemptAt,
+			CreatedAt:     d.CreatedAt,
+			Attempts:      attempts,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, listWebhookDeliveryResponse{"data": res})
+}
@@ -0,0 +1,160 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubWebhookDeliveryLister struct {
+	listIDInp     domain.WebhookID
+	listStatusInp domain.DeliveryStatus
+	list          func(context.Context, domain.WebhookID, domain.DeliveryStatus) ([]domain.WebhookDelivery, *domain.Error)
+}
+
+func (s *stubWebhookDeliveryLister) ListDeliveries(
+	ctx context.Context,
+	ID domain.WebhookID,
+	status domain.DeliveryStatus,
+) ([]domain.WebhookDelivery, *domain.Error) {
+	s.listIDInp = ID
+	s.listStatusInp = status
+	return s.list(ctx, ID, status)
+}
+
+func TestWebhookDeliveryListHandler_Handle(t *testing.T) {
+	id := domain.WebhookID("1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d")
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	listerMock := &stubWebhookDeliveryLister{
+		list: func(context.Context, domain.WebhookID, domain.DeliveryStatus) ([]domain.WebhookDelivery, *domain.Error) {
+			return []domain.WebhookDelivery{{
+				ID:            "5a0d7f5e-3b7e-4c1e-9f57-2d1f3c4b5a6e",
+				Event:         domain.MarketDeletedEvent,
+				Payload:       []byte(`{"id":"event-1"}`),
+				Status:        domain.DeliveryDead,
+				AttemptCount:  1,
+				NextAttemptAt: &at,
+				Attempts: []domain.WebhookAttempt{{
+					Number:      1,
+					StatusCode:  http.StatusServiceUnavailable,
+					Error:       "unexpected status 503",
+					Duration:    120 * time.Millisecond,
+					AttemptedAt: &at,
+				}},
+			}}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/webhooks/%s/deliveries?status=dead", id), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewWebhookDeliveryListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/webhooks/{webhook-id}/deliveries", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got listWebhookDeliveryResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := listWebhookDeliveryResponse{"data": {{
+		ID:            "5a0d7f5e-3b7e-4c1e-9f57-2d1f3c4b5a6e",
+		Event:         domain.MarketDeletedEvent,
+		Status:        domain.DeliveryDead,
+		Payload:       json.RawMessage(`{"id":"event-1"}`),
+		AttemptCount:  1,
+		NextAttemptAt: &at,
+		Attempts: []webhookAttemptResponse{{
+			Number:      1,
+			StatusCode:  http.StatusServiceUnavailable,
+			Error:       "unexpected status 503",
+			DurationMs:  120,
+			AttemptedAt: &at,
+		}},
+	}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+
+	if id != listerMock.listIDInp || listerMock.listStatusInp != domain.DeliveryDead {
+		t.Errorf(
+			"expect delivery lister receive %s %s, got %s %s",
+			id, domain.DeliveryDead, listerMock.listIDInp, listerMock.listStatusInp,
+		)
+	}
+}
+
+func TestWebhookDeliveryListHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		listerErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid input": {
+			listerErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Webhook not found": {
+			listerErr:    &domain.Error{Kind: domain.WebhookNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.WebhookNotFoundErrKd},
+		},
+		"Unexpected error": {
+			listerErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			listerMock := &stubWebhookDeliveryLister{
+				list: func(context.Context, domain.WebhookID, domain.DeliveryStatus) ([]domain.WebhookDelivery, *domain.Error) {
+					return nil, tc.listerErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/webhooks/id/deliveries", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewWebhookDeliveryListHandler(listerMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/v1/webhooks/{webhook-id}/deliveries", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type webhookGetter interface {
+	Get(context.Context, domain.WebhookID) (domain.WebhookSubscription, *domain.Error)
+}
+
+type webhookGetHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+// webhookResponse is a subscription without its secret, which is only known
+// by whoever created it.
+type webhookResponse struct {
+	ID        string             `json:"id"`
+	URL       string             `json:"url"`
+	Events    []domain.EventType `json:"events"`
+	IDdist    string             `json:"id_dist,omitempty"`
+	Region5   string             `json:"region_5,omitempty"`
+	CreatedAt *time.Time         `json:"created_at,omitempty"`
+}
+
+type WebhookGetHandler struct {
+	getter webhookGetter
+	logger webhookGetHandlerLogger
+}
+
+func NewWebhookGetHandler(
+	getter webhookGetter,
+	logger webhookGetHandlerLogger,
+) *WebhookGetHandler {
+	return &WebhookGetHandler{getter, logger}
+}
+
+func (h *WebhookGetHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+	id := domain.WebhookID(vars["webhook-id"])
+
+	sub, err := h.getter.Get(ctx, id)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toWebhookResponse(sub))
+}
+
+func toWebhookResponse(sub domain.WebhookSubscription) webhookResponse {
+	return webhookResponse{
+		ID:        sub.ID,
+		URL:       sub.URL,
+		Events:    sub.Events,
+		IDdist:    sub.IDdist,
+		Region5:   sub.Region5,
+		CreatedAt: sub.CreatedAt,
+	}
+}
@@ -0,0 +1,134 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubWebhookGetter struct {
+	getInp domain.WebhookID
+	get    func(context.Context, domain.WebhookID) (domain.WebhookSubscription, *domain.Error)
+}
+
+func (s *stubWebhookGetter) Get(ctx context.Context, ID domain.WebhookID) (domain.WebhookSubscription, *domain.Error) {
+	s.getInp = ID
+	return s.get(ctx, ID)
+}
+
+func TestWebhookGetHandler_Handle(t *testing.T) {
+	id := domain.WebhookID("1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d")
+	createdAt := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	getterMock := &stubWebhookGetter{
+		get: func(context.Context, domain.WebhookID) (domain.WebhookSubscription, *domain.Error) {
+			return domain.WebhookSubscription{
+				ID:        string(id),
+				URL:       "https://partner.example.com/hooks",
+				Secret:    "0123456789abcdef",
+				Events:    []domain.EventType{domain.MarketUpdatedEvent},
+				IDdist:    "87",
+				CreatedAt: &createdAt,
+			}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/v1/webhooks/%s", id), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewWebhookGetHandler(getterMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/webhooks/{webhook-id}", h.Handle)
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	want := `{"id":"1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d","url":"https://partner.example.com/hooks",` +
+		`"events":["street_market.updated"],"id_dist":"87","created_at":"2026-10-19T10:00:00Z"}`
+	var wantBody, gotBody map[string]interface{}
+	if err := json.Unmarshal([]byte(want), &wantBody); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &gotBody); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+
+	if id != getterMock.getInp {
+		t.Errorf("expect webhook getter receive id %s, got %s", id, getterMock.getInp)
+	}
+}
+
+func TestWebhookGetHandler_Handle_Error(t *testing.T) {
+	testCases := map[string]struct {
+		getterErr    *domain.Error
+		wantStatusCd int
+		wantBody     ErrorResponse
+	}{
+		"Invalid id": {
+			getterErr:    &domain.Error{Kind: domain.InpValidationErrKd, Msg: "Error"},
+			wantStatusCd: http.StatusBadRequest,
+			wantBody:     ErrorResponse{Detail: "Error", Code: domain.InpValidationErrKd},
+		},
+		"Webhook not found": {
+			getterErr:    &domain.Error{Kind: domain.WebhookNotFoundErrKd, Msg: "Not found"},
+			wantStatusCd: http.StatusNotFound,
+			wantBody:     ErrorResponse{Detail: "Not found", Code: domain.WebhookNotFoundErrKd},
+		},
+		"Unexpected error": {
+			getterErr:    &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"},
+			wantStatusCd: http.StatusInternalServerError,
+			wantBody:     ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			getterMock := &stubWebhookGetter{
+				get: func(context.Context, domain.WebhookID) (domain.WebhookSubscription, *domain.Error) {
+					return domain.WebhookSubscription{}, tc.getterErr
+				},
+			}
+
+			req, err := http.NewRequest(http.MethodGet, "/v1/webhooks/id", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			h := NewWebhookGetHandler(getterMock, &stubLogger{})
+			rr := httptest.NewRecorder()
+			r := mux.NewRouter()
+			r.HandleFunc("/v1/webhooks/{webhook-id}", h.Handle)
+			r.ServeHTTP(rr, req)
+
+			if status := rr.Code; status != tc.wantStatusCd {
+				t.Errorf("expect status code %v, got %v", tc.wantStatusCd, status)
+			}
+
+			var got ErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, got, ignoreProblemMeta()); diff != "" {
+				t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package httphandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type webhookLister interface {
+	List(context.Context) ([]domain.WebhookSubscription, *domain.Error)
+}
+
+type webhookListHandlerLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+type listWebhookResponse map[string][]webhookResponse
+
+type WebhookListHandler struct {
+	lister webhookLister
+	logger webhookListHandlerLogger
+}
+
+func NewWebhookListHandler(
+	lister webhookLister,
+	logger webhookListHandlerLogger,
+) *WebhookListHandler {
+	return &WebhookListHandler{lister, logger}
+}
+
+func (h *WebhookListHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subs, err := h.lister.List(ctx)
+	if err != nil {
+		logUnexpected(ctx, h.logger, err)
+		respondError(w, r, err)
+		return
+	}
+
+	res := []webhookResponse{}
+	for _, sub := range subs {
+		res = append(res, toWebhookResponse(sub))
+	}
+
+	respondJSON(w, http.StatusOK, listWebhookResponse{"data": res})
+}
@@ -0,0 +1,95 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubWebhookLister struct {
+	list func(context.Context) ([]domain.WebhookSubscription, *domain.Error)
+}
+
+func (s *stubWebhookLister) List(ctx context.Context) ([]domain.WebhookSubscription, *domain.Error) {
+	return s.list(ctx)
+}
+
+func TestWebhookListHandler_Handle(t *testing.T) {
+	listerMock := &stubWebhookLister{
+		list: func(context.Context) ([]domain.WebhookSubscription, *domain.Error) {
+			return []domain.WebhookSubscription{{
+				ID:      "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+				URL:     "https://partner.example.com/hooks",
+				Secret:  "0123456789abcdef",
+				Events:  domain.EventTypes(),
+				Region5: "Leste",
+			}}, nil
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/webhooks", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewWebhookListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("expect status code %v, got %v", http.StatusOK, status)
+	}
+
+	var got listWebhookResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := listWebhookResponse{"data": {{
+		ID:      "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+		URL:     "https://partner.example.com/hooks",
+		Events:  domain.EventTypes(),
+		Region5: "Leste",
+	}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
+
+func TestWebhookListHandler_Handle_Error(t *testing.T) {
+	listerMock := &stubWebhookLister{
+		list: func(context.Context) ([]domain.WebhookSubscription, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected"}
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/webhooks", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewWebhookListHandler(listerMock, &stubLogger{})
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(h.Handle)
+	handler.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusInternalServerError {
+		t.Errorf("expect status code %v, got %v", http.StatusInternalServerError, status)
+	}
+
+	var got ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ErrorResponse{Detail: "Unexpected error", Code: domain.UnexpectedErrKd}
+	if diff := cmp.Diff(want, got, ignoreProblemMeta()); diff != "" {
+		t.Errorf("want body mismatch with got body (-want +got):\n%s", diff)
+	}
+}
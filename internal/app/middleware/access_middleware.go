@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// AccessMiddleware guards each route with what the caller, identified by
+// APIKeyMiddleware or BearerTokenMiddleware, must be allowed to do.
+type AccessMiddleware struct {
+	respond errorResponder
+}
+
+func NewAccessMiddleware(respond errorResponder) *AccessMiddleware {
+	return &AccessMiddleware{respond}
+}
+
+// Require lets through the requests whose API key has scope or whose user has
+// role.
+func (m *AccessMiddleware) Require(scope domain.Scope, role domain.Role) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := domain.Authorize(r.Context(), scope, role); err != nil {
+				m.respond(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
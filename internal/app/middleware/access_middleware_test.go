@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAccessMiddleware_Require(t *testing.T) {
+	testCases := map[string]struct {
+		ctx        func(context.Context) context.Context
+		wantStatus int
+		wantBody   string
+	}{
+		"When the key has the scope": {
+			ctx: func(ctx context.Context) context.Context {
+				return domain.WithAPIKey(ctx, domain.APIKey{
+					Scopes: []domain.Scope{domain.ScopeMarketsRead, domain.ScopeMarketsWrite},
+				})
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "created",
+		},
+		"When the key lacks the scope": {
+			ctx: func(ctx context.Context) context.Context {
+				return domain.WithAPIKey(ctx, domain.APIKey{Scopes: []domain.Scope{domain.ScopeMarketsRead}})
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   string(domain.ForbiddenErrKd),
+		},
+		"When the user has a role above the one needed": {
+			ctx: func(ctx context.Context) context.Context {
+				return domain.WithUser(ctx, domain.User{Subject: "maria", Role: domain.RoleAdmin})
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "created",
+		},
+		"When the user lacks the role": {
+			ctx: func(ctx context.Context) context.Context {
+				return domain.WithUser(ctx, domain.User{Subject: "maria", Role: domain.RoleViewer})
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   string(domain.ForbiddenErrKd),
+		},
+		"When there is no key nor user": {
+			ctx:        func(ctx context.Context) context.Context { return ctx },
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   string(domain.UnauthenticatedErrKd),
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			m := NewAccessMiddleware(respondKind)
+
+			h := m.Require(domain.ScopeMarketsWrite, domain.RoleEditor)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte("created"))
+				}),
+			)
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/street_market", nil)
+			req = req.WithContext(tc.ctx(req.Context()))
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+			if diff := cmp.Diff(tc.wantBody, rr.Body.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
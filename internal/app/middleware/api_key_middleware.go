@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// APIKeyHeader is the header clients send their key in.
+const APIKeyHeader = "X-API-Key"
+
+type apiKeyAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (domain.APIKey, *domain.Error)
+}
+
+type apiKeyLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+// APIKeyMiddleware tells who makes a request by its API key. It must run before
+// the request is logged so the logs have its ID, AccessMiddleware telling which
+// routes the key may call.
+type APIKeyMiddleware struct {
+	authenticator apiKeyAuthenticator
+	respond       errorResponder
+	logger        apiKeyLogger
+}
+
+func NewAPIKeyMiddleware(
+	authenticator apiKeyAuthenticator,
+	respond errorResponder,
+	logger apiKeyLogger,
+) *APIKeyMiddleware {
+	return &APIKeyMiddleware{authenticator, respond, logger}
+}
+
+// Middleware puts the key of the request in its context. Requests without one
+// go on anonymously, AccessMiddleware refusing them where one is needed, while
+// an invalid key is refused right away.
+func (m *APIKeyMiddleware) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get(APIKeyHeader)
+			if token == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			k, err := m.authenticator.Authenticate(ctx, token)
+			if err != nil {
+				if err.Kind != domain.UnauthenticatedErrKd {
+					m.logger.Error(ctx, *err)
+				}
+				m.respond(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain.WithAPIKey(ctx, k)))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubAPIKeyAuthenticator struct {
+	token string
+	key   domain.APIKey
+	err   *domain.Error
+}
+
+func (s *stubAPIKeyAuthenticator) Authenticate(_ context.Context, token string) (domain.APIKey, *domain.Error) {
+	s.token = token
+
+	return s.key, s.err
+}
+
+type stubLogger struct {
+	errors []domain.Error
+}
+
+func (s *stubLogger) Error(_ context.Context, err domain.Error) {
+	s.errors = append(s.errors, err)
+}
+
+func respondKind(w http.ResponseWriter, _ *http.Request, err *domain.Error) {
+	w.WriteHeader(http.StatusUnauthorized)
+	_, _ = w.Write([]byte(err.Kind))
+}
+
+func TestAPIKeyMiddleware_Middleware(t *testing.T) {
+	key := domain.APIKey{ID: "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d", Scopes: []domain.Scope{domain.ScopeMarketsRead}}
+
+	testCases := map[string]struct {
+		token      string
+		err        *domain.Error
+		wantStatus int
+		wantBody   string
+		wantLogs   int
+	}{
+		"When there is no key the request goes on anonymously": {
+			wantStatus: http.StatusOK,
+			wantBody:   "anonymous",
+		},
+		"When the key is valid it is in the context": {
+			token:      "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d.s3cr3t",
+			wantStatus: http.StatusOK,
+			wantBody:   key.ID,
+		},
+		"When the key is invalid the request is refused": {
+			token:      "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d.guess",
+			err:        &domain.Error{Kind: domain.UnauthenticatedErrKd},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   string(domain.UnauthenticatedErrKd),
+		},
+		"When the key can not be checked the error is logged": {
+			token:      "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d.s3cr3t",
+			err:        &domain.Error{Kind: domain.UnexpectedErrKd},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   string(domain.UnexpectedErrKd),
+			wantLogs:   1,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			authenticator := &stubAPIKeyAuthenticator{key: key, err: tc.err}
+			logger := &stubLogger{}
+			m := NewAPIKeyMiddleware(authenticator, respondKind, logger)
+
+			h := m.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				k, ok := domain.APIKeyFrom(r.Context())
+				if !ok {
+					_, _ = w.Write([]byte("anonymous"))
+					return
+				}
+				_, _ = w.Write([]byte(k.ID))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/street_market", nil)
+			if tc.token != "" {
+				req.Header.Set(APIKeyHeader, tc.token)
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+			if diff := cmp.Diff(tc.wantBody, rr.Body.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if authenticator.token != tc.token {
+				t.Errorf("expect the token %q authenticated, got %q", tc.token, authenticator.token)
+			}
+			if len(logger.errors) != tc.wantLogs {
+				t.Errorf("expect %d errors logged, got %v", tc.wantLogs, logger.errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type tokenVerifier interface {
+	Verify(ctx context.Context, token string) (domain.User, *domain.Error)
+}
+
+// BearerTokenMiddleware tells who makes a request by the token the SSO issued
+// them, sent as Authorization: Bearer <token>. As APIKeyMiddleware, it must
+// run before the request is logged so the logs have the subject.
+type BearerTokenMiddleware struct {
+	verifier tokenVerifier
+	respond  errorResponder
+}
+
+func NewBearerTokenMiddleware(verifier tokenVerifier, respond errorResponder) *BearerTokenMiddleware {
+	return &BearerTokenMiddleware{verifier, respond}
+}
+
+// Middleware puts the user of the request in its context. Requests without a
+// token go on anonymously, while an invalid one is refused right away.
+func (m *BearerTokenMiddleware) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			u, err := m.verifier.Verify(ctx, strings.TrimSpace(token))
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				m.respond(w, r, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain.WithUser(ctx, u)))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubTokenVerifier struct {
+	token string
+	user  domain.User
+	err   *domain.Error
+}
+
+func (s *stubTokenVerifier) Verify(_ context.Context, token string) (domain.User, *domain.Error) {
+	s.token = token
+
+	return s.user, s.err
+}
+
+func TestBearerTokenMiddleware_Middleware(t *testing.T) {
+	user := domain.User{Subject: "maria", Role: domain.RoleEditor}
+
+	testCases := map[string]struct {
+		authorization string
+		err           *domain.Error
+		wantToken     string
+		wantStatus    int
+		wantBody      string
+		wantChallenge string
+	}{
+		"When there is no token the request goes on anonymously": {
+			wantStatus: http.StatusOK,
+			wantBody:   "anonymous",
+		},
+		"When the authorization is not a bearer token it is left alone": {
+			authorization: "Basic bWFyaWE6c2VuaGE=",
+			wantStatus:    http.StatusOK,
+			wantBody:      "anonymous",
+		},
+		"When the token is valid the user is in the context": {
+			authorization: "Bearer eyJ.eyJ.sig",
+			wantToken:     "eyJ.eyJ.sig",
+			wantStatus:    http.StatusOK,
+			wantBody:      "maria editor",
+		},
+		"When the scheme is in lower case": {
+			authorization: "bearer eyJ.eyJ.sig",
+			wantToken:     "eyJ.eyJ.sig",
+			wantStatus:    http.StatusOK,
+			wantBody:      "maria editor",
+		},
+		"When the token is invalid the request is refused": {
+			authorization: "Bearer eyJ.eyJ.guess",
+			err:           &domain.Error{Kind: domain.UnauthenticatedErrKd},
+			wantToken:     "eyJ.eyJ.guess",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      string(domain.UnauthenticatedErrKd),
+			wantChallenge: `Bearer error="invalid_token"`,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			verifier := &stubTokenVerifier{user: user, err: tc.err}
+			m := NewBearerTokenMiddleware(verifier, respondKind)
+
+			h := m.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				u, ok := domain.UserFrom(r.Context())
+				if !ok {
+					_, _ = w.Write([]byte("anonymous"))
+					return
+				}
+				_, _ = w.Write([]byte(u.Subject + " " + string(u.Role)))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/street_market", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+			if diff := cmp.Diff(tc.wantBody, rr.Body.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if verifier.token != tc.wantToken {
+				t.Errorf("expect the token %q verified, got %q", tc.wantToken, verifier.token)
+			}
+			if got := rr.Header().Get("WWW-Authenticate"); got != tc.wantChallenge {
+				t.Errorf("expect challenge %q, got %q", tc.wantChallenge, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// AnyOrigin allows every origin, without credentials.
+const AnyOrigin = "*"
+
+// CORSConfig is what browsers on other origins may do with the API.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight, rounded down to
+	// seconds.
+	MaxAge time.Duration
+}
+
+// CORSMiddleware answers the preflights of browsers and tells them which
+// origins may read the responses (CORS). Preflights are answered before any
+// other middleware, as browsers send them without credentials.
+//
+// It must wrap the router, as preflights of routes without OPTIONS would be
+// answered 405 before router middlewares run.
+type CORSMiddleware struct {
+	cfg       CORSConfig
+	anyOrigin bool
+	origins   map[string]bool
+	methods   map[string]bool
+	headers   map[string]bool
+}
+
+func NewCORSMiddleware(cfg CORSConfig) *CORSMiddleware {
+	m := &CORSMiddleware{
+		cfg:     cfg,
+		origins: map[string]bool{},
+		methods: map[string]bool{},
+		headers: map[string]bool{},
+	}
+	for _, o := range cfg.AllowedOrigins {
+		if o == AnyOrigin {
+			m.anyOrigin = true
+		}
+		m.origins[o] = true
+	}
+	for _, method := range cfg.AllowedMethods {
+		m.methods[strings.ToUpper(method)] = true
+	}
+	for _, h := range cfg.AllowedHeaders {
+		m.headers[http.CanonicalHeaderKey(h)] = true
+	}
+
+	return m
+}
+
+// Middleware answers preflights 204, with the allowed methods and headers when
+// the origin, method and headers requested are all allowed and without them
+// otherwise, so that the browser refuses the request. Other requests of an
+// allowed origin go on with the headers that let the browser read the
+// response.
+func (m *CORSMiddleware) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			h := w.Header()
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Add("Vary", "Origin")
+				h.Add("Vary", "Access-Control-Request-Method")
+				h.Add("Vary", "Access-Control-Request-Headers")
+
+				if m.allowsPreflight(r) {
+					m.setOrigin(h, origin)
+					h.Set("Access-Control-Allow-Methods", strings.Join(m.cfg.AllowedMethods, ", "))
+					if len(m.cfg.AllowedHeaders) > 0 {
+						h.Set("Access-Control-Allow-Headers", strings.Join(m.cfg.AllowedHeaders, ", "))
+					}
+					if m.cfg.MaxAge > 0 {
+						h.Set("Access-Control-Max-Age", strconv.Itoa(int(m.cfg.MaxAge.Seconds())))
+					}
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if origin != "" {
+				h.Add("Vary", "Origin")
+				if m.allowsOrigin(origin) {
+					m.setOrigin(h, origin)
+					if len(m.cfg.ExposedHeaders) > 0 {
+						h.Set("Access-Control-Expose-Headers", strings.Join(m.cfg.ExposedHeaders, ", "))
+					}
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (m *CORSMiddleware) allowsPreflight(r *http.Request) bool {
+	if !m.allowsOrigin(r.Header.Get("Origin")) {
+		return false
+	}
+
+	if !m.methods[strings.ToUpper(r.Header.Get("Access-Control-Request-Method"))] {
+		return false
+	}
+
+	for _, name := range strings.Split(r.Header.Get("Access-Control-Request-Headers"), ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !m.headers[http.CanonicalHeaderKey(name)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (m *CORSMiddleware) allowsOrigin(origin string) bool {
+	return origin != "" && (m.anyOrigin || m.origins[origin])
+}
+
+// setOrigin allows origin to read the response. With credentials the origin is
+// told as it is, as browsers refuse the wildcard along with them.
+func (m *CORSMiddleware) setOrigin(h http.Header, origin string) {
+	if m.anyOrigin && !m.cfg.AllowCredentials {
+		h.Set("Access-Control-Allow-Origin", AnyOrigin)
+		return
+	}
+
+	h.Set("Access-Control-Allow-Origin", origin)
+	if m.cfg.AllowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCORSMiddleware_Middleware(t *testing.T) {
+	cfg := CORSConfig{
+		AllowedOrigins:   []string{"https://feiras.example.com"},
+		AllowedMethods:   []string{"GET", "POST", "PATCH", "DELETE"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type", "X-API-Key"},
+		ExposedHeaders:   []string{"Retry-After", "RateLimit-Remaining"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	testCases := map[string]struct {
+		cfg         CORSConfig
+		method      string
+		headers     map[string]string
+		wantNext    bool
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		"When is a preflight of a PATCH": {
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://feiras.example.com",
+				"Access-Control-Request-Method":  "PATCH",
+				"Access-Control-Request-Headers": "content-type, x-api-key",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://feiras.example.com",
+				"Access-Control-Allow-Methods":     "GET, POST, PATCH, DELETE",
+				"Access-Control-Allow-Headers":     "Authorization, Content-Type, X-API-Key",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Max-Age":           "600",
+				"Access-Control-Expose-Headers":    "",
+			},
+		},
+		"When is a preflight of a DELETE": {
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://feiras.example.com",
+				"Access-Control-Request-Method": "DELETE",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://feiras.example.com",
+				"Access-Control-Allow-Methods": "GET, POST, PATCH, DELETE",
+			},
+		},
+		"When is a preflight of a method not allowed": {
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://feiras.example.com",
+				"Access-Control-Request-Method": "PUT",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		"When is a preflight of a header not allowed": {
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://feiras.example.com",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "X-Custom",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+			},
+		},
+		"When is a preflight of an origin not allowed": {
+			cfg:    cfg,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.example.com",
+				"Access-Control-Request-Method": "GET",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "",
+				"Access-Control-Allow-Methods": "",
+			},
+		},
+		"When is a request of an allowed origin": {
+			cfg:        cfg,
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://feiras.example.com"},
+			wantNext:   true,
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://feiras.example.com",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "Retry-After, RateLimit-Remaining",
+				"Access-Control-Allow-Methods":     "",
+				"Vary":                             "Origin",
+			},
+		},
+		"When is a request of an origin not allowed": {
+			cfg:        cfg,
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://evil.example.com"},
+			wantNext:   true,
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "Origin",
+			},
+		},
+		"When is a request without origin": {
+			cfg:        cfg,
+			method:     http.MethodGet,
+			wantNext:   true,
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin": "",
+				"Vary":                        "",
+			},
+		},
+		"When any origin is allowed": {
+			cfg: CORSConfig{
+				AllowedOrigins: []string{AnyOrigin},
+				AllowedMethods: []string{"GET"},
+			},
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://feiras.example.com"},
+			wantNext:   true,
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "*",
+				"Access-Control-Allow-Credentials": "",
+			},
+		},
+		"When is an OPTIONS that is not a preflight": {
+			cfg:        cfg,
+			method:     http.MethodOptions,
+			headers:    map[string]string{"Origin": "https://feiras.example.com"},
+			wantNext:   true,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			var called bool
+			h := NewCORSMiddleware(tc.cfg).Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			req := httptest.NewRequest(tc.method, "/v1/street_market/1", nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if called != tc.wantNext {
+				t.Errorf("expect next handler called %v, got %v", tc.wantNext, called)
+			}
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+			for name, want := range tc.wantHeaders {
+				if got := rr.Header().Get(name); got != want {
+					t.Errorf("expect header %s %q, got %q", name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware_Middleware_Vary(t *testing.T) {
+	h := NewCORSMiddleware(CORSConfig{AllowedOrigins: []string{AnyOrigin}}).Middleware()(http.NotFoundHandler())
+
+	req := httptest.NewRequest(http.MethodOptions, "/v1/street_market", nil)
+	req.Header.Set("Origin", "https://feiras.example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	want := []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}
+	if diff := cmp.Diff(want, rr.Header().Values("Vary")); diff != "" {
+		t.Errorf("mismatch of the Vary header (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// DeprecatedAliasMiddleware serves the unversioned paths under roots, as
+// /street_market, as aliases of the same paths under prefix. The request is
+// rewritten before routing, so aliases go through the same routes and
+// middlewares, and the response tells the client where to move and until when
+// (RFC 9745 and RFC 8594).
+//
+// It must wrap the router, as router middlewares run after routing.
+type DeprecatedAliasMiddleware struct {
+	prefix       string
+	roots        []string
+	deprecatedAt time.Time
+	sunset       time.Time
+}
+
+func NewDeprecatedAliasMiddleware(
+	prefix string,
+	roots []string,
+	deprecatedAt time.Time,
+	sunset time.Time,
+) *DeprecatedAliasMiddleware {
+	return &DeprecatedAliasMiddleware{prefix, roots, deprecatedAt, sunset}
+}
+
+func (m *DeprecatedAliasMiddleware) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !m.isAlias(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			successor := m.prefix + r.URL.Path
+
+			w.Header().Set("Deprecation", fmt.Sprintf("@%d", m.deprecatedAt.Unix()))
+			w.Header().Set("Sunset", m.sunset.UTC().Format(http.TimeFormat))
+			w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+
+			r = r.Clone(r.Context())
+			r.URL.Path = successor
+			if r.URL.RawPath != "" {
+				r.URL.RawPath = m.prefix + r.URL.RawPath
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (m *DeprecatedAliasMiddleware) isAlias(path string) bool {
+	for _, root := range m.roots {
+		if path == root || strings.HasPrefix(path, root+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeprecatedAliasMiddleware_Middleware(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		path       string
+		wantPath   string
+		wantHeader http.Header
+	}{
+		"When path is an alias": {
+			path:     "/street_market/abc/stalls?page=2",
+			wantPath: "/v1/street_market/abc/stalls",
+			wantHeader: http.Header{
+				"Deprecation": {"@1792368000"},
+				"Sunset":      {"Fri, 30 Apr 2027 00:00:00 GMT"},
+				"Link":        {`</v1/street_market/abc/stalls>; rel="successor-version"`},
+			},
+		},
+		"When path is a root": {
+			path:     "/districts",
+			wantPath: "/v1/districts",
+			wantHeader: http.Header{
+				"Deprecation": {"@1792368000"},
+				"Sunset":      {"Fri, 30 Apr 2027 00:00:00 GMT"},
+				"Link":        {`</v1/districts>; rel="successor-version"`},
+			},
+		},
+		"When path is versioned": {
+			path:       "/v1/street_market",
+			wantPath:   "/v1/street_market",
+			wantHeader: http.Header{},
+		},
+		"When path only shares the prefix of a root": {
+			path:       "/districts_old",
+			wantPath:   "/districts_old",
+			wantHeader: http.Header{},
+		},
+		"When path is not versioned": {
+			path:       "/ping",
+			wantPath:   "/ping",
+			wantHeader: http.Header{},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			var gotPath string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+			})
+
+			m := NewDeprecatedAliasMiddleware("/v1", []string{"/districts", "/street_market"}, deprecatedAt, sunset)
+
+			rr := httptest.NewRecorder()
+			m.Middleware()(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if diff := cmp.Diff(tc.wantPath, gotPath); diff != "" {
+				t.Errorf("path mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantHeader, rr.Header()); diff != "" {
+				t.Errorf("header mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
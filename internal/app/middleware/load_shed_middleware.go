@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// shedRetryAfter is when a request shed may be retried, in seconds. The limit
+// adapts within a few requests, so it is short.
+const shedRetryAfter = 1
+
+type concurrencyLimiter interface {
+	Acquire(ctx context.Context, isCritical bool) (release func(failed bool), ok bool)
+}
+
+// LoadShedMiddleware runs requests under the concurrency limit of limiter,
+// answering the ones shed with 503. Writes go ahead of reads. It wraps only the
+// routes bound to the database, as router.Route.Shed tells, so the health
+// checks and the streams, which would hold a slot for as long as the client is
+// connected, are never shed.
+type LoadShedMiddleware struct {
+	limiter concurrencyLimiter
+	respond errorResponder
+}
+
+func NewLoadShedMiddleware(limiter concurrencyLimiter, respond errorResponder) *LoadShedMiddleware {
+	return &LoadShedMiddleware{limiter, respond}
+}
+
+// Middleware takes a slot of the limiter for the request, freeing it once the
+// request is served, or responds it was shed telling when to retry in
+// Retry-After. Requests responded with a 5xx count as failed.
+func (m *LoadShedMiddleware) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			release, ok := m.limiter.Acquire(r.Context(), !isRead(r.Method))
+			if !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(shedRetryAfter))
+				m.respond(w, r, &domain.Error{
+					Kind: domain.OverloadedErrKd,
+					Msg:  fmt.Sprintf("The API is overloaded, retry in %d seconds", shedRetryAfter),
+				})
+				return
+			}
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				release(sw.status >= http.StatusInternalServerError)
+			}()
+
+			next.ServeHTTP(sw, r)
+		})
+	}
+}
+
+// statusWriter keeps the status a handler responded with.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubConcurrencyLimiter struct {
+	ok       bool
+	acquired []bool
+	released []bool
+}
+
+func (s *stubConcurrencyLimiter) Acquire(_ context.Context, isCritical bool) (func(failed bool), bool) {
+	s.acquired = append(s.acquired, isCritical)
+	if !s.ok {
+		return nil, false
+	}
+
+	return func(failed bool) {
+		s.released = append(s.released, failed)
+	}, true
+}
+
+func TestLoadShedMiddleware_Middleware(t *testing.T) {
+	testCases := map[string]struct {
+		method        string
+		path          string
+		ok            bool
+		handlerStatus int
+		wantAcquired  []bool
+		wantReleased  []bool
+		wantStatus    int
+		wantRetry     string
+	}{
+		"When a read is admitted": {
+			method:        http.MethodGet,
+			path:          "/v1/street_market",
+			ok:            true,
+			handlerStatus: http.StatusOK,
+			wantAcquired:  []bool{false},
+			wantReleased:  []bool{false},
+			wantStatus:    http.StatusOK,
+		},
+		"When a write is admitted and fails": {
+			method:        http.MethodPost,
+			path:          "/v1/street_market",
+			ok:            true,
+			handlerStatus: http.StatusInternalServerError,
+			wantAcquired:  []bool{true},
+			wantReleased:  []bool{true},
+			wantStatus:    http.StatusInternalServerError,
+		},
+		"When a read is shed": {
+			method:       http.MethodGet,
+			path:         "/v1/street_market",
+			wantAcquired: []bool{false},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRetry:    "1",
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			limiter := &stubConcurrencyLimiter{ok: tc.ok}
+			m := NewLoadShedMiddleware(limiter, func(w http.ResponseWriter, _ *http.Request, err *domain.Error) {
+				if err.Kind != domain.OverloadedErrKd {
+					t.Errorf("expect error kind %s, got %s", domain.OverloadedErrKd, err.Kind)
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+			})
+
+			h := m.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.handlerStatus)
+			}))
+
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+			if got := rr.Header().Get("Retry-After"); got != tc.wantRetry {
+				t.Errorf("expect Retry-After %q, got %q", tc.wantRetry, got)
+			}
+			if diff := cmp.Diff(tc.wantAcquired, limiter.acquired); diff != "" {
+				t.Errorf("mismatch of the slots acquired as critical (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantReleased, limiter.released); diff != "" {
+				t.Errorf("mismatch of the slots released as failed (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
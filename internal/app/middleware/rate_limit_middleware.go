@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type rateLimiter interface {
+	Take(key string) domain.RateLimit
+}
+
+// RateLimitMiddleware limits the requests of each client, reads and writes
+// apart, answering the ones over the limit with 429. Clients are known by their
+// API key or user, so it must run after APIKeyMiddleware and
+// BearerTokenMiddleware, and else by their IP.
+type RateLimitMiddleware struct {
+	read    rateLimiter
+	write   rateLimiter
+	respond errorResponder
+}
+
+func NewRateLimitMiddleware(read, write rateLimiter, respond errorResponder) *RateLimitMiddleware {
+	return &RateLimitMiddleware{read, write, respond}
+}
+
+// Middleware takes a token of the bucket of the client, telling the state of
+// the bucket in the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers and, when it is empty, when to retry in
+// Retry-After.
+func (m *RateLimitMiddleware) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limiter := m.write
+			if isRead(r.Method) {
+				limiter = m.read
+			}
+
+			if !limit(w, r, limiter.Take(clientKey(r)), m.respond) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IPRateLimitMiddleware limits the requests of each IP, whatever their
+// credentials, answering the ones over the limit with 429. It must run before
+// APIKeyMiddleware and BearerTokenMiddleware, so guessing keys and tokens is
+// throttled before costing a lookup, with RateLimitMiddleware keeping the limits
+// of each client after them.
+type IPRateLimitMiddleware struct {
+	limiter rateLimiter
+	respond errorResponder
+}
+
+func NewIPRateLimitMiddleware(limiter rateLimiter, respond errorResponder) *IPRateLimitMiddleware {
+	return &IPRateLimitMiddleware{limiter, respond}
+}
+
+// Middleware takes a token of the bucket of the IP, telling its state in the
+// same headers as RateLimitMiddleware, which overwrites them for the requests
+// let through.
+func (m *IPRateLimitMiddleware) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !limit(w, r, m.limiter.Take(ipKey(r)), m.respond) {
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// limit tells the state of the bucket in the headers and whether the request
+// goes on, responding with 429 when it does not.
+func limit(w http.ResponseWriter, r *http.Request, rl domain.RateLimit, respond errorResponder) bool {
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(rl.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(rl.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(seconds(rl.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", rl.Limit, seconds(rl.Window)))
+
+	if !rl.Allowed {
+		retry := seconds(rl.RetryAfter)
+		h.Set("Retry-After", strconv.Itoa(retry))
+		respond(w, r, &domain.Error{
+			Kind: domain.RateLimitedErrKd,
+			Msg:  fmt.Sprintf("Too many requests, retry in %d seconds", retry),
+		})
+		return false
+	}
+
+	return true
+}
+
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// clientKey is who made r, as domain.Actor tells, or the IP it came from.
+func clientKey(r *http.Request) string {
+	if actor := domain.Actor(r.Context()); actor != "" {
+		return actor
+	}
+
+	return ipKey(r)
+}
+
+// ipKey is the IP r came from.
+func ipKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// seconds is d rounded up to whole seconds, as the headers are written in.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRateLimiter struct {
+	keys []string
+	rl   domain.RateLimit
+}
+
+func (s *stubRateLimiter) Take(key string) domain.RateLimit {
+	s.keys = append(s.keys, key)
+
+	return s.rl
+}
+
+func TestRateLimitMiddleware_Middleware(t *testing.T) {
+	allowed := domain.RateLimit{
+		Allowed:   true,
+		Limit:     300,
+		Remaining: 299,
+		Window:    time.Minute,
+		Reset:     200 * time.Millisecond,
+	}
+	limited := domain.RateLimit{
+		Limit:      60,
+		Window:     time.Minute,
+		Reset:      59500 * time.Millisecond,
+		RetryAfter: 500 * time.Millisecond,
+	}
+
+	testCases := map[string]struct {
+		method      string
+		ctx         func(context.Context) context.Context
+		wantLimiter string
+		wantKey     string
+		wantStatus  int
+		wantHeaders map[string]string
+	}{
+		"When a read is under the limit": {
+			method:      http.MethodGet,
+			wantLimiter: "read",
+			wantKey:     "ip:192.0.2.1",
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "300",
+				"RateLimit-Remaining": "299",
+				"RateLimit-Reset":     "1",
+				"RateLimit-Policy":    "300;w=60",
+				"Retry-After":         "",
+			},
+		},
+		"When a write is over the limit": {
+			method:      http.MethodPost,
+			wantLimiter: "write",
+			wantKey:     "ip:192.0.2.1",
+			wantStatus:  http.StatusTooManyRequests,
+			wantHeaders: map[string]string{
+				"RateLimit-Limit":     "60",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "60",
+				"RateLimit-Policy":    "60;w=60",
+				"Retry-After":         "1",
+			},
+		},
+		"When the client has an API key": {
+			method: http.MethodGet,
+			ctx: func(ctx context.Context) context.Context {
+				return domain.WithAPIKey(ctx, domain.APIKey{ID: "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"})
+			},
+			wantLimiter: "read",
+			wantKey:     "api-key:1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+			wantStatus:  http.StatusOK,
+		},
+		"When the client is a user": {
+			method: http.MethodDelete,
+			ctx: func(ctx context.Context) context.Context {
+				return domain.WithUser(ctx, domain.User{Subject: "maria"})
+			},
+			wantLimiter: "write",
+			wantKey:     "maria",
+			wantStatus:  http.StatusTooManyRequests,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			read := &stubRateLimiter{rl: allowed}
+			write := &stubRateLimiter{rl: limited}
+			m := NewRateLimitMiddleware(read, write, func(w http.ResponseWriter, _ *http.Request, err *domain.Error) {
+				if err.Kind != domain.RateLimitedErrKd {
+					t.Errorf("expect error kind %s, got %s", domain.RateLimitedErrKd, err.Kind)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			})
+
+			h := m.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			req := httptest.NewRequest(tc.method, "/v1/street_market", nil)
+			if tc.ctx != nil {
+				req = req.WithContext(tc.ctx(req.Context()))
+			}
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+
+			taken, other := read.keys, write.keys
+			if tc.wantLimiter == "write" {
+				taken, other = write.keys, read.keys
+			}
+			if diff := cmp.Diff([]string{tc.wantKey}, taken); diff != "" {
+				t.Errorf("mismatch of the tokens taken of the %s limiter (-want +got):\n%s", tc.wantLimiter, diff)
+			}
+			if len(other) != 0 {
+				t.Errorf("expect no token taken of the other limiter, got %v", other)
+			}
+
+			for name, want := range tc.wantHeaders {
+				if got := rr.Header().Get(name); got != want {
+					t.Errorf("expect header %s %q, got %q", name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestIPRateLimitMiddleware_Middleware(t *testing.T) {
+	testCases := map[string]struct {
+		rl         domain.RateLimit
+		wantStatus int
+		wantNext   bool
+	}{
+		"When the IP is under the limit": {
+			rl:         domain.RateLimit{Allowed: true, Limit: 600, Remaining: 599, Window: time.Minute},
+			wantStatus: http.StatusOK,
+			wantNext:   true,
+		},
+		"When the IP is over the limit": {
+			rl:         domain.RateLimit{Limit: 600, Window: time.Minute, RetryAfter: 100 * time.Millisecond},
+			wantStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			limiter := &stubRateLimiter{rl: tc.rl}
+			m := NewIPRateLimitMiddleware(limiter, func(w http.ResponseWriter, _ *http.Request, err *domain.Error) {
+				if err.Kind != domain.RateLimitedErrKd {
+					t.Errorf("expect error kind %s, got %s", domain.RateLimitedErrKd, err.Kind)
+				}
+				w.WriteHeader(http.StatusTooManyRequests)
+			})
+
+			called := false
+			h := m.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+
+			// The credentials are not known yet, so the IP is taken even for a
+			// client with an API key.
+			req := httptest.NewRequest(http.MethodGet, "/v1/street_market", nil)
+			req = req.WithContext(domain.WithAPIKey(req.Context(), domain.APIKey{ID: "1f0c1c2e"}))
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+			if called != tc.wantNext {
+				t.Errorf("expect next handler called %v, got %v", tc.wantNext, called)
+			}
+			if diff := cmp.Diff([]string{"ip:192.0.2.1"}, limiter.keys); diff != "" {
+				t.Errorf("mismatch of the tokens taken (-want +got):\n%s", diff)
+			}
+			if got := rr.Header().Get("RateLimit-Policy"); got != "600;w=60" {
+				t.Errorf("expect header RateLimit-Policy %q, got %q", "600;w=60", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+type requestValidator interface {
+	ValidateRequest(method, path string, body []byte) *domain.Error
+}
+
+type errorResponder func(http.ResponseWriter, *http.Request, *domain.Error)
+
+// RequestValidationMiddleware rejects request bodies that do not conform to the
+// API specification before they reach the handlers. Bodies over maxBytes are
+// rejected unread, as the handlers would.
+type RequestValidationMiddleware struct {
+	validator requestValidator
+	respond   errorResponder
+	maxBytes  int64
+}
+
+func NewRequestValidationMiddleware(
+	validator requestValidator,
+	respond errorResponder,
+	maxBytes int64,
+) *RequestValidationMiddleware {
+	return &RequestValidationMiddleware{validator, respond, maxBytes}
+}
+
+func (m *RequestValidationMiddleware) Middleware() mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route == nil || r.Body == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			path, err := route.GetPathTemplate()
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := ioutil.ReadAll(io.LimitReader(r.Body, m.maxBytes+1))
+			if err != nil {
+				m.respond(w, r, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err})
+				return
+			}
+			r.Body.Close()
+
+			if int64(len(body)) > m.maxBytes {
+				m.respond(w, r, &domain.Error{
+					Kind: domain.BodyTooLargeErrKd,
+					Msg:  fmt.Sprintf("The body must have at most %d bytes", m.maxBytes),
+				})
+				return
+			}
+
+			if dErr := m.validator.ValidateRequest(r.Method, path, body); dErr != nil {
+				m.respond(w, r, dErr)
+				return
+			}
+
+			// The handler reads the body again.
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
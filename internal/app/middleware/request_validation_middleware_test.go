@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+type stubRequestValidator struct {
+	err    *domain.Error
+	method string
+	path   string
+	body   string
+}
+
+func (s *stubRequestValidator) ValidateRequest(method, path string, body []byte) *domain.Error {
+	s.method, s.path, s.body = method, path, string(body)
+
+	return s.err
+}
+
+func TestRequestValidationMiddleware_Middleware(t *testing.T) {
+	testCases := map[string]struct {
+		err           *domain.Error
+		maxBytes      int64
+		wantStatus    int
+		wantBody      string
+		wantValidated bool
+	}{
+		"When body is valid the handler reads it": {
+			maxBytes:      1024,
+			wantStatus:    http.StatusOK,
+			wantBody:      `{"name":"feira"}`,
+			wantValidated: true,
+		},
+		"When body is invalid it is rejected": {
+			err:           &domain.Error{Kind: domain.InpValidationErrKd, Msg: "invalid"},
+			maxBytes:      1024,
+			wantStatus:    http.StatusBadRequest,
+			wantBody:      string(domain.InpValidationErrKd),
+			wantValidated: true,
+		},
+		"When body is too large it is rejected unread": {
+			maxBytes:   8,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   string(domain.BodyTooLargeErrKd),
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			validator := &stubRequestValidator{err: tc.err}
+			respond := func(w http.ResponseWriter, r *http.Request, err *domain.Error) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(err.Kind))
+			}
+
+			r := mux.NewRouter()
+			r.Use(NewRequestValidationMiddleware(validator, respond, tc.maxBytes).Middleware())
+			r.HandleFunc("/street_market/{street-market-id}", func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				_, _ = w.Write(body)
+			}).Methods(http.MethodPatch)
+
+			req := httptest.NewRequest(http.MethodPatch, "/street_market/abc", strings.NewReader(`{"name":"feira"}`))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, rr.Body.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+
+			want := stubRequestValidator{err: tc.err}
+			if tc.wantValidated {
+				want.method = http.MethodPatch
+				want.path = "/street_market/{street-market-id}"
+				want.body = `{"name":"feira"}`
+			}
+			if diff := cmp.Diff(want, *validator, cmp.AllowUnexported(stubRequestValidator{})); diff != "" {
+				t.Errorf("validator called with (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
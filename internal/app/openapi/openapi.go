@@ -0,0 +1,347 @@
+// Package openapi holds the OpenAPI document of the API and validates request
+// bodies against it. Only the subset of JSON Schema used by the document is
+// supported.
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/uuid"
+)
+
+//go:embed openapi.json
+var document []byte
+
+// Document returns the OpenAPI document as served to clients.
+func Document() []byte {
+	return document
+}
+
+// Schema is the subset of JSON Schema used by the document.
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*Schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	Enum                 []interface{}      `json:"enum"`
+	MinLength            *int               `json:"minLength"`
+	MaxLength            *int               `json:"maxLength"`
+	MinItems             *int               `json:"minItems"`
+	Minimum              *float64           `json:"minimum"`
+	Pattern              string             `json:"pattern"`
+	Format               string             `json:"format"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type operation struct {
+	RequestBody *struct {
+		Content map[string]mediaType `json:"content"`
+	} `json:"requestBody"`
+}
+
+type doc struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Spec is the parsed document, indexed by the path template and method of
+// each operation with a JSON request body.
+type Spec struct {
+	bodies  map[string]*Schema
+	schemas map[string]*Schema
+}
+
+// Load parses the embedded document.
+func Load() (*Spec, error) {
+	var d doc
+	if err := json.Unmarshal(document, &d); err != nil {
+		return nil, fmt.Errorf("parse openapi document: %w", err)
+	}
+
+	s := &Spec{bodies: map[string]*Schema{}, schemas: d.Components.Schemas}
+
+	for path, item := range d.Paths {
+		for method, raw := range item {
+			if method == "parameters" {
+				continue
+			}
+
+			var op operation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("parse %s %s: %w", method, path, err)
+			}
+			if op.RequestBody == nil {
+				continue
+			}
+
+			if mt, ok := op.RequestBody.Content["application/json"]; ok && mt.Schema != nil {
+				s.bodies[bodyKey(method, path)] = mt.Schema
+			}
+		}
+	}
+
+	if err := s.checkRefs(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ValidateRequest validates body against the request body schema of the
+// operation at the path template, as /v1/street_market/{street-market-id}.
+// Operations without a JSON body accept anything.
+func (s *Spec) ValidateRequest(method, path string, body []byte) *domain.Error {
+	sch, ok := s.bodies[bodyKey(method, path)]
+	if !ok {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return &domain.Error{Kind: domain.InpValidationErrKd, Msg: "malformed body", Cause: err}
+	}
+
+	fe := []domain.FieldError{}
+	s.validate(sch, v, "", &fe)
+	if len(fe) == 0 {
+		return nil
+	}
+
+	return &domain.Error{
+		Kind:   domain.InpValidationErrKd,
+		Msg:    "Body does not conform to the API specification",
+		Fields: fe,
+	}
+}
+
+func (s *Spec) validate(sch *Schema, v interface{}, field string, fe *[]domain.FieldError) {
+	sch = s.resolve(sch)
+
+	add := func(code domain.FieldErrorCode, format string, args ...interface{}) {
+		*fe = append(*fe, domain.FieldError{Field: field, Code: code, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	if sch.Type != "" && !hasType(v, sch.Type) {
+		add(domain.InvalidTypeFieldCd, "%s must be of type %s", name(field), sch.Type)
+		return
+	}
+
+	if len(sch.Enum) > 0 && !inEnum(v, sch.Enum) {
+		add(domain.NotAllowedFieldCd, "%s must be one of %v", name(field), sch.Enum)
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		s.validateObject(sch, t, field, fe)
+	case []interface{}:
+		if sch.MinItems != nil && len(t) < *sch.MinItems {
+			add(domain.TooShortFieldCd, "%s must have at least %d items", name(field), *sch.MinItems)
+		}
+		if sch.Items != nil {
+			for i, item := range t {
+				s.validate(sch.Items, item, fmt.Sprintf("%s[%d]", field, i), fe)
+			}
+		}
+	case string:
+		l := len([]rune(t))
+		if sch.MinLength != nil && l < *sch.MinLength {
+			add(domain.TooShortFieldCd, "%s must have at least %d characters", name(field), *sch.MinLength)
+		}
+		if sch.MaxLength != nil && l > *sch.MaxLength {
+			add(domain.TooLongFieldCd, "%s must have at most %d characters", name(field), *sch.MaxLength)
+		}
+		if !matchesFormat(t, sch.Format, sch.Pattern) {
+			add(domain.InvalidFormatFieldCd, "%s is not a valid %s", name(field), formatName(sch))
+		}
+	case json.Number:
+		if f, err := t.Float64(); err == nil && sch.Minimum != nil && f < *sch.Minimum {
+			add(domain.TooSmallFieldCd, "%s must be at least %v", name(field), *sch.Minimum)
+		}
+	}
+}
+
+func (s *Spec) validateObject(sch *Schema, obj map[string]interface{}, field string, fe *[]domain.FieldError) {
+	for _, r := range sch.Required {
+		if _, ok := obj[r]; !ok {
+			*fe = append(*fe, domain.FieldError{
+				Field: join(field, r),
+				Code:  domain.RequiredFieldCd,
+				Msg:   fmt.Sprintf("%s is required", join(field, r)),
+			})
+		}
+	}
+
+	// Sorted so the errors come in a stable order.
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		prop, ok := sch.Properties[k]
+		if !ok {
+			if sch.AdditionalProperties != nil && !*sch.AdditionalProperties {
+				*fe = append(*fe, domain.FieldError{
+					Field: join(field, k),
+					Code:  domain.UnexpectedFieldCd,
+					Msg:   fmt.Sprintf("%s is not a known field", join(field, k)),
+				})
+			}
+			continue
+		}
+		s.validate(prop, obj[k], join(field, k), fe)
+	}
+}
+
+func (s *Spec) resolve(sch *Schema) *Schema {
+	for sch.Ref != "" {
+		sch = s.schemas[strings.TrimPrefix(sch.Ref, "#/components/schemas/")]
+	}
+
+	return sch
+}
+
+// checkRefs makes sure every reference of the request bodies points to a known
+// schema, so resolve never finds a missing one.
+func (s *Spec) checkRefs() error {
+	var check func(sch *Schema) error
+	check = func(sch *Schema) error {
+		if sch == nil {
+			return nil
+		}
+		if sch.Ref != "" {
+			if _, ok := s.schemas[strings.TrimPrefix(sch.Ref, "#/components/schemas/")]; !ok {
+				return fmt.Errorf("unknown schema reference %s", sch.Ref)
+			}
+		}
+		for _, p := range sch.Properties {
+			if err := check(p); err != nil {
+				return err
+			}
+		}
+
+		return check(sch.Items)
+	}
+
+	for _, sch := range s.schemas {
+		if err := check(sch); err != nil {
+			return err
+		}
+	}
+	for _, sch := range s.bodies {
+		if err := check(sch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasType(v interface{}, typ string) bool {
+	switch typ {
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+
+	return true
+}
+
+func inEnum(v interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesFormat(v, format, pattern string) bool {
+	if pattern != "" {
+		if ok, err := regexp.MatchString(pattern, v); err != nil || !ok {
+			return false
+		}
+	}
+
+	switch format {
+	case "uuid":
+		_, err := uuid.Parse(v)
+		return err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", v)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	}
+
+	return true
+}
+
+func formatName(sch *Schema) string {
+	if sch.Format != "" {
+		return sch.Format
+	}
+
+	return fmt.Sprintf("value for %s", sch.Pattern)
+}
+
+func bodyKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+func join(parent, field string) string {
+	if parent == "" {
+		return field
+	}
+
+	return parent + "." + field
+}
+
+func name(field string) string {
+	if field == "" {
+		return "body"
+	}
+
+	return field
+}
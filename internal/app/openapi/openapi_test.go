@@ -0,0 +1,166 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDocument(t *testing.T) {
+	var d map[string]interface{}
+	if err := json.Unmarshal(Document(), &d); err != nil {
+		t.Fatal(err)
+	}
+
+	if d["openapi"] != "3.1.0" {
+		t.Errorf("expect openapi 3.1.0, got %v", d["openapi"])
+	}
+}
+
+func TestSpec_ValidateRequest(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		method string
+		path   string
+		body   string
+	}{
+		"When street market edit is valid": {
+			method: http.MethodPatch,
+			path:   "/v1/street_market/{street-market-id}",
+			body:   `{"name": "RAPOSO TAVARES", "long": -46548146}`,
+		},
+		"When stall create is valid": {
+			method: http.MethodPost,
+			path:   "/v1/street_market/{street-market-id}/stalls",
+			body:   `{"number": 1, "vendor_name": "Maria", "license_number": "TPU-1", "categories": ["fruits"]}`,
+		},
+		"When schedule is valid": {
+			method: http.MethodPut,
+			path:   "/v1/street_market/{street-market-id}/schedule",
+			body:   `{"weekdays": [{"weekday": "sunday", "start": "07:00", "end": "13:00"}], "exceptions": []}`,
+		},
+		"When webhook create is valid": {
+			method: http.MethodPost,
+			path:   "/v1/webhooks",
+			body: `{"url": "https://partner.example.com/hooks", "secret": "0123456789abcdef",` +
+				` "events": ["street_market.deleted"]}`,
+		},
+		"When operation has no body": {
+			method: http.MethodDelete,
+			path:   "/v1/street_market/{street-market-id}",
+			body:   `not json`,
+		},
+		"When path is not in the document": {
+			method: http.MethodPost,
+			path:   "/unknown",
+			body:   `not json`,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if err := spec.ValidateRequest(tc.method, tc.path, []byte(tc.body)); err != nil {
+				t.Errorf("expect nil, got %v %v", err, err.Fields)
+			}
+		})
+	}
+}
+
+func TestSpec_ValidateRequest_Error(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		method string
+		path   string
+		body   string
+		want   []domain.FieldError
+	}{
+		"When street market edit has wrong types and unknown fields": {
+			method: http.MethodPatch,
+			path:   "/v1/street_market/{street-market-id}",
+			body:   `{"long": "-46", "nickname": "feira", "name": "` + strings.Repeat("a", 51) + `"}`,
+			want: []domain.FieldError{
+				{Field: "long", Code: domain.InvalidTypeFieldCd, Msg: "long must be of type number"},
+				{Field: "name", Code: domain.TooLongFieldCd, Msg: "name must have at most 50 characters"},
+				{Field: "nickname", Code: domain.UnexpectedFieldCd, Msg: "nickname is not a known field"},
+			},
+		},
+		"When stall create misses fields and has invalid items": {
+			method: http.MethodPost,
+			path:   "/v1/street_market/{street-market-id}/stalls",
+			body:   `{"number": 1.5, "categories": ["cars"]}`,
+			want: []domain.FieldError{
+				{Field: "vendor_name", Code: domain.RequiredFieldCd, Msg: "vendor_name is required"},
+				{Field: "license_number", Code: domain.RequiredFieldCd, Msg: "license_number is required"},
+				{
+					Field: "categories[0]",
+					Code:  domain.NotAllowedFieldCd,
+					Msg: "categories[0] must be one of [fruits vegetables greens fish seafood meat poultry eggs dairy " +
+						"pastel bakery spices grains flowers clothing household other]",
+				},
+				{Field: "number", Code: domain.InvalidTypeFieldCd, Msg: "number must be of type integer"},
+			},
+		},
+		"When schedule has invalid formats": {
+			method: http.MethodPut,
+			path:   "/v1/street_market/{street-market-id}/schedule",
+			body:   `{"exceptions": [{"date": "25/12/2026", "start": "7h"}]}`,
+			want: []domain.FieldError{
+				{Field: "exceptions[0].date", Code: domain.InvalidFormatFieldCd, Msg: "exceptions[0].date is not a valid date"},
+				{
+					Field: "exceptions[0].start",
+					Code:  domain.InvalidFormatFieldCd,
+					Msg:   "exceptions[0].start is not a valid value for ^[0-2][0-9]:[0-5][0-9]$",
+				},
+			},
+		},
+		"When body is not an object": {
+			method: http.MethodPost,
+			path:   "/v1/street_market",
+			body:   `[]`,
+			want: []domain.FieldError{
+				{Field: "", Code: domain.InvalidTypeFieldCd, Msg: "body must be of type object"},
+			},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			err := spec.ValidateRequest(tc.method, tc.path, []byte(tc.body))
+			if err == nil {
+				t.Fatal("expect err, got nil")
+			}
+
+			if err.Kind != domain.InpValidationErrKd {
+				t.Errorf("expect kind %s, got %s", domain.InpValidationErrKd, err.Kind)
+			}
+
+			if diff := cmp.Diff(tc.want, err.Fields); diff != "" {
+				t.Errorf("unexpected field errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSpec_ValidateRequest_Malformed(t *testing.T) {
+	spec, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dErr := spec.ValidateRequest(http.MethodPost, "/v1/street_market", []byte(`{"name":`))
+	if dErr == nil || dErr.Msg != "malformed body" {
+		t.Errorf("expect malformed body error, got %v", dErr)
+	}
+}
@@ -0,0 +1,69 @@
+// Package router mounts the versions of the API. Each version is a table of
+// routes under its own path prefix, so a new version can change the shape of
+// the responses while the previous one keeps being served.
+package router
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/gorilla/mux"
+)
+
+// Route is an operation of a version, with a path relative to its prefix.
+// Scope is what an API key must be allowed to do to call it, and Role what a
+// user of the SSO must be. Shed puts it under the concurrency limit of the
+// version, for the operations that query the database.
+type Route struct {
+	Method  string
+	Path    string
+	Scope   domain.Scope
+	Role    domain.Role
+	Shed    bool
+	Handler http.HandlerFunc
+}
+
+// Version is a set of routes served under Prefix, as /v1. Guard, when set,
+// wraps the handler of every route with the check of its scope and role, and
+// Shed the handler of the routes marked Shed with the concurrency limit, once
+// the route let the request through.
+type Version struct {
+	Prefix string
+	Routes []Route
+	Guard  func(domain.Scope, domain.Role) mux.MiddlewareFunc
+	Shed   mux.MiddlewareFunc
+}
+
+// Mount registers the routes of v on r under v.Prefix.
+func Mount(r *mux.Router, v Version) {
+	sr := r.PathPrefix(v.Prefix).Subrouter()
+	for _, rt := range v.Routes {
+		var h http.Handler = rt.Handler
+		if rt.Shed && v.Shed != nil {
+			h = v.Shed(h)
+		}
+		if v.Guard != nil {
+			h = v.Guard(rt.Scope, rt.Role)(h)
+		}
+		sr.Handle(rt.Path, h).Methods(rt.Method)
+	}
+}
+
+// Roots returns the first segment of every route path, as /street_market, in
+// order and without duplicates.
+func (v Version) Roots() []string {
+	seen := map[string]bool{}
+	roots := []string{}
+	for _, rt := range v.Routes {
+		root := "/" + strings.SplitN(strings.TrimPrefix(rt.Path, "/"), "/", 2)[0]
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	sort.Strings(roots)
+
+	return roots
+}
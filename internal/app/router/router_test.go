@@ -0,0 +1,212 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+)
+
+func handler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestMount(t *testing.T) {
+	r := mux.NewRouter()
+	Mount(r, Version{Prefix: "/v1", Routes: []Route{
+		{Method: http.MethodGet, Path: "/street_market", Handler: handler("v1 list")},
+		{Method: http.MethodGet, Path: "/street_market/{street-market-id}/stalls", Handler: handler("v1 stalls")},
+	}})
+	Mount(r, Version{Prefix: "/v2", Routes: []Route{
+		{Method: http.MethodGet, Path: "/street_market", Handler: handler("v2 list")},
+	}})
+
+	testCases := map[string]struct {
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		"When route is in v1": {
+			method:     http.MethodGet,
+			path:       "/v1/street_market",
+			wantStatus: http.StatusOK,
+			wantBody:   "v1 list",
+		},
+		"When route has variables": {
+			method:     http.MethodGet,
+			path:       "/v1/street_market/abc/stalls",
+			wantStatus: http.StatusOK,
+			wantBody:   "v1 stalls",
+		},
+		"When route is in v2": {
+			method:     http.MethodGet,
+			path:       "/v2/street_market",
+			wantStatus: http.StatusOK,
+			wantBody:   "v2 list",
+		},
+		"When route is not in v2": {
+			method:     http.MethodGet,
+			path:       "/v2/street_market/abc/stalls",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "404 page not found\n",
+		},
+		"When route has no prefix": {
+			method:     http.MethodGet,
+			path:       "/street_market",
+			wantStatus: http.StatusNotFound,
+			wantBody:   "404 page not found\n",
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+
+			if diff := cmp.Diff(tc.wantBody, rr.Body.String()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMount_Guard(t *testing.T) {
+	// The guard lets through the routes that only read.
+	guard := func(scope domain.Scope, role domain.Role) mux.MiddlewareFunc {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if scope != domain.ScopeMarketsRead || role != domain.RoleViewer {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	r := mux.NewRouter()
+	Mount(r, Version{Prefix: "/v1", Guard: guard, Routes: []Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market",
+			Scope:   domain.ScopeMarketsRead,
+			Role:    domain.RoleViewer,
+			Handler: handler("list"),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/street_market",
+			Scope:   domain.ScopeMarketsWrite,
+			Role:    domain.RoleEditor,
+			Handler: handler("create"),
+		},
+	}})
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/street_market", nil))
+	if rr.Code != http.StatusOK || rr.Body.String() != "list" {
+		t.Errorf("expect the list served, got %d %q", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/v1/street_market", nil))
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expect status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+}
+
+func TestMount_Shed(t *testing.T) {
+	// The shed refuses everything it wraps, counting the requests.
+	var shed []string
+	shedAll := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			shed = append(shed, r.URL.Path)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+	}
+	guard := func(scope domain.Scope, _ domain.Role) mux.MiddlewareFunc {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if scope != domain.ScopeMarketsRead {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	r := mux.NewRouter()
+	Mount(r, Version{Prefix: "/v1", Guard: guard, Shed: shedAll, Routes: []Route{
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market",
+			Scope:   domain.ScopeMarketsRead,
+			Shed:    true,
+			Handler: handler("list"),
+		},
+		{
+			Method:  http.MethodPost,
+			Path:    "/street_market",
+			Scope:   domain.ScopeMarketsWrite,
+			Shed:    true,
+			Handler: handler("create"),
+		},
+		{
+			Method:  http.MethodGet,
+			Path:    "/street_market/changes",
+			Scope:   domain.ScopeMarketsRead,
+			Handler: handler("changes"),
+		},
+	}})
+
+	testCases := map[string]struct {
+		method     string
+		path       string
+		wantStatus int
+	}{
+		"When the route is shed":                 {http.MethodGet, "/v1/street_market", http.StatusServiceUnavailable},
+		"When the route is not shed":             {http.MethodGet, "/v1/street_market/changes", http.StatusOK},
+		"When the guard refuses before the shed": {http.MethodPost, "/v1/street_market", http.StatusForbidden},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, httptest.NewRequest(tc.method, tc.path, nil))
+
+			if rr.Code != tc.wantStatus {
+				t.Errorf("expect status %d, got %d", tc.wantStatus, rr.Code)
+			}
+		})
+	}
+
+	if diff := cmp.Diff([]string{"/v1/street_market"}, shed); diff != "" {
+		t.Errorf("mismatch of the requests shed (-want +got):\n%s", diff)
+	}
+}
+
+func TestVersion_Roots(t *testing.T) {
+	v := Version{Prefix: "/v1", Routes: []Route{
+		{Method: http.MethodGet, Path: "/street_market"},
+		{Method: http.MethodPost, Path: "/street_market"},
+		{Method: http.MethodGet, Path: "/street_market/{street-market-id}/stalls"},
+		{Method: http.MethodGet, Path: "/snapshots/diff"},
+		{Method: http.MethodGet, Path: "/districts"},
+	}}
+
+	want := []string{"/districts", "/snapshots", "/street_market"}
+	if diff := cmp.Diff(want, v.Roots()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
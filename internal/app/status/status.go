@@ -0,0 +1,52 @@
+// Package status maps the error kinds to how the APIs respond them, so the
+// HTTP and the gRPC API agree on what each kind means to the client.
+package status
+
+import (
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"google.golang.org/grpc/codes"
+)
+
+// Status is how the APIs respond an error kind, over HTTP and over gRPC.
+type Status struct {
+	HTTP int
+	GRPC codes.Code
+}
+
+// statuses is the status of every error kind the client may act upon. The
+// kinds left out are unexpected.
+var statuses = map[domain.KindError]Status{ //nolint:gochecknoglobals
+	domain.InpValidationErrKd:        {http.StatusBadRequest, codes.InvalidArgument},
+	domain.MissingRefErrKd:           {http.StatusBadRequest, codes.InvalidArgument},
+	domain.UnauthenticatedErrKd:      {http.StatusUnauthorized, codes.Unauthenticated},
+	domain.ForbiddenErrKd:            {http.StatusForbidden, codes.PermissionDenied},
+	domain.NothingFoundErrKd:         {http.StatusNotFound, codes.NotFound},
+	domain.NothingUpdatedErrKd:       {http.StatusNotFound, codes.NotFound},
+	domain.NothingDeletedErrKd:       {http.StatusNotFound, codes.NotFound},
+	domain.SMNotFoundErrKd:           {http.StatusNotFound, codes.NotFound},
+	domain.SnapNotFoundErrKd:         {http.StatusNotFound, codes.NotFound},
+	domain.StallNotFoundErrKd:        {http.StatusNotFound, codes.NotFound},
+	domain.SubTHNotFoundErrKd:        {http.StatusNotFound, codes.NotFound},
+	domain.WebhookNotFoundErrKd:      {http.StatusNotFound, codes.NotFound},
+	domain.APIKeyNotFoundErrKd:       {http.StatusNotFound, codes.NotFound},
+	domain.RouteNotFoundErrKd:        {http.StatusNotFound, codes.NotFound},
+	domain.MethodNotAllowedErrKd:     {http.StatusMethodNotAllowed, codes.Unimplemented},
+	domain.DuplicatedErrKd:           {http.StatusConflict, codes.AlreadyExists},
+	domain.StallDupErrKd:             {http.StatusConflict, codes.AlreadyExists},
+	domain.BodyTooLargeErrKd:         {http.StatusRequestEntityTooLarge, codes.ResourceExhausted},
+	domain.UnsupportedMediaTypeErrKd: {http.StatusUnsupportedMediaType, codes.InvalidArgument},
+	domain.RateLimitedErrKd:          {http.StatusTooManyRequests, codes.ResourceExhausted},
+	domain.OverloadedErrKd:           {http.StatusServiceUnavailable, codes.Unavailable},
+}
+
+// Of is the status every API responds kind with, 500 and Internal when it is
+// unexpected.
+func Of(kind domain.KindError) Status {
+	if s, ok := statuses[kind]; ok {
+		return s
+	}
+
+	return Status{http.StatusInternalServerError, codes.Internal}
+}
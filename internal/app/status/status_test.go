@@ -0,0 +1,46 @@
+package status
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"google.golang.org/grpc/codes"
+)
+
+func TestOf(t *testing.T) {
+	testCases := map[domain.KindError]Status{
+		domain.UnexpectedErrKd:           {http.StatusInternalServerError, codes.Internal},
+		domain.NothingCreatedErrKd:       {http.StatusInternalServerError, codes.Internal},
+		domain.NothingUpdatedErrKd:       {http.StatusNotFound, codes.NotFound},
+		domain.NothingDeletedErrKd:       {http.StatusNotFound, codes.NotFound},
+		domain.NothingFoundErrKd:         {http.StatusNotFound, codes.NotFound},
+		domain.DuplicatedErrKd:           {http.StatusConflict, codes.AlreadyExists},
+		domain.SMNotFoundErrKd:           {http.StatusNotFound, codes.NotFound},
+		domain.InpValidationErrKd:        {http.StatusBadRequest, codes.InvalidArgument},
+		domain.SnapNotFoundErrKd:         {http.StatusNotFound, codes.NotFound},
+		domain.StallNotFoundErrKd:        {http.StatusNotFound, codes.NotFound},
+		domain.StallDupErrKd:             {http.StatusConflict, codes.AlreadyExists},
+		domain.MissingRefErrKd:           {http.StatusBadRequest, codes.InvalidArgument},
+		domain.SubTHNotFoundErrKd:        {http.StatusNotFound, codes.NotFound},
+		domain.RouteNotFoundErrKd:        {http.StatusNotFound, codes.NotFound},
+		domain.MethodNotAllowedErrKd:     {http.StatusMethodNotAllowed, codes.Unimplemented},
+		domain.WebhookNotFoundErrKd:      {http.StatusNotFound, codes.NotFound},
+		domain.APIKeyNotFoundErrKd:       {http.StatusNotFound, codes.NotFound},
+		domain.UnauthenticatedErrKd:      {http.StatusUnauthorized, codes.Unauthenticated},
+		domain.ForbiddenErrKd:            {http.StatusForbidden, codes.PermissionDenied},
+		domain.RateLimitedErrKd:          {http.StatusTooManyRequests, codes.ResourceExhausted},
+		domain.OverloadedErrKd:           {http.StatusServiceUnavailable, codes.Unavailable},
+		domain.BodyTooLargeErrKd:         {http.StatusRequestEntityTooLarge, codes.ResourceExhausted},
+		domain.UnsupportedMediaTypeErrKd: {http.StatusUnsupportedMediaType, codes.InvalidArgument},
+		domain.KindError("NOT_DECLARED"): {http.StatusInternalServerError, codes.Internal},
+	}
+
+	for kind, want := range testCases {
+		t.Run(string(kind), func(t *testing.T) {
+			if got := Of(kind); got != want {
+				t.Errorf("expect status %v, got %v", want, got)
+			}
+		})
+	}
+}
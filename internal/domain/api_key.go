@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const apiKeyNameMaxLen = 100
+
+// Scope is an operation an API key may do.
+type Scope string
+
+const (
+	ScopeMarketsRead   Scope = "markets:read"
+	ScopeMarketsWrite  Scope = "markets:write"
+	ScopeMarketsDelete Scope = "markets:delete"
+	ScopeWebhooks      Scope = "webhooks:manage"
+)
+
+// AllScopes are every scope a key may be issued with.
+func AllScopes() []Scope {
+	return []Scope{ScopeMarketsRead, ScopeMarketsWrite, ScopeMarketsDelete, ScopeWebhooks}
+}
+
+func (s Scope) Validate() *Error {
+	for _, v := range AllScopes() {
+		if s == v {
+			return nil
+		}
+	}
+
+	return &Error{Kind: InpValidationErrKd, Msg: fmt.Sprintf("%s is not a valid scope", s)}
+}
+
+type APIKeyID string
+
+func (k *APIKeyID) Validate() *Error {
+	if _, err := uuid.Parse(string(*k)); err != nil {
+		return &Error{Kind: InpValidationErrKd, Msg: err.Error()}
+	}
+
+	return nil
+}
+
+// APIKey identifies a client of the API. Only the hash of its secret is kept,
+// the secret being shown once, when the key is issued.
+type APIKey struct {
+	ID        string
+	Name      string
+	Scopes    []Scope
+	Hash      string
+	CreatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+// HasScope tells whether k may do scope.
+func (k APIKey) HasScope(scope Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type APIKeyInput struct {
+	Name   string
+	Scopes []Scope
+}
+
+// Validate reports every invalid field at once.
+func (d *APIKeyInput) Validate() *Error {
+	fe := fieldErrors{}
+
+	fe.required("Name", d.Name)
+	fe.maxLen("Name", d.Name, apiKeyNameMaxLen)
+
+	if len(d.Scopes) == 0 {
+		fe.add("Scopes", RequiredFieldCd, "Scopes is required")
+	}
+	for _, s := range d.Scopes {
+		if err := s.Validate(); err != nil {
+			fe.add("Scopes", NotAllowedFieldCd, err.Msg)
+		}
+	}
+
+	return fe.err()
+}
+
+// WithAPIKey returns a copy of ctx carrying the key the request was made with.
+func WithAPIKey(ctx context.Context, k APIKey) context.Context {
+	return context.WithValue(ctx, APIKeyCtxKey, k)
+}
+
+// APIKeyFrom returns the key the request of ctx was made with, false when it
+// was made without one.
+func APIKeyFrom(ctx context.Context) (APIKey, bool) {
+	k, ok := ctx.Value(APIKeyCtxKey).(APIKey)
+
+	return k, ok
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAPIKeyID_Validate(t *testing.T) {
+	var id APIKeyID = "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"
+	if err := id.Validate(); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+
+	id = "invalid"
+	if err := id.Validate(); err == nil {
+		t.Error("expect err, got nil")
+	}
+}
+
+func TestAPIKey_HasScope(t *testing.T) {
+	k := APIKey{Scopes: []Scope{ScopeMarketsRead, ScopeMarketsWrite}}
+
+	if !k.HasScope(ScopeMarketsWrite) {
+		t.Error("expect the key to have markets:write")
+	}
+	if k.HasScope(ScopeMarketsDelete) {
+		t.Error("expect the key not to have markets:delete")
+	}
+}
+
+func TestAPIKeyInput_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inp  APIKeyInput
+		want []FieldError
+	}{
+		"When input is valid": {
+			inp: APIKeyInput{Name: "partner", Scopes: []Scope{ScopeMarketsRead}},
+		},
+		"When name and scopes are missing": {
+			inp: APIKeyInput{},
+			want: []FieldError{
+				{Field: "Name", Code: RequiredFieldCd, Msg: "Name is required"},
+				{Field: "Scopes", Code: RequiredFieldCd, Msg: "Scopes is required"},
+			},
+		},
+		"When a scope is unknown": {
+			inp: APIKeyInput{Name: "partner", Scopes: []Scope{ScopeMarketsRead, "markets:admin"}},
+			want: []FieldError{
+				{Field: "Scopes", Code: NotAllowedFieldCd, Msg: "markets:admin is not a valid scope"},
+			},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			err := tc.inp.Validate()
+
+			var got []FieldError
+			if err != nil {
+				got = err.Fields
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected field errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIKeyFrom(t *testing.T) {
+	if _, ok := APIKeyFrom(context.TODO()); ok {
+		t.Error("expect no key in an empty context")
+	}
+
+	k := APIKey{ID: "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d", Scopes: []Scope{ScopeMarketsRead}}
+	got, ok := APIKeyFrom(WithAPIKey(context.TODO(), k))
+	if !ok {
+		t.Fatal("expect the key in the context")
+	}
+	if diff := cmp.Diff(k, got); diff != "" {
+		t.Errorf("unexpected key (-want +got):\n%s", diff)
+	}
+}
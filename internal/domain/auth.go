@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// Role is what a user of the SSO may do, each role including the ones before
+// it: viewers read, editors also create and edit, admins also delete and
+// manage the webhooks.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+func Roles() []Role {
+	return []Role{RoleViewer, RoleEditor, RoleAdmin}
+}
+
+// Includes tells whether r may do what role may. No role includes an unknown
+// one.
+func (r Role) Includes(role Role) bool {
+	return role.rank() > 0 && r.rank() >= role.rank()
+}
+
+func (r Role) rank() int {
+	for i, v := range Roles() {
+		if r == v {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// User is a person authenticated by the SSO, Subject being the sub claim of
+// the token. Role is empty when the token maps to none. IDSubTH is the
+// sub-town hall the user is employed by, AllSubTHs for users of the whole city
+// and empty when the token does not tell.
+type User struct {
+	Subject string
+	Role    Role
+	IDSubTH string
+}
+
+// AllSubTHs is the IDSubTH of the users of the whole city.
+const AllSubTHs = "*"
+
+// WithUser returns a copy of ctx carrying the user who made the request.
+func WithUser(ctx context.Context, u User) context.Context {
+	return context.WithValue(ctx, UserCtxKey, u)
+}
+
+// UserFrom returns the user who made the request of ctx, false when it was not
+// made with a token.
+func UserFrom(ctx context.Context) (User, bool) {
+	u, ok := ctx.Value(UserCtxKey).(User)
+
+	return u, ok
+}
+
+// Authorize tells whether the caller of ctx may do what needs scope from API
+// keys and role from users.
+func Authorize(ctx context.Context, scope Scope, role Role) *Error {
+	if k, ok := APIKeyFrom(ctx); ok {
+		if k.HasScope(scope) {
+			return nil
+		}
+
+		return &Error{Kind: ForbiddenErrKd, Msg: fmt.Sprintf("The API key does not have the %s scope", scope)}
+	}
+
+	if u, ok := UserFrom(ctx); ok {
+		if u.Role.Includes(role) {
+			return nil
+		}
+
+		return &Error{Kind: ForbiddenErrKd, Msg: fmt.Sprintf("The user does not have the %s role", role)}
+	}
+
+	return &Error{Kind: UnauthenticatedErrKd, Msg: "An API key or a bearer token is required"}
+}
+
+// Actor names who made the request of ctx in the records of what it changed,
+// the ID of an API key, as api-key:<id>, or the subject of a user, the same
+// precedence Authorize has. It is empty when the request was made by nobody
+// known.
+func Actor(ctx context.Context) string {
+	if k, ok := APIKeyFrom(ctx); ok {
+		return "api-key:" + k.ID
+	}
+	if u, ok := UserFrom(ctx); ok {
+		return u.Subject
+	}
+
+	return ""
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRole_Includes(t *testing.T) {
+	testCases := map[string]struct {
+		role Role
+		need Role
+		want bool
+	}{
+		"When the role is the one needed":       {role: RoleEditor, need: RoleEditor, want: true},
+		"When the role is above the one needed": {role: RoleAdmin, need: RoleViewer, want: true},
+		"When the role is below the one needed": {role: RoleViewer, need: RoleEditor},
+		"When there is no role":                 {role: "", need: RoleViewer},
+		"When the role needed is unknown":       {role: RoleAdmin, need: "owner"},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if got := tc.role.Includes(tc.need); got != tc.want {
+				t.Errorf("expect %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	key := APIKey{ID: "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d", Scopes: []Scope{ScopeMarketsRead}}
+
+	testCases := map[string]struct {
+		ctx  context.Context
+		wErr KindError
+	}{
+		"When the API key has the scope": {
+			ctx: WithAPIKey(context.TODO(), key),
+		},
+		"When the API key lacks the scope": {
+			ctx:  WithAPIKey(context.TODO(), APIKey{Scopes: []Scope{ScopeWebhooks}}),
+			wErr: ForbiddenErrKd,
+		},
+		"When the user has the role": {
+			ctx: WithUser(context.TODO(), User{Subject: "maria", Role: RoleEditor}),
+		},
+		"When the user lacks the role": {
+			ctx:  WithUser(context.TODO(), User{Subject: "maria"}),
+			wErr: ForbiddenErrKd,
+		},
+		"When the API key is checked before the user": {
+			ctx:  WithAPIKey(WithUser(context.TODO(), User{Subject: "maria", Role: RoleAdmin}), APIKey{}),
+			wErr: ForbiddenErrKd,
+		},
+		"When nobody is authenticated": {
+			ctx:  context.TODO(),
+			wErr: UnauthenticatedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			err := Authorize(tc.ctx, ScopeMarketsRead, RoleViewer)
+			switch {
+			case tc.wErr == "" && err != nil:
+				t.Errorf("expect return nil, got %v", err)
+			case tc.wErr != "" && (err == nil || err.Kind != tc.wErr):
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, err)
+			}
+		})
+	}
+}
+
+func TestActor(t *testing.T) {
+	user := User{Subject: "maria", Role: RoleViewer}
+	key := APIKey{ID: "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"}
+
+	testCases := map[string]struct {
+		ctx  context.Context
+		want string
+	}{
+		"When a user made the request": {
+			ctx:  WithUser(context.TODO(), user),
+			want: "maria",
+		},
+		"When an API key made the request": {
+			ctx:  WithAPIKey(context.TODO(), key),
+			want: "api-key:1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+		},
+		"When nobody is authenticated": {
+			ctx: context.TODO(),
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if got := Actor(tc.ctx); got != tc.want {
+				t.Errorf("expect %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
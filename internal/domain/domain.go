@@ -9,4 +9,6 @@ const (
 	LogLevelDebug   = "debug"
 
 	TraceIDCtxKey ctxKey = "trace-id"
+	APIKeyCtxKey  ctxKey = "api-key"
+	UserCtxKey    ctxKey = "user"
 )
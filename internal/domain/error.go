@@ -1,22 +1,141 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
 type KindError string
 
 const (
-	UnexpectedErrKd     KindError = "UNEXPECTED"
-	NothingCreatedErrKd KindError = "NOTHING_CREATED"
-	NothingUpdatedErrKd KindError = "NOTHING_UPDATED"
-	NothingDeletedErrKd KindError = "NOTHING_DELETED"
-	SMNotFoundErrKd     KindError = "STREET_MARKET_NOT_FOUND"
-	InpValidationErrKd  KindError = "INPUT_IS_INVALID"
+	UnexpectedErrKd       KindError = "UNEXPECTED"
+	NothingCreatedErrKd   KindError = "NOTHING_CREATED"
+	NothingUpdatedErrKd   KindError = "NOTHING_UPDATED"
+	NothingDeletedErrKd   KindError = "NOTHING_DELETED"
+	NothingFoundErrKd     KindError = "NOTHING_FOUND"
+	DuplicatedErrKd       KindError = "DUPLICATED"
+	SMNotFoundErrKd       KindError = "STREET_MARKET_NOT_FOUND"
+	InpValidationErrKd    KindError = "INPUT_IS_INVALID"
+	SnapNotFoundErrKd     KindError = "SNAPSHOT_NOT_FOUND"
+	StallNotFoundErrKd    KindError = "STALL_NOT_FOUND"
+	StallDupErrKd         KindError = "STALL_NUMBER_ALREADY_EXISTS"
+	MissingRefErrKd       KindError = "MISSING_REFERENCE"
+	SubTHNotFoundErrKd    KindError = "SUBTOWNHALL_NOT_FOUND"
+	RouteNotFoundErrKd    KindError = "ROUTE_NOT_FOUND"
+	MethodNotAllowedErrKd KindError = "METHOD_NOT_ALLOWED"
+	WebhookNotFoundErrKd  KindError = "WEBHOOK_NOT_FOUND"
+	APIKeyNotFoundErrKd   KindError = "API_KEY_NOT_FOUND"
+	// UnauthenticatedErrKd is a request without valid credentials,
+	// ForbiddenErrKd one whose credentials do not allow it.
+	UnauthenticatedErrKd KindError = "UNAUTHENTICATED"
+	ForbiddenErrKd       KindError = "FORBIDDEN"
+	// RateLimitedErrKd is a request over the rate limit of the client,
+	// OverloadedErrKd one shed as the API is over its concurrency limit.
+	RateLimitedErrKd KindError = "RATE_LIMITED"
+	OverloadedErrKd  KindError = "OVERLOADED"
+	// BodyTooLargeErrKd is a request body over the size the API reads,
+	// UnsupportedMediaTypeErrKd one of a content type it does not read.
+	BodyTooLargeErrKd         KindError = "BODY_TOO_LARGE"
+	UnsupportedMediaTypeErrKd KindError = "UNSUPPORTED_MEDIA_TYPE"
 )
 
+type FieldErrorCode string
+
+const (
+	RequiredFieldCd FieldErrorCode = "REQUIRED"
+	TooLongFieldCd  FieldErrorCode = "TOO_LONG"
+	ConflictFieldCd FieldErrorCode = "CONFLICT"
+	UnknownFieldCd  FieldErrorCode = "UNKNOWN_REFERENCE"
+	// Codes of the violations found validating a request against the OpenAPI
+	// document.
+	InvalidTypeFieldCd   FieldErrorCode = "INVALID_TYPE"
+	UnexpectedFieldCd    FieldErrorCode = "UNEXPECTED_FIELD"
+	TooShortFieldCd      FieldErrorCode = "TOO_SHORT"
+	TooSmallFieldCd      FieldErrorCode = "TOO_SMALL"
+	NotAllowedFieldCd    FieldErrorCode = "NOT_ALLOWED"
+	InvalidFormatFieldCd FieldErrorCode = "INVALID_FORMAT"
+)
+
+// FieldError is a violation of one input field, Field being the name of the
+// input struct field.
+type FieldError struct {
+	Field string
+	Code  FieldErrorCode
+	Msg   string
+}
+
+// Error is the error of the domain. Wrapping errors chain through Previous,
+// while Cause keeps the error from outside the domain, as a driver error, that
+// started the chain.
 type Error struct {
 	Kind     KindError
 	Msg      string
 	Previous *Error
+	Fields   []FieldError
+	Cause    error
 }
 
 func (e *Error) Error() string {
 	return e.Msg
 }
+
+func (e *Error) Unwrap() error {
+	if e.Previous != nil {
+		return e.Previous
+	}
+
+	return e.Cause
+}
+
+// Is matches by kind, so errors.Is(err, SMNotFoundErrKd) tells whether any
+// error of the chain is of that kind.
+func (e *Error) Is(target error) bool {
+	switch t := target.(type) {
+	case KindError:
+		return e.Kind == t
+	case *Error:
+		return t != nil && e.Kind == t.Kind
+	}
+
+	return false
+}
+
+func (k KindError) Error() string {
+	return string(k)
+}
+
+// fieldErrors collects the violations of an input so all of them are reported
+// at once.
+type fieldErrors []FieldError
+
+func (fe *fieldErrors) required(field, v string) {
+	if v == "" {
+		fe.add(field, RequiredFieldCd, fmt.Sprintf("%s is required", field))
+	}
+}
+
+func (fe *fieldErrors) maxLen(field, v string, max int) {
+	if utf8.RuneCountInString(v) > max {
+		fe.add(field, TooLongFieldCd, fmt.Sprintf("%s must have at most %d characters", field, max))
+	}
+}
+
+func (fe *fieldErrors) add(field string, code FieldErrorCode, msg string) {
+	*fe = append(*fe, FieldError{Field: field, Code: code, Msg: msg})
+}
+
+// err returns nil when nothing was collected, otherwise an InpValidationErrKd
+// whose message joins every violation.
+func (fe fieldErrors) err() *Error {
+	if len(fe) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(fe))
+	for _, f := range fe {
+		msgs = append(msgs, f.Msg)
+	}
+
+	return &Error{Kind: InpValidationErrKd, Msg: strings.Join(msgs, "; "), Fields: fe}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestError_Is(t *testing.T) {
+	cause := io.ErrUnexpectedEOF
+	base := &Error{Kind: NothingFoundErrKd, Msg: "0 rows found", Cause: cause}
+	err := error(&Error{Kind: SMNotFoundErrKd, Msg: "Entity not exists", Previous: base})
+
+	testCases := map[string]struct {
+		target error
+		want   bool
+	}{
+		"When matches the kind of the error":        {target: SMNotFoundErrKd, want: true},
+		"When matches the kind of a previous error": {target: NothingFoundErrKd, want: true},
+		"When matches an error of the same kind":    {target: &Error{Kind: SMNotFoundErrKd}, want: true},
+		"When matches the cause":                    {target: io.ErrUnexpectedEOF, want: true},
+		"When no error has the kind":                {target: UnexpectedErrKd, want: false},
+		"When is other error":                       {target: io.EOF, want: false},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if got := errors.Is(err, tc.target); got != tc.want {
+				t.Errorf("expect %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+type causeError struct{ code string }
+
+func (e *causeError) Error() string { return e.code }
+
+func TestError_As(t *testing.T) {
+	base := &Error{Kind: UnexpectedErrKd, Msg: "insert failed", Cause: &causeError{"23505"}}
+	err := error(&Error{Kind: UnexpectedErrKd, Msg: "Unexpected error when create", Previous: base})
+
+	var dErr *Error
+	if !errors.As(err, &dErr) || dErr.Msg != "Unexpected error when create" {
+		t.Errorf("expect the outer error, got %v", dErr)
+	}
+
+	var cErr *causeError
+	if !errors.As(err, &cErr) || cErr.code != "23505" {
+		t.Errorf("expect the cause with code 23505, got %v", cErr)
+	}
+}
+
+func TestError_Unwrap(t *testing.T) {
+	err := &Error{Kind: UnexpectedErrKd, Msg: "no previous nor cause"}
+	if got := err.Unwrap(); got != nil {
+		t.Errorf("expect nil, got %v", got)
+	}
+}
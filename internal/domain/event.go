@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType names a change of a street market, as the partners subscribed to
+// it receive it.
+type EventType string
+
+const (
+	MarketCreatedEvent EventType = "street_market.created"
+	MarketUpdatedEvent EventType = "street_market.updated"
+	MarketDeletedEvent EventType = "street_market.deleted"
+)
+
+func EventTypes() []EventType {
+	return []EventType{MarketCreatedEvent, MarketUpdatedEvent, MarketDeletedEvent}
+}
+
+func (e EventType) Validate() *Error {
+	for _, t := range EventTypes() {
+		if t == e {
+			return nil
+		}
+	}
+
+	return &Error{Kind: InpValidationErrKd, Msg: fmt.Sprintf("%s is not a valid event type", e)}
+}
+
+// MarketEvent is a change of a street market, written along with it and
+// published afterwards. Before is nil on a create and After is nil on a delete.
+// Actor is who made the change, as Actor tells, kept in the outbox as its audit
+// record.
+type MarketEvent struct {
+	ID         string
+	Type       EventType
+	MarketID   string
+	Before     *StreetMarket
+	After      *StreetMarket
+	Actor      string
+	OccurredAt *time.Time
+}
+
+// Market is the street market of e as it is after a create or an update and
+// as it was before a delete.
+func (e MarketEvent) Market() StreetMarket {
+	if e.After != nil {
+		return *e.After
+	}
+	if e.Before != nil {
+		return *e.Before
+	}
+
+	return StreetMarket{ID: e.MarketID}
+}
+
+// OutboxEvent is a MarketEvent not published yet, Attempts being how many
+// times publishing it failed.
+type OutboxEvent struct {
+	Event    MarketEvent
+	Attempts int
+}
+
+// ChangeFilter narrows the events followed to the street markets of a district
+// and of a region, an empty one matching all of them. Names are compared as
+// the street market filters do.
+type ChangeFilter struct {
+	District string
+	Region5  string
+}
+
+// Matches tells whether e is about a street market f wants, either before or
+// after the change, so following a district also tells when a market leaves
+// it.
+func (f ChangeFilter) Matches(e MarketEvent) bool {
+	if e.Before != nil && f.matchesMarket(*e.Before) {
+		return true
+	}
+
+	return f.matchesMarket(e.Market())
+}
+
+func (f ChangeFilter) matchesMarket(sm StreetMarket) bool {
+	if f.District != "" && !sameName(f.District, sm.District) {
+		return false
+	}
+
+	return f.Region5 == "" || sameName(f.Region5, sm.Region5)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEventType_Validate(t *testing.T) {
+	for _, e := range EventTypes() {
+		if err := e.Validate(); err != nil {
+			t.Errorf("expect nil for %s, got %v", e, err)
+		}
+	}
+
+	e := EventType("street_market.moved")
+	if err := e.Validate(); err == nil {
+		t.Error("expect err, got nil")
+	}
+}
+
+func TestMarketEvent_Market(t *testing.T) {
+	before := StreetMarket{ID: "1", Name: "VILA FORMOSA"}
+	after := StreetMarket{ID: "1", Name: "VILA CARRAO"}
+
+	testCases := map[string]struct {
+		event MarketEvent
+		want  StreetMarket
+	}{
+		"When created": {
+			event: MarketEvent{Type: MarketCreatedEvent, MarketID: "1", After: &after},
+			want:  after,
+		},
+		"When updated": {
+			event: MarketEvent{Type: MarketUpdatedEvent, MarketID: "1", Before: &before, After: &after},
+			want:  after,
+		},
+		"When deleted": {
+			event: MarketEvent{Type: MarketDeletedEvent, MarketID: "1", Before: &before},
+			want:  before,
+		},
+		"When it has no payload": {
+			event: MarketEvent{Type: MarketDeletedEvent, MarketID: "1"},
+			want:  StreetMarket{ID: "1"},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.event.Market()); diff != "" {
+				t.Errorf("unexpected street market (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestChangeFilter_Matches(t *testing.T) {
+	before := StreetMarket{ID: "1", District: "VILA FORMOSA", Region5: "Leste"}
+	after := StreetMarket{ID: "1", District: "VILA MARIANA", Region5: "Sul"}
+	moved := MarketEvent{Type: MarketUpdatedEvent, MarketID: "1", Before: &before, After: &after}
+
+	testCases := map[string]struct {
+		filter ChangeFilter
+		event  MarketEvent
+		want   bool
+	}{
+		"When it has no filter": {
+			event: moved,
+			want:  true,
+		},
+		"When the district matches before the change": {
+			filter: ChangeFilter{District: "vila formosa"},
+			event:  moved,
+			want:   true,
+		},
+		"When the district matches after the change": {
+			filter: ChangeFilter{District: "VILA MARIANA", Region5: "sul"},
+			event:  moved,
+			want:   true,
+		},
+		"When the region does not match": {
+			filter: ChangeFilter{Region5: "Norte"},
+			event:  moved,
+		},
+		"When district and region match different sides": {
+			filter: ChangeFilter{District: "VILA FORMOSA", Region5: "Sul"},
+			event:  moved,
+		},
+		"When a deleted market matches": {
+			filter: ChangeFilter{Region5: "Leste"},
+			event:  MarketEvent{Type: MarketDeletedEvent, MarketID: "1", Before: &before},
+			want:   true,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if got := tc.filter.Matches(tc.event); got != tc.want {
+				t.Errorf("expect %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+// MetricKind is how a metric changes, a gauge going up and down and a counter
+// only up.
+type MetricKind string
+
+const (
+	GaugeMetricKd   MetricKind = "gauge"
+	CounterMetricKd MetricKind = "counter"
+)
+
+// Metric is a sample of a measure of the API, as the requests shed, told apart
+// from the other samples of Name by Labels.
+type Metric struct {
+	Name   string
+	Help   string
+	Kind   MetricKind
+	Labels map[string]string
+	Value  float64
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// AuthorizeMarketChange tells whether the caller of ctx may make the change of
+// sm that needs scope from API keys, as ScopeMarketsWrite to edit it or
+// ScopeMarketsDelete to delete it, once Authorize let them call the operation.
+// Users employed by a sub-town hall only change its street markets, while
+// users of the whole city, as AllSubTHs or the admin role tells, change any.
+// Users with neither are refused, so a token missing the claim does not open
+// every street market. API keys are not of a sub-town hall and
+// change any street market only with scope. Changes without a caller are
+// refused.
+func AuthorizeMarketChange(ctx context.Context, sm StreetMarket, scope Scope) *Error {
+	if k, ok := APIKeyFrom(ctx); ok {
+		if k.HasScope(scope) {
+			return nil
+		}
+
+		return &Error{Kind: ForbiddenErrKd, Msg: fmt.Sprintf("The API key does not have the %s scope", scope)}
+	}
+
+	u, ok := UserFrom(ctx)
+	if !ok {
+		return &Error{Kind: UnauthenticatedErrKd, Msg: "An API key or a bearer token is required"}
+	}
+
+	switch {
+	case u.IDSubTH == AllSubTHs, u.IDSubTH == "" && u.Role == RoleAdmin:
+		return nil
+	case u.IDSubTH == "":
+		return &Error{Kind: ForbiddenErrKd, Msg: "The token does not tell the sub-town hall of the user"}
+	case u.IDSubTH == sm.IDSubTH:
+		return nil
+	}
+
+	return &Error{
+		Kind: ForbiddenErrKd,
+		Msg:  fmt.Sprintf("The street market is not of the sub-town hall %s of the user", u.IDSubTH),
+	}
+}
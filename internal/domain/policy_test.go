@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAuthorizeMarketChange(t *testing.T) {
+	sm := StreetMarket{ID: "c882edc1-c1f3-4b20-b8f6-36156d99bc48", IDSubTH: "26"}
+
+	testCases := map[string]struct {
+		ctx   context.Context
+		scope Scope
+		wErr  KindError
+	}{
+		"When the user is of the sub-town hall of the street market": {
+			ctx: WithUser(context.TODO(), User{Subject: "maria", Role: RoleEditor, IDSubTH: "26"}),
+		},
+		"When the user is of another sub-town hall": {
+			ctx:  WithUser(context.TODO(), User{Subject: "maria", Role: RoleEditor, IDSubTH: "25"}),
+			wErr: ForbiddenErrKd,
+		},
+		"When an admin is of another sub-town hall": {
+			ctx:  WithUser(context.TODO(), User{Subject: "maria", Role: RoleAdmin, IDSubTH: "25"}),
+			wErr: ForbiddenErrKd,
+		},
+		"When the user is of the whole city": {
+			ctx: WithUser(context.TODO(), User{Subject: "maria", Role: RoleEditor, IDSubTH: AllSubTHs}),
+		},
+		"When an admin has no sub-town hall": {
+			ctx: WithUser(context.TODO(), User{Subject: "maria", Role: RoleAdmin}),
+		},
+		"When an editor has no sub-town hall": {
+			ctx:  WithUser(context.TODO(), User{Subject: "maria", Role: RoleEditor}),
+			wErr: ForbiddenErrKd,
+		},
+		"When an API key with the write scope edits": {
+			ctx: WithAPIKey(
+				WithUser(context.TODO(), User{Subject: "maria", IDSubTH: "25"}),
+				APIKey{Scopes: []Scope{ScopeMarketsWrite}},
+			),
+			scope: ScopeMarketsWrite,
+		},
+		"When an API key with the delete scope deletes": {
+			ctx:   WithAPIKey(context.TODO(), APIKey{Scopes: []Scope{ScopeMarketsDelete}}),
+			scope: ScopeMarketsDelete,
+		},
+		"When an API key with the write scope deletes": {
+			ctx:   WithAPIKey(context.TODO(), APIKey{Scopes: []Scope{ScopeMarketsWrite}}),
+			scope: ScopeMarketsDelete,
+			wErr:  ForbiddenErrKd,
+		},
+		"When an API key only reads": {
+			ctx:   WithAPIKey(context.TODO(), APIKey{Scopes: []Scope{ScopeMarketsRead, ScopeWebhooks}}),
+			scope: ScopeMarketsWrite,
+			wErr:  ForbiddenErrKd,
+		},
+		"When nobody is authenticated": {
+			ctx:  context.TODO(),
+			wErr: UnauthenticatedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			scope := tc.scope
+			if scope == "" {
+				scope = ScopeMarketsWrite
+			}
+
+			err := AuthorizeMarketChange(tc.ctx, sm, scope)
+			switch {
+			case tc.wErr == "" && err != nil:
+				t.Errorf("expect return nil, got %v", err)
+			case tc.wErr != "" && (err == nil || err.Kind != tc.wErr):
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, err)
+			}
+		})
+	}
+}
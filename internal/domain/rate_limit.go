@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// RateLimit is the bucket of a client after a request took a token of it, or
+// could not. Limit tokens are refilled every Window, Remaining are left and
+// the bucket is full again in Reset. When the request is not Allowed, a token
+// is back in RetryAfter.
+type RateLimit struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Window     time.Duration
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
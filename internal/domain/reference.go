@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// District, SubTownHall and the regions are the administrative reference data
+// of São Paulo, seeded from the DEINFO files. Region5 and Region8 are known by
+// their names, which work as their codes.
+type District struct {
+	ID            string
+	Name          string
+	SubTownHallID string
+}
+
+type SubTownHall struct {
+	ID        string
+	Name      string
+	Region8   string
+	Districts []District
+}
+
+type Region8 struct {
+	Name         string
+	Region5      string
+	SubTownHalls []SubTownHall
+}
+
+type Region5 struct {
+	Name    string
+	Regions []Region8
+}
+
+// Hierarchy is the administrative chain of a street market, from its district
+// up to the 5 regions.
+type Hierarchy struct {
+	IDdist      string
+	District    string
+	IDSubTH     string
+	SubTownHall string
+	Region8     string
+	Region5     string
+}
+
+// Check compares h with ref, the reference chain of the same district, and
+// reports the first pair of fields that do not agree. Names are compared
+// ignoring case and surrounding spaces.
+func (h Hierarchy) Check(ref Hierarchy) *Error {
+	switch {
+	case !sameName(h.District, ref.District):
+		return hierarchyConflict("IDdist", "District", fmt.Sprintf("district %s is %s", ref.IDdist, ref.District))
+	case h.IDSubTH != ref.IDSubTH:
+		return hierarchyConflict(
+			"District", "IDSubTH",
+			fmt.Sprintf("district %s belongs to sub-town hall %s", ref.District, ref.IDSubTH),
+		)
+	case !sameName(h.SubTownHall, ref.SubTownHall):
+		return hierarchyConflict(
+			"IDSubTH", "SubTownHall",
+			fmt.Sprintf("sub-town hall %s is %s", ref.IDSubTH, ref.SubTownHall),
+		)
+	case !sameName(h.Region8, ref.Region8):
+		return hierarchyConflict(
+			"SubTownHall", "Region8",
+			fmt.Sprintf("sub-town hall %s belongs to region %s", ref.SubTownHall, ref.Region8),
+		)
+	case !sameName(h.Region5, ref.Region5):
+		return hierarchyConflict(
+			"Region8", "Region5",
+			fmt.Sprintf("region %s belongs to region %s", ref.Region8, ref.Region5),
+		)
+	}
+
+	return nil
+}
+
+func hierarchyConflict(field, other, detail string) *Error {
+	msg := fmt.Sprintf("%s and %s conflict: %s", field, other, detail)
+
+	return &Error{
+		Kind: InpValidationErrKd,
+		Msg:  msg,
+		Fields: []FieldError{
+			{Field: field, Code: ConflictFieldCd, Msg: msg},
+			{Field: other, Code: ConflictFieldCd, Msg: msg},
+		},
+	}
+}
+
+func sameName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHierarchy_Check(t *testing.T) {
+	ref := Hierarchy{
+		IDdist:      "87",
+		District:    "VILA FORMOSA",
+		IDSubTH:     "26",
+		SubTownHall: "ARICANDUVA-FORMOSA-CARRAO",
+		Region8:     "Leste 1",
+		Region5:     "Leste",
+	}
+
+	h := Hierarchy{
+		IDdist:      "87",
+		District:    "Vila Formosa ",
+		IDSubTH:     "26",
+		SubTownHall: "aricanduva-formosa-carrao",
+		Region8:     "LESTE 1",
+		Region5:     "Leste",
+	}
+
+	if err := h.Check(ref); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+}
+
+func TestHierarchy_Check_Error(t *testing.T) {
+	ref := Hierarchy{
+		IDdist:      "87",
+		District:    "VILA FORMOSA",
+		IDSubTH:     "26",
+		SubTownHall: "ARICANDUVA-FORMOSA-CARRAO",
+		Region8:     "Leste 1",
+		Region5:     "Leste",
+	}
+
+	testCases := map[string]struct {
+		change func(h *Hierarchy)
+		wMsg   string
+	}{
+		"When district name does not match its code": {
+			change: func(h *Hierarchy) { h.District = "VILA MATILDE" },
+			wMsg:   "IDdist and District conflict",
+		},
+		"When district does not belong to the sub-town hall": {
+			change: func(h *Hierarchy) { h.IDSubTH = "21" },
+			wMsg:   "District and IDSubTH conflict",
+		},
+		"When sub-town hall name does not match its code": {
+			change: func(h *Hierarchy) { h.SubTownHall = "PENHA" },
+			wMsg:   "IDSubTH and SubTownHall conflict",
+		},
+		"When sub-town hall does not belong to the region 8": {
+			change: func(h *Hierarchy) { h.Region8 = "Leste 2" },
+			wMsg:   "SubTownHall and Region8 conflict",
+		},
+		"When region 8 does not belong to the region 5": {
+			change: func(h *Hierarchy) { h.Region5 = "Oeste" },
+			wMsg:   "Region8 and Region5 conflict",
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			h := ref
+			tc.change(&h)
+
+			err := h.Check(ref)
+			if err == nil {
+				t.Fatal("expect err, got nil")
+			}
+
+			if err.Kind != InpValidationErrKd {
+				t.Errorf("expect kind %s, got %s", InpValidationErrKd, err.Kind)
+			}
+
+			if !strings.HasPrefix(err.Msg, tc.wMsg) {
+				t.Errorf("expect message starting with %q, got %q", tc.wMsg, err.Msg)
+			}
+		})
+	}
+}
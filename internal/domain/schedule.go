@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ScheduleTimeZone = "America/Sao_Paulo"
+	ScheduleDateFmt  = "2006-01-02"
+
+	minutesPerDay = 24 * 60
+)
+
+// ClockTime is a wall clock time of the day, stored as minutes since midnight.
+type ClockTime int
+
+// ParseClockTime parses HH:MM. 24:00 is the end of the day, so that a market
+// can close at midnight, and String formats it back the same way.
+func ParseClockTime(s string) (ClockTime, *Error) {
+	if s == "24:00" {
+		return minutesPerDay, nil
+	}
+
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, &Error{Kind: InpValidationErrKd, Msg: fmt.Sprintf("%s is not a valid time, use HH:MM", s)}
+	}
+
+	return ClockTime(t.Hour()*60 + t.Minute()), nil
+}
+
+func ClockTimeOf(t time.Time) ClockTime {
+	return ClockTime(t.Hour()*60 + t.Minute())
+}
+
+func (c ClockTime) String() string {
+	return fmt.Sprintf("%02d:%02d", int(c)/60, int(c)%60)
+}
+
+func ParseWeekday(s string) (time.Weekday, *Error) {
+	switch strings.ToLower(s) {
+	case "sunday", "domingo":
+		return time.Sunday, nil
+	case "monday", "segunda":
+		return time.Monday, nil
+	case "tuesday", "terca":
+		return time.Tuesday, nil
+	case "wednesday", "quarta":
+		return time.Wednesday, nil
+	case "thursday", "quinta":
+		return time.Thursday, nil
+	case "friday", "sexta":
+		return time.Friday, nil
+	case "saturday", "sabado":
+		return time.Saturday, nil
+	}
+
+	return 0, &Error{Kind: InpValidationErrKd, Msg: fmt.Sprintf("%s is not a valid weekday", s)}
+}
+
+type ScheduleSlot struct {
+	Weekday time.Weekday
+	Start   ClockTime
+	End     ClockTime
+}
+
+func (s *ScheduleSlot) Validate() *Error {
+	if s.Weekday < time.Sunday || s.Weekday > time.Saturday {
+		return &Error{Kind: InpValidationErrKd, Msg: "Weekday is invalid"}
+	}
+
+	return validateInterval(s.Start, s.End)
+}
+
+// ScheduleException overrides the weekly slots on a specific date, closing the
+// market or opening it on a different interval.
+type ScheduleException struct {
+	Date   time.Time
+	Closed bool
+	Start  ClockTime
+	End    ClockTime
+	Note   string
+}
+
+func (s *ScheduleException) Validate() *Error {
+	if s.Date.IsZero() {
+		return &Error{Kind: InpValidationErrKd, Msg: "Date is required"}
+	}
+	if len(s.Note) > 250 {
+		return &Error{Kind: InpValidationErrKd, Msg: "Note must have at most 250 characters"}
+	}
+	if s.Closed {
+		return nil
+	}
+
+	return validateInterval(s.Start, s.End)
+}
+
+type Schedule struct {
+	StreetMarketID string
+	Slots          []ScheduleSlot
+	Exceptions     []ScheduleException
+}
+
+func (s *Schedule) Validate() *Error {
+	for _, sl := range s.Slots {
+		if err := sl.Validate(); err != nil {
+			return err
+		}
+	}
+
+	dates := map[string]bool{}
+	for _, ex := range s.Exceptions {
+		if err := ex.Validate(); err != nil {
+			return err
+		}
+
+		d := ex.Date.Format(ScheduleDateFmt)
+		if dates[d] {
+			return &Error{Kind: InpValidationErrKd, Msg: fmt.Sprintf("Exception for %s is duplicated", d)}
+		}
+		dates[d] = true
+	}
+
+	return nil
+}
+
+// OpenAt reports whether the market is open at t. The caller must give t in
+// the ScheduleTimeZone location.
+func (s *Schedule) OpenAt(t time.Time) bool {
+	ct := ClockTimeOf(t)
+	d := t.Format(ScheduleDateFmt)
+
+	for _, ex := range s.Exceptions {
+		if ex.Date.Format(ScheduleDateFmt) == d {
+			return !ex.Closed && ex.Start <= ct && ct < ex.End
+		}
+	}
+
+	for _, sl := range s.Slots {
+		if sl.Weekday == t.Weekday() && sl.Start <= ct && ct < sl.End {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateInterval(start, end ClockTime) *Error {
+	if start < 0 || start >= minutesPerDay || end <= 0 || end > minutesPerDay {
+		return &Error{Kind: InpValidationErrKd, Msg: "Start and End must be within the day"}
+	}
+	if start >= end {
+		return &Error{Kind: InpValidationErrKd, Msg: "Start must be before End"}
+	}
+
+	return nil
+}
+
+type ScheduledStreetMarket struct {
+	StreetMarket StreetMarket
+	Schedule     Schedule
+}
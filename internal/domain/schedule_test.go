@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClockTime(t *testing.T) {
+	got, err := ParseClockTime("08:30")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if got != 510 {
+		t.Errorf("expect 510, got %v", got)
+	}
+
+	if got.String() != "08:30" {
+		t.Errorf("expect 08:30, got %s", got.String())
+	}
+}
+
+func TestParseClockTime_EndOfDay(t *testing.T) {
+	got, err := ParseClockTime("24:00")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if got != 1440 {
+		t.Errorf("expect 1440, got %v", got)
+	}
+}
+
+func TestClockTime_String_EndOfDay(t *testing.T) {
+	if got := ClockTime(1440).String(); got != "24:00" {
+		t.Errorf("expect 24:00, got %s", got)
+	}
+}
+
+func TestParseClockTime_Error(t *testing.T) {
+	for _, v := range []string{"", "8h", "25:00", "08:60", "24:01"} {
+		t.Run(v, func(t *testing.T) {
+			_, err := ParseClockTime(v)
+			if err == nil {
+				t.Fatal("expect err, got nil")
+			}
+
+			if err.Kind != InpValidationErrKd {
+				t.Errorf("expect error kind %s,  got %s", InpValidationErrKd, err.Kind)
+			}
+		})
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	testCases := map[string]time.Weekday{
+		"saturday": time.Saturday,
+		"Sabado":   time.Saturday,
+		"SUNDAY":   time.Sunday,
+		"quarta":   time.Wednesday,
+	}
+
+	for v, want := range testCases {
+		t.Run(v, func(t *testing.T) {
+			got, err := ParseWeekday(v)
+			if err != nil {
+				t.Fatalf("expect nil, got %v", err)
+			}
+
+			if got != want {
+				t.Errorf("expect %v, got %v", want, got)
+			}
+		})
+	}
+
+	if _, err := ParseWeekday("someday"); err == nil {
+		t.Error("expect err, got nil")
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	sch := Schedule{
+		Slots: []ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+		Exceptions: []ScheduleException{
+			{Date: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), Closed: true},
+			{Date: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), Start: 360, End: 660},
+		},
+	}
+
+	if err := sch.Validate(); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+}
+
+func TestSchedule_Validate_Error(t *testing.T) {
+	christmas := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	testCases := map[string]Schedule{
+		"When weekday is invalid": {
+			Slots: []ScheduleSlot{{Weekday: 7, Start: 420, End: 780}},
+		},
+		"When start is after end": {
+			Slots: []ScheduleSlot{{Weekday: time.Saturday, Start: 780, End: 420}},
+		},
+		"When exception has no date": {
+			Exceptions: []ScheduleException{{Closed: true}},
+		},
+		"When open exception has no interval": {
+			Exceptions: []ScheduleException{{Date: christmas}},
+		},
+		"When exception is duplicated": {
+			Exceptions: []ScheduleException{{Date: christmas, Closed: true}, {Date: christmas, Closed: true}},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			err := tc.Validate()
+			if err == nil {
+				t.Fatal("expect err, got nil")
+			}
+
+			if err.Kind != InpValidationErrKd {
+				t.Errorf("expect error kind %s,  got %s", InpValidationErrKd, err.Kind)
+			}
+		})
+	}
+}
+
+func TestSchedule_OpenAt(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	sch := Schedule{
+		Slots: []ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+		Exceptions: []ScheduleException{
+			{Date: time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC), Closed: true},
+			{Date: time.Date(2026, 10, 28, 0, 0, 0, 0, time.UTC), Start: 360, End: 660},
+		},
+	}
+
+	testCases := map[string]struct {
+		at   time.Time
+		want bool
+	}{
+		"When saturday inside the slot":   {at: time.Date(2026, 10, 17, 8, 0, 0, 0, loc), want: true},
+		"When saturday at the slot end":   {at: time.Date(2026, 10, 17, 13, 0, 0, 0, loc), want: false},
+		"When sunday":                     {at: time.Date(2026, 10, 18, 8, 0, 0, 0, loc), want: false},
+		"When saturday closed by holiday": {at: time.Date(2026, 10, 24, 8, 0, 0, 0, loc), want: false},
+		"When wednesday opened by except": {at: time.Date(2026, 10, 28, 8, 0, 0, 0, loc), want: true},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if got := sch.OpenAt(tc.at); got != tc.want {
+				t.Errorf("expect %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import "fmt"
+
+const (
+	FirstSnapshotEdition = 2003
+	LastSnapshotEdition  = 2099
+)
+
+type StreetMarketSnapshot struct {
+	Edition       int
+	Long          float64
+	Lat           float64
+	SectCens      string
+	Area          string
+	IDdist        string
+	District      string
+	IDSubTH       string
+	SubTownHall   string
+	Region5       string
+	Region8       string
+	Name          string
+	Register      string
+	Street        string
+	Number        string
+	Neighborhood  string
+	AddrExtraInfo string
+}
+
+func (s *StreetMarketSnapshot) Relocated(to StreetMarketSnapshot) bool {
+	return s.Long != to.Long ||
+		s.Lat != to.Lat ||
+		s.Street != to.Street ||
+		s.Number != to.Number ||
+		s.Neighborhood != to.Neighborhood
+}
+
+func (s *StreetMarketSnapshot) Renamed(to StreetMarketSnapshot) bool {
+	return s.Name != to.Name
+}
+
+type SnapshotDiffInput struct {
+	From int
+	To   int
+}
+
+func (d *SnapshotDiffInput) Validate() *Error {
+	if d.From < FirstSnapshotEdition || d.From > LastSnapshotEdition {
+		return &Error{
+			Kind: InpValidationErrKd,
+			Msg:  fmt.Sprintf("From must be an edition year since %v", FirstSnapshotEdition),
+		}
+	}
+	if d.To < FirstSnapshotEdition || d.To > LastSnapshotEdition {
+		return &Error{
+			Kind: InpValidationErrKd,
+			Msg:  fmt.Sprintf("To must be an edition year since %v", FirstSnapshotEdition),
+		}
+	}
+	if d.From == d.To {
+		return &Error{Kind: InpValidationErrKd, Msg: "From and To must be different editions"}
+	}
+
+	return nil
+}
+
+type SnapshotChange struct {
+	Before StreetMarketSnapshot
+	After  StreetMarketSnapshot
+}
+
+type SnapshotDiff struct {
+	From      int
+	To        int
+	Added     []StreetMarketSnapshot
+	Removed   []StreetMarketSnapshot
+	Relocated []SnapshotChange
+	Renamed   []SnapshotChange
+}
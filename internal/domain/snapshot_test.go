@@ -0,0 +1,90 @@
+package domain
+
+import "testing"
+
+func TestSnapshotDiffInput_Validate(t *testing.T) {
+	inp := SnapshotDiffInput{From: 2003, To: 2014}
+
+	if err := inp.Validate(); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+}
+
+func TestSnapshotDiffInput_Validate_Error(t *testing.T) {
+	testCases := map[string]SnapshotDiffInput{
+		"When is empty":          {},
+		"When From is too old":   {From: 1999, To: 2014},
+		"When To is empty":       {From: 2003},
+		"When editions are same": {From: 2010, To: 2010},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			err := tc.Validate()
+			if err == nil {
+				t.Fatal("expect err, got nil")
+			}
+
+			if err.Kind != InpValidationErrKd {
+				t.Errorf("expect error kind %s,  got %s", InpValidationErrKd, err.Kind)
+			}
+		})
+	}
+}
+
+func TestStreetMarketSnapshot_Relocated(t *testing.T) {
+	before := StreetMarketSnapshot{
+		Long:         -46548146,
+		Lat:          -23568390,
+		Street:       "RUA CODAJ-S",
+		Number:       "45",
+		Neighborhood: "VILA FORMOSA",
+	}
+
+	testCases := map[string]struct {
+		after StreetMarketSnapshot
+		want  bool
+	}{
+		"When nothing changes": {after: before, want: false},
+		"When coordinates change": {
+			after: StreetMarketSnapshot{
+				Long:         -46550164,
+				Lat:          -23558733,
+				Street:       before.Street,
+				Number:       before.Number,
+				Neighborhood: before.Neighborhood,
+			},
+			want: true,
+		},
+		"When address changes": {
+			after: StreetMarketSnapshot{
+				Long:         before.Long,
+				Lat:          before.Lat,
+				Street:       "RUA MARAGOJIPE",
+				Number:       "S/N",
+				Neighborhood: before.Neighborhood,
+			},
+			want: true,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if got := before.Relocated(tc.after); got != tc.want {
+				t.Errorf("expect %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestStreetMarketSnapshot_Renamed(t *testing.T) {
+	before := StreetMarketSnapshot{Name: "PRACA LEAO X"}
+
+	if before.Renamed(StreetMarketSnapshot{Name: "PRACA LEAO X"}) {
+		t.Error("expect not renamed, got renamed")
+	}
+
+	if !before.Renamed(StreetMarketSnapshot{Name: "VILA FORMOSA"}) {
+		t.Error("expect renamed, got not renamed")
+	}
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductCategory string
+
+const (
+	FruitsCategory     ProductCategory = "fruits"
+	VegetablesCategory ProductCategory = "vegetables"
+	GreensCategory     ProductCategory = "greens"
+	FishCategory       ProductCategory = "fish"
+	SeafoodCategory    ProductCategory = "seafood"
+	MeatCategory       ProductCategory = "meat"
+	PoultryCategory    ProductCategory = "poultry"
+	EggsCategory       ProductCategory = "eggs"
+	DairyCategory      ProductCategory = "dairy"
+	PastelCategory     ProductCategory = "pastel"
+	BakeryCategory     ProductCategory = "bakery"
+	SpicesCategory     ProductCategory = "spices"
+	GrainsCategory     ProductCategory = "grains"
+	FlowersCategory    ProductCategory = "flowers"
+	ClothingCategory   ProductCategory = "clothing"
+	HouseholdCategory  ProductCategory = "household"
+	OtherCategory      ProductCategory = "other"
+)
+
+func ProductCategories() []ProductCategory {
+	return []ProductCategory{
+		FruitsCategory,
+		VegetablesCategory,
+		GreensCategory,
+		FishCategory,
+		SeafoodCategory,
+		MeatCategory,
+		PoultryCategory,
+		EggsCategory,
+		DairyCategory,
+		PastelCategory,
+		BakeryCategory,
+		SpicesCategory,
+		GrainsCategory,
+		FlowersCategory,
+		ClothingCategory,
+		HouseholdCategory,
+		OtherCategory,
+	}
+}
+
+func (p ProductCategory) Validate() *Error {
+	for _, c := range ProductCategories() {
+		if c == p {
+			return nil
+		}
+	}
+
+	return &Error{Kind: InpValidationErrKd, Msg: fmt.Sprintf("%s is not a valid product category", p)}
+}
+
+type StallID string
+
+func (s *StallID) Validate() *Error {
+	if _, err := uuid.Parse(string(*s)); err != nil {
+		return &Error{Kind: InpValidationErrKd, Msg: err.Error()}
+	}
+
+	return nil
+}
+
+const (
+	stallVendorNameMaxLen    = 100
+	stallLicenseNumberMaxLen = 50
+)
+
+type Stall struct {
+	ID             string
+	StreetMarketID string
+	Number         int
+	VendorName     string
+	LicenseNumber  string
+	Categories     []ProductCategory
+	CreatedAt      *time.Time
+}
+
+type StallCreateInput struct {
+	Number        int
+	VendorName    string
+	LicenseNumber string
+	Categories    []ProductCategory
+}
+
+func (d *StallCreateInput) Validate() *Error {
+	var fe fieldErrors
+
+	if d.Number <= 0 {
+		fe.add("Number", RequiredFieldCd, "Number is required")
+	}
+	fe.required("VendorName", d.VendorName)
+	fe.required("LicenseNumber", d.LicenseNumber)
+	if len(d.Categories) == 0 {
+		fe.add("Categories", RequiredFieldCd, "Categories is required")
+	}
+	validateStallFields(&fe, d.VendorName, d.LicenseNumber, d.Categories)
+
+	return fe.err()
+}
+
+// StallEditInput holds the fields to change, zero values are left untouched
+// and a nil Categories keeps the current ones.
+type StallEditInput struct {
+	Number        int
+	VendorName    string
+	LicenseNumber string
+	Categories    []ProductCategory
+}
+
+func (d *StallEditInput) Validate() *Error {
+	var fe fieldErrors
+
+	if d.Number < 0 {
+		fe.add("Number", TooSmallFieldCd, "Number must be positive")
+	}
+	if d.Categories != nil && len(d.Categories) == 0 {
+		fe.add("Categories", RequiredFieldCd, "Categories can not be empty")
+	}
+	validateStallFields(&fe, d.VendorName, d.LicenseNumber, d.Categories)
+
+	return fe.err()
+}
+
+func validateStallFields(fe *fieldErrors, vendorName, licenseNumber string, categories []ProductCategory) {
+	fe.maxLen("VendorName", vendorName, stallVendorNameMaxLen)
+	fe.maxLen("LicenseNumber", licenseNumber, stallLicenseNumberMaxLen)
+
+	seen := map[ProductCategory]bool{}
+	for _, c := range categories {
+		if err := c.Validate(); err != nil {
+			fe.add("Categories", NotAllowedFieldCd, err.Msg)
+			continue
+		}
+		if seen[c] {
+			fe.add("Categories", ConflictFieldCd, fmt.Sprintf("Category %s is duplicated", c))
+		}
+		seen[c] = true
+	}
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProductCategory_Validate(t *testing.T) {
+	for _, c := range ProductCategories() {
+		if err := c.Validate(); err != nil {
+			t.Errorf("expect nil for %s, got %v", c, err)
+		}
+	}
+
+	pc := ProductCategory("cars")
+	if err := pc.Validate(); err == nil {
+		t.Error("expect err, got nil")
+	}
+}
+
+func TestStallCreateInput_Validate(t *testing.T) {
+	inp := StallCreateInput{
+		Number:        1,
+		VendorName:    "Maria da Silva",
+		LicenseNumber: "TPU-2022-0001",
+		Categories:    []ProductCategory{FruitsCategory, GreensCategory},
+	}
+
+	if err := inp.Validate(); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+
+	t.Run("When the names have accents up to the limit", func(t *testing.T) {
+		inp := inp
+		inp.VendorName = "João Conceição " + strings.Repeat("ã", 85)
+		inp.LicenseNumber = strings.Repeat("ç", 50)
+
+		if err := inp.Validate(); err != nil {
+			t.Errorf("expect nil, got %v", err)
+		}
+	})
+}
+
+func TestStallCreateInput_Validate_Fields(t *testing.T) {
+	inp := StallCreateInput{
+		VendorName: strings.Repeat("a", 101),
+		Categories: []ProductCategory{"cars", FishCategory, FishCategory},
+	}
+
+	want := []FieldError{
+		{Field: "Number", Code: RequiredFieldCd, Msg: "Number is required"},
+		{Field: "LicenseNumber", Code: RequiredFieldCd, Msg: "LicenseNumber is required"},
+		{Field: "VendorName", Code: TooLongFieldCd, Msg: "VendorName must have at most 100 characters"},
+		{Field: "Categories", Code: NotAllowedFieldCd, Msg: "cars is not a valid product category"},
+		{Field: "Categories", Code: ConflictFieldCd, Msg: "Category fish is duplicated"},
+	}
+
+	err := inp.Validate()
+	if err == nil {
+		t.Fatal("expect err, got nil")
+	}
+
+	if diff := cmp.Diff(want, err.Fields); diff != "" {
+		t.Errorf("field errors mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStallCreateInput_Validate_Error(t *testing.T) {
+	valid := func() StallCreateInput {
+		return StallCreateInput{
+			Number:        1,
+			VendorName:    "Maria da Silva",
+			LicenseNumber: "TPU-2022-0001",
+			Categories:    []ProductCategory{FruitsCategory},
+		}
+	}
+
+	testCases := map[string]func(*StallCreateInput){
+		"Number is missing":        func(i *StallCreateInput) { i.Number = 0 },
+		"VendorName is missing":    func(i *StallCreateInput) { i.VendorName = "" },
+		"LicenseNumber is missing": func(i *StallCreateInput) { i.LicenseNumber = "" },
+		"Categories is missing":    func(i *StallCreateInput) { i.Categories = nil },
+		"VendorName is too long":   func(i *StallCreateInput) { i.VendorName = strings.Repeat("a", 101) },
+		"LicenseNumber is too long": func(i *StallCreateInput) {
+			i.LicenseNumber = strings.Repeat("a", 51)
+		},
+		"Category is invalid": func(i *StallCreateInput) { i.Categories = []ProductCategory{"cars"} },
+		"Category is duplicated": func(i *StallCreateInput) {
+			i.Categories = []ProductCategory{FishCategory, FishCategory}
+		},
+	}
+
+	for title, change := range testCases {
+		t.Run(title, func(t *testing.T) {
+			inp := valid()
+			change(&inp)
+
+			err := inp.Validate()
+			if err == nil {
+				t.Fatal("expect err, got nil")
+			}
+
+			if err.Kind != InpValidationErrKd {
+				t.Errorf("expect error kind %s,  got %s", InpValidationErrKd, err.Kind)
+			}
+		})
+	}
+}
+
+func TestStallEditInput_Validate(t *testing.T) {
+	testCases := map[string]struct {
+		inp     StallEditInput
+		wantErr bool
+	}{
+		"Empty input":             {inp: StallEditInput{}},
+		"Categories replaced":     {inp: StallEditInput{Categories: []ProductCategory{EggsCategory}}},
+		"Negative number":         {inp: StallEditInput{Number: -1}, wantErr: true},
+		"Empty categories":        {inp: StallEditInput{Categories: []ProductCategory{}}, wantErr: true},
+		"Invalid category":        {inp: StallEditInput{Categories: []ProductCategory{"cars"}}, wantErr: true},
+		"VendorName is too long":  {inp: StallEditInput{VendorName: strings.Repeat("a", 101)}, wantErr: true},
+		"VendorName with accents": {inp: StallEditInput{VendorName: strings.Repeat("ã", 100)}},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			err := tc.inp.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("expect err, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("expect nil, got %v", err)
+			}
+		})
+	}
+}
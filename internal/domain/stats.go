@@ -0,0 +1,56 @@
+package domain
+
+import "fmt"
+
+// StatsGroupBy is the street market column the stats are grouped by.
+type StatsGroupBy string
+
+const (
+	DistrictGroup    StatsGroupBy = "district"
+	SubTownHallGroup StatsGroupBy = "subtownhall"
+	Region5Group     StatsGroupBy = "region5"
+	Region8Group     StatsGroupBy = "region8"
+)
+
+func (g StatsGroupBy) Validate() *Error {
+	switch g {
+	case DistrictGroup, SubTownHallGroup, Region5Group, Region8Group:
+		return nil
+	}
+
+	return &Error{Kind: InpValidationErrKd, Msg: fmt.Sprintf("%s is not a valid group", g)}
+}
+
+// StreetMarketStatsInput counts the street markets matching Filter. A zero
+// Snapshot counts the current markets, otherwise the markets of that DEINFO
+// edition, which only carry the address filters.
+type StreetMarketStatsInput struct {
+	GroupBy  StatsGroupBy
+	Filter   StreetMarketFilter
+	Snapshot int
+}
+
+func (d *StreetMarketStatsInput) Validate() *Error {
+	if err := d.GroupBy.Validate(); err != nil {
+		return err
+	}
+	if d.Snapshot == 0 {
+		return nil
+	}
+	if d.Snapshot < FirstSnapshotEdition || d.Snapshot > LastSnapshotEdition {
+		return &Error{
+			Kind: InpValidationErrKd,
+			Msg:  fmt.Sprintf("Snapshot must be an edition year since %v", FirstSnapshotEdition),
+		}
+	}
+	if d.Filter.OpenOn != nil || d.Filter.OpenAt != nil || d.Filter.Sells != "" {
+		return &Error{Kind: InpValidationErrKd, Msg: "Schedule and stall filters can not be used with a snapshot"}
+	}
+
+	return nil
+}
+
+type StreetMarketCount struct {
+	Group string
+	Count int
+}
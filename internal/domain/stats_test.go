@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreetMarketStatsInput_Validate(t *testing.T) {
+	openOn := time.Saturday
+
+	testCases := map[string]struct {
+		inp     StreetMarketStatsInput
+		wantErr bool
+	}{
+		"Current markets": {
+			inp: StreetMarketStatsInput{GroupBy: Region5Group, Filter: StreetMarketFilter{OpenOn: &openOn}},
+		},
+		"Snapshot edition": {
+			inp: StreetMarketStatsInput{GroupBy: DistrictGroup, Snapshot: 2014},
+		},
+		"Invalid group": {
+			inp:     StreetMarketStatsInput{GroupBy: "street"},
+			wantErr: true,
+		},
+		"Missing group": {
+			inp:     StreetMarketStatsInput{},
+			wantErr: true,
+		},
+		"Invalid snapshot": {
+			inp:     StreetMarketStatsInput{GroupBy: Region8Group, Snapshot: 14},
+			wantErr: true,
+		},
+		"Snapshot with schedule filter": {
+			inp: StreetMarketStatsInput{
+				GroupBy:  SubTownHallGroup,
+				Filter:   StreetMarketFilter{OpenOn: &openOn},
+				Snapshot: 2014,
+			},
+			wantErr: true,
+		},
+		"Snapshot with stall filter": {
+			inp: StreetMarketStatsInput{
+				GroupBy:  SubTownHallGroup,
+				Filter:   StreetMarketFilter{Sells: FishCategory},
+				Snapshot: 2014,
+			},
+			wantErr: true,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			err := tc.inp.Validate()
+			if tc.wantErr && (err == nil || err.Kind != InpValidationErrKd) {
+				t.Errorf("expect error kind %s, got %v", InpValidationErrKd, err)
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("expect nil, got %v", err)
+			}
+		})
+	}
+}
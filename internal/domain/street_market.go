@@ -21,6 +21,9 @@ type StreetMarketFilter struct {
 	Region5      string
 	Name         string
 	Neighborhood string
+	OpenOn       *time.Weekday
+	OpenAt       *time.Time
+	Sells        ProductCategory
 }
 
 type StreetMarket struct {
@@ -42,6 +45,7 @@ type StreetMarket struct {
 	Neighborhood  string
 	AddrExtraInfo string
 	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
 }
 
 type StreetMarketCreateInput struct {
@@ -63,57 +67,58 @@ type StreetMarketCreateInput struct {
 	AddrExtraInfo string
 }
 
+// Column sizes of the street_market table.
+const (
+	smFieldMaxLen         = 50
+	smAddrExtraInfoMaxLen = 250
+)
+
+// Validate reports every missing or overlong field at once.
 func (d *StreetMarketCreateInput) Validate() *Error {
+	fe := fieldErrors{}
+
 	if d.Long == 0.0 {
-		return &Error{Kind: InpValidationErrKd, Msg: "Long is required"}
+		fe.add("Long", RequiredFieldCd, "Long is required")
 	}
 	if d.Lat == 0.0 {
-		return &Error{Kind: InpValidationErrKd, Msg: "Lat is required"}
-	}
-	if d.SectCens == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "SectCens is required"}
-	}
-	if d.Area == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "Area is required"}
-	}
-	if d.IDdist == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "IDdist is required"}
-	}
-	if d.District == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "District is required"}
-	}
-	if d.IDSubTH == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "IDSubTH is required"}
-	}
-	if d.SubTownHall == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "SubTownHall is required"}
-	}
-	if d.Region5 == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "Region5 is required"}
-	}
-	if d.Region8 == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "Region8 is required"}
-	}
-	if d.Name == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "Name is required"}
-	}
-	if d.Register == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "Register is required"}
+		fe.add("Lat", RequiredFieldCd, "Lat is required")
 	}
-	if d.Street == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "Street is required"}
+	for _, f := range d.textFields() {
+		fe.required(f.name, f.value)
 	}
-	if d.Number == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "Number is required"}
-	}
-	if d.Neighborhood == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "Neighborhood is required"}
-	}
-	if d.AddrExtraInfo == "" {
-		return &Error{Kind: InpValidationErrKd, Msg: "AddrExtraInfo is required"}
+	validateStreetMarketLengths(&fe, d.textFields())
+
+	return fe.err()
+}
+
+func (d *StreetMarketCreateInput) textFields() []textField {
+	return []textField{
+		{"SectCens", d.SectCens},
+		{"Area", d.Area},
+		{"IDdist", d.IDdist},
+		{"District", d.District},
+		{"IDSubTH", d.IDSubTH},
+		{"SubTownHall", d.SubTownHall},
+		{"Region5", d.Region5},
+		{"Region8", d.Region8},
+		{"Name", d.Name},
+		{"Register", d.Register},
+		{"Street", d.Street},
+		{"Number", d.Number},
+		{"Neighborhood", d.Neighborhood},
+		{"AddrExtraInfo", d.AddrExtraInfo},
 	}
+}
 
-	return nil
+func (d *StreetMarketCreateInput) Hierarchy() Hierarchy {
+	return Hierarchy{
+		IDdist:      d.IDdist,
+		District:    d.District,
+		IDSubTH:     d.IDSubTH,
+		SubTownHall: d.SubTownHall,
+		Region8:     d.Region8,
+		Region5:     d.Region5,
+	}
 }
 
 type StreetMarketEditInput struct {
@@ -135,6 +140,109 @@ type StreetMarketEditInput struct {
 	AddrExtraInfo string
 }
 
+// Validate reports every overlong field at once. Every field is optional, an
+// empty one is left unchanged.
+func (d *StreetMarketEditInput) Validate() *Error {
+	fe := fieldErrors{}
+
+	// Both inputs have the same fields, the conversion only reuses the list.
+	c := StreetMarketCreateInput(*d)
+	validateStreetMarketLengths(&fe, c.textFields())
+
+	return fe.err()
+}
+
+type textField struct {
+	name  string
+	value string
+}
+
+func validateStreetMarketLengths(fe *fieldErrors, fields []textField) {
+	for _, f := range fields {
+		max := smFieldMaxLen
+		if f.name == "AddrExtraInfo" {
+			max = smAddrExtraInfoMaxLen
+		}
+		fe.maxLen(f.name, f.value, max)
+	}
+}
+
+// Hierarchy returns the administrative chain of sm with the fields set in the
+// input replacing the current ones.
+func (d *StreetMarketEditInput) Hierarchy(sm StreetMarket) Hierarchy {
+	h := Hierarchy{
+		IDdist:      sm.IDdist,
+		District:    sm.District,
+		IDSubTH:     sm.IDSubTH,
+		SubTownHall: sm.SubTownHall,
+		Region8:     sm.Region8,
+		Region5:     sm.Region5,
+	}
+
+	if d.IDdist != "" {
+		h.IDdist = d.IDdist
+	}
+	if d.District != "" {
+		h.District = d.District
+	}
+	if d.IDSubTH != "" {
+		h.IDSubTH = d.IDSubTH
+	}
+	if d.SubTownHall != "" {
+		h.SubTownHall = d.SubTownHall
+	}
+	if d.Region8 != "" {
+		h.Region8 = d.Region8
+	}
+	if d.Region5 != "" {
+		h.Region5 = d.Region5
+	}
+
+	return h
+}
+
+// Apply returns sm with the fields set in the input replacing the current ones,
+// the street market as it is after the edit.
+func (d *StreetMarketEditInput) Apply(sm StreetMarket) StreetMarket {
+	if d.Long != 0 {
+		sm.Long = d.Long
+	}
+	if d.Lat != 0 {
+		sm.Lat = d.Lat
+	}
+
+	fields := map[*string]string{
+		&sm.SectCens:      d.SectCens,
+		&sm.Area:          d.Area,
+		&sm.IDdist:        d.IDdist,
+		&sm.District:      d.District,
+		&sm.IDSubTH:       d.IDSubTH,
+		&sm.SubTownHall:   d.SubTownHall,
+		&sm.Region5:       d.Region5,
+		&sm.Region8:       d.Region8,
+		&sm.Name:          d.Name,
+		&sm.Register:      d.Register,
+		&sm.Street:        d.Street,
+		&sm.Number:        d.Number,
+		&sm.Neighborhood:  d.Neighborhood,
+		&sm.AddrExtraInfo: d.AddrExtraInfo,
+	}
+	for cur, v := range fields {
+		if v != "" {
+			*cur = v
+		}
+	}
+
+	return sm
+}
+
+// ChangesHierarchy tells whether the input edits any field of the
+// administrative chain.
+func (d *StreetMarketEditInput) ChangesHierarchy() bool {
+	return d.IDdist != "" || d.District != "" || d.IDSubTH != "" ||
+		d.SubTownHall != "" || d.Region8 != "" || d.Region5 != ""
+}
+
 type Pagination struct {
 	Offset int
 	Limit  int
@@ -1,7 +1,10 @@
 package domain
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestSMID_Validate(t *testing.T) {
@@ -211,3 +214,103 @@ func TestStreetMarketCreateInput_Validate_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestStreetMarketCreateInput_Validate_AllFields(t *testing.T) {
+	create := StreetMarketCreateInput{
+		Long:          -46548146,
+		SectCens:      "355030885000019",
+		Area:          "3550308005040",
+		IDdist:        "87",
+		District:      "VILA FORMOSA",
+		IDSubTH:       "26",
+		SubTownHall:   "ARICANDUVA",
+		Region5:       "Leste",
+		Region8:       "Leste 1",
+		Name:          strings.Repeat("a", 51),
+		Register:      "1129-0",
+		Street:        "Rua dos Bobos",
+		Number:        "500",
+		AddrExtraInfo: strings.Repeat("é", 251),
+	}
+
+	err := create.Validate()
+	if err == nil {
+		t.Fatal("expect err, got nil")
+	}
+
+	want := []FieldError{
+		{Field: "Lat", Code: RequiredFieldCd, Msg: "Lat is required"},
+		{Field: "Neighborhood", Code: RequiredFieldCd, Msg: "Neighborhood is required"},
+		{Field: "Name", Code: TooLongFieldCd, Msg: "Name must have at most 50 characters"},
+		{Field: "AddrExtraInfo", Code: TooLongFieldCd, Msg: "AddrExtraInfo must have at most 250 characters"},
+	}
+	if diff := cmp.Diff(want, err.Fields); diff != "" {
+		t.Errorf("unexpected field errors (-want +got):\n%s", diff)
+	}
+
+	if err.Kind != InpValidationErrKd {
+		t.Errorf("expect error kind %s, got %s", InpValidationErrKd, err.Kind)
+	}
+}
+
+func TestStreetMarketEditInput_Validate(t *testing.T) {
+	edit := StreetMarketEditInput{
+		Name:          "RAPOSO TAVARES",
+		AddrExtraInfo: strings.Repeat("é", 250),
+	}
+
+	if err := edit.Validate(); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+
+	if err := (&StreetMarketEditInput{}).Validate(); err != nil {
+		t.Errorf("expect nil for an empty edit, got %v", err)
+	}
+}
+
+func TestStreetMarketEditInput_Validate_Error(t *testing.T) {
+	edit := StreetMarketEditInput{
+		Street: strings.Repeat("a", 51),
+		Number: strings.Repeat("1", 51),
+	}
+
+	err := edit.Validate()
+	if err == nil {
+		t.Fatal("expect err, got nil")
+	}
+
+	want := []FieldError{
+		{Field: "Street", Code: TooLongFieldCd, Msg: "Street must have at most 50 characters"},
+		{Field: "Number", Code: TooLongFieldCd, Msg: "Number must have at most 50 characters"},
+	}
+	if diff := cmp.Diff(want, err.Fields); diff != "" {
+		t.Errorf("unexpected field errors (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreetMarketEditInput_Apply(t *testing.T) {
+	sm := StreetMarket{
+		ID:       "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		Long:     -46548146,
+		Lat:      -23568390,
+		IDdist:   "87",
+		District: "VILA FORMOSA",
+		Name:     "RAPOSO TAVARES",
+		Street:   "Rua dos Bobos",
+	}
+	edit := StreetMarketEditInput{Lat: -23568391, Name: "VILA FORMOSA", Neighborhood: "JARDIM SARAH"}
+
+	want := StreetMarket{
+		ID:           sm.ID,
+		Long:         sm.Long,
+		Lat:          -23568391,
+		IDdist:       "87",
+		District:     "VILA FORMOSA",
+		Name:         "VILA FORMOSA",
+		Street:       "Rua dos Bobos",
+		Neighborhood: "JARDIM SARAH",
+	}
+	if diff := cmp.Diff(want, edit.Apply(sm)); diff != "" {
+		t.Errorf("unexpected street market (-want +got):\n%s", diff)
+	}
+}
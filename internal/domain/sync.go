@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyncToken tells how far a client synced the street markets. It is opaque to
+// clients, which send it back as they got it on their next sync.
+type SyncToken string
+
+func NewSyncToken(position int64) SyncToken {
+	return SyncCursor{Position: position}.Token()
+}
+
+// SyncCursor is where a sync stands. Position is where its changes are read
+// from, zero for every street market. While they are paged, Next is the
+// position of the following sync and AfterTxID and AfterID are the key of the
+// last street market responded, which the next page starts after.
+type SyncCursor struct {
+	Position  int64
+	Next      int64
+	AfterTxID int64
+	AfterID   string
+}
+
+// Paging tells whether c is in the middle of the changes of a sync.
+func (c SyncCursor) Paging() bool {
+	return c.AfterID != ""
+}
+
+func (c SyncCursor) Token() SyncToken {
+	s := strconv.FormatInt(c.Position, 10)
+	if c.Paging() {
+		s = fmt.Sprintf("%d.%d.%d.%s", c.Position, c.Next, c.AfterTxID, c.AfterID)
+	}
+
+	return SyncToken(base64.RawURLEncoding.EncodeToString([]byte(s)))
+}
+
+// Cursor is where the sync that issued t stopped, the zero cursor for an empty
+// token.
+func (t SyncToken) Cursor() (SyncCursor, *Error) {
+	if t == "" {
+		return SyncCursor{}, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(string(t))
+	if err != nil {
+		return SyncCursor{}, &Error{Kind: InpValidationErrKd, Msg: "since is not a sync token", Cause: err}
+	}
+
+	parts := strings.Split(string(b), ".")
+	if len(parts) != 1 && len(parts) != 4 {
+		return SyncCursor{}, &Error{Kind: InpValidationErrKd, Msg: "since is not a sync token"}
+	}
+
+	nums := make([]int64, 3)
+	for i := 0; i < len(parts) && i < len(nums); i++ {
+		if nums[i], err = strconv.ParseInt(parts[i], 10, 64); err != nil || nums[i] < 0 {
+			return SyncCursor{}, &Error{Kind: InpValidationErrKd, Msg: "since is not a sync token", Cause: err}
+		}
+	}
+
+	c := SyncCursor{Position: nums[0]}
+	valid := c.Position > 0
+	if len(parts) == 4 {
+		c.Next, c.AfterTxID, c.AfterID = nums[1], nums[2], parts[3]
+		// Only a sync of every street market pages from position zero.
+		valid = c.Paging() && c.Next > 0 && c.AfterTxID > 0
+	}
+	if !valid {
+		return SyncCursor{}, &Error{Kind: InpValidationErrKd, Msg: "since is not a sync token"}
+	}
+
+	return c, nil
+}
+
+// Tombstone is a deleted street market, so clients can drop their copy.
+type Tombstone struct {
+	ID        string
+	DeletedAt *time.Time
+}
+
+// StreetMarketChanges are a page of the street markets created or updated
+// since a sync and of the ones deleted since, and the Cursor of the next page
+// or, after the last one, of the next sync.
+type StreetMarketChanges struct {
+	Markets []StreetMarket
+	Deleted []Tombstone
+	Cursor  SyncCursor
+}
+
+// Next is the token of the next page or sync.
+func (c StreetMarketChanges) Next() SyncToken {
+	return c.Cursor.Token()
+}
+
+// More tells whether the sync has pages left.
+func (c StreetMarketChanges) More() bool {
+	return c.Cursor.Paging()
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSyncToken_Cursor(t *testing.T) {
+	testCases := map[string]SyncCursor{
+		"When it is the next sync": {Position: 740213},
+		"When it pages a sync since a position": {
+			Position:  740100,
+			Next:      740213,
+			AfterTxID: 740150,
+			AfterID:   "1966d99f-20e8-4e5e-8f68-eb88ca67f95f",
+		},
+		"When it pages the first sync": {
+			Next:      740213,
+			AfterTxID: 740150,
+			AfterID:   "1966d99f-20e8-4e5e-8f68-eb88ca67f95f",
+		},
+	}
+
+	for title, want := range testCases {
+		t.Run(title, func(t *testing.T) {
+			got, err := want.Token().Cursor()
+			if err != nil {
+				t.Fatalf("expect return nil, got %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("unexpected cursor (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	empty, err := SyncToken("").Cursor()
+	if err != nil || empty != (SyncCursor{}) {
+		t.Errorf("expect the zero cursor and nil, got %v and %v", empty, err)
+	}
+
+	if got, err := NewSyncToken(740213).Cursor(); err != nil || got != (SyncCursor{Position: 740213}) {
+		t.Errorf("expect position 740213 and nil, got %v and %v", got, err)
+	}
+}
+
+func TestSyncToken_Cursor_Error(t *testing.T) {
+	token := func(s string) SyncToken {
+		return SyncToken(base64.RawURLEncoding.EncodeToString([]byte(s)))
+	}
+
+	testCases := map[string]SyncToken{
+		"When it is not base64":              "not a token!",
+		"When it is not a number":            SyncToken("bm9wZQ"),
+		"When it is not a position":          NewSyncToken(0),
+		"When it is a negative one":          NewSyncToken(-3),
+		"When it has a part missing":         token("740100.740213.740150"),
+		"When its last key is not a number":  token("740100.740213.last.1966d99f"),
+		"When it pages without a next sync":  token("740100.0.740150.1966d99f"),
+		"When it pages without a last key":   token("740100.740213.0.1966d99f"),
+		"When it pages with a negative part": token("-1.740213.740150.1966d99f"),
+		"When it pages without a last ID":    token("740100.740213.740150."),
+	}
+
+	for title, token := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if _, err := token.Cursor(); err == nil || err.Kind != InpValidationErrKd {
+				t.Errorf("Want error kind %v, got error %v", InpValidationErrKd, err)
+			}
+		})
+	}
+}
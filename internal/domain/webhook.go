@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Sizes of the webhook_subscription columns, the secret having a minimum so
+// signatures can not be guessed.
+const (
+	webhookURLMaxLen    = 2048
+	webhookSecretMinLen = 16
+	webhookSecretMaxLen = 250
+)
+
+type WebhookID string
+
+func (w *WebhookID) Validate() *Error {
+	if _, err := uuid.Parse(string(*w)); err != nil {
+		return &Error{Kind: InpValidationErrKd, Msg: err.Error()}
+	}
+
+	return nil
+}
+
+// WebhookSubscription is a partner URL that receives the events it subscribed
+// to. IDdist and Region5 narrow the street markets notified, an empty one
+// matching all of them.
+type WebhookSubscription struct {
+	ID        string
+	URL       string
+	Secret    string
+	Events    []EventType
+	IDdist    string
+	Region5   string
+	CreatedAt *time.Time
+}
+
+// Matches tells whether s wants event of sm.
+func (s WebhookSubscription) Matches(event EventType, sm StreetMarket) bool {
+	if s.IDdist != "" && s.IDdist != sm.IDdist {
+		return false
+	}
+	if s.Region5 != "" && !sameName(s.Region5, sm.Region5) {
+		return false
+	}
+
+	for _, e := range s.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebhookSubscriptionInput struct {
+	URL     string
+	Secret  string
+	Events  []EventType
+	IDdist  string
+	Region5 string
+}
+
+// Validate reports every invalid field at once.
+func (d *WebhookSubscriptionInput) Validate() *Error {
+	fe := fieldErrors{}
+
+	fe.required("URL", d.URL)
+	fe.maxLen("URL", d.URL, webhookURLMaxLen)
+	if d.URL != "" && !isWebhookURL(d.URL) {
+		fe.add("URL", InvalidFormatFieldCd, "URL must be an absolute http or https URL")
+	}
+
+	fe.required("Secret", d.Secret)
+	if d.Secret != "" && utf8.RuneCountInString(d.Secret) < webhookSecretMinLen {
+		fe.add("Secret", TooShortFieldCd, fmt.Sprintf("Secret must have at least %d characters", webhookSecretMinLen))
+	}
+	fe.maxLen("Secret", d.Secret, webhookSecretMaxLen)
+
+	if len(d.Events) == 0 {
+		fe.add("Events", RequiredFieldCd, "Events is required")
+	}
+	for _, e := range d.Events {
+		if err := e.Validate(); err != nil {
+			fe.add("Events", NotAllowedFieldCd, err.Msg)
+		}
+	}
+
+	fe.maxLen("IDdist", d.IDdist, smFieldMaxLen)
+	fe.maxLen("Region5", d.Region5, smFieldMaxLen)
+
+	return fe.err()
+}
+
+// isWebhookURL tells whether s is an absolute http or https URL.
+func isWebhookURL(s string) bool {
+	u, err := url.Parse(s)
+
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+type DeliveryStatus string
+
+const (
+	// DeliveryPending is waiting for its next attempt, DeliveryDead gave up
+	// after the last one failed.
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryDead      DeliveryStatus = "dead"
+)
+
+func (s DeliveryStatus) Validate() *Error {
+	switch s {
+	case DeliveryPending, DeliveryDelivered, DeliveryDead:
+		return nil
+	}
+
+	return &Error{Kind: InpValidationErrKd, Msg: fmt.Sprintf("%s is not a valid delivery status", s)}
+}
+
+// WebhookDelivery is one event sent to one subscription. Payload is the body
+// posted on every attempt. Subscription only has the ID, unless the delivery
+// is being dispatched, when its URL and Secret are also set.
+type WebhookDelivery struct {
+	ID            string
+	Subscription  WebhookSubscription
+	Event         EventType
+	Payload       []byte
+	Status        DeliveryStatus
+	Attempts      []WebhookAttempt
+	AttemptCount  int
+	NextAttemptAt *time.Time
+	CreatedAt     *time.Time
+}
+
+// WebhookAttempt is the outcome of one POST of a delivery. StatusCode is zero
+// when no response was received, Error telling why.
+type WebhookAttempt struct {
+	Number      int
+	StatusCode  int
+	Error       string
+	Duration    time.Duration
+	AttemptedAt *time.Time
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWebhookID_Validate(t *testing.T) {
+	var id WebhookID = "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"
+	if err := id.Validate(); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+
+	id = "invalid"
+	if err := id.Validate(); err == nil {
+		t.Error("expect err, got nil")
+	}
+}
+
+func TestWebhookSubscription_Matches(t *testing.T) {
+	sm := StreetMarket{IDdist: "87", Region5: "Leste"}
+
+	testCases := map[string]struct {
+		sub   WebhookSubscription
+		event EventType
+		want  bool
+	}{
+		"When there is no filter": {
+			sub:   WebhookSubscription{Events: []EventType{MarketCreatedEvent}},
+			event: MarketCreatedEvent,
+			want:  true,
+		},
+		"When the event is not subscribed": {
+			sub:   WebhookSubscription{Events: []EventType{MarketCreatedEvent}},
+			event: MarketDeletedEvent,
+		},
+		"When the district matches": {
+			sub:   WebhookSubscription{Events: EventTypes(), IDdist: "87"},
+			event: MarketUpdatedEvent,
+			want:  true,
+		},
+		"When the district does not match": {
+			sub:   WebhookSubscription{Events: EventTypes(), IDdist: "12"},
+			event: MarketUpdatedEvent,
+		},
+		"When the region matches ignoring case": {
+			sub:   WebhookSubscription{Events: EventTypes(), Region5: "LESTE"},
+			event: MarketDeletedEvent,
+			want:  true,
+		},
+		"When the region does not match": {
+			sub:   WebhookSubscription{Events: EventTypes(), IDdist: "87", Region5: "Oeste"},
+			event: MarketDeletedEvent,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if got := tc.sub.Matches(tc.event, sm); got != tc.want {
+				t.Errorf("expect %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWebhookSubscriptionInput_Validate(t *testing.T) {
+	inp := WebhookSubscriptionInput{
+		URL:     "https://partner.example.com/hooks",
+		Secret:  "0123456789abcdef",
+		Events:  []EventType{MarketCreatedEvent, MarketDeletedEvent},
+		Region5: "Leste",
+	}
+
+	if err := inp.Validate(); err != nil {
+		t.Errorf("expect nil, got %v", err)
+	}
+}
+
+func TestWebhookSubscriptionInput_Validate_Error(t *testing.T) {
+	testCases := map[string]struct {
+		inp  WebhookSubscriptionInput
+		want []FieldError
+	}{
+		"When every field is missing": {
+			want: []FieldError{
+				{Field: "URL", Code: RequiredFieldCd, Msg: "URL is required"},
+				{Field: "Secret", Code: RequiredFieldCd, Msg: "Secret is required"},
+				{Field: "Events", Code: RequiredFieldCd, Msg: "Events is required"},
+			},
+		},
+		"When fields are invalid": {
+			inp: WebhookSubscriptionInput{
+				URL:    "ftp://partner.example.com",
+				Secret: "short",
+				Events: []EventType{MarketCreatedEvent, "street_market.moved"},
+				IDdist: strings.Repeat("1", 51),
+			},
+			want: []FieldError{
+				{Field: "URL", Code: InvalidFormatFieldCd, Msg: "URL must be an absolute http or https URL"},
+				{Field: "Secret", Code: TooShortFieldCd, Msg: "Secret must have at least 16 characters"},
+				{Field: "Events", Code: NotAllowedFieldCd, Msg: "street_market.moved is not a valid event type"},
+				{Field: "IDdist", Code: TooLongFieldCd, Msg: "IDdist must have at most 50 characters"},
+			},
+		},
+		"When the URL is relative": {
+			inp: WebhookSubscriptionInput{
+				URL:    "/hooks",
+				Secret: "0123456789abcdef",
+				Events: EventTypes(),
+			},
+			want: []FieldError{
+				{Field: "URL", Code: InvalidFormatFieldCd, Msg: "URL must be an absolute http or https URL"},
+			},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			err := tc.inp.Validate()
+			if err == nil {
+				t.Fatal("expect err, got nil")
+			}
+
+			if diff := cmp.Diff(tc.want, err.Fields); diff != "" {
+				t.Errorf("unexpected field errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeliveryStatus_Validate(t *testing.T) {
+	for _, s := range []DeliveryStatus{DeliveryPending, DeliveryDelivered, DeliveryDead} {
+		if err := s.Validate(); err != nil {
+			t.Errorf("expect nil for %s, got %v", s, err)
+		}
+	}
+
+	s := DeliveryStatus("lost")
+	if err := s.Validate(); err == nil {
+		t.Error("expect err, got nil")
+	}
+}
@@ -0,0 +1,57 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryAuthenticator interface {
+	GetAPIKey(ctx context.Context, ID string) (domain.APIKey, *domain.Error)
+}
+
+type Authenticator struct {
+	repo repositoryAuthenticator
+}
+
+func NewAuthenticator(repo repositoryAuthenticator) *Authenticator {
+	return &Authenticator{repo}
+}
+
+// Authenticate returns the key of token. Malformed, unknown and revoked tokens
+// are all UnauthenticatedErrKd with the same message, so clients can not tell
+// which keys exist.
+func (s *Authenticator) Authenticate(ctx context.Context, token string) (domain.APIKey, *domain.Error) {
+	invalid := &domain.Error{Kind: domain.UnauthenticatedErrKd, Msg: "Invalid API key"}
+
+	ID, secret, ok := strings.Cut(token, ".")
+	if !ok {
+		return domain.APIKey{}, invalid
+	}
+	keyID := domain.APIKeyID(ID)
+	if err := keyID.Validate(); err != nil {
+		return domain.APIKey{}, invalid
+	}
+
+	k, err := s.repo.GetAPIKey(ctx, ID)
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return domain.APIKey{}, invalid
+		default:
+			return domain.APIKey{}, &domain.Error{
+				Kind:     domain.UnexpectedErrKd,
+				Msg:      "Unexpected error when authenticate",
+				Previous: err,
+			}
+		}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(k.Hash)) != 1 || k.RevokedAt != nil {
+		return domain.APIKey{}, invalid
+	}
+
+	return k, nil
+}
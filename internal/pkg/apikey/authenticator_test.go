@@ -0,0 +1,96 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRepositoryAuthenticator struct {
+	getAPIKey func(context.Context, string) (domain.APIKey, *domain.Error)
+}
+
+func (s *stubRepositoryAuthenticator) GetAPIKey(ctx context.Context, ID string) (domain.APIKey, *domain.Error) {
+	return s.getAPIKey(ctx, ID)
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	k := domain.APIKey{
+		ID:     string(validKeyID),
+		Name:   "partner",
+		Scopes: []domain.Scope{domain.ScopeMarketsRead},
+		Hash:   hashSecret("s3cr3t"),
+	}
+	repoMock := &stubRepositoryAuthenticator{
+		getAPIKey: func(context.Context, string) (domain.APIKey, *domain.Error) {
+			return k, nil
+		},
+	}
+
+	got, err := NewAuthenticator(repoMock).Authenticate(context.TODO(), string(validKeyID)+".s3cr3t")
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(k, got); diff != "" {
+		t.Errorf("unexpected key (-want +got):\n%s", diff)
+	}
+}
+
+func TestAuthenticator_Authenticate_Error(t *testing.T) {
+	revokedAt := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		token string
+		key   domain.APIKey
+		rErr  *domain.Error
+		wErr  domain.KindError
+	}{
+		"When the token has no secret": {
+			token: string(validKeyID),
+			wErr:  domain.UnauthenticatedErrKd,
+		},
+		"When the token ID is invalid": {
+			token: "42.s3cr3t",
+			wErr:  domain.UnauthenticatedErrKd,
+		},
+		"When the key not exists": {
+			token: string(validKeyID) + ".s3cr3t",
+			rErr:  &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr:  domain.UnauthenticatedErrKd,
+		},
+		"When the secret is wrong": {
+			token: string(validKeyID) + ".guess",
+			key:   domain.APIKey{ID: string(validKeyID), Hash: hashSecret("s3cr3t")},
+			wErr:  domain.UnauthenticatedErrKd,
+		},
+		"When the key is revoked": {
+			token: string(validKeyID) + ".s3cr3t",
+			key:   domain.APIKey{ID: string(validKeyID), Hash: hashSecret("s3cr3t"), RevokedAt: &revokedAt},
+			wErr:  domain.UnauthenticatedErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			token: string(validKeyID) + ".s3cr3t",
+			rErr:  &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr:  domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryAuthenticator{
+				getAPIKey: func(context.Context, string) (domain.APIKey, *domain.Error) {
+					return tc.key, tc.rErr
+				},
+			}
+
+			_, gErr := NewAuthenticator(repoMock).Authenticate(context.TODO(), tc.token)
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
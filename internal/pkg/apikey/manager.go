@@ -0,0 +1,119 @@
+// Package apikey issues the API keys and tells who made a request by its key.
+// A key is its ID and a random secret joined by a dot, only the SHA-256 of the
+// secret being stored, so keys can not be recovered from the database.
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// secretLen is how many random bytes a secret has.
+const secretLen = 32
+
+type repositoryManager interface {
+	CreateAPIKey(ctx context.Context, k domain.APIKey) *domain.Error
+	ListAPIKeys(ctx context.Context) ([]domain.APIKey, *domain.Error)
+	RevokeAPIKey(ctx context.Context, ID string) *domain.Error
+}
+
+type uuidGenerator func() string
+
+type secretGenerator func() (string, error)
+
+type APIKeyManager struct {
+	repo      repositoryManager
+	idGen     uuidGenerator
+	secretGen secretGenerator
+}
+
+func NewManager(repo repositoryManager, idGen uuidGenerator, secretGen secretGenerator) *APIKeyManager {
+	return &APIKeyManager{repo, idGen, secretGen}
+}
+
+// Issue creates a key and returns it along with the token its client sends,
+// which is not kept anywhere.
+func (s *APIKeyManager) Issue(ctx context.Context, inp domain.APIKeyInput) (domain.APIKey, string, *domain.Error) {
+	if err := inp.Validate(); err != nil {
+		return domain.APIKey{}, "", &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid input",
+			Previous: err,
+			Fields:   err.Fields,
+		}
+	}
+
+	secret, err := s.secretGen()
+	if err != nil {
+		return domain.APIKey{}, "", &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   "Unexpected error when generate secret",
+			Cause: err,
+		}
+	}
+
+	k := domain.APIKey{ID: s.idGen(), Name: inp.Name, Scopes: inp.Scopes, Hash: hashSecret(secret)}
+	if err := s.repo.CreateAPIKey(ctx, k); err != nil {
+		return domain.APIKey{}, "", &domain.Error{
+			Kind:     domain.UnexpectedErrKd,
+			Msg:      "Unexpected error when create",
+			Previous: err,
+		}
+	}
+
+	return k, k.ID + "." + secret, nil
+}
+
+func (s *APIKeyManager) List(ctx context.Context) ([]domain.APIKey, *domain.Error) {
+	ks, err := s.repo.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when list", Previous: err}
+	}
+
+	return ks, nil
+}
+
+// Revoke stops the key from being accepted. Revoked keys are kept, so they are
+// still listed.
+func (s *APIKeyManager) Revoke(ctx context.Context, ID domain.APIKeyID) *domain.Error {
+	if err := ID.Validate(); err != nil {
+		return &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	if err := s.repo.RevokeAPIKey(ctx, string(ID)); err != nil {
+		switch err.Kind {
+		case domain.NothingUpdatedErrKd:
+			return &domain.Error{Kind: domain.APIKeyNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when revoke", Previous: err}
+		}
+	}
+
+	return nil
+}
+
+// RandomSecret is the secret generator of the server.
+func RandomSecret() (string, error) {
+	b := make([]byte, secretLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("%w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashSecret(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,202 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+const validKeyID domain.APIKeyID = "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"
+
+type stubRepositoryManager struct {
+	createInp    domain.APIKey
+	createAPIKey func(context.Context, domain.APIKey) *domain.Error
+	listAPIKeys  func(context.Context) ([]domain.APIKey, *domain.Error)
+	revokeAPIKey func(context.Context, string) *domain.Error
+}
+
+func (s *stubRepositoryManager) CreateAPIKey(ctx context.Context, k domain.APIKey) *domain.Error {
+	s.createInp = k
+	return s.createAPIKey(ctx, k)
+}
+
+func (s *stubRepositoryManager) ListAPIKeys(ctx context.Context) ([]domain.APIKey, *domain.Error) {
+	return s.listAPIKeys(ctx)
+}
+
+func (s *stubRepositoryManager) RevokeAPIKey(ctx context.Context, ID string) *domain.Error {
+	return s.revokeAPIKey(ctx, ID)
+}
+
+func idGen() string {
+	return string(validKeyID)
+}
+
+func secretGen() (string, error) {
+	return "s3cr3t", nil
+}
+
+func TestAPIKeyManager_Issue(t *testing.T) {
+	repoMock := &stubRepositoryManager{
+		createAPIKey: func(context.Context, domain.APIKey) *domain.Error {
+			return nil
+		},
+	}
+
+	srv := NewManager(repoMock, idGen, secretGen)
+
+	inp := domain.APIKeyInput{Name: "partner", Scopes: []domain.Scope{domain.ScopeMarketsRead}}
+	got, token, err := srv.Issue(context.TODO(), inp)
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	if token != string(validKeyID)+".s3cr3t" {
+		t.Errorf("unexpected token %s", token)
+	}
+
+	want := domain.APIKey{
+		ID:     string(validKeyID),
+		Name:   "partner",
+		Scopes: []domain.Scope{domain.ScopeMarketsRead},
+		Hash:   "4e738ca5563c06cfd0018299933d58db1dd8bf97f6973dc99bf6cdc64b5550bd",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected key (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, repoMock.createInp); diff != "" {
+		t.Errorf("unexpected key when calls create (-want +got):\n%s", diff)
+	}
+}
+
+func TestAPIKeyManager_Issue_Error(t *testing.T) {
+	valid := domain.APIKeyInput{Name: "partner", Scopes: []domain.Scope{domain.ScopeMarketsRead}}
+
+	testCases := map[string]struct {
+		inp       domain.APIKeyInput
+		secretErr error
+		rErr      *domain.Error
+		wErr      domain.KindError
+	}{
+		"When input is invalid": {
+			wErr: domain.InpValidationErrKd,
+		},
+		"When the secret can not be generated": {
+			inp:       valid,
+			secretErr: errors.New("no entropy"),
+			wErr:      domain.UnexpectedErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			inp:  valid,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryManager{
+				createAPIKey: func(context.Context, domain.APIKey) *domain.Error {
+					return tc.rErr
+				},
+			}
+			secretGen := func() (string, error) {
+				return "s3cr3t", tc.secretErr
+			}
+
+			srv := NewManager(repoMock, idGen, secretGen)
+
+			_, _, gErr := srv.Issue(context.TODO(), tc.inp)
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestAPIKeyManager_List(t *testing.T) {
+	ks := []domain.APIKey{{ID: string(validKeyID), Name: "partner"}}
+	repoMock := &stubRepositoryManager{
+		listAPIKeys: func(context.Context) ([]domain.APIKey, *domain.Error) {
+			return ks, nil
+		},
+	}
+
+	got, err := NewManager(repoMock, idGen, secretGen).List(context.TODO())
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+	if diff := cmp.Diff(ks, got); diff != "" {
+		t.Errorf("unexpected keys (-want +got):\n%s", diff)
+	}
+
+	repoMock.listAPIKeys = func(context.Context) ([]domain.APIKey, *domain.Error) {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd}
+	}
+	if _, err := NewManager(repoMock, idGen, secretGen).List(context.TODO()); err == nil ||
+		err.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, err)
+	}
+}
+
+func TestAPIKeyManager_Revoke(t *testing.T) {
+	testCases := map[string]struct {
+		ID   domain.APIKeyID
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When the key is revoked": {
+			ID: validKeyID,
+		},
+		"When the ID is invalid": {
+			ID:   "42",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When the key not exists": {
+			ID:   validKeyID,
+			rErr: &domain.Error{Kind: domain.NothingUpdatedErrKd},
+			wErr: domain.APIKeyNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			ID:   validKeyID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryManager{
+				revokeAPIKey: func(context.Context, string) *domain.Error {
+					return tc.rErr
+				},
+			}
+
+			gErr := NewManager(repoMock, idGen, secretGen).Revoke(context.TODO(), tc.ID)
+			switch {
+			case tc.wErr == "" && gErr != nil:
+				t.Errorf("expect return nil, got %v", gErr)
+			case tc.wErr != "" && (gErr == nil || gErr.Kind != tc.wErr):
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestRandomSecret(t *testing.T) {
+	a, err := RandomSecret()
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+	b, err := RandomSecret()
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	if a == b || len(a) != 43 {
+		t.Errorf("expect two distinct 43 characters secrets, got %s and %s", a, b)
+	}
+}
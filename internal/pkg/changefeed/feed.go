@@ -0,0 +1,197 @@
+package changefeed
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	// replayPage is how many missed events are read at a time when resuming.
+	replayPage = 100
+	// followBuffer is how many events a follower may fall behind before it is
+	// dropped, to resume from the last event it got.
+	followBuffer = 64
+)
+
+type repositoryFeed interface {
+	GetEvent(ctx context.Context, ID string) (domain.MarketEvent, *domain.Error)
+	ListEventsAfter(ctx context.Context, ID string, limit int) ([]domain.MarketEvent, *domain.Error)
+}
+
+type feedLogger interface {
+	Error(context.Context, domain.Error)
+}
+
+// ChangeFeed fans the street market events written by any replica out to the
+// clients following them from this one.
+type ChangeFeed struct {
+	repo      repositoryFeed
+	logger    feedLogger
+	mu        sync.Mutex
+	followers map[*follower]struct{}
+}
+
+type follower struct {
+	filter domain.ChangeFilter
+	events chan domain.MarketEvent
+}
+
+func NewFeed(repo repositoryFeed, logger feedLogger) *ChangeFeed {
+	return &ChangeFeed{repo: repo, logger: logger, followers: map[*follower]struct{}{}}
+}
+
+// Run sends the events notified by their ID on IDs to the followers they
+// match until ctx is done. An empty ID means events may have been missed, so
+// every follower is dropped to resume from the last event it got.
+func (f *ChangeFeed) Run(ctx context.Context, IDs <-chan string) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ID := <-IDs:
+			f.dispatch(ctx, ID)
+		}
+	}
+}
+
+func (f *ChangeFeed) dispatch(ctx context.Context, ID string) {
+	if ID == "" {
+		f.dropAll()
+		return
+	}
+
+	if f.idle() {
+		return
+	}
+
+	ev, err := f.repo.GetEvent(ctx, ID)
+	if err != nil {
+		f.logger.Error(ctx, domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when get event", Previous: err})
+		f.dropAll()
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for fl := range f.followers {
+		if !fl.filter.Matches(ev) {
+			continue
+		}
+
+		select {
+		case fl.events <- ev:
+		default:
+			f.drop(fl)
+		}
+	}
+}
+
+// Follow returns the events matching filter as they are written, the ones
+// after lastEventID first unless it is empty. The channel is closed once ctx is
+// done or the follower fell too far behind, clients resuming from the last
+// event they got.
+func (f *ChangeFeed) Follow(
+	ctx context.Context,
+	filter domain.ChangeFilter,
+	lastEventID string,
+) (<-chan domain.MarketEvent, *domain.Error) {
+	if lastEventID != "" {
+		if err := f.validateLastEvent(ctx, lastEventID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Following before the missed events are read, so the ones written
+	// meanwhile are not lost.
+	fl := f.follow(filter)
+	s := &stream{repo: f.repo, logger: f.logger, follower: fl, cursor: lastEventID, replayed: map[string]bool{}}
+
+	out := make(chan domain.MarketEvent)
+	go func() {
+		defer close(out)
+		defer f.unfollow(fl)
+
+		for {
+			ev, ok := s.next(ctx)
+			if !ok {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- ev:
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (f *ChangeFeed) validateLastEvent(ctx context.Context, ID string) *domain.Error {
+	if _, err := uuid.Parse(ID); err != nil {
+		return &domain.Error{
+			Kind:   domain.InpValidationErrKd,
+			Msg:    "Last-Event-ID must be an event id",
+			Fields: []domain.FieldError{{Field: "Last-Event-ID", Code: domain.InvalidFormatFieldCd, Msg: err.Error()}},
+		}
+	}
+
+	if _, err := f.repo.GetEvent(ctx, ID); err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return &domain.Error{
+				Kind:     domain.InpValidationErrKd,
+				Msg:      "Last-Event-ID is not a known event",
+				Previous: err,
+				Fields: []domain.FieldError{
+					{Field: "Last-Event-ID", Code: domain.UnknownFieldCd, Msg: "Last-Event-ID is not a known event"},
+				},
+			}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when get event", Previous: err}
+		}
+	}
+
+	return nil
+}
+
+func (f *ChangeFeed) follow(filter domain.ChangeFilter) *follower {
+	fl := &follower{filter: filter, events: make(chan domain.MarketEvent, followBuffer)}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.followers[fl] = struct{}{}
+
+	return fl
+}
+
+func (f *ChangeFeed) unfollow(fl *follower) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.followers, fl)
+}
+
+func (f *ChangeFeed) idle() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.followers) == 0
+}
+
+func (f *ChangeFeed) dropAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for fl := range f.followers {
+		f.drop(fl)
+	}
+}
+
+// drop closes the events of fl, ending its stream. f.mu must be held.
+func (f *ChangeFeed) drop(fl *follower) {
+	delete(f.followers, fl)
+	close(fl.events)
+}
@@ -0,0 +1,247 @@
+package changefeed
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+const lastEventID = "0b0e7c1a-3d5e-4f6a-8b9c-1d2e3f4a5b6c"
+
+type stubRepositoryFeed struct {
+	getEvent        func(context.Context, string) (domain.MarketEvent, *domain.Error)
+	listEventsAfter func(context.Context, string, int) ([]domain.MarketEvent, *domain.Error)
+}
+
+func (s *stubRepositoryFeed) GetEvent(ctx context.Context, ID string) (domain.MarketEvent, *domain.Error) {
+	return s.getEvent(ctx, ID)
+}
+
+func (s *stubRepositoryFeed) ListEventsAfter(
+	ctx context.Context,
+	ID string,
+	limit int,
+) ([]domain.MarketEvent, *domain.Error) {
+	return s.listEventsAfter(ctx, ID, limit)
+}
+
+type stubLogger struct {
+	mu     sync.Mutex
+	errors []domain.Error
+}
+
+func (s *stubLogger) Error(_ context.Context, err domain.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = append(s.errors, err)
+}
+
+// events is the repository of evs, by their ID.
+func events(evs ...domain.MarketEvent) func(context.Context, string) (domain.MarketEvent, *domain.Error) {
+	return func(_ context.Context, ID string) (domain.MarketEvent, *domain.Error) {
+		for _, ev := range evs {
+			if ev.ID == ID {
+				return ev, nil
+			}
+		}
+		return domain.MarketEvent{}, &domain.Error{Kind: domain.NothingFoundErrKd}
+	}
+}
+
+func marketEvent(ID, district string) domain.MarketEvent {
+	return domain.MarketEvent{
+		ID:       ID,
+		Type:     domain.MarketCreatedEvent,
+		MarketID: "1",
+		After:    &domain.StreetMarket{ID: "1", District: district},
+	}
+}
+
+// receive is the next event of evs, failing when none comes.
+func receive(t *testing.T, evs <-chan domain.MarketEvent) domain.MarketEvent {
+	t.Helper()
+
+	select {
+	case ev, ok := <-evs:
+		if !ok {
+			t.Fatal("expect an event, got the stream closed")
+		}
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("expect an event, got none")
+	}
+
+	return domain.MarketEvent{}
+}
+
+// closed fails unless evs is closed without sending anything else.
+func closed(t *testing.T, evs <-chan domain.MarketEvent) {
+	t.Helper()
+
+	select {
+	case ev, ok := <-evs:
+		if ok {
+			t.Fatalf("expect the stream closed, got %v", ev)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expect the stream closed")
+	}
+}
+
+func TestChangeFeed_Follow(t *testing.T) {
+	formosa := marketEvent("e1", "VILA FORMOSA")
+	mariana := marketEvent("e2", "VILA MARIANA")
+
+	repoMock := &stubRepositoryFeed{getEvent: events(formosa, mariana)}
+	feed := NewFeed(repoMock, &stubLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	IDs := make(chan string)
+	go feed.Run(ctx, IDs)
+
+	all, err := feed.Follow(ctx, domain.ChangeFilter{}, "")
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+	formosaOnly, err := feed.Follow(ctx, domain.ChangeFilter{District: "vila formosa"}, "")
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	IDs <- "e2"
+	IDs <- "e1"
+
+	if diff := cmp.Diff(mariana, receive(t, all)); diff != "" {
+		t.Errorf("unexpected event (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(formosa, receive(t, all)); diff != "" {
+		t.Errorf("unexpected event (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(formosa, receive(t, formosaOnly)); diff != "" {
+		t.Errorf("unexpected event (-want +got):\n%s", diff)
+	}
+
+	// Events may have been missed, so everyone resumes.
+	IDs <- ""
+	closed(t, all)
+	closed(t, formosaOnly)
+}
+
+func TestChangeFeed_Follow_Behind(t *testing.T) {
+	ev := marketEvent("e1", "VILA FORMOSA")
+	repoMock := &stubRepositoryFeed{getEvent: events(ev)}
+	feed := NewFeed(repoMock, &stubLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	evs, err := feed.Follow(ctx, domain.ChangeFilter{}, "")
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	// Nothing is read from evs meanwhile, so the buffer fills up.
+	for i := 0; i < followBuffer+2; i++ {
+		feed.dispatch(ctx, "e1")
+	}
+
+	// One more may be waiting to be sent out of the buffer.
+	n := 0
+	for range evs {
+		n++
+	}
+	if n < followBuffer || n > followBuffer+1 {
+		t.Errorf("expect the buffered events before the stream closed, got %d", n)
+	}
+}
+
+func TestChangeFeed_Follow_Unfollow(t *testing.T) {
+	feed := NewFeed(&stubRepositoryFeed{}, &stubLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	evs, err := feed.Follow(ctx, domain.ChangeFilter{}, "")
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	cancel()
+	closed(t, evs)
+
+	if !feed.idle() {
+		t.Error("expect no follower left")
+	}
+}
+
+func TestChangeFeed_Follow_Error(t *testing.T) {
+	testCases := map[string]struct {
+		lastEventID string
+		rErr        *domain.Error
+		wErr        domain.KindError
+	}{
+		"When the last event id is invalid": {
+			lastEventID: "42",
+			wErr:        domain.InpValidationErrKd,
+		},
+		"When the last event not exists": {
+			lastEventID: lastEventID,
+			rErr:        &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr:        domain.InpValidationErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			lastEventID: lastEventID,
+			rErr:        &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr:        domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryFeed{
+				getEvent: func(context.Context, string) (domain.MarketEvent, *domain.Error) {
+					return domain.MarketEvent{}, tc.rErr
+				},
+			}
+			feed := NewFeed(repoMock, &stubLogger{})
+
+			_, gErr := feed.Follow(context.TODO(), domain.ChangeFilter{}, tc.lastEventID)
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+
+			if !feed.idle() {
+				t.Error("expect no follower left")
+			}
+		})
+	}
+}
+
+func TestChangeFeed_dispatch_Error(t *testing.T) {
+	repoMock := &stubRepositoryFeed{
+		getEvent: func(context.Context, string) (domain.MarketEvent, *domain.Error) {
+			return domain.MarketEvent{}, &domain.Error{Kind: domain.UnexpectedErrKd}
+		},
+	}
+	logMock := &stubLogger{}
+	feed := NewFeed(repoMock, logMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	evs, err := feed.Follow(ctx, domain.ChangeFilter{}, "")
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	feed.dispatch(ctx, "e1")
+
+	closed(t, evs)
+	if len(logMock.errors) != 1 {
+		t.Errorf("expect the error logged, got %v", logMock.errors)
+	}
+}
@@ -0,0 +1,77 @@
+package changefeed
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// stream is what a follower gets: the events it missed, read page by page
+// after cursor, then the ones written since it followed. Those written while
+// it was catching up come both ways and are sent once.
+type stream struct {
+	repo     repositoryFeed
+	logger   feedLogger
+	follower *follower
+	cursor   string
+	missed   []domain.MarketEvent
+	replayed map[string]bool
+}
+
+// next returns the next event matching the filter of the follower, false once
+// ctx is done, the follower was dropped or the missed events could not be
+// read.
+func (s *stream) next(ctx context.Context) (domain.MarketEvent, bool) {
+	for {
+		if len(s.missed) == 0 && s.cursor != "" {
+			if !s.readMissed(ctx) {
+				return domain.MarketEvent{}, false
+			}
+			continue
+		}
+
+		if len(s.missed) > 0 {
+			ev := s.missed[0]
+			s.missed = s.missed[1:]
+			s.replayed[ev.ID] = true
+			if s.follower.filter.Matches(ev) {
+				return ev, true
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return domain.MarketEvent{}, false
+		case ev, ok := <-s.follower.events:
+			if !ok {
+				return domain.MarketEvent{}, false
+			}
+			if !s.replayed[ev.ID] {
+				return ev, true
+			}
+		}
+	}
+}
+
+func (s *stream) readMissed(ctx context.Context) bool {
+	evs, err := s.repo.ListEventsAfter(ctx, s.cursor, replayPage)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error(ctx, domain.Error{
+				Kind:     domain.UnexpectedErrKd,
+				Msg:      "Unexpected error when list missed events",
+				Previous: err,
+			})
+		}
+		return false
+	}
+
+	s.missed = evs
+	s.cursor = ""
+	if len(evs) == replayPage {
+		s.cursor = evs[len(evs)-1].ID
+	}
+
+	return true
+}
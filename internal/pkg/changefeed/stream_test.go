@@ -0,0 +1,96 @@
+package changefeed
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStream_next(t *testing.T) {
+	// Two full pages of missed events and one more, e1 being the last one
+	// the follower got.
+	missed := []domain.MarketEvent{}
+	for i := 2; i <= 2*replayPage+2; i++ {
+		district := "VILA FORMOSA"
+		if i%2 == 1 {
+			district = "VILA MARIANA"
+		}
+		missed = append(missed, marketEvent(fmt.Sprintf("e%d", i), district))
+	}
+
+	cursors := []string{}
+	repoMock := &stubRepositoryFeed{
+		listEventsAfter: func(_ context.Context, ID string, limit int) ([]domain.MarketEvent, *domain.Error) {
+			cursors = append(cursors, ID)
+			for i, ev := range append([]domain.MarketEvent{{ID: "e1"}}, missed...) {
+				if ev.ID != ID {
+					continue
+				}
+				end := i + limit
+				if end > len(missed) {
+					end = len(missed)
+				}
+				return missed[i:end], nil
+			}
+			return nil, nil
+		},
+	}
+
+	fl := &follower{filter: domain.ChangeFilter{District: "VILA FORMOSA"}, events: make(chan domain.MarketEvent, 2)}
+	// The last one missed was written while catching up, so it comes live too.
+	fl.events <- missed[len(missed)-1]
+	fl.events <- marketEvent("live", "VILA FORMOSA")
+
+	s := &stream{repo: repoMock, logger: &stubLogger{}, follower: fl, cursor: "e1", replayed: map[string]bool{}}
+
+	got := []string{}
+	for {
+		ev, ok := s.next(context.TODO())
+		if !ok {
+			t.Fatal("expect an event, got the stream ended")
+		}
+		got = append(got, ev.ID)
+		if ev.ID == "live" {
+			break
+		}
+	}
+
+	want := []string{}
+	for _, ev := range missed {
+		if ev.After.District == "VILA FORMOSA" {
+			want = append(want, ev.ID)
+		}
+	}
+	want = append(want, "live")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+
+	wCursors := []string{"e1", missed[replayPage-1].ID, missed[2*replayPage-1].ID}
+	if diff := cmp.Diff(wCursors, cursors); diff != "" {
+		t.Errorf("unexpected pages read (-want +got):\n%s", diff)
+	}
+}
+
+func TestStream_next_Error(t *testing.T) {
+	repoMock := &stubRepositoryFeed{
+		listEventsAfter: func(context.Context, string, int) ([]domain.MarketEvent, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd}
+		},
+	}
+	logMock := &stubLogger{}
+
+	fl := &follower{events: make(chan domain.MarketEvent, 1)}
+	s := &stream{repo: repoMock, logger: logMock, follower: fl, cursor: "e1", replayed: map[string]bool{}}
+
+	if _, ok := s.next(context.TODO()); ok {
+		t.Error("expect the stream ended")
+	}
+
+	if len(logMock.errors) != 1 {
+		t.Errorf("expect the error logged, got %v", logMock.errors)
+	}
+}
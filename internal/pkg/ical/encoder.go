@@ -0,0 +1,187 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+const (
+	prodID        = "-//unicoAPITest//Street Markets//PT"
+	uidDomain     = "unicoapitest"
+	localFmt      = "20060102T150405"
+	utcFmt        = "20060102T150405Z"
+	maxLineOctets = 75
+)
+
+type nowFunc func() time.Time
+
+// Encoder renders street market schedules as an RFC 5545 calendar. Weekly
+// slots become recurring events and schedule exceptions become EXDATEs or
+// single events.
+type Encoder struct {
+	loc *time.Location
+	now nowFunc
+}
+
+func NewEncoder(loc *time.Location, now nowFunc) *Encoder {
+	return &Encoder{loc, now}
+}
+
+func (e *Encoder) Encode(ssms []domain.ScheduledStreetMarket) []byte {
+	now := e.now()
+	w := &writer{}
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + prodID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	e.timezone(w, now)
+
+	for _, ssm := range ssms {
+		e.events(w, ssm, now)
+	}
+
+	w.line("END:VCALENDAR")
+
+	return w.Bytes()
+}
+
+// timezone writes the VTIMEZONE of the encoder location. São Paulo abolished
+// daylight saving time in 2019, so a single STANDARD observance is emitted with
+// the offset in force at now.
+func (e *Encoder) timezone(w *writer, now time.Time) {
+	name, offset := now.In(e.loc).Zone()
+
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + e.loc.String())
+	w.line("BEGIN:STANDARD")
+	w.line("DTSTART:19700101T000000")
+	w.line("TZOFFSETFROM:" + formatOffset(offset))
+	w.line("TZOFFSETTO:" + formatOffset(offset))
+	w.line("TZNAME:" + name)
+	w.line("END:STANDARD")
+	w.line("END:VTIMEZONE")
+}
+
+func (e *Encoder) events(w *writer, ssm domain.ScheduledStreetMarket, now time.Time) {
+	sm := ssm.StreetMarket
+	today := now.In(e.loc)
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, e.loc)
+
+	for _, sl := range ssm.Schedule.Slots {
+		first := today.AddDate(0, 0, (int(sl.Weekday)-int(today.Weekday())+7)%7)
+
+		w.line("BEGIN:VEVENT")
+		w.line(fmt.Sprintf("UID:%s-%s-%s@%s", sm.ID, byDay(sl.Weekday), clock(sl.Start), uidDomain))
+		w.line("DTSTAMP:" + now.UTC().Format(utcFmt))
+		w.line(e.dateTime("DTSTART", first, sl.Start))
+		w.line(e.dateTime("DTEND", first, sl.End))
+		w.line("RRULE:FREQ=WEEKLY;BYDAY=" + byDay(sl.Weekday))
+		for _, ex := range ssm.Schedule.Exceptions {
+			if ex.Date.Weekday() == sl.Weekday {
+				w.line(e.dateTime("EXDATE", ex.Date, sl.Start))
+			}
+		}
+		e.describe(w, sm, "")
+		w.line("END:VEVENT")
+	}
+
+	for _, ex := range ssm.Schedule.Exceptions {
+		if ex.Closed {
+			continue
+		}
+
+		w.line("BEGIN:VEVENT")
+		w.line(fmt.Sprintf("UID:%s-%s@%s", sm.ID, ex.Date.Format("20060102"), uidDomain))
+		w.line("DTSTAMP:" + now.UTC().Format(utcFmt))
+		w.line(e.dateTime("DTSTART", ex.Date, ex.Start))
+		w.line(e.dateTime("DTEND", ex.Date, ex.End))
+		e.describe(w, sm, ex.Note)
+		w.line("END:VEVENT")
+	}
+}
+
+func (e *Encoder) describe(w *writer, sm domain.StreetMarket, note string) {
+	w.line("SUMMARY:" + escape("Feira livre "+sm.Name))
+	w.line("LOCATION:" + escape(address(sm)))
+
+	desc := []string{}
+	if sm.AddrExtraInfo != "" {
+		desc = append(desc, sm.AddrExtraInfo)
+	}
+	if note != "" {
+		desc = append(desc, note)
+	}
+	if len(desc) > 0 {
+		w.line("DESCRIPTION:" + escape(strings.Join(desc, "\n")))
+	}
+}
+
+func (e *Encoder) dateTime(prop string, date time.Time, ct domain.ClockTime) string {
+	t := time.Date(date.Year(), date.Month(), date.Day(), int(ct)/60, int(ct)%60, 0, 0, e.loc)
+	return fmt.Sprintf("%s;TZID=%s:%s", prop, e.loc.String(), t.Format(localFmt))
+}
+
+func address(sm domain.StreetMarket) string {
+	addr := sm.Street
+	if sm.Number != "" {
+		addr = fmt.Sprintf("%s, %s", addr, sm.Number)
+	}
+	if sm.Neighborhood != "" {
+		addr = fmt.Sprintf("%s - %s", addr, sm.Neighborhood)
+	}
+
+	return addr
+}
+
+func byDay(wd time.Weekday) string {
+	return strings.ToUpper(wd.String()[:2])
+}
+
+func clock(ct domain.ClockTime) string {
+	return fmt.Sprintf("%02d%02d", int(ct)/60, int(ct)%60)
+}
+
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+// escape applies the TEXT escaping of RFC 5545 section 3.3.11.
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+type writer struct {
+	bytes.Buffer
+}
+
+// line writes a content line folded at 75 octets, as RFC 5545 section 3.1
+// requires, without splitting multi-byte characters.
+func (w *writer) line(s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,111 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEncoder_Encode(t *testing.T) {
+	loc, err := time.LoadLocation(domain.ScheduleTimeZone)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Monday, 2026-10-19 10:00 in São Paulo
+	now := time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)
+
+	ssms := []domain.ScheduledStreetMarket{{
+		StreetMarket: domain.StreetMarket{
+			ID:            "aaa1be24-ddec-4590-839d-b7ae54b9ed78",
+			Name:          "VILA FORMOSA",
+			Street:        "RUA MARAGOJIPE",
+			Number:        "S/N",
+			Neighborhood:  "VL FORMOSA",
+			AddrExtraInfo: "TV RUA PRETORIA",
+		},
+		Schedule: domain.Schedule{
+			Slots: []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+			Exceptions: []domain.ScheduleException{
+				{Date: time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC), Closed: true},
+				{Date: time.Date(2026, 12, 24, 0, 0, 0, 0, time.UTC), Start: 360, End: 660, Note: "Véspera de Natal"},
+			},
+		},
+	}}
+
+	enc := NewEncoder(loc, func() time.Time { return now })
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//unicoAPITest//Street Markets//PT",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/Sao_Paulo",
+		"BEGIN:STANDARD",
+		"DTSTART:19700101T000000",
+		"TZOFFSETFROM:-0300",
+		"TZOFFSETTO:-0300",
+		"TZNAME:-03",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:aaa1be24-ddec-4590-839d-b7ae54b9ed78-SA-0700@unicoapitest",
+		"DTSTAMP:20261019T130000Z",
+		"DTSTART;TZID=America/Sao_Paulo:20261024T070000",
+		"DTEND;TZID=America/Sao_Paulo:20261024T130000",
+		"RRULE:FREQ=WEEKLY;BYDAY=SA",
+		"EXDATE;TZID=America/Sao_Paulo:20261226T070000",
+		"SUMMARY:Feira livre VILA FORMOSA",
+		"LOCATION:RUA MARAGOJIPE\\, S/N - VL FORMOSA",
+		"DESCRIPTION:TV RUA PRETORIA",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:aaa1be24-ddec-4590-839d-b7ae54b9ed78-20261224@unicoapitest",
+		"DTSTAMP:20261019T130000Z",
+		"DTSTART;TZID=America/Sao_Paulo:20261224T060000",
+		"DTEND;TZID=America/Sao_Paulo:20261224T110000",
+		"SUMMARY:Feira livre VILA FORMOSA",
+		"LOCATION:RUA MARAGOJIPE\\, S/N - VL FORMOSA",
+		"DESCRIPTION:TV RUA PRETORIA\\nVéspera de Natal",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	got := string(enc.Encode(ssms))
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected calendar (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriter_line(t *testing.T) {
+	w := &writer{}
+	w.line("DESCRIPTION:" + strings.Repeat("á", 40))
+
+	for _, l := range strings.Split(strings.TrimSuffix(w.String(), "\r\n"), "\r\n") {
+		if len(l) > maxLineOctets {
+			t.Errorf("expect lines up to %v octets, got %v", maxLineOctets, len(l))
+		}
+	}
+
+	unfolded := strings.ReplaceAll(w.String(), "\r\n ", "")
+	if want := "DESCRIPTION:" + strings.Repeat("á", 40) + "\r\n"; unfolded != want {
+		t.Errorf("expect unfolded line %q, got %q", want, unfolded)
+	}
+}
+
+func TestEscape(t *testing.T) {
+	got := escape("a,b;c\\d\ne")
+	want := `a\,b\;c\\d\ne`
+
+	if got != want {
+		t.Errorf("expect %s, got %s", want, got)
+	}
+}
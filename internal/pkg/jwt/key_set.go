@@ -0,0 +1,144 @@
+// Package jwt verifies the tokens of the municipal SSO (RFC 7519) against the
+// keys of a local JWKS file (RFC 7517). Tokens are signed with HS256, by a
+// shared secret, or RS256, by the private key of an RSA public key of the set.
+package jwt
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+)
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// key verifies the tokens of one algorithm, secret for HS256 and public for
+// RS256.
+type key struct {
+	alg    string
+	secret []byte
+	public *rsa.PublicKey
+}
+
+// KeySet are the keys tokens may be signed with, by their kid.
+type KeySet struct {
+	keys map[string]key
+}
+
+// LoadKeySet reads the JWKS file at path.
+func LoadKeySet(path string) (*KeySet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return ParseKeySet(data)
+}
+
+// ParseKeySet reads the oct and RSA keys of a JWKS document. Keys of other
+// types, as EC, are left out, as tokens signed with them are not accepted.
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var doc struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	ks := &KeySet{keys: map[string]key{}}
+	for _, j := range doc.Keys {
+		var (
+			k   key
+			err error
+		)
+		switch j.Kty {
+		case "oct":
+			k, err = octKey(j)
+		case "RSA":
+			k, err = rsaKey(j)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", j.Kid, err)
+		}
+		if _, ok := ks.keys[j.Kid]; ok {
+			return nil, fmt.Errorf("key %q is repeated", j.Kid)
+		}
+
+		ks.keys[j.Kid] = k
+	}
+
+	if len(ks.keys) == 0 {
+		return nil, errors.New("no HS256 or RS256 key in the set")
+	}
+
+	return ks, nil
+}
+
+// lookup returns the key of kid. Tokens without a kid are only accepted when
+// the set has a single key.
+func (ks *KeySet) lookup(kid string) (key, bool) {
+	if kid == "" && len(ks.keys) == 1 {
+		for _, k := range ks.keys {
+			return k, true
+		}
+	}
+
+	k, ok := ks.keys[kid]
+
+	return k, ok
+}
+
+func octKey(j jwk) (key, error) {
+	if j.Alg != "" && j.Alg != algHS256 {
+		return key{}, fmt.Errorf("algorithm %s is not supported", j.Alg)
+	}
+
+	secret, err := base64.RawURLEncoding.DecodeString(j.K)
+	if err != nil {
+		return key{}, fmt.Errorf("%w", err)
+	}
+	if len(secret) == 0 {
+		return key{}, errors.New("secret is empty")
+	}
+
+	return key{alg: algHS256, secret: secret}, nil
+}
+
+func rsaKey(j jwk) (key, error) {
+	if j.Alg != "" && j.Alg != algRS256 {
+		return key{}, fmt.Errorf("algorithm %s is not supported", j.Alg)
+	}
+
+	n, err := base64.RawURLEncoding.DecodeString(j.N)
+	if err != nil {
+		return key{}, fmt.Errorf("%w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(j.E)
+	if err != nil {
+		return key{}, fmt.Errorf("%w", err)
+	}
+
+	exp := new(big.Int).SetBytes(e)
+	if len(n) == 0 || !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > 1<<31-1 {
+		return key{}, errors.New("modulus or exponent is invalid")
+	}
+
+	return key{alg: algRS256, public: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}}, nil
+}
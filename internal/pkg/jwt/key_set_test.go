@@ -0,0 +1,142 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func rsaJWK(t *testing.T, kid string) (*rsa.PrivateKey, string) {
+	t.Helper()
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := base64.RawURLEncoding.EncodeToString(priv.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(priv.E)).Bytes())
+
+	return priv, fmt.Sprintf(`{"kty":"RSA","kid":%q,"alg":"RS256","n":%q,"e":%q}`, kid, n, e)
+}
+
+func octJWK(kid string, secret []byte) string {
+	return fmt.Sprintf(`{"kty":"oct","kid":%q,"k":%q}`, kid, base64.RawURLEncoding.EncodeToString(secret))
+}
+
+func TestParseKeySet(t *testing.T) {
+	_, rsaKey := rsaJWK(t, "rsa")
+	oct := octJWK("oct", []byte("s3cr3t"))
+
+	testCases := map[string]struct {
+		doc   string
+		wKeys map[string]string
+		wErr  bool
+	}{
+		"When the set has oct and RSA keys": {
+			doc:   `{"keys":[` + oct + `,` + rsaKey + `]}`,
+			wKeys: map[string]string{"oct": algHS256, "rsa": algRS256},
+		},
+		"When the set has keys of other types": {
+			doc:   `{"keys":[` + oct + `,{"kty":"EC","kid":"ec","crv":"P-256"}]}`,
+			wKeys: map[string]string{"oct": algHS256},
+		},
+		"When the set has only keys of other types": {
+			doc:  `{"keys":[{"kty":"EC","kid":"ec","crv":"P-256"}]}`,
+			wErr: true,
+		},
+		"When a key declares another algorithm": {
+			doc:  `{"keys":[{"kty":"oct","kid":"oct","alg":"HS512","k":"czNjcjN0"}]}`,
+			wErr: true,
+		},
+		"When a kid is repeated": {
+			doc:  `{"keys":[` + oct + `,` + oct + `]}`,
+			wErr: true,
+		},
+		"When a secret is empty": {
+			doc:  `{"keys":[{"kty":"oct","kid":"oct","k":""}]}`,
+			wErr: true,
+		},
+		"When the exponent is invalid": {
+			doc:  `{"keys":[{"kty":"RSA","kid":"rsa","n":"AQAB","e":"AQ"}]}`,
+			wErr: true,
+		},
+		"When the document is not JSON": {
+			doc:  `keys`,
+			wErr: true,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			ks, err := ParseKeySet([]byte(tc.doc))
+			if tc.wErr {
+				if err == nil {
+					t.Error("expect an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect return nil, got %v", err)
+			}
+
+			if len(ks.keys) != len(tc.wKeys) {
+				t.Errorf("expect %d keys, got %d", len(tc.wKeys), len(ks.keys))
+			}
+			for kid, alg := range tc.wKeys {
+				if k, ok := ks.keys[kid]; !ok || k.alg != alg {
+					t.Errorf("expect key %q for %s, got %+v", kid, alg, k)
+				}
+			}
+		})
+	}
+}
+
+func TestKeySet_lookup(t *testing.T) {
+	single, err := ParseKeySet([]byte(`{"keys":[` + octJWK("a", []byte("a")) + `]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	many, err := ParseKeySet([]byte(`{"keys":[` + octJWK("a", []byte("a")) + `,` + octJWK("b", []byte("b")) + `]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := map[string]struct {
+		ks  *KeySet
+		kid string
+		wOk bool
+	}{
+		"When the kid is in the set":                      {ks: many, kid: "b", wOk: true},
+		"When the kid is not in the set":                  {ks: many, kid: "c"},
+		"When there is no kid and a single key":           {ks: single, kid: "", wOk: true},
+		"When there is no kid and more than a single key": {ks: many, kid: ""},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			if _, ok := tc.ks.lookup(tc.kid); ok != tc.wOk {
+				t.Errorf("expect %v, got %v", tc.wOk, ok)
+			}
+		})
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(`{"keys":[`+octJWK("a", []byte("a"))+`]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadKeySet(path); err != nil {
+		t.Errorf("expect return nil, got %v", err)
+	}
+	if _, err := LoadKeySet(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expect an error, got nil")
+	}
+}
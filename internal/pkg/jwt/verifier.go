@@ -0,0 +1,201 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// clockSkew is how far the clock of the SSO may be from ours when checking exp
+// and nbf.
+const clockSkew = 30 * time.Second
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// claims are the registered claims checked and the private ones of the SSO,
+// roles and id_sub_th, the code of the sub-town hall of the user.
+type claims struct {
+	Sub     string          `json:"sub"`
+	Exp     *float64        `json:"exp"`
+	Nbf     *float64        `json:"nbf"`
+	Aud     json.RawMessage `json:"aud"`
+	Roles   json.RawMessage `json:"roles"`
+	IDSubTH string          `json:"id_sub_th"`
+}
+
+type clock func() time.Time
+
+// Verifier tells who a token is from. Roles maps the values of the roles claim
+// to the roles of the API, the highest one being the role of the user.
+type Verifier struct {
+	keys     *KeySet
+	audience string
+	roles    map[string]domain.Role
+	now      clock
+}
+
+func NewVerifier(keys *KeySet, audience string, roles map[string]domain.Role, now clock) *Verifier {
+	return &Verifier{keys, audience, roles, now}
+}
+
+// Verify returns the user of token. Tokens not signed by a key of the set, with
+// an algorithm other than the one of the key, expired, not valid yet, for
+// other audiences or without a subject are UnauthenticatedErrKd.
+func (v *Verifier) Verify(_ context.Context, token string) (domain.User, *domain.Error) {
+	invalid := &domain.Error{Kind: domain.UnauthenticatedErrKd, Msg: "Invalid bearer token"}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return domain.User{}, invalid
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return domain.User{}, invalid
+	}
+
+	k, ok := v.keys.lookup(h.Kid)
+	if !ok || k.alg != h.Alg {
+		return domain.User{}, invalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !k.verify(parts[0]+"."+parts[1], sig) {
+		return domain.User{}, invalid
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil || c.Sub == "" || c.Exp == nil {
+		return domain.User{}, invalid
+	}
+
+	now := v.now()
+	if !now.Before(unix(*c.Exp).Add(clockSkew)) {
+		return domain.User{}, &domain.Error{Kind: domain.UnauthenticatedErrKd, Msg: "The bearer token expired"}
+	}
+	if c.Nbf != nil && now.Add(clockSkew).Before(unix(*c.Nbf)) {
+		return domain.User{}, &domain.Error{Kind: domain.UnauthenticatedErrKd, Msg: "The bearer token is not valid yet"}
+	}
+
+	aud, err := stringList(c.Aud)
+	if err != nil || !contains(aud, v.audience) {
+		return domain.User{}, &domain.Error{Kind: domain.UnauthenticatedErrKd, Msg: "The bearer token is not for this API"}
+	}
+
+	roles, err := stringList(c.Roles)
+	if err != nil {
+		return domain.User{}, invalid
+	}
+
+	return domain.User{Subject: c.Sub, Role: v.role(roles), IDSubTH: c.IDSubTH}, nil
+}
+
+// role is the highest role the values of the roles claim map to.
+func (v *Verifier) role(values []string) domain.Role {
+	var best domain.Role
+	for _, val := range values {
+		r, ok := v.roles[val]
+		if ok && (best == "" || r.Includes(best)) {
+			best = r
+		}
+	}
+
+	return best
+}
+
+func (k key) verify(signed string, sig []byte) bool {
+	switch k.alg {
+	case algHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write([]byte(signed))
+		return hmac.Equal(mac.Sum(nil), sig)
+	case algRS256:
+		h := sha256.Sum256([]byte(signed))
+		return rsa.VerifyPKCS1v15(k.public, crypto.SHA256, h[:], sig) == nil
+	default:
+		return false
+	}
+}
+
+// ParseRoles reads the mapping of the values of the roles claim to the roles
+// of the API, as sso-feiras-admin=admin,sso-feiras=viewer. An empty one maps
+// every role to itself.
+func ParseRoles(s string) (map[string]domain.Role, error) {
+	roles := map[string]domain.Role{}
+	if s == "" {
+		for _, r := range domain.Roles() {
+			roles[string(r)] = r
+		}
+		return roles, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		val, r, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		role := domain.Role(r)
+		if !ok || val == "" || !role.Includes(role) {
+			return nil, fmt.Errorf("%q does not map a claim value to viewer, editor or admin", pair)
+		}
+		roles[val] = role
+	}
+
+	return roles, nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// stringList reads a claim that is either a string or a list of them, as aud.
+func stringList(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}, nil
+	}
+
+	var ss []string
+	if err := json.Unmarshal(raw, &ss); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return ss, nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// unix is the time of a NumericDate, seconds since the epoch.
+func unix(secs float64) time.Time {
+	return time.Unix(0, int64(secs*float64(time.Second)))
+}
@@ -0,0 +1,248 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func sign(t *testing.T, h map[string]interface{}, c map[string]interface{}, signer func([]byte) []byte) string {
+	t.Helper()
+
+	seg := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	signed := seg(h) + "." + seg(c)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signer([]byte(signed)))
+}
+
+func hs256(secret []byte) func([]byte) []byte {
+	return func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signed)
+		return mac.Sum(nil)
+	}
+}
+
+func rs256(t *testing.T, priv *rsa.PrivateKey) func([]byte) []byte {
+	return func(signed []byte) []byte {
+		h := sha256.Sum256(signed)
+		sig, err := rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, h[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	now := time.Date(2022, 10, 10, 12, 0, 0, 0, time.UTC)
+	secret := []byte("s3cr3t")
+	priv, rsaKey := rsaJWK(t, "rsa")
+
+	ks, err := ParseKeySet([]byte(`{"keys":[` + octJWK("oct", secret) + `,` + rsaKey + `]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roles := map[string]domain.Role{
+		"feiras-leitura": domain.RoleViewer,
+		"feiras-edicao":  domain.RoleEditor,
+		"feiras-admin":   domain.RoleAdmin,
+	}
+
+	claims := func(change func(map[string]interface{})) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":   "maria",
+			"aud":   "feiras-api",
+			"exp":   now.Add(time.Hour).Unix(),
+			"roles": []string{"feiras-leitura"},
+		}
+		if change != nil {
+			change(c)
+		}
+		return c
+	}
+	hsHeader := map[string]interface{}{"alg": "HS256", "kid": "oct"}
+	rsHeader := map[string]interface{}{"alg": "RS256", "kid": "rsa"}
+
+	testCases := map[string]struct {
+		token string
+		want  domain.User
+		wErr  string
+	}{
+		"When the token is signed with HS256": {
+			token: sign(t, hsHeader, claims(nil), hs256(secret)),
+			want:  domain.User{Subject: "maria", Role: domain.RoleViewer},
+		},
+		"When the token is signed with RS256": {
+			token: sign(t, rsHeader, claims(nil), rs256(t, priv)),
+			want:  domain.User{Subject: "maria", Role: domain.RoleViewer},
+		},
+		"When the user has many roles": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) {
+				c["roles"] = []string{"feiras-admin", "feiras-leitura", "feiras-edicao"}
+			}), hs256(secret)),
+			want: domain.User{Subject: "maria", Role: domain.RoleAdmin},
+		},
+		"When the user is of a sub-town hall": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) {
+				c["roles"] = "feiras-edicao"
+				c["id_sub_th"] = "26"
+			}), hs256(secret)),
+			want: domain.User{Subject: "maria", Role: domain.RoleEditor, IDSubTH: "26"},
+		},
+		"When the roles claim is a string": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) { c["roles"] = "feiras-edicao" }), hs256(secret)),
+			want:  domain.User{Subject: "maria", Role: domain.RoleEditor},
+		},
+		"When no role maps to the API": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) { c["roles"] = []string{"rh"} }), hs256(secret)),
+			want:  domain.User{Subject: "maria"},
+		},
+		"When the audience is a list": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) {
+				c["aud"] = []string{"outra-api", "feiras-api"}
+			}), hs256(secret)),
+			want: domain.User{Subject: "maria", Role: domain.RoleViewer},
+		},
+		"When the token expired within the clock skew": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) {
+				c["exp"] = now.Add(-10 * time.Second).Unix()
+			}), hs256(secret)),
+			want: domain.User{Subject: "maria", Role: domain.RoleViewer},
+		},
+		"When the token expired": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) {
+				c["exp"] = now.Add(-time.Minute).Unix()
+			}), hs256(secret)),
+			wErr: "The bearer token expired",
+		},
+		"When the token has no expiration": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) { delete(c, "exp") }), hs256(secret)),
+			wErr:  "Invalid bearer token",
+		},
+		"When the token is not valid yet": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) {
+				c["nbf"] = now.Add(time.Minute).Unix()
+			}), hs256(secret)),
+			wErr: "The bearer token is not valid yet",
+		},
+		"When the token is for another audience": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) { c["aud"] = "outra-api" }), hs256(secret)),
+			wErr:  "The bearer token is not for this API",
+		},
+		"When the token has no subject": {
+			token: sign(t, hsHeader, claims(func(c map[string]interface{}) { delete(c, "sub") }), hs256(secret)),
+			wErr:  "Invalid bearer token",
+		},
+		"When the signature is wrong": {
+			token: sign(t, hsHeader, claims(nil), hs256([]byte("outro"))),
+			wErr:  "Invalid bearer token",
+		},
+		"When the algorithm is not the one of the key": {
+			token: sign(t, map[string]interface{}{"alg": "HS256", "kid": "rsa"}, claims(nil), hs256(priv.N.Bytes())),
+			wErr:  "Invalid bearer token",
+		},
+		"When the algorithm is none": {
+			token: sign(t, map[string]interface{}{"alg": "none", "kid": "oct"}, claims(nil), func([]byte) []byte {
+				return nil
+			}),
+			wErr: "Invalid bearer token",
+		},
+		"When the kid is unknown": {
+			token: sign(t, map[string]interface{}{"alg": "HS256", "kid": "other"}, claims(nil), hs256(secret)),
+			wErr:  "Invalid bearer token",
+		},
+		"When the token is malformed": {
+			token: "not.a-token",
+			wErr:  "Invalid bearer token",
+		},
+	}
+
+	v := NewVerifier(ks, "feiras-api", roles, func() time.Time { return now })
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			got, gErr := v.Verify(context.TODO(), tc.token)
+
+			if tc.wErr != "" {
+				if gErr == nil || gErr.Kind != domain.UnauthenticatedErrKd || gErr.Msg != tc.wErr {
+					t.Errorf("Want error %q, got error %v", tc.wErr, gErr)
+				}
+				return
+			}
+			if gErr != nil {
+				t.Fatalf("expect return nil, got %v", gErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestParseRoles(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want map[string]domain.Role
+		wErr bool
+	}{
+		"When it is empty": {
+			in: "",
+			want: map[string]domain.Role{
+				"viewer": domain.RoleViewer,
+				"editor": domain.RoleEditor,
+				"admin":  domain.RoleAdmin,
+			},
+		},
+		"When it maps claim values": {
+			in:   "feiras-admin=admin, feiras=viewer",
+			want: map[string]domain.Role{"feiras-admin": domain.RoleAdmin, "feiras": domain.RoleViewer},
+		},
+		"When a role is unknown": {
+			in:   "feiras=owner",
+			wErr: true,
+		},
+		"When a pair has no role": {
+			in:   "feiras",
+			wErr: true,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			got, err := ParseRoles(tc.in)
+			if tc.wErr {
+				if err == nil {
+					t.Error("expect an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect return nil, got %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
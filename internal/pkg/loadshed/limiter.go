@@ -0,0 +1,253 @@
+// Package loadshed keeps the API from taking more requests than the database
+// can serve. Requests run up to a concurrency limit that adapts to their
+// latency, as in AIMD: it grows by one for every limit requests served in time
+// and backs off when they are slow or fail. A few requests over the limit wait
+// for a slot, critical ones first, and the rest are shed.
+package loadshed
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// Config is how the limit adapts and how many requests wait over it.
+type Config struct {
+	InitialLimit int
+	MinLimit     int
+	MaxLimit     int
+	// TargetLatency is the latency above which the limit backs off, by Backoff
+	// times.
+	TargetLatency time.Duration
+	Backoff       float64
+	// MaxQueue requests wait up to MaxWait for a slot.
+	MaxQueue int
+	MaxWait  time.Duration
+}
+
+type priority int
+
+const (
+	normal priority = iota
+	critical
+)
+
+func priorityOf(isCritical bool) priority {
+	if isCritical {
+		return critical
+	}
+
+	return normal
+}
+
+func (p priority) String() string {
+	if p == critical {
+		return "critical"
+	}
+
+	return "normal"
+}
+
+// waiter is a request waiting for a slot, told through admit whether it got
+// one or was shed.
+type waiter struct {
+	admit chan bool
+}
+
+type clock func() time.Time
+
+type Limiter struct {
+	cfg Config
+	now clock
+
+	mu       sync.Mutex
+	limit    float64
+	inFlight int
+	queues   [2][]*waiter
+	shed     [2]uint64
+}
+
+func NewLimiter(cfg Config, now clock) *Limiter {
+	return &Limiter{cfg: cfg, now: now, limit: float64(cfg.InitialLimit)}
+}
+
+// Acquire takes a slot for a request, critical ones going ahead of the others
+// and, when the queue is full, taking the place of the last of them. The
+// request must call release once done, telling whether it failed. When it is
+// shed, ok is false.
+func (l *Limiter) Acquire(ctx context.Context, isCritical bool) (release func(failed bool), ok bool) {
+	p := priorityOf(isCritical)
+
+	l.mu.Lock()
+	if l.inFlight < l.slots() && l.waitingAhead(p) == 0 {
+		l.inFlight++
+		l.mu.Unlock()
+		return l.releaser(), true
+	}
+
+	if l.queued() >= l.cfg.MaxQueue {
+		if p == normal || len(l.queues[normal]) == 0 {
+			l.shed[p]++
+			l.mu.Unlock()
+			return nil, false
+		}
+		last := l.queues[normal][len(l.queues[normal])-1]
+		l.queues[normal] = l.queues[normal][:len(l.queues[normal])-1]
+		l.shed[normal]++
+		last.admit <- false
+	}
+
+	w := &waiter{admit: make(chan bool, 1)}
+	l.queues[p] = append(l.queues[p], w)
+	l.mu.Unlock()
+
+	t := time.NewTimer(l.cfg.MaxWait)
+	defer t.Stop()
+
+	select {
+	case admitted := <-w.admit:
+		return l.admitted(admitted)
+	case <-t.C:
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	if l.remove(p, w) {
+		l.shed[p]++
+		l.mu.Unlock()
+		return nil, false
+	}
+	l.mu.Unlock()
+
+	// It was admitted or shed meanwhile.
+	return l.admitted(<-w.admit)
+}
+
+func (l *Limiter) admitted(ok bool) (func(failed bool), bool) {
+	if !ok {
+		return nil, false
+	}
+
+	return l.releaser(), true
+}
+
+// releaser frees the slot of a request started now, adapting the limit to how
+// it went and handing the slot to the next request waiting.
+func (l *Limiter) releaser() func(failed bool) {
+	start := l.now()
+	var once sync.Once
+
+	return func(failed bool) {
+		once.Do(func() {
+			latency := l.now().Sub(start)
+
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			// The limit only grows when it was in use, else requests served in
+			// time say nothing of whether more of them would be.
+			used := l.inFlight*2 >= l.slots()
+			l.inFlight--
+
+			switch {
+			case failed || latency > l.cfg.TargetLatency:
+				l.limit = math.Max(float64(l.cfg.MinLimit), l.limit*l.cfg.Backoff)
+			case used:
+				l.limit = math.Min(float64(l.cfg.MaxLimit), l.limit+1/l.limit)
+			}
+
+			l.dispatch()
+		})
+	}
+}
+
+// dispatch hands the free slots to the requests waiting, critical ones first.
+func (l *Limiter) dispatch() {
+	for l.inFlight < l.slots() {
+		p := critical
+		if len(l.queues[critical]) == 0 {
+			p = normal
+		}
+		if len(l.queues[p]) == 0 {
+			return
+		}
+
+		w := l.queues[p][0]
+		l.queues[p] = l.queues[p][1:]
+		l.inFlight++
+		w.admit <- true
+	}
+}
+
+func (l *Limiter) slots() int {
+	return int(l.limit)
+}
+
+// waitingAhead is how many requests wait that go before one of priority p.
+func (l *Limiter) waitingAhead(p priority) int {
+	if p == critical {
+		return len(l.queues[critical])
+	}
+
+	return l.queued()
+}
+
+func (l *Limiter) queued() int {
+	return len(l.queues[critical]) + len(l.queues[normal])
+}
+
+func (l *Limiter) remove(p priority, w *waiter) bool {
+	for i, v := range l.queues[p] {
+		if v == w {
+			l.queues[p] = append(l.queues[p][:i], l.queues[p][i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// Metrics are the limit, the requests in flight and waiting and the requests
+// shed by priority.
+func (l *Limiter) Metrics() []domain.Metric {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ms := []domain.Metric{
+		{
+			Name:  "unicoapi_concurrency_limit",
+			Help:  "Requests that may run at once.",
+			Kind:  domain.GaugeMetricKd,
+			Value: float64(l.slots()),
+		},
+		{
+			Name:  "unicoapi_concurrency_in_flight",
+			Help:  "Requests running.",
+			Kind:  domain.GaugeMetricKd,
+			Value: float64(l.inFlight),
+		},
+	}
+	for _, p := range []priority{critical, normal} {
+		ms = append(ms, domain.Metric{
+			Name:   "unicoapi_concurrency_queue_depth",
+			Help:   "Requests waiting for a slot.",
+			Kind:   domain.GaugeMetricKd,
+			Labels: map[string]string{"priority": p.String()},
+			Value:  float64(len(l.queues[p])),
+		})
+	}
+	for _, p := range []priority{critical, normal} {
+		ms = append(ms, domain.Metric{
+			Name:   "unicoapi_requests_shed_total",
+			Help:   "Requests refused as the API was over its concurrency limit.",
+			Kind:   domain.CounterMetricKd,
+			Labels: map[string]string{"priority": p.String()},
+			Value:  float64(l.shed[p]),
+		})
+	}
+
+	return ms
+}
@@ -0,0 +1,211 @@
+package loadshed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubClock struct {
+	now time.Time
+}
+
+func (c *stubClock) Now() time.Time {
+	return c.now
+}
+
+func config() Config {
+	return Config{
+		InitialLimit:  2,
+		MinLimit:      1,
+		MaxLimit:      4,
+		TargetLatency: 100 * time.Millisecond,
+		Backoff:       0.5,
+		MaxQueue:      1,
+		MaxWait:       time.Second,
+	}
+}
+
+type result struct {
+	release func(bool)
+	ok      bool
+}
+
+// acquireAsync acquires a slot in the background, once it is waiting for one.
+func acquireAsync(t *testing.T, l *Limiter, isCritical bool) <-chan result {
+	t.Helper()
+
+	p := priorityOf(isCritical)
+	l.mu.Lock()
+	queued := len(l.queues[p])
+	l.mu.Unlock()
+
+	done := make(chan result, 1)
+	go func() {
+		release, ok := l.Acquire(context.TODO(), isCritical)
+		done <- result{release, ok}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		l.mu.Lock()
+		n := len(l.queues[p])
+		l.mu.Unlock()
+		if n > queued {
+			return done
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("expect the request waiting for a slot")
+
+	return nil
+}
+
+func TestLimiter_Acquire(t *testing.T) {
+	clock := &stubClock{now: time.Date(2022, 10, 12, 12, 0, 0, 0, time.UTC)}
+	l := NewLimiter(config(), clock.Now)
+
+	first, ok := l.Acquire(context.TODO(), false)
+	if !ok {
+		t.Fatal("expect the first request admitted")
+	}
+	if _, ok := l.Acquire(context.TODO(), false); !ok {
+		t.Fatal("expect the second request admitted")
+	}
+
+	waiting := acquireAsync(t, l, false)
+
+	if _, ok := l.Acquire(context.TODO(), false); ok {
+		t.Error("expect a request over the full queue shed")
+	}
+
+	// A critical request takes the place of the one waiting.
+	waitingCritical := acquireAsync(t, l, true)
+	if r := <-waiting; r.ok {
+		t.Error("expect the request waiting shed for the critical one")
+	}
+
+	first(false)
+	if r := <-waitingCritical; !r.ok {
+		t.Error("expect the critical request admitted once a slot is free")
+	}
+
+	want := []domain.Metric{
+		{Name: "unicoapi_concurrency_limit", Kind: domain.GaugeMetricKd, Value: 2},
+		{Name: "unicoapi_concurrency_in_flight", Kind: domain.GaugeMetricKd, Value: 2},
+		{
+			Name:   "unicoapi_concurrency_queue_depth",
+			Kind:   domain.GaugeMetricKd,
+			Labels: map[string]string{"priority": "critical"},
+		},
+		{
+			Name:   "unicoapi_concurrency_queue_depth",
+			Kind:   domain.GaugeMetricKd,
+			Labels: map[string]string{"priority": "normal"},
+		},
+		{
+			Name:   "unicoapi_requests_shed_total",
+			Kind:   domain.CounterMetricKd,
+			Labels: map[string]string{"priority": "critical"},
+		},
+		{
+			Name:   "unicoapi_requests_shed_total",
+			Kind:   domain.CounterMetricKd,
+			Labels: map[string]string{"priority": "normal"},
+			Value:  2,
+		},
+	}
+	for i := range want {
+		want[i].Help = l.Metrics()[i].Help
+	}
+	if diff := cmp.Diff(want, l.Metrics()); diff != "" {
+		t.Errorf("mismatch of the metrics (-want +got):\n%s", diff)
+	}
+}
+
+func TestLimiter_Acquire_Timeout(t *testing.T) {
+	cfg := config()
+	cfg.InitialLimit = 1
+	cfg.MaxWait = 10 * time.Millisecond
+	l := NewLimiter(cfg, time.Now)
+
+	if _, ok := l.Acquire(context.TODO(), false); !ok {
+		t.Fatal("expect the first request admitted")
+	}
+
+	if _, ok := l.Acquire(context.TODO(), false); ok {
+		t.Error("expect the request that waited too long shed")
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if _, ok := l.Acquire(ctx, true); ok {
+		t.Error("expect the request gone shed")
+	}
+
+	if l.queued() != 0 || l.shed[normal] != 1 || l.shed[critical] != 1 {
+		t.Errorf("expect the requests shed out of the queue, got %d waiting and %v shed", l.queued(), l.shed)
+	}
+}
+
+func TestLimiter_release(t *testing.T) {
+	testCases := map[string]struct {
+		latency   time.Duration
+		failed    bool
+		inFlight  int
+		wantLimit float64
+	}{
+		"When the request is served in time with the limit in use": {
+			latency:   50 * time.Millisecond,
+			inFlight:  4,
+			wantLimit: 4.25,
+		},
+		"When the request is served in time with the limit unused": {
+			latency:   50 * time.Millisecond,
+			inFlight:  1,
+			wantLimit: 4,
+		},
+		"When the request is slow": {
+			latency:   200 * time.Millisecond,
+			inFlight:  4,
+			wantLimit: 2,
+		},
+		"When the request fails": {
+			latency:   50 * time.Millisecond,
+			failed:    true,
+			inFlight:  4,
+			wantLimit: 2,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			cfg := config()
+			cfg.InitialLimit = 4
+			cfg.MaxLimit = 8
+			clock := &stubClock{now: time.Date(2022, 10, 12, 12, 0, 0, 0, time.UTC)}
+			l := NewLimiter(cfg, clock.Now)
+
+			release, ok := l.Acquire(context.TODO(), false)
+			if !ok {
+				t.Fatal("expect the request admitted")
+			}
+			// The request along with the others in flight.
+			l.inFlight = tc.inFlight
+
+			clock.now = clock.now.Add(tc.latency)
+			release(tc.failed)
+			// Releasing twice frees a single slot.
+			release(tc.failed)
+
+			if l.limit != tc.wantLimit {
+				t.Errorf("expect limit %v, got %v", tc.wantLimit, l.limit)
+			}
+			if l.inFlight != tc.inFlight-1 {
+				t.Errorf("expect %d requests in flight, got %d", tc.inFlight-1, l.inFlight)
+			}
+		})
+	}
+}
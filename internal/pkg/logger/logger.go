@@ -3,6 +3,7 @@ package logger
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -15,10 +16,17 @@ type log struct {
 	Level      string                 `json:"level,omitempty"`
 	Msg        string                 `json:"msg,omitempty"`
 	TraceID    string                 `json:"trace_id,omitempty"`
+	APIKeyID   string                 `json:"api_key_id,omitempty"`
+	Subject    string                 `json:"subject,omitempty"`
 	MetaData   map[string]interface{} `json:"meta_data,omitempty"`
 	StackTrace map[string]string      `json:"stack_trace,omitempty"`
 }
 
+// sqlStater is implemented by database driver errors, as pq.Error.
+type sqlStater interface {
+	SQLState() string
+}
+
 type Logger struct {
 	writer io.Writer
 	pretty bool
@@ -53,12 +61,23 @@ func (l *Logger) Errorf(ctx context.Context, err domain.Error, md map[string]int
 	msg := err.Error()
 
 	stackTrace := map[string]string{}
+	last := &err
 	prevErr := err.Previous
 	for prevErr != nil {
 		stackTrace[string(prevErr.Kind)] = prevErr.Error()
+		last = prevErr
 		prevErr = prevErr.Previous
 	}
 
+	if last.Cause != nil {
+		stackTrace["cause"] = last.Cause.Error()
+
+		var st sqlStater
+		if errors.As(last.Cause, &st) {
+			stackTrace["sqlstate"] = st.SQLState()
+		}
+	}
+
 	l.print(ctx, domain.LogLevelError, msg, md, stackTrace)
 }
 
@@ -77,6 +96,8 @@ func (l *Logger) print(
 		Level:      lvl,
 		Msg:        msg,
 		TraceID:    traceID,
+		APIKeyID:   getAPIKeyID(ctx),
+		Subject:    getSubject(ctx),
 		MetaData:   metaData,
 		StackTrace: stackTrace,
 	}
@@ -108,3 +129,19 @@ func getTraceID(ctx context.Context) string {
 	}
 	return v.(string)
 }
+
+// getAPIKeyID is the ID of the key the request was made with, empty when it
+// was made without one.
+func getAPIKeyID(ctx context.Context) string {
+	k, _ := domain.APIKeyFrom(ctx)
+
+	return k.ID
+}
+
+// getSubject is the subject of the user the request was made by, empty when it
+// was made without a bearer token.
+func getSubject(ctx context.Context) string {
+	u, _ := domain.UserFrom(ctx)
+
+	return u.Subject
+}
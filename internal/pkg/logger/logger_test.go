@@ -20,14 +20,18 @@ func (s *stubWriter) Write(p []byte) (n int, err error) {
 }
 
 const (
-	traceID = "tracing"
-	msg     = "Log message"
+	traceID  = "tracing"
+	apiKeyID = "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"
+	subject  = "maria"
+	msg      = "Log message"
 )
 
 func TestLogger_print(t *testing.T) {
 	pretty := []bool{false, true}
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, domain.TraceIDCtxKey, traceID)
+	ctx = domain.WithAPIKey(ctx, domain.APIKey{ID: apiKeyID})
+	ctx = domain.WithUser(ctx, domain.User{Subject: subject})
 
 	lvl := "test"
 	metaData := map[string]interface{}{
@@ -74,6 +78,14 @@ func TestLogger_print(t *testing.T) {
 			t.Errorf("expect error with traceID as %v, got as %v", domain.TraceIDCtxKey, gotL.TraceID)
 		}
 
+		if apiKeyID != gotL.APIKeyID {
+			t.Errorf("expect error with API key ID as %v, got as %v", apiKeyID, gotL.APIKeyID)
+		}
+
+		if subject != gotL.Subject {
+			t.Errorf("expect error with subject as %v, got as %v", subject, gotL.Subject)
+		}
+
 		if diff := cmp.Diff(metaData, gotL.MetaData); diff != "" {
 			t.Errorf("unexpected metaData (-want +got):\n%s", diff)
 		}
@@ -84,6 +96,28 @@ func TestLogger_print(t *testing.T) {
 	}
 }
 
+func TestLogger_getAPIKeyID(t *testing.T) {
+	if id := getAPIKeyID(context.Background()); id != "" {
+		t.Errorf("expect id \"\", got %s", id)
+	}
+
+	ctx := domain.WithAPIKey(context.Background(), domain.APIKey{ID: apiKeyID})
+	if id := getAPIKeyID(ctx); id != apiKeyID {
+		t.Errorf("expect id %s, got %s", apiKeyID, id)
+	}
+}
+
+func TestLogger_getSubject(t *testing.T) {
+	if s := getSubject(context.Background()); s != "" {
+		t.Errorf("expect subject \"\", got %s", s)
+	}
+
+	ctx := domain.WithUser(context.Background(), domain.User{Subject: subject, Role: domain.RoleViewer})
+	if s := getSubject(ctx); s != subject {
+		t.Errorf("expect subject %s, got %s", subject, s)
+	}
+}
+
 func TestLogget_getTraceID(t *testing.T) {
 	t.Run("With keys in context", func(t *testing.T) {
 		ctx := context.Background()
@@ -302,6 +336,40 @@ func TestLogger_Errorf(t *testing.T) {
 	}
 }
 
+type stubSQLError struct{}
+
+func (stubSQLError) Error() string    { return "pq: duplicate key value violates unique constraint" }
+func (stubSQLError) SQLState() string { return "23505" }
+
+func TestLogger_Errorf_Cause(t *testing.T) {
+	writerMock := &stubWriter{
+		write: func(p []byte) (n int, err error) {
+			return 1, nil
+		},
+	}
+
+	lgg := NewLogger(writerMock, false)
+
+	errB := &domain.Error{Kind: domain.DuplicatedErrKd, Msg: "Ground base", Cause: stubSQLError{}}
+	errInp := &domain.Error{Kind: domain.StallDupErrKd, Msg: "Roof Error", Previous: errB}
+
+	lgg.Errorf(context.Background(), *errInp, nil)
+
+	var gotL log
+	if err := json.Unmarshal(writerMock.writeInp, &gotL); err != nil {
+		t.Fatal(err)
+	}
+
+	stackTrace := map[string]string{
+		string(errB.Kind): errB.Error(),
+		"cause":           "pq: duplicate key value violates unique constraint",
+		"sqlstate":        "23505",
+	}
+	if diff := cmp.Diff(stackTrace, gotL.StackTrace); diff != "" {
+		t.Errorf("unexpected stack trace (-want +got):\n%s", diff)
+	}
+}
+
 func TestLogger_Info(t *testing.T) {
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, domain.TraceIDCtxKey, traceID)
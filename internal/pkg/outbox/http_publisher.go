@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// responseDrainLimit bounds what is read of a response before closing it, so
+// the connection can be reused.
+const responseDrainLimit = 64 << 10
+
+type httpDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// HTTPPublisher posts every event to url as JSON. Any status but a 2xx fails
+// the publish.
+type HTTPPublisher struct {
+	client httpDoer
+	url    string
+}
+
+func NewHTTPPublisher(client httpDoer, url string) *HTTPPublisher {
+	return &HTTPPublisher{client, url}
+}
+
+func (p *HTTPPublisher) Publish(ctx context.Context, ev domain.MarketEvent) *domain.Error {
+	b, err := json.Marshal(NewMessage(ev))
+	if err != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(b))
+	if err != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", ev.ID)
+	req.Header.Set("X-Event-Type", string(ev.Type))
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, responseDrainLimit)) //nolint:errcheck
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: fmt.Sprintf("unexpected status %d", res.StatusCode)}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHTTPPublisher_Publish(t *testing.T) {
+	ev := domain.MarketEvent{
+		ID:       "e1",
+		Type:     domain.MarketCreatedEvent,
+		MarketID: "1",
+		After:    &domain.StreetMarket{ID: "1", Name: "VILA"},
+	}
+
+	var (
+		got     Message
+		headers http.Header
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	p := NewHTTPPublisher(srv.Client(), srv.URL)
+
+	if err := p.Publish(context.TODO(), ev); err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(NewMessage(ev), got); diff != "" {
+		t.Errorf("unexpected message posted (-want +got):\n%s", diff)
+	}
+
+	wHeaders := map[string]string{
+		"Content-Type": "application/json",
+		"X-Event-Id":   "e1",
+		"X-Event-Type": "street_market.created",
+	}
+	for k, v := range wHeaders {
+		if got := headers.Get(k); got != v {
+			t.Errorf("expect header %s %q, got %q", k, v, got)
+		}
+	}
+}
+
+func TestHTTPPublisher_Publish_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL
+	down.Close()
+
+	testCases := map[string]struct {
+		url  string
+		wMsg string
+	}{
+		"When the response is not a 2xx": {
+			url:  srv.URL,
+			wMsg: "unexpected status 503",
+		},
+		"When there is no response": {
+			url: downURL,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			p := NewHTTPPublisher(http.DefaultClient, tc.url)
+
+			gErr := p.Publish(context.TODO(), domain.MarketEvent{ID: "e1"})
+			if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+				t.Fatalf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+			}
+
+			if tc.wMsg != "" && gErr.Msg != tc.wMsg {
+				t.Errorf("Want error message %q, got %q", tc.wMsg, gErr.Msg)
+			}
+		})
+	}
+}
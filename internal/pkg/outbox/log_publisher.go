@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// LogPublisher writes every event to w as a line of JSON, as to stdout for a
+// log collector to ship.
+type LogPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewLogPublisher(w io.Writer) *LogPublisher {
+	return &LogPublisher{w: w}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, ev domain.MarketEvent) *domain.Error {
+	b, err := json.Marshal(NewMessage(ev))
+	if err != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.w.Write(append(b, '\n')); err != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+
+	return nil
+}
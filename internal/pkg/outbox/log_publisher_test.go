@@ -0,0 +1,52 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("closed")
+}
+
+func TestLogPublisher_Publish(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+
+	p := NewLogPublisher(&buf)
+
+	ev := domain.MarketEvent{
+		ID:         "e1",
+		Type:       domain.MarketDeletedEvent,
+		MarketID:   "1",
+		Before:     &domain.StreetMarket{ID: "1", Name: "VILA"},
+		OccurredAt: &at,
+	}
+	if err := p.Publish(context.TODO(), ev); err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	want := `{"id":"e1","type":"street_market.deleted","street_market_id":"1",` +
+		`"occurred_at":"2026-10-19T10:00:00Z","before":{"id":"1","long":0,"lat":0,"sect_cens":"","area":"",` +
+		`"id_dist":"","district":"","id_sub_th":"","subtownhall":"","region_5":"","region_8":"","name":"VILA",` +
+		`"register":"","street":"","number":"","neighborhood":"","addr_extra_info":""},"after":null}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("expect line %s, got %s", want, got)
+	}
+}
+
+func TestLogPublisher_Publish_Error(t *testing.T) {
+	p := NewLogPublisher(failingWriter{})
+
+	gErr := p.Publish(context.TODO(), domain.MarketEvent{ID: "e1"})
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+}
@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// Publisher sends the street market events to whoever consumes them. An event
+// may be published more than once, as it is published again when marking it as
+// published fails, so consumers should dedupe by its ID.
+type Publisher interface {
+	Publish(ctx context.Context, ev domain.MarketEvent) *domain.Error
+}
+
+// Publishers publishes every event to all of them, failing if any fails. The
+// ones that succeeded receive the event again on the retry.
+type Publishers []Publisher
+
+func (ps Publishers) Publish(ctx context.Context, ev domain.MarketEvent) *domain.Error {
+	var first *domain.Error
+	for _, p := range ps {
+		if err := p.Publish(ctx, ev); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// Message is an event as the built in publishers send it.
+type Message struct {
+	ID             string           `json:"id"`
+	Type           domain.EventType `json:"type"`
+	StreetMarketID string           `json:"street_market_id"`
+	OccurredAt     time.Time        `json:"occurred_at"`
+	Before         *MarketData      `json:"before"`
+	After          *MarketData      `json:"after"`
+}
+
+func NewMessage(ev domain.MarketEvent) Message {
+	m := Message{ID: ev.ID, Type: ev.Type, StreetMarketID: ev.MarketID}
+	if ev.OccurredAt != nil {
+		m.OccurredAt = ev.OccurredAt.UTC()
+	}
+	if ev.Before != nil {
+		d := NewMarketData(*ev.Before)
+		m.Before = &d
+	}
+	if ev.After != nil {
+		d := NewMarketData(*ev.After)
+		m.After = &d
+	}
+
+	return m
+}
+
+// MarketData is a street market as the events carry it.
+type MarketData struct {
+	ID            string  `json:"id"`
+	Long          float64 `json:"long"`
+	Lat           float64 `json:"lat"`
+	SectCens      string  `json:"sect_cens"`
+	Area          string  `json:"area"`
+	IDdist        string  `json:"id_dist"`
+	District      string  `json:"district"`
+	IDSubTH       string  `json:"id_sub_th"`
+	SubTownHall   string  `json:"subtownhall"`
+	Region5       string  `json:"region_5"`
+	Region8       string  `json:"region_8"`
+	Name          string  `json:"name"`
+	Register      string  `json:"register"`
+	Street        string  `json:"street"`
+	Number        string  `json:"number"`
+	Neighborhood  string  `json:"neighborhood"`
+	AddrExtraInfo string  `json:"addr_extra_info"`
+}
+
+func NewMarketData(sm domain.StreetMarket) MarketData {
+	return MarketData{
+		ID:            sm.ID,
+		Long:          sm.Long,
+		Lat:           sm.Lat,
+		SectCens:      sm.SectCens,
+		Area:          sm.Area,
+		IDdist:        sm.IDdist,
+		District:      sm.District,
+		IDSubTH:       sm.IDSubTH,
+		SubTownHall:   sm.SubTownHall,
+		Region5:       sm.Region5,
+		Region8:       sm.Region8,
+		Name:          sm.Name,
+		Register:      sm.Register,
+		Street:        sm.Street,
+		Number:        sm.Number,
+		Neighborhood:  sm.Neighborhood,
+		AddrExtraInfo: sm.AddrExtraInfo,
+	}
+}
@@ -0,0 +1,62 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubPublisher struct {
+	published []domain.MarketEvent
+	err       *domain.Error
+}
+
+func (s *stubPublisher) Publish(_ context.Context, ev domain.MarketEvent) *domain.Error {
+	s.published = append(s.published, ev)
+	return s.err
+}
+
+func TestPublishers_Publish(t *testing.T) {
+	failing := &stubPublisher{err: &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "down"}}
+	ok := &stubPublisher{}
+	ev := domain.MarketEvent{ID: "e1"}
+
+	gErr := Publishers{failing, ok}.Publish(context.TODO(), ev)
+	if gErr != failing.err {
+		t.Errorf("expect the error of the failing publisher, got %v", gErr)
+	}
+
+	if len(ok.published) != 1 {
+		t.Errorf("expect every publisher to receive the event, got %v", ok.published)
+	}
+}
+
+func TestNewMessage(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 7, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+	before := domain.StreetMarket{ID: "1", Name: "VILA FORMOSA"}
+	after := domain.StreetMarket{ID: "1", Name: "VILA CARRAO"}
+
+	got := NewMessage(domain.MarketEvent{
+		ID:         "e1",
+		Type:       domain.MarketUpdatedEvent,
+		MarketID:   "1",
+		Before:     &before,
+		After:      &after,
+		OccurredAt: &at,
+	})
+
+	want := Message{
+		ID:             "e1",
+		Type:           domain.MarketUpdatedEvent,
+		StreetMarketID: "1",
+		OccurredAt:     time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC),
+		Before:         &MarketData{ID: "1", Name: "VILA FORMOSA"},
+		After:          &MarketData{ID: "1", Name: "VILA CARRAO"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected message (-want +got):\n%s", diff)
+	}
+}
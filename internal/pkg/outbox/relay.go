@@ -0,0 +1,178 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+const (
+	// relayBatch is how many events are claimed at a time. They are published
+	// one after the other, so claimLease should outlast publishing all of them;
+	// when it does not, an event may be published twice but never out of order.
+	relayBatch = 100
+	claimLease = 5 * time.Minute
+)
+
+type repositoryRelay interface {
+	ClaimEvents(ctx context.Context, now, until time.Time, limit int) ([]domain.OutboxEvent, *domain.Error)
+	MarkPublished(ctx context.Context, ID string, at time.Time) *domain.Error
+	MarkFailed(ctx context.Context, ID string, attempts int, next time.Time, reason string) *domain.Error
+	MarkDead(ctx context.Context, ID string, attempts int, at time.Time, reason string) *domain.Error
+}
+
+type relayLogger interface {
+	Warnf(ctx context.Context, msg string, md map[string]interface{})
+	Error(context.Context, domain.Error)
+}
+
+// Retry is how failed events are retried. The n-th retry waits Backoff doubled
+// n-1 times, up to MaxBackoff, and an event is dead once MaxAttempts attempts
+// failed, so one the publisher always refuses does not hold back the later
+// events of its street market forever.
+type Retry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+func (r Retry) delay(attempt int) time.Duration {
+	d := r.Backoff
+	for i := 1; i < attempt && d < r.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+
+	return d
+}
+
+// OutboxRelay publishes the events written to the outbox along with the street
+// market changes, at least once each.
+type OutboxRelay struct {
+	repo      repositoryRelay
+	publisher Publisher
+	retry     Retry
+	now       func() time.Time
+	logger    relayLogger
+}
+
+func NewRelay(
+	repo repositoryRelay,
+	publisher Publisher,
+	retry Retry,
+	now func() time.Time,
+	logger relayLogger,
+) *OutboxRelay {
+	return &OutboxRelay{repo, publisher, retry, now, logger}
+}
+
+// Run relays the due events every interval until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		for {
+			n, err := r.Relay(ctx)
+			if err != nil {
+				r.logger.Error(ctx, *err)
+			}
+			// Publishing an event of a street market lets its next event
+			// be claimed, so claim until none is due.
+			if err != nil || n == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Relay publishes a batch of the events due now, oldest first, and returns how
+// many were claimed. A batch has the oldest unpublished event of each street
+// market only, so a later event is not claimed until the ones before it are
+// published.
+func (r *OutboxRelay) Relay(ctx context.Context) (int, *domain.Error) {
+	now := r.now()
+	evs, err := r.repo.ClaimEvents(ctx, now, now.Add(claimLease), relayBatch)
+	if err != nil {
+		return 0, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when claim events", Previous: err}
+	}
+
+	for _, oe := range evs {
+		r.publish(ctx, oe)
+	}
+
+	return len(evs), nil
+}
+
+// publish publishes oe once and records how it went.
+func (r *OutboxRelay) publish(ctx context.Context, oe domain.OutboxEvent) {
+	pErr := r.publisher.Publish(ctx, oe.Event)
+	at := r.now()
+
+	if pErr == nil {
+		if err := r.repo.MarkPublished(ctx, oe.Event.ID, at); err != nil {
+			r.logger.Error(ctx, domain.Error{
+				Kind:     domain.UnexpectedErrKd,
+				Msg:      "Unexpected error when mark event published",
+				Previous: err,
+			})
+		}
+		return
+	}
+
+	attempts := oe.Attempts + 1
+	if attempts >= r.retry.MaxAttempts {
+		r.dead(ctx, oe, attempts, at, pErr)
+		return
+	}
+
+	next := at.Add(r.retry.delay(attempts))
+	r.logger.Warnf(ctx, "Outbox event publish failed", map[string]interface{}{
+		"event_id":         oe.Event.ID,
+		"event":            oe.Event.Type,
+		"street_market_id": oe.Event.MarketID,
+		"attempt":          attempts,
+		"error":            pErr.Error(),
+		"next_attempt_at":  next,
+	})
+
+	if err := r.repo.MarkFailed(ctx, oe.Event.ID, attempts, next, pErr.Error()); err != nil {
+		r.logger.Error(ctx, domain.Error{
+			Kind:     domain.UnexpectedErrKd,
+			Msg:      "Unexpected error when mark event failed",
+			Previous: err,
+		})
+	}
+}
+
+// dead gives up on oe, whose last attempt failed with pErr.
+func (r *OutboxRelay) dead(ctx context.Context, oe domain.OutboxEvent, attempts int, at time.Time, pErr *domain.Error) {
+	r.logger.Error(ctx, domain.Error{
+		Kind: domain.UnexpectedErrKd,
+		Msg: fmt.Sprintf(
+			"Outbox event %s of street market %s is dead after %d attempts",
+			oe.Event.ID,
+			oe.Event.MarketID,
+			attempts,
+		),
+		Previous: pErr,
+	})
+
+	if err := r.repo.MarkDead(ctx, oe.Event.ID, attempts, at, pErr.Error()); err != nil {
+		r.logger.Error(ctx, domain.Error{
+			Kind:     domain.UnexpectedErrKd,
+			Msg:      "Unexpected error when mark event dead",
+			Previous: err,
+		})
+	}
+}
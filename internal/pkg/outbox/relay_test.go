@@ -0,0 +1,222 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type failure struct {
+	ID       string
+	Attempts int
+	Next     time.Time
+	Reason   string
+}
+
+type stubRepositoryRelay struct {
+	claim     func(ctx context.Context, now, until time.Time, limit int) ([]domain.OutboxEvent, *domain.Error)
+	published []string
+	failed    []failure
+	dead      []failure
+	markErr   *domain.Error
+}
+
+func (s *stubRepositoryRelay) ClaimEvents(
+	ctx context.Context,
+	now, until time.Time,
+	limit int,
+) ([]domain.OutboxEvent, *domain.Error) {
+	return s.claim(ctx, now, until, limit)
+}
+
+func (s *stubRepositoryRelay) MarkPublished(_ context.Context, ID string, _ time.Time) *domain.Error {
+	s.published = append(s.published, ID)
+	return s.markErr
+}
+
+func (s *stubRepositoryRelay) MarkFailed(
+	_ context.Context,
+	ID string,
+	attempts int,
+	next time.Time,
+	reason string,
+) *domain.Error {
+	s.failed = append(s.failed, failure{ID, attempts, next, reason})
+	return s.markErr
+}
+
+func (s *stubRepositoryRelay) MarkDead(
+	_ context.Context,
+	ID string,
+	attempts int,
+	at time.Time,
+	reason string,
+) *domain.Error {
+	s.dead = append(s.dead, failure{ID, attempts, at, reason})
+	return s.markErr
+}
+
+// stubMarketPublisher fails the events of the street markets in fail.
+type stubMarketPublisher struct {
+	fail      map[string]bool
+	published []string
+}
+
+func (s *stubMarketPublisher) Publish(_ context.Context, ev domain.MarketEvent) *domain.Error {
+	s.published = append(s.published, ev.ID)
+	if s.fail[ev.MarketID] {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "unexpected status 503"}
+	}
+	return nil
+}
+
+type stubLogger struct {
+	errors []domain.Error
+	warns  []map[string]interface{}
+}
+
+func (s *stubLogger) Error(_ context.Context, err domain.Error) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *stubLogger) Warnf(_ context.Context, _ string, md map[string]interface{}) {
+	s.warns = append(s.warns, md)
+}
+
+func TestRetry_delay(t *testing.T) {
+	r := Retry{Backoff: 5 * time.Second, MaxBackoff: 30 * time.Second}
+
+	want := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 30 * time.Second, 30 * time.Second}
+	for i, w := range want {
+		if got := r.delay(i + 1); got != w {
+			t.Errorf("expect delay %v after attempt %d, got %v", w, i+1, got)
+		}
+	}
+}
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	repoMock := &stubRepositoryRelay{
+		claim: func(_ context.Context, gNow, until time.Time, limit int) ([]domain.OutboxEvent, *domain.Error) {
+			if !gNow.Equal(now) || !until.Equal(now.Add(claimLease)) || limit != relayBatch {
+				t.Errorf("unexpected claim %v %v %d", gNow, until, limit)
+			}
+			return []domain.OutboxEvent{
+				{Event: domain.MarketEvent{ID: "a-created", MarketID: "a"}},
+				{Event: domain.MarketEvent{ID: "b-created", MarketID: "b"}, Attempts: 1},
+				{Event: domain.MarketEvent{ID: "c-updated", MarketID: "c"}},
+			}, nil
+		},
+	}
+	pubMock := &stubMarketPublisher{fail: map[string]bool{"b": true}}
+	logMock := &stubLogger{}
+
+	retry := Retry{MaxAttempts: 5, Backoff: 5 * time.Second, MaxBackoff: time.Minute}
+	r := NewRelay(repoMock, pubMock, retry, func() time.Time { return now }, logMock)
+
+	n, err := r.Relay(context.TODO())
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expect 3 events claimed, got %d", n)
+	}
+
+	if diff := cmp.Diff([]string{"a-created", "b-created", "c-updated"}, pubMock.published); diff != "" {
+		t.Errorf("unexpected events published (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"a-created", "c-updated"}, repoMock.published); diff != "" {
+		t.Errorf("unexpected events marked published (-want +got):\n%s", diff)
+	}
+
+	wFailed := []failure{{ID: "b-created", Attempts: 2, Next: now.Add(10 * time.Second), Reason: "unexpected status 503"}}
+	if diff := cmp.Diff(wFailed, repoMock.failed); diff != "" {
+		t.Errorf("unexpected events marked failed (-want +got):\n%s", diff)
+	}
+
+	if len(logMock.warns) != 1 || len(logMock.errors) != 0 {
+		t.Errorf("expect the failure logged, got %v and %v", logMock.warns, logMock.errors)
+	}
+}
+
+func TestOutboxRelay_Relay_Dead(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	repoMock := &stubRepositoryRelay{
+		claim: func(context.Context, time.Time, time.Time, int) ([]domain.OutboxEvent, *domain.Error) {
+			return []domain.OutboxEvent{
+				{Event: domain.MarketEvent{ID: "a-created", MarketID: "a"}, Attempts: 4},
+				{Event: domain.MarketEvent{ID: "b-created", MarketID: "b"}, Attempts: 4},
+			}, nil
+		},
+	}
+	pubMock := &stubMarketPublisher{fail: map[string]bool{"a": true}}
+	logMock := &stubLogger{}
+
+	retry := Retry{MaxAttempts: 5, Backoff: 5 * time.Second, MaxBackoff: time.Minute}
+	r := NewRelay(repoMock, pubMock, retry, func() time.Time { return now }, logMock)
+
+	if _, err := r.Relay(context.TODO()); err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	wDead := []failure{{ID: "a-created", Attempts: 5, Next: now, Reason: "unexpected status 503"}}
+	if diff := cmp.Diff(wDead, repoMock.dead); diff != "" {
+		t.Errorf("unexpected events marked dead (-want +got):\n%s", diff)
+	}
+	if len(repoMock.failed) != 0 {
+		t.Errorf("expect no event retried, got %v", repoMock.failed)
+	}
+	if diff := cmp.Diff([]string{"b-created"}, repoMock.published); diff != "" {
+		t.Errorf("unexpected events marked published (-want +got):\n%s", diff)
+	}
+
+	// Giving up is logged as an error, not a warning as the failures before.
+	if len(logMock.errors) != 1 || len(logMock.warns) != 0 {
+		t.Errorf("expect the dead event logged as an error, got %v and %v", logMock.errors, logMock.warns)
+	}
+}
+
+func TestOutboxRelay_Relay_Error(t *testing.T) {
+	repoMock := &stubRepositoryRelay{
+		claim: func(context.Context, time.Time, time.Time, int) ([]domain.OutboxEvent, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd}
+		},
+	}
+
+	r := NewRelay(repoMock, &stubMarketPublisher{}, Retry{}, time.Now, &stubLogger{})
+
+	_, gErr := r.Relay(context.TODO())
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+}
+
+func TestOutboxRelay_Relay_MarkError(t *testing.T) {
+	repoMock := &stubRepositoryRelay{
+		claim: func(context.Context, time.Time, time.Time, int) ([]domain.OutboxEvent, *domain.Error) {
+			return []domain.OutboxEvent{
+				{Event: domain.MarketEvent{ID: "a-created", MarketID: "a"}},
+				{Event: domain.MarketEvent{ID: "b-created", MarketID: "b"}},
+			}, nil
+		},
+		markErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+	}
+	logMock := &stubLogger{}
+
+	retry := Retry{MaxAttempts: 5}
+	r := NewRelay(repoMock, &stubMarketPublisher{fail: map[string]bool{"b": true}}, retry, time.Now, logMock)
+
+	if _, err := r.Relay(context.TODO()); err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	if len(logMock.errors) != 2 {
+		t.Errorf("expect both mark errors logged, got %v", logMock.errors)
+	}
+}
@@ -0,0 +1,144 @@
+// Package ratelimit limits the requests of each client with a token bucket.
+// A bucket holds up to the burst of the limit and is refilled evenly, the burst
+// every window, each request taking a token.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// Limit is Burst requests per Window.
+type Limit struct {
+	Burst  int
+	Window time.Duration
+}
+
+// ParseLimit reads a limit written as <requests>/<window>, as 300/1m.
+func ParseLimit(s string) (Limit, error) {
+	burst, window, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("%q is not written as <requests>/<window>", s)
+	}
+
+	n, err := strconv.Atoi(burst)
+	if err != nil {
+		return Limit{}, fmt.Errorf("%w", err)
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil {
+		return Limit{}, fmt.Errorf("%w", err)
+	}
+	if n < 1 || d <= 0 {
+		return Limit{}, errors.New("requests and window of a limit must be positive")
+	}
+
+	return Limit{Burst: n, Window: d}, nil
+}
+
+// rate is how many tokens are refilled per second.
+func (l Limit) rate() float64 {
+	return float64(l.Burst) / l.Window.Seconds()
+}
+
+type bucket struct {
+	tokens float64
+	at     time.Time
+}
+
+type clock func() time.Time
+
+// Limiter keeps a bucket per client, by the key the client is known by.
+type Limiter struct {
+	limit Limit
+	now   clock
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+func NewLimiter(limit Limit, now clock) *Limiter {
+	return &Limiter{limit: limit, now: now, buckets: map[string]*bucket{}}
+}
+
+// Take takes a token of the bucket of key, which starts full.
+func (l *Limiter) Take(key string) domain.RateLimit {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit.Burst), at: now}
+		l.buckets[key] = b
+	}
+	l.refill(b, now)
+
+	rl := domain.RateLimit{Limit: l.limit.Burst, Window: l.limit.Window}
+	if b.tokens >= 1 {
+		b.tokens--
+		rl.Allowed = true
+	} else {
+		rl.RetryAfter = l.after(1 - b.tokens)
+	}
+	rl.Remaining = int(math.Floor(b.tokens))
+	rl.Reset = l.after(float64(l.limit.Burst) - b.tokens)
+
+	return rl
+}
+
+// Sweep drops the buckets full again, which are as the ones of new clients,
+// and returns how many were dropped.
+func (l *Limiter) Sweep() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	n := 0
+	for key, b := range l.buckets {
+		l.refill(b, now)
+		if b.tokens >= float64(l.limit.Burst) {
+			delete(l.buckets, key)
+			n++
+		}
+	}
+
+	return n
+}
+
+// Run sweeps the buckets every interval until ctx is done, so the clients
+// gone do not pile up.
+func (l *Limiter) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			l.Sweep()
+		}
+	}
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) {
+	if now.After(b.at) {
+		b.tokens = math.Min(float64(l.limit.Burst), b.tokens+now.Sub(b.at).Seconds()*l.limit.rate())
+		b.at = now
+	}
+}
+
+// after is how long refilling tokens takes, to the millisecond as the floating
+// point arithmetic is off in the nanoseconds.
+func (l *Limiter) after(tokens float64) time.Duration {
+	return time.Duration(math.Round(tokens/l.limit.rate()*1000)) * time.Millisecond
+}
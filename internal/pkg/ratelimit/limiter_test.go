@@ -0,0 +1,109 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubClock struct {
+	now time.Time
+}
+
+func (c *stubClock) Now() time.Time {
+	return c.now
+}
+
+func TestParseLimit(t *testing.T) {
+	testCases := map[string]struct {
+		in   string
+		want Limit
+		wErr bool
+	}{
+		"When it is requests per minute": {in: "300/1m", want: Limit{Burst: 300, Window: time.Minute}},
+		"When it is requests per second": {in: "10/1s", want: Limit{Burst: 10, Window: time.Second}},
+		"When there is no window":        {in: "300", wErr: true},
+		"When requests is not a number":  {in: "many/1m", wErr: true},
+		"When the window is invalid":     {in: "300/minute", wErr: true},
+		"When requests is zero":          {in: "0/1m", wErr: true},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			got, err := ParseLimit(tc.in)
+			if tc.wErr {
+				if err == nil {
+					t.Error("expect an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expect return nil, got %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLimiter_Take(t *testing.T) {
+	clock := &stubClock{now: time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC)}
+	// A token every 10 seconds.
+	l := NewLimiter(Limit{Burst: 3, Window: 30 * time.Second}, clock.Now)
+
+	for i := 2; i >= 0; i-- {
+		got := l.Take("ip:10.0.0.1")
+		want := domain.RateLimit{
+			Allowed:   true,
+			Limit:     3,
+			Remaining: i,
+			Window:    30 * time.Second,
+			Reset:     time.Duration(3-i) * 10 * time.Second,
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch on take %d (-want +got):\n%s", 3-i, diff)
+		}
+	}
+
+	clock.now = clock.now.Add(4 * time.Second)
+	got := l.Take("ip:10.0.0.1")
+	want := domain.RateLimit{
+		Limit:      3,
+		Window:     30 * time.Second,
+		Reset:      26 * time.Second,
+		RetryAfter: 6 * time.Second,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch when the bucket is empty (-want +got):\n%s", diff)
+	}
+
+	if got := l.Take("api-key:1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"); !got.Allowed || got.Remaining != 2 {
+		t.Errorf("expect another client with its own bucket, got %+v", got)
+	}
+
+	clock.now = clock.now.Add(6 * time.Second)
+	if got := l.Take("ip:10.0.0.1"); !got.Allowed || got.Remaining != 0 {
+		t.Errorf("expect a token refilled, got %+v", got)
+	}
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	clock := &stubClock{now: time.Date(2022, 10, 11, 12, 0, 0, 0, time.UTC)}
+	l := NewLimiter(Limit{Burst: 3, Window: 30 * time.Second}, clock.Now)
+
+	l.Take("ip:10.0.0.1")
+	clock.now = clock.now.Add(5 * time.Second)
+	l.Take("ip:10.0.0.2")
+
+	clock.now = clock.now.Add(6 * time.Second)
+	if n := l.Sweep(); n != 1 {
+		t.Errorf("expect 1 bucket swept, got %d", n)
+	}
+	if _, ok := l.buckets["ip:10.0.0.2"]; !ok {
+		t.Error("expect the bucket not full kept")
+	}
+}
@@ -0,0 +1,63 @@
+package reference
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryReader interface {
+	ListDistricts(ctx context.Context) ([]domain.District, *domain.Error)
+	ListDistrictsBySubTownHall(ctx context.Context, ID string) ([]domain.District, *domain.Error)
+	ListSubTownHalls(ctx context.Context) ([]domain.SubTownHall, *domain.Error)
+	ListRegions(ctx context.Context) ([]domain.Region5, *domain.Error)
+}
+
+type ReferenceReader struct {
+	repo repositoryReader
+}
+
+func NewReader(repo repositoryReader) *ReferenceReader {
+	return &ReferenceReader{repo}
+}
+
+func (s *ReferenceReader) Districts(ctx context.Context) ([]domain.District, *domain.Error) {
+	ds, err := s.repo.ListDistricts(ctx)
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+	}
+
+	return ds, nil
+}
+
+func (s *ReferenceReader) SubTownHallDistricts(ctx context.Context, ID string) ([]domain.District, *domain.Error) {
+	ds, err := s.repo.ListDistrictsBySubTownHall(ctx, ID)
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return nil, &domain.Error{Kind: domain.SubTHNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+		}
+	}
+
+	return ds, nil
+}
+
+func (s *ReferenceReader) SubTownHalls(ctx context.Context) ([]domain.SubTownHall, *domain.Error) {
+	sths, err := s.repo.ListSubTownHalls(ctx)
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+	}
+
+	return sths, nil
+}
+
+func (s *ReferenceReader) Regions(ctx context.Context) ([]domain.Region5, *domain.Error) {
+	rs, err := s.repo.ListRegions(ctx)
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+	}
+
+	return rs, nil
+}
@@ -0,0 +1,108 @@
+package reference
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRepositoryReader struct {
+	listDistrictsBySTHInp string
+	listDistrictsBySTH    func(context.Context, string) ([]domain.District, *domain.Error)
+	listRegions           func(context.Context) ([]domain.Region5, *domain.Error)
+}
+
+func (s *stubRepositoryReader) ListDistricts(context.Context) ([]domain.District, *domain.Error) {
+	return []domain.District{}, nil
+}
+
+func (s *stubRepositoryReader) ListDistrictsBySubTownHall(
+	ctx context.Context,
+	ID string,
+) ([]domain.District, *domain.Error) {
+	s.listDistrictsBySTHInp = ID
+	return s.listDistrictsBySTH(ctx, ID)
+}
+
+func (s *stubRepositoryReader) ListSubTownHalls(context.Context) ([]domain.SubTownHall, *domain.Error) {
+	return []domain.SubTownHall{}, nil
+}
+
+func (s *stubRepositoryReader) ListRegions(ctx context.Context) ([]domain.Region5, *domain.Error) {
+	return s.listRegions(ctx)
+}
+
+func TestReferenceReader_SubTownHallDistricts(t *testing.T) {
+	want := []domain.District{{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}}
+
+	repoMock := &stubRepositoryReader{
+		listDistrictsBySTH: func(context.Context, string) ([]domain.District, *domain.Error) {
+			return want, nil
+		},
+	}
+
+	srv := NewReader(repoMock)
+
+	got, err := srv.SubTownHallDistricts(context.TODO(), "26")
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+
+	if repoMock.listDistrictsBySTHInp != "26" {
+		t.Errorf("expect repository receive id 26, got %s", repoMock.listDistrictsBySTHInp)
+	}
+}
+
+func TestReferenceReader_SubTownHallDistricts_Error(t *testing.T) {
+	testCases := map[string]struct {
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When sub town hall not exists": {
+			rErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr: domain.SubTHNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryReader{
+				listDistrictsBySTH: func(context.Context, string) ([]domain.District, *domain.Error) {
+					return nil, tc.rErr
+				},
+			}
+
+			srv := NewReader(repoMock)
+
+			_, err := srv.SubTownHallDistricts(context.TODO(), "99")
+			if err == nil || err.Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, err)
+			}
+		})
+	}
+}
+
+func TestReferenceReader_Regions_Error(t *testing.T) {
+	repoMock := &stubRepositoryReader{
+		listRegions: func(context.Context) ([]domain.Region5, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd}
+		},
+	}
+
+	srv := NewReader(repoMock)
+
+	_, err := srv.Regions(context.TODO())
+	if err == nil || err.Kind != domain.UnexpectedErrKd {
+		t.Errorf("expect error kind %s, got %v", domain.UnexpectedErrKd, err)
+	}
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/lib/pq"
+)
+
+const apiKeySelect = "SELECT id, name, keyhash, scopes, createdat, revokedat FROM api_key"
+
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, k domain.APIKey) *domain.Error {
+	q := "INSERT INTO api_key (id,name,keyhash,scopes) VALUES ($1,$2,$3,$4)"
+
+	qr, err := r.db.ExecContext(ctx, q, k.ID, k.Name, k.Hash, pq.Array(scopeStrings(k.Scopes)))
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	ra, err := qr.RowsAffected()
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if ra < 1 {
+		return &domain.Error{
+			Kind: domain.NothingCreatedErrKd,
+			Msg:  fmt.Sprintf("0 rows affected for id %s", k.ID),
+		}
+	}
+
+	return nil
+}
+
+// GetAPIKey returns the key with its hash, revoked or not.
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, ID string) (domain.APIKey, *domain.Error) {
+	ks, err := r.listAPIKeys(ctx, apiKeySelect+" WHERE id = $1", ID)
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	if len(ks) == 0 {
+		return domain.APIKey{}, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  fmt.Sprintf("0 rows found for id %s", ID),
+		}
+	}
+
+	return ks[0], nil
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context) ([]domain.APIKey, *domain.Error) {
+	return r.listAPIKeys(ctx, apiKeySelect+" ORDER BY createdat")
+}
+
+// RevokeAPIKey marks the key revoked, NothingUpdatedErrKd telling it does not
+// exist or was already revoked.
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, ID string) *domain.Error {
+	q := "UPDATE api_key SET revokedat = NOW() WHERE id = $1 AND revokedat IS NULL"
+
+	qr, err := r.db.ExecContext(ctx, q, ID)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	ra, err := qr.RowsAffected()
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if ra < 1 {
+		return &domain.Error{
+			Kind: domain.NothingUpdatedErrKd,
+			Msg:  fmt.Sprintf("0 rows affected for id %s", ID),
+		}
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) listAPIKeys(
+	ctx context.Context,
+	q string,
+	args ...interface{},
+) ([]domain.APIKey, *domain.Error) {
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	ks := []domain.APIKey{}
+	for res.Next() {
+		k := domain.APIKey{}
+		scopes := []string{}
+		if err := res.Scan(&k.ID, &k.Name, &k.Hash, pq.Array(&scopes), &k.CreatedAt, &k.RevokedAt); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+
+		for _, s := range scopes {
+			k.Scopes = append(k.Scopes, domain.Scope(s))
+		}
+		ks = append(ks, k)
+	}
+
+	return ks, nil
+}
+
+func scopeStrings(scopes []domain.Scope) []string {
+	ss := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		ss = append(ss, string(s))
+	}
+
+	return ss
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+)
+
+func TestAPIKeyRepository_CreateAPIKey(t *testing.T) {
+	k := domain.APIKey{
+		ID:     "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+		Name:   "partner",
+		Scopes: []domain.Scope{domain.ScopeMarketsRead, domain.ScopeMarketsWrite},
+		Hash:   "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+	}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO api_key (id,name,keyhash,scopes) VALUES ($1,$2,$3,$4)").
+		WithArgs(k.ID, k.Name, k.Hash, pq.Array([]string{"markets:read", "markets:write"})).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewAPIKeyRepository(db)
+
+	if dErr := repo.CreateAPIKey(context.TODO(), k); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAPIKeyRepository_GetAPIKey(t *testing.T) {
+	createdAt := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	revokedAt := createdAt.Add(time.Hour)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(apiKeySelect + " WHERE id = $1").
+		WithArgs("1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "keyhash", "scopes", "createdat", "revokedat"}).
+				AddRow(
+					"1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+					"partner",
+					"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+					"{markets:read,markets:delete}",
+					createdAt,
+					revokedAt,
+				),
+		)
+
+	repo := NewAPIKeyRepository(db)
+
+	got, dErr := repo.GetAPIKey(context.TODO(), "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d")
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := domain.APIKey{
+		ID:        "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+		Name:      "partner",
+		Scopes:    []domain.Scope{domain.ScopeMarketsRead, domain.ScopeMarketsDelete},
+		Hash:      "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		CreatedAt: &createdAt,
+		RevokedAt: &revokedAt,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("want mismatch with got (-want +got):\n%s", diff)
+	}
+}
+
+func TestAPIKeyRepository_GetAPIKey_Error(t *testing.T) {
+	testCases := map[string]struct {
+		rows *sqlmock.Rows
+		err  error
+		wErr domain.KindError
+	}{
+		"When the key not exists": {
+			rows: sqlmock.NewRows([]string{"id", "name", "keyhash", "scopes", "createdat", "revokedat"}),
+			wErr: domain.NothingFoundErrKd,
+		},
+		"When the query fails": {
+			err:  errors.New("connection refused"),
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			exp := mock.ExpectQuery("SELECT (.+) FROM api_key")
+			if tc.err != nil {
+				exp.WillReturnError(tc.err)
+			} else {
+				exp.WillReturnRows(tc.rows)
+			}
+
+			repo := NewAPIKeyRepository(db)
+
+			_, gErr := repo.GetAPIKey(context.TODO(), "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d")
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRepository_RevokeAPIKey(t *testing.T) {
+	testCases := map[string]struct {
+		result driver.Result
+		err    error
+		wErr   domain.KindError
+	}{
+		"When the key is revoked": {
+			result: sqlmock.NewResult(0, 1),
+		},
+		"When nothing is revoked": {
+			result: sqlmock.NewResult(0, 0),
+			wErr:   domain.NothingUpdatedErrKd,
+		},
+		"When the query fails": {
+			err:  errors.New("connection refused"),
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec("UPDATE api_key SET revokedat = NOW() WHERE id = $1 AND revokedat IS NULL").
+				WithArgs("1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d").
+				WillReturnResult(tc.result).
+				WillReturnError(tc.err)
+
+			repo := NewAPIKeyRepository(db)
+
+			gErr := repo.RevokeAPIKey(context.TODO(), "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d")
+			switch {
+			case tc.wErr == "" && gErr != nil:
+				t.Errorf("expect return nil, got %v", gErr)
+			case tc.wErr != "" && (gErr == nil || gErr.Kind != tc.wErr):
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// changesChannel is where the outbox trigger notifies the ID of every event
+	// written, once its transaction commits.
+	changesChannel = "street_market_changes"
+	// listenerPing is how often an idle connection is checked, as a dropped one
+	// is otherwise only noticed on the next notification.
+	listenerPing = 90 * time.Second
+)
+
+// ChangeListener holds the connection every replica keeps listening to the
+// events written by any of them.
+type ChangeListener struct {
+	listener *pq.Listener
+}
+
+func NewChangeListener(connInfo string) (*ChangeListener, error) {
+	l := pq.NewListener(connInfo, time.Second, time.Minute, nil)
+	if err := l.Listen(changesChannel); err != nil {
+		l.Close() //nolint:errcheck
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return &ChangeListener{l}, nil
+}
+
+// Changes sends to out the ID of every event written until ctx is done. An
+// empty ID is sent once the connection is back after being lost, as the
+// events written meanwhile were not notified.
+func (l *ChangeListener) Changes(ctx context.Context, out chan<- string) {
+	defer l.listener.Close() //nolint:errcheck
+
+	forwardChanges(ctx, l.listener.NotificationChannel(), l.ping, listenerPing, out)
+}
+
+func (l *ChangeListener) ping() {
+	l.listener.Ping() //nolint:errcheck
+}
+
+func forwardChanges(
+	ctx context.Context,
+	ns <-chan *pq.Notification,
+	ping func(),
+	every time.Duration,
+	out chan<- string,
+) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+
+	for {
+		var ID string
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			go ping()
+			continue
+		case n := <-ns:
+			if n != nil {
+				ID = n.Extra
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case out <- ID:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+)
+
+func TestForwardChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ns := make(chan *pq.Notification)
+	out := make(chan string)
+	pinged := make(chan struct{}, 1)
+	ping := func() {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		forwardChanges(ctx, ns, ping, time.Millisecond, out)
+		close(done)
+	}()
+
+	go func() {
+		ns <- &pq.Notification{Channel: changesChannel, Extra: "e1"}
+		ns <- nil
+		ns <- &pq.Notification{Channel: changesChannel, Extra: "e2"}
+	}()
+
+	got := []string{<-out, <-out, <-out}
+	if diff := cmp.Diff([]string{"e1", "", "e2"}, got); diff != "" {
+		t.Errorf("unexpected IDs forwarded (-want +got):\n%s", diff)
+	}
+
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Error("expect the connection pinged")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expect it to return once ctx is done")
+	}
+}
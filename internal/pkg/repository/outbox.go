@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db}
+}
+
+// ClaimEvents returns up to limit unpublished events due at now, oldest first.
+// Only the oldest unpublished event of each street market is claimed, so the
+// events of a street market are published in order however many relays run
+// and however long publishing takes. Dead events are neither claimed nor hold
+// back the ones after them. Their next attempt is pushed to until, so
+// no other relay claims them meanwhile and they are published again if this
+// one stops before marking them.
+func (r *OutboxRepository) ClaimEvents(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.OutboxEvent, *domain.Error) {
+	q := "UPDATE outbox SET nextattemptat = $2 WHERE id IN (" +
+		"SELECT o.id FROM outbox o WHERE o.publishedat IS NULL AND o.deadat IS NULL AND o.nextattemptat <= $1 " +
+		"AND NOT EXISTS (SELECT 1 FROM outbox p WHERE p.aggregateid = o.aggregateid " +
+		"AND p.publishedat IS NULL AND p.deadat IS NULL AND (p.occurredat, p.id) < (o.occurredat, o.id)) " +
+		"ORDER BY o.occurredat LIMIT $3 FOR UPDATE OF o SKIP LOCKED" +
+		") RETURNING id, event, aggregateid, before, after, occurredat, attempts"
+
+	res, err := r.db.QueryContext(ctx, q, now, until, limit)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	evs := []domain.OutboxEvent{}
+	for res.Next() {
+		var (
+			oe            domain.OutboxEvent
+			before, after []byte
+			occurredAt    time.Time
+		)
+		if err := res.Scan(
+			&oe.Event.ID,
+			&oe.Event.Type,
+			&oe.Event.MarketID,
+			&before,
+			&after,
+			&occurredAt,
+			&oe.Attempts,
+		); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		oe.Event.OccurredAt = &occurredAt
+
+		if oe.Event.Before, err = unmarshalMarket(before); err != nil {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+		}
+		if oe.Event.After, err = unmarshalMarket(after); err != nil {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+		}
+
+		evs = append(evs, oe)
+	}
+
+	// RETURNING does not keep the order of the subquery.
+	sort.SliceStable(evs, func(i, j int) bool {
+		return evs[i].Event.OccurredAt.Before(*evs[j].Event.OccurredAt)
+	})
+
+	return evs, nil
+}
+
+// GetEvent returns the event ID, published or not.
+func (r *OutboxRepository) GetEvent(ctx context.Context, ID string) (domain.MarketEvent, *domain.Error) {
+	q := "SELECT id, event, aggregateid, before, after, occurredat FROM outbox WHERE id = $1"
+
+	return scanEvent(r.db.QueryRowContext(ctx, q, ID))
+}
+
+// ListEventsAfter returns up to limit events that occurred after the event ID,
+// published or not, oldest first. Events that occurred at the same time are
+// ordered by their ID, so paging through them skips none.
+func (r *OutboxRepository) ListEventsAfter(
+	ctx context.Context,
+	ID string,
+	limit int,
+) ([]domain.MarketEvent, *domain.Error) {
+	q := "SELECT o.id, o.event, o.aggregateid, o.before, o.after, o.occurredat FROM outbox o " +
+		"JOIN outbox l ON l.id = $1 WHERE (o.occurredat, o.id) > (l.occurredat, l.id) " +
+		"ORDER BY o.occurredat, o.id LIMIT $2"
+
+	res, err := r.db.QueryContext(ctx, q, ID, limit)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	evs := []domain.MarketEvent{}
+	for res.Next() {
+		ev, err := scanEvent(res)
+		if err != nil {
+			return nil, err
+		}
+		evs = append(evs, ev)
+	}
+
+	return evs, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, ID string, at time.Time) *domain.Error {
+	q := "UPDATE outbox SET publishedat = $1 WHERE id = $2"
+
+	if _, err := r.db.ExecContext(ctx, q, at, ID); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+// MarkFailed records that publishing the event failed for reason, attempts
+// times so far, and when to try it again.
+func (r *OutboxRepository) MarkFailed(
+	ctx context.Context,
+	ID string,
+	attempts int,
+	next time.Time,
+	reason string,
+) *domain.Error {
+	q := "UPDATE outbox SET attempts = $1, nextattemptat = $2, lasterror = $3 WHERE id = $4"
+
+	if _, err := r.db.ExecContext(ctx, q, attempts, next, reason, ID); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+// MarkDead records that publishing the event failed for reason for the last
+// time, attempts times in all, so it is not published anymore.
+func (r *OutboxRepository) MarkDead(
+	ctx context.Context,
+	ID string,
+	attempts int,
+	at time.Time,
+	reason string,
+) *domain.Error {
+	q := "UPDATE outbox SET attempts = $1, deadat = $2, lasterror = $3 WHERE id = $4"
+
+	if _, err := r.db.ExecContext(ctx, q, attempts, at, reason, ID); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+// insertEvent writes ev to the outbox in tx, so it is published only if the
+// change it is about is committed.
+func insertEvent(ctx context.Context, tx *sql.Tx, ev domain.MarketEvent) *domain.Error {
+	before, err := marshalMarket(ev.Before)
+	if err != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+	after, err := marshalMarket(ev.After)
+	if err != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+
+	q := "INSERT INTO outbox (id,event,aggregateid,before,after,actor) VALUES ($1,$2,$3,$4,$5,$6)"
+	if _, err := tx.ExecContext(ctx, q, ev.ID, string(ev.Type), ev.MarketID, before, after, ev.Actor); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+func scanEvent(row rowScanner) (domain.MarketEvent, *domain.Error) {
+	var (
+		ev            domain.MarketEvent
+		before, after []byte
+		occurredAt    time.Time
+	)
+	err := row.Scan(&ev.ID, &ev.Type, &ev.MarketID, &before, &after, &occurredAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.MarketEvent{}, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  "0 rows found for event",
+		}
+	}
+	if err != nil {
+		return domain.MarketEvent{}, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	ev.OccurredAt = &occurredAt
+
+	if ev.Before, err = unmarshalMarket(before); err != nil {
+		return domain.MarketEvent{}, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+	if ev.After, err = unmarshalMarket(after); err != nil {
+		return domain.MarketEvent{}, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+	}
+
+	return ev, nil
+}
+
+// marshalMarket is sm as stored in the outbox, NULL when there is none.
+func marshalMarket(sm *domain.StreetMarket) (interface{}, error) {
+	if sm == nil {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(sm)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return b, nil
+}
+
+func unmarshalMarket(b []byte) (*domain.StreetMarket, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	sm := &domain.StreetMarket{}
+	if err := json.Unmarshal(b, sm); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return sm, nil
+}
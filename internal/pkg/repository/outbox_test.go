@@ -0,0 +1,401 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+// marketJSON is sm as the outbox stores it.
+func marketJSON(t *testing.T, sm *domain.StreetMarket) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(sm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestOutboxRepository_ClaimEvents(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	until := now.Add(time.Minute)
+	created := now.Add(-2 * time.Second)
+	deleted := now.Add(-time.Second)
+	sm := domain.StreetMarket{ID: "944ec25d-aac4-4c35-8301-6b35e0d7c05f", Name: "RAPOSO TAVARES"}
+	other := domain.StreetMarket{ID: "0b4e1b5c-3b0f-4d5e-9a55-3c5c2d3f8e11", Name: "VILA FORMOSA"}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	// Only the oldest unpublished event of each street market is claimed, the
+	// dead ones left out.
+	claim := `UPDATE outbox SET nextattemptat = \$2 .+ o.deadat IS NULL .+ AND NOT EXISTS \(SELECT 1 FROM outbox p ` +
+		`WHERE p.aggregateid = o.aggregateid AND p.publishedat IS NULL AND p.deadat IS NULL ` +
+		`AND \(p.occurredat, p.id\) < \(o.occurredat, o.id\)\) .+ FOR UPDATE OF o SKIP LOCKED\) RETURNING`
+	mock.ExpectQuery(claim).
+		WithArgs(now, until, 10).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event", "aggregateid", "before", "after", "occurredat", "attempts"}).
+				AddRow("e2", "street_market.deleted", other.ID, marketJSON(t, &other), nil, deleted, 0).
+				AddRow("e1", "street_market.created", sm.ID, nil, marketJSON(t, &sm), created, 3),
+		)
+
+	repo := NewOutboxRepository(db)
+
+	got, dErr := repo.ClaimEvents(context.TODO(), now, until, 10)
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.OutboxEvent{
+		{
+			Event: domain.MarketEvent{
+				ID:         "e1",
+				Type:       domain.MarketCreatedEvent,
+				MarketID:   sm.ID,
+				After:      &sm,
+				OccurredAt: &created,
+			},
+			Attempts: 3,
+		},
+		{
+			Event: domain.MarketEvent{
+				ID:         "e2",
+				Type:       domain.MarketDeletedEvent,
+				MarketID:   other.ID,
+				Before:     &other,
+				OccurredAt: &deleted,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutboxRepository_ClaimEvents_Error(t *testing.T) {
+	testCases := map[string]struct {
+		rows *sqlmock.Rows
+		mErr error
+	}{
+		"When unexpected error occurs": {
+			mErr: errSome,
+		},
+		"When a payload is not valid": {
+			rows: sqlmock.NewRows([]string{"id", "event", "aggregateid", "before", "after", "occurredat", "attempts"}).
+				AddRow("e1", "street_market.created", "1", nil, []byte("{"), time.Now(), 0),
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			if tc.rows != nil {
+				mock.ExpectQuery("UPDATE outbox").WillReturnRows(tc.rows)
+			} else {
+				mock.ExpectQuery("UPDATE outbox").WillReturnError(tc.mErr)
+			}
+
+			repo := NewOutboxRepository(db)
+
+			_, gErr := repo.ClaimEvents(context.TODO(), time.Now(), time.Now(), 10)
+			if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+				t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+			}
+		})
+	}
+}
+
+func TestOutboxRepository_GetEvent(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	sm := domain.StreetMarket{ID: "944ec25d-aac4-4c35-8301-6b35e0d7c05f", Name: "RAPOSO TAVARES"}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, event, aggregateid, before, after, occurredat FROM outbox WHERE id = $1").
+		WithArgs("e1").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event", "aggregateid", "before", "after", "occurredat"}).
+				AddRow("e1", "street_market.created", sm.ID, nil, marketJSON(t, &sm), at),
+		)
+
+	repo := NewOutboxRepository(db)
+
+	got, dErr := repo.GetEvent(context.TODO(), "e1")
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := domain.MarketEvent{
+		ID:         "e1",
+		Type:       domain.MarketCreatedEvent,
+		MarketID:   sm.ID,
+		After:      &sm,
+		OccurredAt: &at,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected event (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutboxRepository_GetEvent_Error(t *testing.T) {
+	testCases := map[string]struct {
+		mErr error
+		wErr domain.KindError
+	}{
+		"When event not exists": {
+			mErr: sql.ErrNoRows,
+			wErr: domain.NothingFoundErrKd,
+		},
+		"When unexpected error occurs": {
+			mErr: errSome,
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery("SELECT").WillReturnError(tc.mErr)
+
+			repo := NewOutboxRepository(db)
+
+			_, gErr := repo.GetEvent(context.TODO(), "e1")
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestOutboxRepository_ListEventsAfter(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	sm := domain.StreetMarket{ID: "944ec25d-aac4-4c35-8301-6b35e0d7c05f", Name: "RAPOSO TAVARES"}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`FROM outbox o JOIN outbox l ON l.id = \$1 .+ ORDER BY o.occurredat, o.id LIMIT \$2`).
+		WithArgs("e1", 50).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "event", "aggregateid", "before", "after", "occurredat"}).
+				AddRow("e2", "street_market.updated", sm.ID, marketJSON(t, &sm), marketJSON(t, &sm), at).
+				AddRow("e3", "street_market.deleted", sm.ID, marketJSON(t, &sm), nil, at),
+		)
+
+	repo := NewOutboxRepository(db)
+
+	got, dErr := repo.ListEventsAfter(context.TODO(), "e1", 50)
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.MarketEvent{
+		{ID: "e2", Type: domain.MarketUpdatedEvent, MarketID: sm.ID, Before: &sm, After: &sm, OccurredAt: &at},
+		{ID: "e3", Type: domain.MarketDeletedEvent, MarketID: sm.ID, Before: &sm, OccurredAt: &at},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected events (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutboxRepository_ListEventsAfter_Error(t *testing.T) {
+	testCases := map[string]struct {
+		rows *sqlmock.Rows
+		mErr error
+	}{
+		"When unexpected error occurs": {
+			mErr: errSome,
+		},
+		"When a payload is not valid": {
+			rows: sqlmock.NewRows([]string{"id", "event", "aggregateid", "before", "after", "occurredat"}).
+				AddRow("e2", "street_market.created", "1", nil, []byte("{"), time.Now()),
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			if tc.rows != nil {
+				mock.ExpectQuery("SELECT").WillReturnRows(tc.rows)
+			} else {
+				mock.ExpectQuery("SELECT").WillReturnError(tc.mErr)
+			}
+
+			repo := NewOutboxRepository(db)
+
+			_, gErr := repo.ListEventsAfter(context.TODO(), "e1", 50)
+			if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+				t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+			}
+		})
+	}
+}
+
+func TestOutboxRepository_MarkPublished(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE outbox SET publishedat = $1 WHERE id = $2").
+		WithArgs(at, "e1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewOutboxRepository(db)
+
+	if dErr := repo.MarkPublished(context.TODO(), "e1", at); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutboxRepository_MarkPublished_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE outbox").WillReturnError(errSome)
+
+	repo := NewOutboxRepository(db)
+
+	gErr := repo.MarkPublished(context.TODO(), "e1", time.Now())
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+}
+
+func TestOutboxRepository_MarkFailed(t *testing.T) {
+	next := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE outbox SET attempts = $1, nextattemptat = $2, lasterror = $3 WHERE id = $4").
+		WithArgs(2, next, "unexpected status 503", "e1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewOutboxRepository(db)
+
+	if dErr := repo.MarkFailed(context.TODO(), "e1", 2, next, "unexpected status 503"); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutboxRepository_MarkFailed_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE outbox").WillReturnError(errSome)
+
+	repo := NewOutboxRepository(db)
+
+	gErr := repo.MarkFailed(context.TODO(), "e1", 1, time.Now(), "")
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+}
+
+func TestOutboxRepository_MarkDead(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE outbox SET attempts = $1, deadat = $2, lasterror = $3 WHERE id = $4").
+		WithArgs(20, at, "unexpected status 400", "e1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewOutboxRepository(db)
+
+	if dErr := repo.MarkDead(context.TODO(), "e1", 20, at, "unexpected status 400"); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOutboxRepository_MarkDead_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE outbox").WillReturnError(errSome)
+
+	repo := NewOutboxRepository(db)
+
+	gErr := repo.MarkDead(context.TODO(), "e1", 1, time.Now(), "")
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+}
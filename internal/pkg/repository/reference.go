@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/lib/pq"
+)
+
+const pqForeignKeyViolation = "23503"
+
+type ReferenceRepository struct {
+	db *sql.DB
+}
+
+func NewReferenceRepository(db *sql.DB) *ReferenceRepository {
+	return &ReferenceRepository{db}
+}
+
+func (r *ReferenceRepository) ListDistricts(ctx context.Context) ([]domain.District, *domain.Error) {
+	q := "SELECT id, name, subtownhallid FROM district ORDER BY name"
+
+	return r.listDistricts(ctx, q)
+}
+
+func (r *ReferenceRepository) ListDistrictsBySubTownHall(
+	ctx context.Context,
+	ID string,
+) ([]domain.District, *domain.Error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, "SELECT COUNT(1) FROM subtownhall WHERE id = $1", ID).Scan(&count); err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if count < 1 {
+		return nil, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  fmt.Sprintf("0 rows found for id %s", ID),
+		}
+	}
+
+	q := "SELECT id, name, subtownhallid FROM district WHERE subtownhallid = $1 ORDER BY name"
+
+	return r.listDistricts(ctx, q, ID)
+}
+
+func (r *ReferenceRepository) ListSubTownHalls(ctx context.Context) ([]domain.SubTownHall, *domain.Error) {
+	q := "SELECT id, name, region8 FROM subtownhall ORDER BY name"
+
+	res, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	sths := []domain.SubTownHall{}
+	for res.Next() {
+		sth := domain.SubTownHall{}
+		if err := res.Scan(&sth.ID, &sth.Name, &sth.Region8); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		sths = append(sths, sth)
+	}
+
+	return sths, nil
+}
+
+// ListRegions returns the whole hierarchy, from the 5 regions down to the
+// districts.
+func (r *ReferenceRepository) ListRegions(ctx context.Context) ([]domain.Region5, *domain.Error) {
+	q := "SELECT r8.region5, r8.name, s.id, s.name, d.id, d.name FROM region8 r8 " +
+		"JOIN subtownhall s ON s.region8 = r8.name JOIN district d ON d.subtownhallid = s.id " +
+		"ORDER BY r8.region5, r8.name, s.name, d.name"
+
+	res, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	r5s := []domain.Region5{}
+	for res.Next() {
+		var r5, r8 string
+		sth := domain.SubTownHall{}
+		d := domain.District{}
+		if err := res.Scan(&r5, &r8, &sth.ID, &sth.Name, &d.ID, &d.Name); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		d.SubTownHallID = sth.ID
+		sth.Region8 = r8
+
+		// Rows are ordered, so a new node only has to be compared with the last one.
+		if len(r5s) == 0 || r5s[len(r5s)-1].Name != r5 {
+			r5s = append(r5s, domain.Region5{Name: r5, Regions: []domain.Region8{}})
+		}
+		lr5 := &r5s[len(r5s)-1]
+
+		if len(lr5.Regions) == 0 || lr5.Regions[len(lr5.Regions)-1].Name != r8 {
+			lr5.Regions = append(lr5.Regions, domain.Region8{Name: r8, Region5: r5, SubTownHalls: []domain.SubTownHall{}})
+		}
+		lr8 := &lr5.Regions[len(lr5.Regions)-1]
+
+		if len(lr8.SubTownHalls) == 0 || lr8.SubTownHalls[len(lr8.SubTownHalls)-1].ID != sth.ID {
+			sth.Districts = []domain.District{}
+			lr8.SubTownHalls = append(lr8.SubTownHalls, sth)
+		}
+		lsth := &lr8.SubTownHalls[len(lr8.SubTownHalls)-1]
+
+		lsth.Districts = append(lsth.Districts, d)
+	}
+
+	return r5s, nil
+}
+
+// GetHierarchy returns the reference chain of the district IDdist.
+func (r *ReferenceRepository) GetHierarchy(ctx context.Context, IDdist string) (domain.Hierarchy, *domain.Error) {
+	q := "SELECT d.id, d.name, s.id, s.name, r8.name, r8.region5 FROM district d " +
+		"JOIN subtownhall s ON s.id = d.subtownhallid JOIN region8 r8 ON r8.name = s.region8 WHERE d.id = $1"
+
+	h := domain.Hierarchy{}
+	err := r.db.QueryRowContext(ctx, q, IDdist).Scan(
+		&h.IDdist,
+		&h.District,
+		&h.IDSubTH,
+		&h.SubTownHall,
+		&h.Region8,
+		&h.Region5,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Hierarchy{}, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  fmt.Sprintf("0 rows found for id %s", IDdist),
+		}
+	}
+	if err != nil {
+		return domain.Hierarchy{}, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return h, nil
+}
+
+func (r *ReferenceRepository) listDistricts(
+	ctx context.Context,
+	q string,
+	args ...interface{},
+) ([]domain.District, *domain.Error) {
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	ds := []domain.District{}
+	for res.Next() {
+		d := domain.District{}
+		if err := res.Scan(&d.ID, &d.Name, &d.SubTownHallID); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		ds = append(ds, d)
+	}
+
+	return ds, nil
+}
+
+// referenceError reports a write rejected by a street_market foreign key as a
+// MissingRefErrKd whose message is the field holding the unknown code.
+func referenceError(err error) (*domain.Error, bool) {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != pqForeignKeyViolation {
+		return nil, false
+	}
+
+	var field string
+	switch pqErr.Constraint {
+	case "street_market_iddist_fkey":
+		field = "IDdist"
+	case "street_market_idsubth_fkey":
+		field = "IDSubTH"
+	case "street_market_region5_fkey":
+		field = "Region5"
+	case "street_market_region8_fkey":
+		field = "Region8"
+	default:
+		return nil, false
+	}
+
+	return &domain.Error{Kind: domain.MissingRefErrKd, Msg: field, Cause: pqErr}, true
+}
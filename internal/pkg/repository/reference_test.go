@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReferenceRepository_ListDistrictsBySubTownHall(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT(1) FROM subtownhall WHERE id = $1").
+		WithArgs("26").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT id, name, subtownhallid FROM district WHERE subtownhallid = $1 ORDER BY name").
+		WithArgs("26").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "subtownhallid"}).
+				AddRow("04", "ARICANDUVA", "26").
+				AddRow("87", "VILA FORMOSA", "26"),
+		)
+
+	repo := NewReferenceRepository(db)
+
+	got, dErr := repo.ListDistrictsBySubTownHall(context.TODO(), "26")
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.District{
+		{ID: "04", Name: "ARICANDUVA", SubTownHallID: "26"},
+		{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected districts (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestReferenceRepository_ListDistrictsBySubTownHall_Error(t *testing.T) {
+	testCases := map[string]struct {
+		notFound bool
+		wErr     domain.KindError
+	}{
+		"When unexpected error occurs": {
+			wErr: domain.UnexpectedErrKd,
+		},
+		"When sub town hall not exists": {
+			notFound: true,
+			wErr:     domain.NothingFoundErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			if tc.notFound {
+				mock.ExpectQuery(".+").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			} else {
+				mock.ExpectQuery(".+").WillReturnError(errSome)
+			}
+
+			repo := NewReferenceRepository(db)
+
+			_, gErr := repo.ListDistrictsBySubTownHall(context.TODO(), "99")
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestReferenceRepository_ListRegions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT r8.region5, r8.name, s.id, s.name, d.id, d.name FROM region8 r8 .+").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"region5", "region8", "sid", "sname", "did", "dname"}).
+				AddRow("Centro", "Centro", "9", "SE", "09", "BELA VISTA").
+				AddRow("Centro", "Centro", "9", "SE", "77", "SE").
+				AddRow("Leste", "Leste 1", "26", "ARICANDUVA", "87", "VILA FORMOSA").
+				AddRow("Leste", "Leste 2", "28", "GUAIANASES", "34", "GUAIANASES"),
+		)
+
+	repo := NewReferenceRepository(db)
+
+	got, dErr := repo.ListRegions(context.TODO())
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.Region5{
+		{Name: "Centro", Regions: []domain.Region8{{
+			Name:    "Centro",
+			Region5: "Centro",
+			SubTownHalls: []domain.SubTownHall{{
+				ID:      "9",
+				Name:    "SE",
+				Region8: "Centro",
+				Districts: []domain.District{
+					{ID: "09", Name: "BELA VISTA", SubTownHallID: "9"},
+					{ID: "77", Name: "SE", SubTownHallID: "9"},
+				},
+			}},
+		}}},
+		{Name: "Leste", Regions: []domain.Region8{
+			{
+				Name:    "Leste 1",
+				Region5: "Leste",
+				SubTownHalls: []domain.SubTownHall{{
+					ID:        "26",
+					Name:      "ARICANDUVA",
+					Region8:   "Leste 1",
+					Districts: []domain.District{{ID: "87", Name: "VILA FORMOSA", SubTownHallID: "26"}},
+				}},
+			},
+			{
+				Name:    "Leste 2",
+				Region5: "Leste",
+				SubTownHalls: []domain.SubTownHall{{
+					ID:        "28",
+					Name:      "GUAIANASES",
+					Region8:   "Leste 2",
+					Districts: []domain.District{{ID: "34", Name: "GUAIANASES", SubTownHallID: "28"}},
+				}},
+			},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected regions (-want +got):\n%s", diff)
+	}
+}
+
+func TestReferenceRepository_GetHierarchy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT d.id, d.name, s.id, s.name, r8.name, r8.region5 FROM district d " +
+		"JOIN subtownhall s ON s.id = d.subtownhallid JOIN region8 r8 ON r8.name = s.region8 WHERE d.id = $1").
+		WithArgs("87").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "name", "id", "name", "name", "region5"}).
+				AddRow("87", "VILA FORMOSA", "26", "ARICANDUVA-FORMOSA-CARRAO", "Leste 1", "Leste"),
+		)
+
+	repo := NewReferenceRepository(db)
+
+	got, dErr := repo.GetHierarchy(context.TODO(), "87")
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := domain.Hierarchy{
+		IDdist:      "87",
+		District:    "VILA FORMOSA",
+		IDSubTH:     "26",
+		SubTownHall: "ARICANDUVA-FORMOSA-CARRAO",
+		Region8:     "Leste 1",
+		Region5:     "Leste",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected hierarchy (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestReferenceRepository_GetHierarchy_Error(t *testing.T) {
+	testCases := map[string]struct {
+		err  error
+		wErr domain.KindError
+	}{
+		"When district not exists": {
+			err:  sql.ErrNoRows,
+			wErr: domain.NothingFoundErrKd,
+		},
+		"When unexpected error occurs": {
+			err:  errors.New("unexpected"),
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery("SELECT").WillReturnError(tc.err)
+
+			repo := NewReferenceRepository(db)
+
+			_, dErr := repo.GetHierarchy(context.TODO(), "99")
+			if dErr == nil || dErr.Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, dErr)
+			}
+		})
+	}
+}
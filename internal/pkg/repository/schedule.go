@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/lib/pq"
+)
+
+type ScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) *ScheduleRepository {
+	return &ScheduleRepository{db}
+}
+
+func (r *ScheduleRepository) GetByStreetMarketID(ctx context.Context, ID string) (domain.Schedule, *domain.Error) {
+	sch := domain.Schedule{
+		StreetMarketID: ID,
+		Slots:          []domain.ScheduleSlot{},
+		Exceptions:     []domain.ScheduleException{},
+	}
+
+	if err := streetMarketExists(ctx, r.db, ID, domain.NothingFoundErrKd); err != nil {
+		return domain.Schedule{}, err
+	}
+
+	q := "SELECT weekday, startminute, endminute FROM street_market_schedule " +
+		"WHERE streetmarketid = $1 ORDER BY weekday, startminute"
+	res, err := r.db.QueryContext(ctx, q, ID)
+	if err != nil {
+		return domain.Schedule{}, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	for res.Next() {
+		sl := domain.ScheduleSlot{}
+		if err := res.Scan(&sl.Weekday, &sl.Start, &sl.End); err != nil {
+			return domain.Schedule{}, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		sch.Slots = append(sch.Slots, sl)
+	}
+
+	q = "SELECT date, closed, startminute, endminute, note FROM street_market_schedule_exception " +
+		"WHERE streetmarketid = $1 ORDER BY date"
+	res, err = r.db.QueryContext(ctx, q, ID)
+	if err != nil {
+		return domain.Schedule{}, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	for res.Next() {
+		ex := domain.ScheduleException{}
+		if err := res.Scan(&ex.Date, &ex.Closed, &ex.Start, &ex.End, &ex.Note); err != nil {
+			return domain.Schedule{}, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		sch.Exceptions = append(sch.Exceptions, ex)
+	}
+
+	return sch, nil
+}
+
+// ListByStreetMarketIDs returns the schedules of the given street markets keyed
+// by street market ID. Markets without any slot or exception, or deleted, are
+// left out.
+func (r *ScheduleRepository) ListByStreetMarketIDs(
+	ctx context.Context,
+	IDs []string,
+) (map[string]domain.Schedule, *domain.Error) {
+	schs := map[string]domain.Schedule{}
+	get := func(ID string) domain.Schedule {
+		sch, ok := schs[ID]
+		if !ok {
+			sch = domain.Schedule{
+				StreetMarketID: ID,
+				Slots:          []domain.ScheduleSlot{},
+				Exceptions:     []domain.ScheduleException{},
+			}
+		}
+		return sch
+	}
+
+	q := "SELECT streetmarketid, weekday, startminute, endminute FROM street_market_schedule " +
+		"WHERE streetmarketid = ANY($1) AND " + liveMarketClause + " ORDER BY streetmarketid, weekday, startminute"
+	res, err := r.db.QueryContext(ctx, q, pq.Array(IDs))
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var ID string
+		sl := domain.ScheduleSlot{}
+		if err := res.Scan(&ID, &sl.Weekday, &sl.Start, &sl.End); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		sch := get(ID)
+		sch.Slots = append(sch.Slots, sl)
+		schs[ID] = sch
+	}
+
+	q = "SELECT streetmarketid, date, closed, startminute, endminute, note FROM street_market_schedule_exception " +
+		"WHERE streetmarketid = ANY($1) AND " + liveMarketClause + " ORDER BY streetmarketid, date"
+	res, err = r.db.QueryContext(ctx, q, pq.Array(IDs))
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	for res.Next() {
+		var ID string
+		ex := domain.ScheduleException{}
+		if err := res.Scan(&ID, &ex.Date, &ex.Closed, &ex.Start, &ex.End, &ex.Note); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		sch := get(ID)
+		sch.Exceptions = append(sch.Exceptions, ex)
+		schs[ID] = sch
+	}
+
+	return schs, nil
+}
+
+// Replace swaps every slot and exception of the street market by the given ones
+// in a single transaction.
+func (r *ScheduleRepository) Replace(ctx context.Context, sch domain.Schedule) *domain.Error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := streetMarketExists(ctx, tx, sch.StreetMarketID, domain.NothingUpdatedErrKd); err != nil {
+		return err
+	}
+
+	stmts := []string{
+		"DELETE FROM street_market_schedule WHERE streetmarketid = $1",
+		"DELETE FROM street_market_schedule_exception WHERE streetmarketid = $1",
+	}
+	for _, q := range stmts {
+		if _, err := tx.ExecContext(ctx, q, sch.StreetMarketID); err != nil {
+			return &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+	}
+
+	for _, sl := range sch.Slots {
+		q := "INSERT INTO street_market_schedule (streetmarketid,weekday,startminute,endminute) VALUES ($1,$2,$3,$4)"
+		if _, err := tx.ExecContext(ctx, q, sch.StreetMarketID, int(sl.Weekday), int(sl.Start), int(sl.End)); err != nil {
+			return &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+	}
+
+	for _, ex := range sch.Exceptions {
+		q := "INSERT INTO street_market_schedule_exception (streetmarketid,date,closed,startminute,endminute,note) " +
+			"VALUES ($1,$2,$3,$4,$5,$6)"
+		if _, err := tx.ExecContext(
+			ctx,
+			q,
+			sch.StreetMarketID,
+			ex.Date.Format(domain.ScheduleDateFmt),
+			ex.Closed,
+			int(ex.Start),
+			int(ex.End),
+			ex.Note,
+		); err != nil {
+			return &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func streetMarketExists(ctx context.Context, db queryRower, ID string, kind domain.KindError) *domain.Error {
+	var count int
+	q := "SELECT COUNT(1) FROM street_market WHERE id = $1 AND " + liveClause
+	if err := db.QueryRowContext(ctx, q, ID).Scan(&count); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if count < 1 {
+		return &domain.Error{
+			Kind: kind,
+			Msg:  fmt.Sprintf("0 rows found for id %s", ID),
+		}
+	}
+
+	return nil
+}
+
+// scheduleClauses returns the WHERE conditions that filter street markets by
+// their schedule. Placeholders start after the given number of arguments.
+func scheduleClauses(query domain.StreetMarketFilter, argc int) (where []string, args []interface{}) {
+	if query.OpenOn != nil {
+		args = append(args, int(*query.OpenOn))
+		where = append(where, fmt.Sprintf(
+			"id IN (SELECT streetmarketid FROM street_market_schedule WHERE weekday = $%v)",
+			argc+len(args),
+		))
+	}
+
+	if query.OpenAt != nil {
+		t := *query.OpenAt
+		args = append(args, t.Format(domain.ScheduleDateFmt), int(t.Weekday()), int(domain.ClockTimeOf(t)))
+		where = append(where, fmt.Sprintf(
+			"(id IN (SELECT streetmarketid FROM street_market_schedule_exception "+
+				"WHERE date = $%[1]v AND NOT closed AND startminute <= $%[3]v AND endminute > $%[3]v) "+
+				"OR (id NOT IN (SELECT streetmarketid FROM street_market_schedule_exception WHERE date = $%[1]v) "+
+				"AND id IN (SELECT streetmarketid FROM street_market_schedule "+
+				"WHERE weekday = $%[2]v AND startminute <= $%[3]v AND endminute > $%[3]v)))",
+			argc+len(args)-2,
+			argc+len(args)-1,
+			argc+len(args),
+		))
+	}
+
+	return where, args
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScheduleRepository_GetByStreetMarketID(t *testing.T) {
+	id := "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	christmas := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("SELECT COUNT(1) FROM street_market WHERE id = $1 AND deletedat IS NULL").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(
+		"SELECT weekday, startminute, endminute FROM street_market_schedule " +
+			"WHERE streetmarketid = $1 ORDER BY weekday, startminute",
+	).WithArgs(id).WillReturnRows(
+		sqlmock.NewRows([]string{"weekday", "startminute", "endminute"}).AddRow(6, 420, 780),
+	)
+	mock.ExpectQuery(
+		"SELECT date, closed, startminute, endminute, note FROM street_market_schedule_exception " +
+			"WHERE streetmarketid = $1 ORDER BY date",
+	).WithArgs(id).WillReturnRows(
+		sqlmock.NewRows([]string{"date", "closed", "startminute", "endminute", "note"}).
+			AddRow(christmas, true, 0, 0, "Natal"),
+	)
+
+	repo := NewScheduleRepository(db)
+
+	got, dErr := repo.GetByStreetMarketID(context.TODO(), id)
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := domain.Schedule{
+		StreetMarketID: id,
+		Slots:          []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+		Exceptions:     []domain.ScheduleException{{Date: christmas, Closed: true, Note: "Natal"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected schedule (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestScheduleRepository_GetByStreetMarketID_Error(t *testing.T) {
+	testCases := map[string]struct {
+		notFound bool
+		wErr     domain.KindError
+	}{
+		"When unexpected error occurs": {
+			wErr: domain.UnexpectedErrKd,
+		},
+		"When street market not exists": {
+			notFound: true,
+			wErr:     domain.NothingFoundErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			if tc.notFound {
+				mock.ExpectQuery(".+").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			} else {
+				mock.ExpectQuery(".+").WillReturnError(errSome)
+			}
+
+			repo := NewScheduleRepository(db)
+
+			_, gErr := repo.GetByStreetMarketID(context.TODO(), "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10")
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+		})
+	}
+}
+
+func TestScheduleRepository_Replace(t *testing.T) {
+	id := "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	sch := domain.Schedule{
+		StreetMarketID: id,
+		Slots:          []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+		Exceptions: []domain.ScheduleException{
+			{Date: time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC), Closed: true, Note: "Natal"},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT(1) FROM street_market WHERE id = $1 AND deletedat IS NULL").
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec("DELETE FROM street_market_schedule WHERE streetmarketid = $1").
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("DELETE FROM street_market_schedule_exception WHERE streetmarketid = $1").
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(
+		"INSERT INTO street_market_schedule (streetmarketid,weekday,startminute,endminute) VALUES ($1,$2,$3,$4)",
+	).WithArgs(id, 6, 420, 780).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(
+		"INSERT INTO street_market_schedule_exception (streetmarketid,date,closed,startminute,endminute,note) "+
+			"VALUES ($1,$2,$3,$4,$5,$6)",
+	).WithArgs(id, "2026-12-25", true, 0, 0, "Natal").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewScheduleRepository(db)
+
+	if err := repo.Replace(context.TODO(), sch); err != nil {
+		t.Errorf("expect return nil, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestScheduleRepository_Replace_Error(t *testing.T) {
+	testCases := map[string]struct {
+		notFound bool
+		wErr     domain.KindError
+	}{
+		"When unexpected error occurs": {
+			wErr: domain.UnexpectedErrKd,
+		},
+		"When street market not exists": {
+			notFound: true,
+			wErr:     domain.NothingUpdatedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			if tc.notFound {
+				mock.ExpectQuery(".+").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			} else {
+				mock.ExpectQuery(".+").WillReturnError(errSome)
+			}
+			mock.ExpectRollback()
+
+			repo := NewScheduleRepository(db)
+
+			gErr := repo.Replace(context.TODO(), domain.Schedule{StreetMarketID: "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"})
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestScheduleRepository_ListByStreetMarketIDs(t *testing.T) {
+	id := "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"
+	other := "2c809e53-6e2e-4a60-bbf4-de8913562970"
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	christmas := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(
+		"SELECT streetmarketid, weekday, startminute, endminute FROM street_market_schedule " +
+			"WHERE streetmarketid = ANY($1) AND streetmarketid IN (SELECT id FROM street_market WHERE deletedat IS NULL) " +
+			"ORDER BY streetmarketid, weekday, startminute",
+	).WithArgs(sqlmock.AnyArg()).WillReturnRows(
+		sqlmock.NewRows([]string{"streetmarketid", "weekday", "startminute", "endminute"}).
+			AddRow(id, 6, 420, 780).
+			AddRow(id, 0, 420, 720),
+	)
+	mock.ExpectQuery(
+		"SELECT streetmarketid, date, closed, startminute, endminute, note FROM street_market_schedule_exception " +
+			"WHERE streetmarketid = ANY($1) AND streetmarketid IN (SELECT id FROM street_market WHERE deletedat IS NULL) " +
+			"ORDER BY streetmarketid, date",
+	).WithArgs(sqlmock.AnyArg()).WillReturnRows(
+		sqlmock.NewRows([]string{"streetmarketid", "date", "closed", "startminute", "endminute", "note"}).
+			AddRow(other, christmas, true, 0, 0, "Natal"),
+	)
+
+	repo := NewScheduleRepository(db)
+
+	got, dErr := repo.ListByStreetMarketIDs(context.TODO(), []string{id, other})
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := map[string]domain.Schedule{
+		id: {
+			StreetMarketID: id,
+			Slots: []domain.ScheduleSlot{
+				{Weekday: time.Saturday, Start: 420, End: 780},
+				{Weekday: time.Sunday, Start: 420, End: 720},
+			},
+			Exceptions: []domain.ScheduleException{},
+		},
+		other: {
+			StreetMarketID: other,
+			Slots:          []domain.ScheduleSlot{},
+			Exceptions:     []domain.ScheduleException{{Date: christmas, Closed: true, Note: "Natal"}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected schedules (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestScheduleRepository_ListByStreetMarketIDs_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(".+").WillReturnError(errSome)
+
+	repo := NewScheduleRepository(db)
+
+	_, gErr := repo.ListByStreetMarketIDs(context.TODO(), []string{"8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"})
+
+	if gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr.Kind)
+	}
+}
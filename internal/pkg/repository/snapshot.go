@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+const snapshotColumns = "edition,long,lat,sectcens,area,iddist,district,idsubth,subtownhall," +
+	"region5,region8,name,register,street,number,neighborhood,addrextrainfo"
+
+type SnapshotRepository struct {
+	db *sql.DB
+}
+
+func NewSnapshotRepository(db *sql.DB) *SnapshotRepository {
+	return &SnapshotRepository{db}
+}
+
+func (r *SnapshotRepository) ListByEdition(
+	ctx context.Context,
+	edition int,
+) ([]domain.StreetMarketSnapshot, *domain.Error) {
+	q := fmt.Sprintf("SELECT %s FROM street_market_snapshot WHERE edition = $1 ORDER BY register", snapshotColumns)
+
+	res, err := r.db.QueryContext(ctx, q, edition)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	sss := []domain.StreetMarketSnapshot{}
+	for res.Next() {
+		ss := domain.StreetMarketSnapshot{}
+		if err := res.Scan(
+			&ss.Edition,
+			&ss.Long,
+			&ss.Lat,
+			&ss.SectCens,
+			&ss.Area,
+			&ss.IDdist,
+			&ss.District,
+			&ss.IDSubTH,
+			&ss.SubTownHall,
+			&ss.Region5,
+			&ss.Region8,
+			&ss.Name,
+			&ss.Register,
+			&ss.Street,
+			&ss.Number,
+			&ss.Neighborhood,
+			&ss.AddrExtraInfo,
+		); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		sss = append(sss, ss)
+	}
+
+	return sss, nil
+}
+
+// CountBy counts the street markets of an edition matching the address fields
+// of the filter per value of the groupBy column, largest groups first.
+func (r *SnapshotRepository) CountBy(
+	ctx context.Context,
+	edition int,
+	groupBy domain.StatsGroupBy,
+	query domain.StreetMarketFilter,
+) ([]domain.StreetMarketCount, *domain.Error) {
+	col, dErr := groupByColumn(groupBy)
+	if dErr != nil {
+		return nil, dErr
+	}
+
+	cls, vls, args := buildArgs(query)
+
+	where := []string{}
+	for i := 0; i < len(cls); i++ {
+		where = append(where, fmt.Sprintf("%s = %s", cls[i], vls[i]))
+	}
+	args = append(args, edition)
+	where = append(where, fmt.Sprintf("edition = $%v", len(args)))
+
+	q := fmt.Sprintf(
+		"SELECT %s, COUNT(1) FROM street_market_snapshot WHERE %s GROUP BY %s ORDER BY COUNT(1) DESC, %s",
+		col,
+		strings.Join(where, " AND "),
+		col,
+		col,
+	)
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	return scanCounts(res)
+}
+
+// Create adds ss to its edition. An edition has each register once, so ss is
+// left out, with DuplicatedErrKd, when its register is already on it.
+func (r *SnapshotRepository) Create(ctx context.Context, ss domain.StreetMarketSnapshot) *domain.Error {
+	q := fmt.Sprintf(
+		"INSERT INTO street_market_snapshot (%s) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17) "+
+			"ON CONFLICT (edition, register) DO NOTHING",
+		snapshotColumns,
+	)
+
+	qr, err := r.db.ExecContext(
+		ctx,
+		q,
+		ss.Edition,
+		ss.Long,
+		ss.Lat,
+		ss.SectCens,
+		ss.Area,
+		ss.IDdist,
+		ss.District,
+		ss.IDSubTH,
+		ss.SubTownHall,
+		ss.Region5,
+		ss.Region8,
+		ss.Name,
+		ss.Register,
+		ss.Street,
+		ss.Number,
+		ss.Neighborhood,
+		ss.AddrExtraInfo,
+	)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	ra, err := qr.RowsAffected()
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if ra < 1 {
+		return &domain.Error{
+			Kind: domain.DuplicatedErrKd,
+			Msg:  fmt.Sprintf("Register %s is already on edition %v", ss.Register, ss.Edition),
+		}
+	}
+
+	return nil
+}
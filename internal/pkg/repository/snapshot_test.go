@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+var snapshot = domain.StreetMarketSnapshot{
+	Edition:       2003,
+	Long:          -46548146,
+	Lat:           -23568390,
+	SectCens:      "355030885000019",
+	Area:          "3550308005040",
+	IDdist:        "87",
+	District:      "VILA FORMOSA",
+	IDSubTH:       "26",
+	SubTownHall:   "ARICANDUVA",
+	Region5:       "Leste",
+	Region8:       "Leste 1",
+	Name:          "PRACA LEAO X",
+	Register:      "7216-8",
+	Street:        "RUA CODAJAS",
+	Number:        "45",
+	Neighborhood:  "VILA FORMOSA",
+	AddrExtraInfo: "PRACA MARECHAL LEITAO BANDEIRA",
+}
+
+func TestSnapshotRepository_ListByEdition(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	columns := []string{
+		"edition",
+		"long",
+		"lat",
+		"sectcens",
+		"area",
+		"iddist",
+		"district",
+		"idsubth",
+		"subtownhall",
+		"region5",
+		"region8",
+		"name",
+		"register",
+		"street",
+		"number",
+		"neighborhood",
+		"addrextrainfo",
+	}
+
+	rows := sqlmock.NewRows(columns).AddRow(
+		snapshot.Edition,
+		snapshot.Long,
+		snapshot.Lat,
+		snapshot.SectCens,
+		snapshot.Area,
+		snapshot.IDdist,
+		snapshot.District,
+		snapshot.IDSubTH,
+		snapshot.SubTownHall,
+		snapshot.Region5,
+		snapshot.Region8,
+		snapshot.Name,
+		snapshot.Register,
+		snapshot.Street,
+		snapshot.Number,
+		snapshot.Neighborhood,
+		snapshot.AddrExtraInfo,
+	)
+
+	mock.ExpectQuery(
+		"SELECT " + snapshotColumns + " FROM street_market_snapshot WHERE edition = $1 ORDER BY register",
+	).WithArgs(snapshot.Edition).WillReturnRows(rows)
+
+	repo := NewSnapshotRepository(db)
+
+	got, dErr := repo.ListByEdition(context.TODO(), snapshot.Edition)
+	if dErr != nil {
+		t.Errorf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.StreetMarketSnapshot{snapshot}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected snapshots (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSnapshotRepository_ListByEdition_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(".+").WillReturnError(errSome)
+
+	repo := NewSnapshotRepository(db)
+
+	_, gErr := repo.ListByEdition(context.TODO(), 2003)
+
+	if gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr.Kind)
+	}
+}
+
+func TestSnapshotRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(`INSERT INTO street_market_snapshot .+ ON CONFLICT \(edition, register\) DO NOTHING`).WithArgs(
+		snapshot.Edition,
+		snapshot.Long,
+		snapshot.Lat,
+		snapshot.SectCens,
+		snapshot.Area,
+		snapshot.IDdist,
+		snapshot.District,
+		snapshot.IDSubTH,
+		snapshot.SubTownHall,
+		snapshot.Region5,
+		snapshot.Region8,
+		snapshot.Name,
+		snapshot.Register,
+		snapshot.Street,
+		snapshot.Number,
+		snapshot.Neighborhood,
+		snapshot.AddrExtraInfo,
+	).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewSnapshotRepository(db)
+
+	if err := repo.Create(context.TODO(), snapshot); err != nil {
+		t.Errorf("expect return nil, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSnapshotRepository_Create_Error(t *testing.T) {
+	testCases := map[string]struct {
+		createNothing bool
+		wErr          domain.KindError
+		mErr          error
+	}{
+		"When unexpected error occurs": {
+			wErr: domain.UnexpectedErrKd,
+			mErr: errSome,
+		},
+		"When the register is already on the edition": {
+			createNothing: true,
+			wErr:          domain.DuplicatedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			if tc.createNothing {
+				mock.ExpectExec(".+").WillReturnResult(sqlmock.NewResult(1, 0))
+			} else {
+				mock.ExpectExec(".+").WillReturnError(tc.mErr)
+			}
+
+			repo := NewSnapshotRepository(db)
+
+			gErr := repo.Create(context.TODO(), snapshot)
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+		})
+	}
+}
+
+func TestSnapshotRepository_CountBy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	wQ := "SELECT subtownhall, COUNT(1) FROM street_market_snapshot WHERE region5 = $1 AND edition = $2 " +
+		"GROUP BY subtownhall ORDER BY COUNT(1) DESC, subtownhall"
+
+	mock.ExpectQuery(wQ).
+		WithArgs("Leste", 2014).
+		WillReturnRows(sqlmock.NewRows([]string{"subtownhall", "count"}).AddRow("ARICANDUVA", 7))
+
+	repo := NewSnapshotRepository(db)
+
+	got, dErr := repo.CountBy(context.TODO(), 2014, domain.SubTownHallGroup, domain.StreetMarketFilter{Region5: "Leste"})
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.StreetMarketCount{{Group: "ARICANDUVA", Count: 7}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSnapshotRepository_CountBy_UnknownGroup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	repo := NewSnapshotRepository(db)
+
+	_, gErr := repo.CountBy(context.TODO(), 2014, "district; DROP TABLE street_market", domain.StreetMarketFilter{})
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
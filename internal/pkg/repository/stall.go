@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/lib/pq"
+)
+
+const (
+	pqUniqueViolation = "23505"
+
+	stallSelect = "SELECT s.id, s.streetmarketid, s.number, s.vendorname, s.licensenumber, s.createdat, " +
+		"COALESCE(array_agg(c.category ORDER BY c.category) FILTER (WHERE c.category IS NOT NULL), '{}') " +
+		"FROM stall s LEFT JOIN stall_category c ON c.stallid = s.id"
+)
+
+type StallRepository struct {
+	db *sql.DB
+}
+
+func NewStallRepository(db *sql.DB) *StallRepository {
+	return &StallRepository{db}
+}
+
+func (r *StallRepository) ListByStreetMarketID(ctx context.Context, smID string) ([]domain.Stall, *domain.Error) {
+	if err := streetMarketExists(ctx, r.db, smID, domain.NothingFoundErrKd); err != nil {
+		return nil, err
+	}
+
+	q := stallSelect + " WHERE s.streetmarketid = $1 GROUP BY s.id ORDER BY s.number"
+	res, err := r.db.QueryContext(ctx, q, smID)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	sts := []domain.Stall{}
+	for res.Next() {
+		st, err := scanStall(res)
+		if err != nil {
+			return nil, err
+		}
+		sts = append(sts, st)
+	}
+
+	return sts, nil
+}
+
+func (r *StallRepository) GetByID(ctx context.Context, smID, ID string) (domain.Stall, *domain.Error) {
+	q := stallSelect + " WHERE s.streetmarketid = $1 AND s.id = $2 AND " + liveMarketClause + " GROUP BY s.id"
+
+	st, err := scanStall(r.db.QueryRowContext(ctx, q, smID, ID))
+	if err != nil {
+		return domain.Stall{}, err
+	}
+
+	return st, nil
+}
+
+func (r *StallRepository) Create(ctx context.Context, st domain.Stall) *domain.Error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := streetMarketExists(ctx, tx, st.StreetMarketID, domain.NothingFoundErrKd); err != nil {
+		return err
+	}
+
+	q := "INSERT INTO stall (id,streetmarketid,number,vendorname,licensenumber) VALUES ($1,$2,$3,$4,$5)"
+	qr, err := tx.ExecContext(ctx, q, st.ID, st.StreetMarketID, st.Number, st.VendorName, st.LicenseNumber)
+	if err != nil {
+		return stallWriteError(err)
+	}
+
+	ra, err := qr.RowsAffected()
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if ra < 1 {
+		return &domain.Error{
+			Kind: domain.NothingCreatedErrKd,
+			Msg:  fmt.Sprintf("0 rows affected for id %s", st.ID),
+		}
+	}
+
+	if err := insertCategories(ctx, tx, st.ID, st.Categories); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+// Update changes the non-empty fields of the stall and, when Categories is not
+// nil, replaces its categories.
+func (r *StallRepository) Update(ctx context.Context, st domain.Stall) *domain.Error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	cl, vls, args := buildArgs(struct {
+		Number        int
+		VendorName    string
+		LicenseNumber string
+	}{st.Number, st.VendorName, st.LicenseNumber})
+	lArgs := len(cl)
+
+	var q string
+	if lArgs > 0 {
+		set := []string{}
+		for i := 0; i < lArgs; i++ {
+			set = append(set, fmt.Sprintf("%s = %s", cl[i], vls[i]))
+		}
+		q = fmt.Sprintf(
+			"UPDATE stall SET %s WHERE id = $%v AND streetmarketid = $%v AND %s",
+			strings.Join(set, ","),
+			lArgs+1,
+			lArgs+2,
+			liveMarketClause,
+		)
+	} else {
+		q = "UPDATE stall SET id = id WHERE id = $1 AND streetmarketid = $2 AND " + liveMarketClause
+	}
+	args = append(args, st.ID, st.StreetMarketID)
+
+	qr, err := tx.ExecContext(ctx, q, args...)
+	if err != nil {
+		return stallWriteError(err)
+	}
+
+	ra, err := qr.RowsAffected()
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if ra < 1 {
+		return &domain.Error{
+			Kind: domain.NothingUpdatedErrKd,
+			Msg:  fmt.Sprintf("0 rows affected for id %s", st.ID),
+		}
+	}
+
+	if st.Categories != nil {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM stall_category WHERE stallid = $1", st.ID); err != nil {
+			return &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		if err := insertCategories(ctx, tx, st.ID, st.Categories); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+func (r *StallRepository) DeleteByID(ctx context.Context, smID, ID string) *domain.Error {
+	q := "DELETE FROM stall WHERE id = $1 AND streetmarketid = $2 AND " + liveMarketClause
+
+	qr, err := r.db.ExecContext(ctx, q, ID, smID)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	ra, err := qr.RowsAffected()
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if ra < 1 {
+		return &domain.Error{
+			Kind: domain.NothingDeletedErrKd,
+			Msg:  fmt.Sprintf("0 rows affected for id %s", ID),
+		}
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanStall(row rowScanner) (domain.Stall, *domain.Error) {
+	st := domain.Stall{}
+	cts := []string{}
+	err := row.Scan(
+		&st.ID,
+		&st.StreetMarketID,
+		&st.Number,
+		&st.VendorName,
+		&st.LicenseNumber,
+		&st.CreatedAt,
+		pq.Array(&cts),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Stall{}, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  "0 rows found for stall",
+		}
+	}
+	if err != nil {
+		return domain.Stall{}, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	st.Categories = []domain.ProductCategory{}
+	for _, c := range cts {
+		st.Categories = append(st.Categories, domain.ProductCategory(c))
+	}
+
+	return st, nil
+}
+
+func insertCategories(ctx context.Context, tx *sql.Tx, ID string, cts []domain.ProductCategory) *domain.Error {
+	for _, c := range cts {
+		q := "INSERT INTO stall_category (stallid,category) VALUES ($1,$2)"
+		if _, err := tx.ExecContext(ctx, q, ID, string(c)); err != nil {
+			return &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+	}
+
+	return nil
+}
+
+func stallWriteError(err error) *domain.Error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+		return &domain.Error{
+			Kind:  domain.DuplicatedErrKd,
+			Msg:   pqErr.Message,
+			Cause: pqErr,
+		}
+	}
+
+	return &domain.Error{
+		Kind:  domain.UnexpectedErrKd,
+		Msg:   err.Error(),
+		Cause: err,
+	}
+}
+
+// stallClauses returns the WHERE conditions that filter street markets by the
+// product categories sold on their stalls. Placeholders start after the given
+// number of arguments.
+func stallClauses(query domain.StreetMarketFilter, argc int) (where []string, args []interface{}) {
+	if query.Sells != "" {
+		args = append(args, string(query.Sells))
+		where = append(where, fmt.Sprintf(
+			"id IN (SELECT s.streetmarketid FROM stall s JOIN stall_category c ON c.stallid = s.id WHERE c.category = $%v)",
+			argc+len(args),
+		))
+	}
+
+	return where, args
+}
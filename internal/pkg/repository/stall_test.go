@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+)
+
+// liveMarket leaves out the stalls of deleted street markets, which are kept.
+const liveMarket = "streetmarketid IN (SELECT id FROM street_market WHERE deletedat IS NULL)"
+
+func TestStallRepository_ListByStreetMarketID(t *testing.T) {
+	smID := "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"
+	id := "12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf"
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT(1) FROM street_market WHERE id = $1 AND deletedat IS NULL").
+		WithArgs(smID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(stallSelect + " WHERE s.streetmarketid = $1 GROUP BY s.id ORDER BY s.number").
+		WithArgs(smID).
+		WillReturnRows(
+			sqlmock.NewRows([]string{
+				"id", "streetmarketid", "number", "vendorname", "licensenumber", "createdat", "categories",
+			}).AddRow(id, smID, 1, "Maria da Silva", "TPU-2022-0001", nil, "{fish,seafood}"),
+		)
+
+	repo := NewStallRepository(db)
+
+	got, dErr := repo.ListByStreetMarketID(context.TODO(), smID)
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.Stall{{
+		ID:             id,
+		StreetMarketID: smID,
+		Number:         1,
+		VendorName:     "Maria da Silva",
+		LicenseNumber:  "TPU-2022-0001",
+		Categories:     []domain.ProductCategory{domain.FishCategory, domain.SeafoodCategory},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected stalls (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStallRepository_GetByID_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(".+").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	repo := NewStallRepository(db)
+
+	_, gErr := repo.GetByID(context.TODO(), "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10", "id")
+	if gErr == nil || gErr.Kind != domain.NothingFoundErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.NothingFoundErrKd, gErr)
+	}
+}
+
+func TestStallRepository_DeletedStreetMarket(t *testing.T) {
+	smID := "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"
+	id := "12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf"
+
+	testCases := map[string]struct {
+		expect func(mock sqlmock.Sqlmock)
+		call   func(repo *StallRepository) *domain.Error
+		wErr   domain.KindError
+	}{
+		"When get a stall": {
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(stallSelect+" WHERE s.streetmarketid = $1 AND s.id = $2 AND "+liveMarket+" GROUP BY s.id").
+					WithArgs(smID, id).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			call: func(repo *StallRepository) *domain.Error {
+				_, err := repo.GetByID(context.TODO(), smID, id)
+				return err
+			},
+			wErr: domain.NothingFoundErrKd,
+		},
+		"When update a stall": {
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE stall SET id = id WHERE id = $1 AND streetmarketid = $2 AND "+liveMarket).
+					WithArgs(id, smID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			call: func(repo *StallRepository) *domain.Error {
+				return repo.Update(context.TODO(), domain.Stall{ID: id, StreetMarketID: smID})
+			},
+			wErr: domain.NothingUpdatedErrKd,
+		},
+		"When delete a stall": {
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM stall WHERE id = $1 AND streetmarketid = $2 AND "+liveMarket).
+					WithArgs(id, smID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			call: func(repo *StallRepository) *domain.Error {
+				return repo.DeleteByID(context.TODO(), smID, id)
+			},
+			wErr: domain.NothingDeletedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			tc.expect(mock)
+
+			gErr := tc.call(NewStallRepository(db))
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestStallRepository_Create(t *testing.T) {
+	st := domain.Stall{
+		ID:             "12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf",
+		StreetMarketID: "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10",
+		Number:         1,
+		VendorName:     "Maria da Silva",
+		LicenseNumber:  "TPU-2022-0001",
+		Categories:     []domain.ProductCategory{domain.FishCategory},
+	}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT(1) FROM street_market WHERE id = $1 AND deletedat IS NULL").
+		WithArgs(st.StreetMarketID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectExec("INSERT INTO stall (id,streetmarketid,number,vendorname,licensenumber) VALUES ($1,$2,$3,$4,$5)").
+		WithArgs(st.ID, st.StreetMarketID, st.Number, st.VendorName, st.LicenseNumber).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO stall_category (stallid,category) VALUES ($1,$2)").
+		WithArgs(st.ID, "fish").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewStallRepository(db)
+
+	if dErr := repo.Create(context.TODO(), st); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStallRepository_Create_Error(t *testing.T) {
+	testCases := map[string]struct {
+		notFound bool
+		execErr  error
+		wErr     domain.KindError
+	}{
+		"When street market not exists": {
+			notFound: true,
+			wErr:     domain.NothingFoundErrKd,
+		},
+		"When stall number already exists": {
+			execErr: &pq.Error{Code: pqUniqueViolation},
+			wErr:    domain.DuplicatedErrKd,
+		},
+		"When unexpected error occurs": {
+			execErr: errSome,
+			wErr:    domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			if tc.notFound {
+				mock.ExpectQuery(".+").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			} else {
+				mock.ExpectQuery(".+").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectExec(".+").WillReturnError(tc.execErr)
+			}
+			mock.ExpectRollback()
+
+			repo := NewStallRepository(db)
+
+			gErr := repo.Create(context.TODO(), domain.Stall{ID: "id", StreetMarketID: "sm"})
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestStallRepository_Update(t *testing.T) {
+	st := domain.Stall{
+		ID:             "12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf",
+		StreetMarketID: "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10",
+		VendorName:     "Joana",
+		Categories:     []domain.ProductCategory{domain.EggsCategory},
+	}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE stall SET vendorname = $1 WHERE id = $2 AND streetmarketid = $3 AND "+liveMarket).
+		WithArgs(st.VendorName, st.ID, st.StreetMarketID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("DELETE FROM stall_category WHERE stallid = $1").
+		WithArgs(st.ID).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec("INSERT INTO stall_category (stallid,category) VALUES ($1,$2)").
+		WithArgs(st.ID, "eggs").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewStallRepository(db)
+
+	if dErr := repo.Update(context.TODO(), st); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStallRepository_DeleteByID(t *testing.T) {
+	testCases := map[string]struct {
+		affected int64
+		wErr     *domain.Error
+	}{
+		"When stall is deleted": {
+			affected: 1,
+		},
+		"When stall not exists": {
+			affected: 0,
+			wErr:     &domain.Error{Kind: domain.NothingDeletedErrKd, Msg: "0 rows affected for id id"},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec("DELETE FROM stall WHERE id = $1 AND streetmarketid = $2 AND "+liveMarket).
+				WithArgs("id", "sm").
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+
+			repo := NewStallRepository(db)
+
+			gErr := repo.DeleteByID(context.TODO(), "sm", "id")
+			if diff := cmp.Diff(tc.wErr, gErr); diff != "" {
+				t.Errorf("unexpected error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
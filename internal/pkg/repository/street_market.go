@@ -3,19 +3,31 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 )
 
+// streetMarketColumns are read in the order scanStreetMarket scans them.
+const streetMarketColumns = "id,long,lat,sectcens,area,iddist,district,idsubth,subtownhall," +
+	"region5,region8,name,register,street,number,neighborhood,addrextrainfo,createdat,updatedat"
+
+// liveClause leaves the deleted street markets out. They are kept as
+// tombstones for the clients that sync.
+const liveClause = "deletedat IS NULL"
+
+// liveMarketClause leaves out the rows of the deleted street markets in the
+// tables that reference them, as deleting a street market keeps its rows.
+const liveMarketClause = "streetmarketid IN (SELECT id FROM street_market WHERE " + liveClause + ")"
+
 type StreetMarketRepository struct {
 	db *sql.DB
 }
 
-var ()
-
 func NewStreetMarketRepository(db *sql.DB) *StreetMarketRepository {
 	return &StreetMarketRepository{db}
 }
@@ -25,83 +37,219 @@ func (r *StreetMarketRepository) List(
 	pg domain.Pagination,
 	query domain.StreetMarketFilter,
 ) ([]domain.StreetMarket, *domain.Error) {
-	bq := "SELECT * FROM street_market"
+	bq := fmt.Sprintf("SELECT %s FROM street_market", streetMarketColumns)
 
-	cls, vls, args := buildArgs(query)
+	where, args := filterClauses(query)
+	bq = fmt.Sprintf("%s WHERE %s", bq, strings.Join(append([]string{liveClause}, where...), " AND "))
 
-	where := []string{}
+	q := fmt.Sprintf("%s ORDER BY createdat DESC OFFSET %v LIMIT %v", bq, pg.Offset, pg.Limit)
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
 
-	for i := 0; i < len(cls); i++ {
-		where = append(where, fmt.Sprintf("%s = %s", cls[i], vls[i]))
+	rrs := []domain.StreetMarket{}
+	for res.Next() {
+		sm, err := scanStreetMarket(res)
+		if err != nil {
+			return nil, err
+		}
+		rrs = append(rrs, sm)
 	}
 
-	if len(where) > 0 {
-		bq = fmt.Sprintf("%s WHERE %s", bq, strings.Join(where, " AND "))
+	return rrs, nil
+}
+
+// CountBy counts the street markets matching the filter per value of the
+// groupBy column, largest groups first.
+func (r *StreetMarketRepository) CountBy(
+	ctx context.Context,
+	groupBy domain.StatsGroupBy,
+	query domain.StreetMarketFilter,
+) ([]domain.StreetMarketCount, *domain.Error) {
+	col, dErr := groupByColumn(groupBy)
+	if dErr != nil {
+		return nil, dErr
 	}
 
-	q := fmt.Sprintf("%s ORDER BY createdat DESC OFFSET %v LIMIT %v", bq, pg.Offset, pg.Limit)
+	bq := fmt.Sprintf("SELECT %s, COUNT(1) FROM street_market", col)
+
+	where, args := filterClauses(query)
+	bq = fmt.Sprintf("%s WHERE %s", bq, strings.Join(append([]string{liveClause}, where...), " AND "))
+
+	q := fmt.Sprintf("%s GROUP BY %s ORDER BY COUNT(1) DESC, %s", bq, col, col)
 	res, err := r.db.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, &domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
 		}
 	}
+	defer res.Close()
 
-	rrs := []domain.StreetMarket{}
-	for res.Next() {
-		sm := domain.StreetMarket{}
-		if err := res.Scan(
-			&sm.ID,
-			&sm.Long,
-			&sm.Lat,
-			&sm.SectCens,
-			&sm.Area,
-			&sm.IDdist,
-			&sm.District,
-			&sm.IDSubTH,
-			&sm.SubTownHall,
-			&sm.Region5,
-			&sm.Region8,
-			&sm.Name,
-			&sm.Register,
-			&sm.Street,
-			&sm.Number,
-			&sm.Neighborhood,
-			&sm.AddrExtraInfo,
-			&sm.CreatedAt,
-		); err != nil {
-			return nil, &domain.Error{
-				Kind: domain.UnexpectedErrKd,
-				Msg:  err.Error(),
+	return scanCounts(res)
+}
+
+func (r *StreetMarketRepository) GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+	q := fmt.Sprintf("SELECT %s FROM street_market WHERE id = $1 AND %s", streetMarketColumns, liveClause)
+
+	sm, err := scanStreetMarket(r.db.QueryRowContext(ctx, q, ID))
+	if err != nil && err.Kind == domain.NothingFoundErrKd {
+		return domain.StreetMarket{}, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  fmt.Sprintf("0 rows found for id %s", ID),
+		}
+	}
+	if err != nil {
+		return domain.StreetMarket{}, err
+	}
+
+	return sm, nil
+}
+
+// ListChanges returns up to limit street markets created, updated or deleted
+// since the sync at cur, or not deleted when it is a sync of every street
+// market, and the cursor of the next page or, after the last one, of the next
+// sync.
+//
+// Positions are transaction ids. The next sync starts at the oldest
+// transaction still running when the first page is read, in the same snapshot,
+// so a change committed after it, even between pages, is never older and is
+// not missed. Changes committed just before may come again in the next sync.
+// Pages are ordered by the transaction that changed the street markets last
+// and their ID, so a street market changed while paging comes later, if again.
+func (r *StreetMarketRepository) ListChanges(
+	ctx context.Context,
+	cur domain.SyncCursor,
+	limit int,
+) (domain.StreetMarketChanges, *domain.Error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return domain.StreetMarketChanges{}, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	next := cur.Next
+	if !cur.Paging() {
+		if err := tx.QueryRowContext(ctx, "SELECT txid_snapshot_xmin(txid_current_snapshot())").Scan(&next); err != nil {
+			return domain.StreetMarketChanges{}, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
 			}
 		}
-		rrs = append(rrs, sm)
 	}
 
-	return rrs, nil
+	where := []string{liveClause}
+	args := []interface{}{}
+	if cur.Position > 0 {
+		args = append(args, cur.Position)
+		where = []string{"changetxid >= $1"}
+	}
+	if cur.Paging() {
+		args = append(args, cur.AfterTxID, cur.AfterID)
+		where = append(where, fmt.Sprintf("(changetxid, id) > ($%v, $%v)", len(args)-1, len(args)))
+	}
+
+	q := fmt.Sprintf(
+		"SELECT %s,deletedat,changetxid FROM street_market WHERE %s ORDER BY changetxid, id LIMIT %v",
+		streetMarketColumns,
+		strings.Join(where, " AND "),
+		limit+1,
+	)
+	res, err := tx.QueryContext(ctx, q, args...)
+	if err != nil {
+		return domain.StreetMarketChanges{}, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	chs := domain.StreetMarketChanges{
+		Markets: []domain.StreetMarket{},
+		Deleted: []domain.Tombstone{},
+		Cursor:  domain.SyncCursor{Position: next},
+	}
+	var (
+		lastTxID int64
+		lastID   string
+	)
+	for n := 0; res.Next(); n++ {
+		// The row after limit only tells there is another page.
+		if n == limit {
+			chs.Cursor = domain.SyncCursor{Position: cur.Position, Next: next, AfterTxID: lastTxID, AfterID: lastID}
+			break
+		}
+
+		var deletedAt *time.Time
+		sm, err := scanStreetMarket(res, &deletedAt, &lastTxID)
+		if err != nil {
+			return domain.StreetMarketChanges{}, err
+		}
+		lastID = sm.ID
+
+		if deletedAt != nil {
+			chs.Deleted = append(chs.Deleted, domain.Tombstone{ID: sm.ID, DeletedAt: deletedAt})
+			continue
+		}
+		chs.Markets = append(chs.Markets, sm)
+	}
+
+	return chs, nil
 }
 
-func (r *StreetMarketRepository) Create(ctx context.Context, streetMarket domain.StreetMarket) *domain.Error {
+// Create inserts streetMarket and writes ev to the outbox, both or none.
+func (r *StreetMarketRepository) Create(
+	ctx context.Context,
+	streetMarket domain.StreetMarket,
+	ev domain.MarketEvent,
+) *domain.Error {
 	bq := "INSERT INTO street_market (%s) VALUES (%s)"
 
 	cl, vls, args := buildArgs(streetMarket)
 
 	q := fmt.Sprintf(bq, strings.Join(cl, ","), strings.Join(vls, ","))
 
-	qr, err := r.db.ExecContext(ctx, q, args...)
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qr, err := tx.ExecContext(ctx, q, args...)
 	if err != nil {
+		if refErr, ok := referenceError(err); ok {
+			return refErr
+		}
 		return &domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
 		}
 	}
 
 	ra, err := qr.RowsAffected()
 	if err != nil {
 		return &domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
 		}
 	}
 
@@ -112,17 +260,25 @@ func (r *StreetMarketRepository) Create(ctx context.Context, streetMarket domain
 		}
 	}
 
-	return nil
+	return commitWithEvent(ctx, tx, ev)
 }
 
-func (r *StreetMarketRepository) Update(ctx context.Context, sm domain.StreetMarket) *domain.Error {
+// Update sets the non empty fields of sm and writes ev to the outbox, both or
+// none. The street market is only updated while of the sub-town hall of
+// ev.Before, the one the change was authorized on, so it is not changed once
+// moved out of it meanwhile.
+func (r *StreetMarketRepository) Update(
+	ctx context.Context,
+	sm domain.StreetMarket,
+	ev domain.MarketEvent,
+) *domain.Error {
 	id := sm.ID
 	sm.ID = ""
 
 	cl, vls, args := buildArgs(sm)
 	lArgs := len(cl)
 
-	bq := "UPDATE street_market SET %s WHERE id = $%v"
+	bq := "UPDATE street_market SET %s WHERE id = $%v AND " + liveClause
 
 	set := []string{}
 	for i := 0; i < lArgs; i++ {
@@ -130,21 +286,40 @@ func (r *StreetMarketRepository) Update(ctx context.Context, sm domain.StreetMar
 	}
 
 	q := fmt.Sprintf(bq, strings.Join(set, ","), lArgs+1)
-
 	args = append(args, id)
-	qr, err := r.db.ExecContext(ctx, q, args...)
+	if ev.Before != nil {
+		q = fmt.Sprintf("%s AND idsubth = $%v", q, lArgs+2)
+		args = append(args, ev.Before.IDSubTH)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	qr, err := tx.ExecContext(ctx, q, args...)
 	if err != nil {
+		if refErr, ok := referenceError(err); ok {
+			return refErr
+		}
 		return &domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
 		}
 	}
 
 	ra, err := qr.RowsAffected()
 	if err != nil {
 		return &domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
 		}
 	}
 
@@ -155,25 +330,45 @@ func (r *StreetMarketRepository) Update(ctx context.Context, sm domain.StreetMar
 		}
 	}
 
-	return nil
+	return commitWithEvent(ctx, tx, ev)
 }
 
-func (r *StreetMarketRepository) DeleteByID(ctx context.Context, ID string) *domain.Error {
-	q := "DELETE FROM street_market WHERE id = $1"
+// DeleteByID deletes the street market and writes ev to the outbox, both or
+// none. The row is kept as a tombstone, only marked deleted. As in Update, the
+// street market is only deleted while of the sub-town hall of ev.Before.
+func (r *StreetMarketRepository) DeleteByID(ctx context.Context, ID string, ev domain.MarketEvent) *domain.Error {
+	q := "UPDATE street_market SET deletedat = NOW() WHERE id = $1 AND " + liveClause
+	args := []interface{}{ID}
+	if ev.Before != nil {
+		q += " AND idsubth = $2"
+		args = append(args, ev.Before.IDSubTH)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
 
-	qr, err := r.db.ExecContext(ctx, q, ID)
+	qr, err := tx.ExecContext(ctx, q, args...)
 	if err != nil {
 		return &domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
 		}
 	}
 
 	ra, err := qr.RowsAffected()
 	if err != nil {
 		return &domain.Error{
-			Kind: domain.UnexpectedErrKd,
-			Msg:  err.Error(),
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
 		}
 	}
 
@@ -184,16 +379,132 @@ func (r *StreetMarketRepository) DeleteByID(ctx context.Context, ID string) *dom
 		}
 	}
 
+	return commitWithEvent(ctx, tx, ev)
+}
+
+// commitWithEvent writes ev to the outbox and commits tx.
+func commitWithEvent(ctx context.Context, tx *sql.Tx, ev domain.MarketEvent) *domain.Error {
+	if err := insertEvent(ctx, tx, ev); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
 	return nil
 }
 
+// filterClauses returns the WHERE conditions of the filter, placeholders
+// numbered from $1.
+func filterClauses(query domain.StreetMarketFilter) (where []string, args []interface{}) {
+	cls, vls, args := buildArgs(query)
+
+	for i := 0; i < len(cls); i++ {
+		where = append(where, fmt.Sprintf("%s = %s", cls[i], vls[i]))
+	}
+
+	schWhere, schArgs := scheduleClauses(query, len(args))
+	where = append(where, schWhere...)
+	args = append(args, schArgs...)
+
+	stWhere, stArgs := stallClauses(query, len(args))
+	where = append(where, stWhere...)
+	args = append(args, stArgs...)
+
+	return where, args
+}
+
+// scanStreetMarket scans the streetMarketColumns of row, then extra.
+func scanStreetMarket(row rowScanner, extra ...interface{}) (domain.StreetMarket, *domain.Error) {
+	sm := domain.StreetMarket{}
+	dest := []interface{}{
+		&sm.ID,
+		&sm.Long,
+		&sm.Lat,
+		&sm.SectCens,
+		&sm.Area,
+		&sm.IDdist,
+		&sm.District,
+		&sm.IDSubTH,
+		&sm.SubTownHall,
+		&sm.Region5,
+		&sm.Region8,
+		&sm.Name,
+		&sm.Register,
+		&sm.Street,
+		&sm.Number,
+		&sm.Neighborhood,
+		&sm.AddrExtraInfo,
+		&sm.CreatedAt,
+		&sm.UpdatedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.StreetMarket{}, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  "0 rows found for street market",
+		}
+	}
+	if err != nil {
+		return domain.StreetMarket{}, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return sm, nil
+}
+
+// groupByColumn is the street market column of groupBy. The column goes
+// straight into the query, so only the known groups are accepted.
+func groupByColumn(groupBy domain.StatsGroupBy) (string, *domain.Error) {
+	switch groupBy {
+	case domain.DistrictGroup:
+		return "district", nil
+	case domain.SubTownHallGroup:
+		return "subtownhall", nil
+	case domain.Region5Group:
+		return "region5", nil
+	case domain.Region8Group:
+		return "region8", nil
+	}
+
+	return "", &domain.Error{
+		Kind: domain.UnexpectedErrKd,
+		Msg:  fmt.Sprintf("%s is not a known group", groupBy),
+	}
+}
+
+func scanCounts(res *sql.Rows) ([]domain.StreetMarketCount, *domain.Error) {
+	cs := []domain.StreetMarketCount{}
+	for res.Next() {
+		c := domain.StreetMarketCount{}
+		if err := res.Scan(&c.Group, &c.Count); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		cs = append(cs, c)
+	}
+
+	return cs, nil
+}
+
 func buildArgs(inp interface{}) (columns, placeHolders []string, values []interface{}) {
 	v := reflect.ValueOf(inp)
 	t := reflect.TypeOf(inp)
 
 	asEmpty := []any{"", 0, 0.0, nil}
 
-	blacklist := []any{"createdat"}
+	blacklist := []any{"createdat", "updatedat", "openon", "openat", "sells"}
 
 	phC := 1
 	for i := 0; i < v.NumField(); i++ {
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"testing"
@@ -10,10 +12,16 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
 )
 
 var errSome = errors.New("some error")
 
+const softDeleteQuery = `UPDATE street_market SET deletedat = NOW\(\) WHERE id = \$1 AND deletedat IS NULL$`
+
+const guardedSoftDeleteQuery = `UPDATE street_market SET deletedat = NOW\(\) WHERE id = \$1 AND deletedat IS NULL ` +
+	`AND idsubth = \$2`
+
 func TestStreetMarketRepository_Delete(t *testing.T) {
 	id := "84713a81-0e31-4c14-a62f-7e1f67bc526d"
 	db, mock, err := sqlmock.New()
@@ -22,11 +30,24 @@ func TestStreetMarketRepository_Delete(t *testing.T) {
 	}
 	defer db.Close()
 
-	mock.ExpectExec("DELETE FROM street_market WHERE .+").WithArgs(id).WillReturnResult(sqlmock.NewResult(1, 1))
+	ev := domain.MarketEvent{
+		ID:       "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c",
+		Type:     domain.MarketDeletedEvent,
+		MarketID: id,
+		Before:   &domain.StreetMarket{ID: id, Name: "RAPOSO TAVARES", IDSubTH: "26"},
+		Actor:    "maria",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(guardedSoftDeleteQuery).WithArgs(id, "26").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`INSERT INTO outbox \(id,event,aggregateid,before,after,actor\)`).
+		WithArgs(ev.ID, "street_market.deleted", id, marketJSON(t, ev.Before), nil, "maria").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	repo := NewStreetMarketRepository(db)
 
-	if err := repo.DeleteByID(context.TODO(), id); err != nil {
+	if err := repo.DeleteByID(context.TODO(), id, ev); err != nil {
 		t.Errorf("expect return nil, got %v", err)
 	}
 
@@ -37,10 +58,11 @@ func TestStreetMarketRepository_Delete(t *testing.T) {
 
 func TestStreetMarketRepository_Delete_Error(t *testing.T) {
 	testCases := map[string]struct {
-		wErr   domain.KindError
-		mErr   error
-		notUpd bool
-		id     string
+		wErr     domain.KindError
+		mErr     error
+		notUpd   bool
+		eventErr error
+		id       string
 	}{
 		"When unexpected error occurs": {
 			wErr: domain.UnexpectedErrKd,
@@ -52,6 +74,11 @@ func TestStreetMarketRepository_Delete_Error(t *testing.T) {
 			wErr:   domain.NothingDeletedErrKd,
 			id:     "65ea9603-c3bb-4686-9700-8881c8a89374",
 		},
+		"When the event is not written": {
+			eventErr: errSome,
+			wErr:     domain.UnexpectedErrKd,
+			id:       "65ea9603-c3bb-4686-9700-8881c8a89374",
+		},
 	}
 
 	for title, tc := range testCases {
@@ -62,15 +89,21 @@ func TestStreetMarketRepository_Delete_Error(t *testing.T) {
 			}
 			defer db.Close()
 
-			if tc.notUpd {
-				mock.ExpectExec("DELETE FROM street_market WHERE .+").WithArgs(tc.id).WillReturnResult(sqlmock.NewResult(1, 0))
-			} else {
-				mock.ExpectExec("DELETE FROM street_market WHERE .+").WithArgs(tc.id).WillReturnError(tc.mErr)
+			mock.ExpectBegin()
+			switch {
+			case tc.notUpd:
+				mock.ExpectExec(softDeleteQuery).WithArgs(tc.id).WillReturnResult(sqlmock.NewResult(1, 0))
+			case tc.eventErr != nil:
+				mock.ExpectExec(softDeleteQuery).WithArgs(tc.id).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnError(tc.eventErr)
+			default:
+				mock.ExpectExec(softDeleteQuery).WithArgs(tc.id).WillReturnError(tc.mErr)
 			}
+			mock.ExpectRollback()
 
 			repo := NewStreetMarketRepository(db)
 
-			gErr := repo.DeleteByID(context.TODO(), tc.id)
+			gErr := repo.DeleteByID(context.TODO(), tc.id, domain.MarketEvent{})
 
 			if gErr.Kind != tc.wErr {
 				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
@@ -99,6 +132,15 @@ func TestStreetMarketRepository_Create(t *testing.T) {
 		Neighborhood: "JARDIM SARAH",
 	}
 
+	ev := domain.MarketEvent{
+		ID:       "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c",
+		Type:     domain.MarketCreatedEvent,
+		MarketID: inp.ID,
+		After:    &inp,
+		Actor:    "api-key:1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+	}
+
+	mock.ExpectBegin()
 	mock.ExpectExec(
 		"INSERT INTO street_market (id,name,register,street,number,neighborhood) VALUES ($1,$2,$3,$4,$5,$6)",
 	).WithArgs(
@@ -109,10 +151,14 @@ func TestStreetMarketRepository_Create(t *testing.T) {
 		inp.Number,
 		inp.Neighborhood,
 	).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox (id,event,aggregateid,before,after,actor) VALUES ($1,$2,$3,$4,$5,$6)").
+		WithArgs(ev.ID, "street_market.created", inp.ID, nil, marketJSON(t, &inp), ev.Actor).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	repo := NewStreetMarketRepository(db)
 
-	if err := repo.Create(context.TODO(), inp); err != nil {
+	if err := repo.Create(context.TODO(), inp, ev); err != nil {
 		t.Errorf("expect return nil, got %v", err)
 	}
 
@@ -124,6 +170,7 @@ func TestStreetMarketRepository_Create(t *testing.T) {
 func TestStreetMarketRepository_Create_Error(t *testing.T) {
 	testCases := map[string]struct {
 		createNothing bool
+		eventErr      error
 		wErr          domain.KindError
 		mErr          error
 	}{
@@ -135,6 +182,14 @@ func TestStreetMarketRepository_Create_Error(t *testing.T) {
 			createNothing: true,
 			wErr:          domain.NothingCreatedErrKd,
 		},
+		"When a code does not exist in the reference data": {
+			wErr: domain.MissingRefErrKd,
+			mErr: &pq.Error{Code: pqForeignKeyViolation, Constraint: "street_market_iddist_fkey"},
+		},
+		"When the event is not written": {
+			eventErr: errSome,
+			wErr:     domain.UnexpectedErrKd,
+		},
 	}
 
 	for title, tc := range testCases {
@@ -145,19 +200,29 @@ func TestStreetMarketRepository_Create_Error(t *testing.T) {
 			}
 			defer db.Close()
 
-			if tc.createNothing {
+			mock.ExpectBegin()
+			switch {
+			case tc.createNothing:
 				mock.ExpectExec(".+").WillReturnResult(sqlmock.NewResult(1, 0))
-			} else {
+			case tc.eventErr != nil:
+				mock.ExpectExec("INSERT INTO street_market").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnError(tc.eventErr)
+			default:
 				mock.ExpectExec(".+").WillReturnError(tc.mErr)
 			}
+			mock.ExpectRollback()
 
 			repo := NewStreetMarketRepository(db)
 
-			gErr := repo.Create(context.TODO(), domain.StreetMarket{})
+			gErr := repo.Create(context.TODO(), domain.StreetMarket{}, domain.MarketEvent{})
 
 			if gErr.Kind != tc.wErr {
 				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
 			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
 		})
 	}
 }
@@ -182,6 +247,7 @@ func TestStreetMarketRepository_List(t *testing.T) {
 		Neighborhood:  "JARDIM SARAH",
 		AddrExtraInfo: "Loren ipsum",
 		CreatedAt:     &time.Time{},
+		UpdatedAt:     &time.Time{},
 	}
 
 	columns := []string{
@@ -203,6 +269,7 @@ func TestStreetMarketRepository_List(t *testing.T) {
 		"neighborhood",
 		"addrextrainfo",
 		"createdat",
+		"updatedat",
 	}
 
 	t.Run("When use filter and return results", func(t *testing.T) {
@@ -236,6 +303,7 @@ func TestStreetMarketRepository_List(t *testing.T) {
 				sm.Neighborhood,
 				sm.AddrExtraInfo,
 				sm.CreatedAt,
+				sm.UpdatedAt,
 			)
 		}
 
@@ -248,7 +316,8 @@ func TestStreetMarketRepository_List(t *testing.T) {
 			Region5:  "west",
 		}
 
-		wQB := "SELECT * FROM street_market WHERE district = $1 AND region5 = $2 ORDER BY createdat DESC OFFSET %v LIMIT %v"
+		wQB := "SELECT " + streetMarketColumns + " FROM street_market WHERE deletedat IS NULL AND district = $1 AND " +
+			"region5 = $2 ORDER BY createdat DESC OFFSET %v LIMIT %v"
 
 		wQ := fmt.Sprintf(wQB, pg.Offset, pg.Limit)
 
@@ -284,7 +353,8 @@ func TestStreetMarketRepository_List(t *testing.T) {
 			Offset: 101,
 			Limit:  100,
 		}
-		wQB := "SELECT * FROM street_market ORDER BY createdat DESC OFFSET %v LIMIT %v"
+		wQB := "SELECT " + streetMarketColumns + " FROM street_market WHERE deletedat IS NULL " +
+			"ORDER BY createdat DESC OFFSET %v LIMIT %v"
 
 		wQ := fmt.Sprintf(wQB, pg.Offset, pg.Limit)
 		mock.ExpectQuery(wQ).WillReturnRows(rows)
@@ -302,6 +372,71 @@ func TestStreetMarketRepository_List(t *testing.T) {
 			t.Errorf("unexpected street market when calls create (-want +got):\n%s", diff)
 		}
 
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+	t.Run("When filter by schedule", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		defer db.Close()
+
+		openOn := time.Saturday
+		openAt := time.Date(2026, 10, 18, 8, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+		inp := domain.StreetMarketFilter{
+			District: "VILA FORMOSA",
+			OpenOn:   &openOn,
+			OpenAt:   &openAt,
+		}
+
+		wQ := "SELECT " + streetMarketColumns + " FROM street_market WHERE deletedat IS NULL AND district = $1 AND " +
+			"id IN (SELECT streetmarketid FROM street_market_schedule WHERE weekday = $2) AND " +
+			"(id IN (SELECT streetmarketid FROM street_market_schedule_exception " +
+			"WHERE date = $3 AND NOT closed AND startminute <= $5 AND endminute > $5) " +
+			"OR (id NOT IN (SELECT streetmarketid FROM street_market_schedule_exception WHERE date = $3) " +
+			"AND id IN (SELECT streetmarketid FROM street_market_schedule " +
+			"WHERE weekday = $4 AND startminute <= $5 AND endminute > $5))) " +
+			"ORDER BY createdat DESC OFFSET 0 LIMIT 100"
+
+		mock.ExpectQuery(wQ).
+			WithArgs("VILA FORMOSA", 6, "2026-10-18", 0, 480).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		repo := NewStreetMarketRepository(db)
+
+		if _, dErr := repo.List(context.TODO(), domain.Pagination{Limit: 100}, inp); dErr != nil {
+			t.Errorf("expect return nil, got %v", dErr)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+	t.Run("When filter by product category", func(t *testing.T) {
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		defer db.Close()
+
+		inp := domain.StreetMarketFilter{Region5: "Leste", Sells: domain.FishCategory}
+
+		wQ := "SELECT " + streetMarketColumns + " FROM street_market WHERE deletedat IS NULL AND region5 = $1 AND " +
+			"id IN (SELECT s.streetmarketid FROM stall s JOIN stall_category c ON c.stallid = s.id " +
+			"WHERE c.category = $2) ORDER BY createdat DESC OFFSET 0 LIMIT 100"
+
+		mock.ExpectQuery(wQ).
+			WithArgs("Leste", "fish").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		repo := NewStreetMarketRepository(db)
+
+		if _, dErr := repo.List(context.TODO(), domain.Pagination{Limit: 100}, inp); dErr != nil {
+			t.Errorf("expect return nil, got %v", dErr)
+		}
+
 		if err := mock.ExpectationsWereMet(); err != nil {
 			t.Errorf("there were unfulfilled expectations: %s", err)
 		}
@@ -340,6 +475,284 @@ func TestStreetMarketRepository_List_Error(t *testing.T) {
 	}
 }
 
+func TestStreetMarketRepository_GetByID(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	want := domain.StreetMarket{
+		ID:            "1966d99f-20e8-4e5e-8f68-eb88ca67f95f",
+		Long:          -46548146,
+		Lat:           -23568390,
+		SectCens:      "355030885000019",
+		Area:          "3550308005040",
+		IDdist:        "87",
+		District:      "VILA FORMOSA",
+		IDSubTH:       "26",
+		SubTownHall:   "ARICANDUVA",
+		Region5:       "Leste",
+		Region8:       "Leste 1",
+		Name:          "RAPOSO TAVARES",
+		Register:      "1129-0",
+		Street:        "Rua dos Bobos",
+		Number:        "500",
+		Neighborhood:  "JARDIM SARAH",
+		AddrExtraInfo: "Loren ipsum",
+		CreatedAt:     &time.Time{},
+		UpdatedAt:     &time.Time{},
+	}
+
+	rows := sqlmock.NewRows([]string{
+		"id", "long", "lat", "sectcens", "area", "iddist", "district", "idsubth", "subtownhall",
+		"region5", "region8", "name", "register", "street", "number", "neighborhood", "addrextrainfo", "createdat",
+		"updatedat",
+	}).AddRow(
+		want.ID, want.Long, want.Lat, want.SectCens, want.Area, want.IDdist, want.District, want.IDSubTH,
+		want.SubTownHall, want.Region5, want.Region8, want.Name, want.Register, want.Street, want.Number,
+		want.Neighborhood, want.AddrExtraInfo, want.CreatedAt, want.UpdatedAt,
+	)
+
+	mock.ExpectQuery("SELECT " + streetMarketColumns + " FROM street_market WHERE id = $1 AND deletedat IS NULL").
+		WithArgs(want.ID).
+		WillReturnRows(rows)
+
+	repo := NewStreetMarketRepository(db)
+
+	got, dErr := repo.GetByID(context.TODO(), want.ID)
+	if dErr != nil {
+		t.Errorf("expect return nil, got %v", dErr)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected street market (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStreetMarketRepository_GetByID_Error(t *testing.T) {
+	testCases := map[string]struct {
+		wErr domain.KindError
+		mErr error
+	}{
+		"When unexpected error occurs": {
+			wErr: domain.UnexpectedErrKd,
+			mErr: errSome,
+		},
+		"When nothing is found": {
+			wErr: domain.NothingFoundErrKd,
+			mErr: sql.ErrNoRows,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery(".+").WillReturnError(tc.mErr)
+
+			repo := NewStreetMarketRepository(db)
+
+			_, gErr := repo.GetByID(context.TODO(), "1966d99f-20e8-4e5e-8f68-eb88ca67f95f")
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+		})
+	}
+}
+
+func TestStreetMarketRepository_ListChanges(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	updated := domain.StreetMarket{
+		ID:        "1966d99f-20e8-4e5e-8f68-eb88ca67f95f",
+		Name:      "RAPOSO TAVARES",
+		CreatedAt: &at,
+		UpdatedAt: &at,
+	}
+	deleted := domain.StreetMarket{ID: "84713a81-0e31-4c14-a62f-7e1f67bc526d", CreatedAt: &at, UpdatedAt: &at}
+	other := domain.StreetMarket{ID: "9b1c7a0e-5f7b-4a0f-9d2a-0c8f1d2e3b4a", CreatedAt: &at, UpdatedAt: &at}
+
+	columns := []string{
+		"id", "long", "lat", "sectcens", "area", "iddist", "district", "idsubth", "subtownhall", "region5", "region8",
+		"name", "register", "street", "number", "neighborhood", "addrextrainfo", "createdat", "updatedat", "deletedat",
+		"changetxid",
+	}
+	row := func(sm domain.StreetMarket, deletedAt *time.Time, txID int64) []driver.Value {
+		return []driver.Value{
+			sm.ID, sm.Long, sm.Lat, sm.SectCens, sm.Area, sm.IDdist, sm.District, sm.IDSubTH, sm.SubTownHall,
+			sm.Region5, sm.Region8, sm.Name, sm.Register, sm.Street, sm.Number, sm.Neighborhood, sm.AddrExtraInfo,
+			sm.CreatedAt, sm.UpdatedAt, deletedAt, txID,
+		}
+	}
+	sel := "SELECT " + streetMarketColumns + ",deletedat,changetxid FROM street_market WHERE "
+
+	testCases := map[string]struct {
+		cur   domain.SyncCursor
+		wXmin bool
+		wQ    string
+		wArgs []driver.Value
+		rows  *sqlmock.Rows
+		want  domain.StreetMarketChanges
+	}{
+		"When it is the first sync": {
+			wXmin: true,
+			wQ:    sel + "deletedat IS NULL ORDER BY changetxid, id LIMIT 3",
+			rows:  sqlmock.NewRows(columns).AddRow(row(updated, nil, 740150)...),
+			want: domain.StreetMarketChanges{
+				Markets: []domain.StreetMarket{updated},
+				Deleted: []domain.Tombstone{},
+				Cursor:  domain.SyncCursor{Position: 740213},
+			},
+		},
+		"When it syncs since a position": {
+			cur:   domain.SyncCursor{Position: 740100},
+			wXmin: true,
+			wQ:    sel + "changetxid >= $1 ORDER BY changetxid, id LIMIT 3",
+			wArgs: []driver.Value{int64(740100)},
+			rows: sqlmock.NewRows(columns).
+				AddRow(row(updated, nil, 740150)...).
+				AddRow(row(deleted, &at, 740160)...),
+			want: domain.StreetMarketChanges{
+				Markets: []domain.StreetMarket{updated},
+				Deleted: []domain.Tombstone{{ID: deleted.ID, DeletedAt: &at}},
+				Cursor:  domain.SyncCursor{Position: 740213},
+			},
+		},
+		"When the changes do not fit a page": {
+			cur:   domain.SyncCursor{Position: 740100},
+			wXmin: true,
+			wQ:    sel + "changetxid >= $1 ORDER BY changetxid, id LIMIT 3",
+			wArgs: []driver.Value{int64(740100)},
+			rows: sqlmock.NewRows(columns).
+				AddRow(row(updated, nil, 740150)...).
+				AddRow(row(deleted, &at, 740160)...).
+				AddRow(row(other, nil, 740170)...),
+			want: domain.StreetMarketChanges{
+				Markets: []domain.StreetMarket{updated},
+				Deleted: []domain.Tombstone{{ID: deleted.ID, DeletedAt: &at}},
+				Cursor:  domain.SyncCursor{Position: 740100, Next: 740213, AfterTxID: 740160, AfterID: deleted.ID},
+			},
+		},
+		"When it pages the first sync": {
+			cur:   domain.SyncCursor{Next: 740213, AfterTxID: 740160, AfterID: deleted.ID},
+			wQ:    sel + "deletedat IS NULL AND (changetxid, id) > ($1, $2) ORDER BY changetxid, id LIMIT 3",
+			wArgs: []driver.Value{int64(740160), deleted.ID},
+			rows:  sqlmock.NewRows(columns).AddRow(row(other, nil, 740170)...),
+			want: domain.StreetMarketChanges{
+				Markets: []domain.StreetMarket{other},
+				Deleted: []domain.Tombstone{},
+				Cursor:  domain.SyncCursor{Position: 740213},
+			},
+		},
+		"When it pages a sync since a position": {
+			cur:   domain.SyncCursor{Position: 740100, Next: 740213, AfterTxID: 740160, AfterID: deleted.ID},
+			wQ:    sel + "changetxid >= $1 AND (changetxid, id) > ($2, $3) ORDER BY changetxid, id LIMIT 3",
+			wArgs: []driver.Value{int64(740100), int64(740160), deleted.ID},
+			rows:  sqlmock.NewRows(columns).AddRow(row(other, nil, 740170)...),
+			want: domain.StreetMarketChanges{
+				Markets: []domain.StreetMarket{other},
+				Deleted: []domain.Tombstone{},
+				Cursor:  domain.SyncCursor{Position: 740213},
+			},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			if tc.wXmin {
+				mock.ExpectQuery("SELECT txid_snapshot_xmin(txid_current_snapshot())").
+					WillReturnRows(sqlmock.NewRows([]string{"xmin"}).AddRow(740213))
+			}
+			mock.ExpectQuery(tc.wQ).WithArgs(tc.wArgs...).WillReturnRows(tc.rows)
+			mock.ExpectRollback()
+
+			repo := NewStreetMarketRepository(db)
+
+			got, dErr := repo.ListChanges(context.TODO(), tc.cur, 2)
+			if dErr != nil {
+				t.Fatalf("expect return nil, got %v", dErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("unexpected changes (-want +got):\n%s", diff)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestStreetMarketRepository_ListChanges_Error(t *testing.T) {
+	testCases := map[string]struct {
+		beginErr error
+		xminErr  error
+		listErr  error
+	}{
+		"When the transaction does not begin": {
+			beginErr: errSome,
+		},
+		"When the position is not read": {
+			xminErr: errSome,
+		},
+		"When the changes are not read": {
+			listErr: errSome,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			switch {
+			case tc.beginErr != nil:
+				mock.ExpectBegin().WillReturnError(tc.beginErr)
+			case tc.xminErr != nil:
+				mock.ExpectBegin()
+				mock.ExpectQuery("txid_snapshot_xmin").WillReturnError(tc.xminErr)
+				mock.ExpectRollback()
+			default:
+				mock.ExpectBegin()
+				mock.ExpectQuery("txid_snapshot_xmin").WillReturnRows(sqlmock.NewRows([]string{"xmin"}).AddRow(1))
+				mock.ExpectQuery("FROM street_market").WillReturnError(tc.listErr)
+				mock.ExpectRollback()
+			}
+
+			repo := NewStreetMarketRepository(db)
+
+			_, gErr := repo.ListChanges(context.TODO(), domain.SyncCursor{Position: 1}, 10)
+			if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+				t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func TestStreetMarketRepository_Update(t *testing.T) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
 	if err != nil {
@@ -356,8 +769,20 @@ func TestStreetMarketRepository_Update(t *testing.T) {
 		Neighborhood: "JARDIM SARAH",
 	}
 
+	before := domain.StreetMarket{ID: inp.ID, Name: "VILA FORMOSA", IDSubTH: "26"}
+	ev := domain.MarketEvent{
+		ID:       "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c",
+		Type:     domain.MarketUpdatedEvent,
+		MarketID: inp.ID,
+		Before:   &before,
+		After:    &inp,
+		Actor:    "maria",
+	}
+
+	mock.ExpectBegin()
 	mock.ExpectExec(
-		"UPDATE street_market SET name = $1,register = $2,street = $3,number = $4,neighborhood = $5 WHERE id = $6",
+		"UPDATE street_market SET name = $1,register = $2,street = $3,number = $4,neighborhood = $5 "+
+			"WHERE id = $6 AND deletedat IS NULL AND idsubth = $7",
 	).WithArgs(
 		inp.Name,
 		inp.Register,
@@ -365,11 +790,16 @@ func TestStreetMarketRepository_Update(t *testing.T) {
 		inp.Number,
 		inp.Neighborhood,
 		inp.ID,
+		before.IDSubTH,
 	).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO outbox (id,event,aggregateid,before,after,actor) VALUES ($1,$2,$3,$4,$5,$6)").
+		WithArgs(ev.ID, "street_market.updated", inp.ID, marketJSON(t, &before), marketJSON(t, &inp), "maria").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	repo := NewStreetMarketRepository(db)
 
-	if err := repo.Update(context.TODO(), inp); err != nil {
+	if err := repo.Update(context.TODO(), inp, ev); err != nil {
 		t.Errorf("expect return nil, got %v", err)
 	}
 
@@ -381,6 +811,7 @@ func TestStreetMarketRepository_Update(t *testing.T) {
 func TestStreetMarketRepository_Update_Error(t *testing.T) {
 	testCases := map[string]struct {
 		updateNothing bool
+		eventErr      error
 		wErr          domain.KindError
 		mErr          error
 	}{
@@ -392,6 +823,10 @@ func TestStreetMarketRepository_Update_Error(t *testing.T) {
 			updateNothing: true,
 			wErr:          domain.NothingUpdatedErrKd,
 		},
+		"When the event is not written": {
+			eventErr: errSome,
+			wErr:     domain.UnexpectedErrKd,
+		},
 	}
 
 	for title, tc := range testCases {
@@ -402,19 +837,29 @@ func TestStreetMarketRepository_Update_Error(t *testing.T) {
 			}
 			defer db.Close()
 
-			if tc.updateNothing {
+			mock.ExpectBegin()
+			switch {
+			case tc.updateNothing:
 				mock.ExpectExec(".+").WillReturnResult(sqlmock.NewResult(1, 0))
-			} else {
+			case tc.eventErr != nil:
+				mock.ExpectExec("UPDATE street_market").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO outbox").WillReturnError(tc.eventErr)
+			default:
 				mock.ExpectExec(".+").WillReturnError(tc.mErr)
 			}
+			mock.ExpectRollback()
 
 			repo := NewStreetMarketRepository(db)
 
-			gErr := repo.Update(context.TODO(), domain.StreetMarket{})
+			gErr := repo.Update(context.TODO(), domain.StreetMarket{}, domain.MarketEvent{})
 
 			if gErr.Kind != tc.wErr {
 				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
 			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
 		})
 	}
 }
@@ -463,3 +908,75 @@ func TestStreetMarketRepository_ignore(t *testing.T) {
 		t.Errorf("expect no ignore b")
 	}
 }
+
+func TestStreetMarketRepository_CountBy(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	wQ := "SELECT region5, COUNT(1) FROM street_market WHERE deletedat IS NULL AND district = $1 AND " +
+		"id IN (SELECT s.streetmarketid FROM stall s JOIN stall_category c ON c.stallid = s.id " +
+		"WHERE c.category = $2) GROUP BY region5 ORDER BY COUNT(1) DESC, region5"
+
+	mock.ExpectQuery(wQ).
+		WithArgs("VILA FORMOSA", "fish").
+		WillReturnRows(sqlmock.NewRows([]string{"region5", "count"}).AddRow("Leste", 3).AddRow("Norte", 1))
+
+	repo := NewStreetMarketRepository(db)
+
+	got, dErr := repo.CountBy(
+		context.TODO(),
+		domain.Region5Group,
+		domain.StreetMarketFilter{District: "VILA FORMOSA", Sells: domain.FishCategory},
+	)
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.StreetMarketCount{{Group: "Leste", Count: 3}, {Group: "Norte", Count: 1}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected counts (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestStreetMarketRepository_CountBy_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(".+").WillReturnError(errSome)
+
+	repo := NewStreetMarketRepository(db)
+
+	_, gErr := repo.CountBy(context.TODO(), domain.DistrictGroup, domain.StreetMarketFilter{})
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+}
+
+func TestStreetMarketRepository_CountBy_UnknownGroup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	repo := NewStreetMarketRepository(db)
+
+	_, gErr := repo.CountBy(context.TODO(), "district; DROP TABLE street_market", domain.StreetMarketFilter{})
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
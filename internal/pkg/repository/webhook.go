@@ -0,0 +1,408 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/lib/pq"
+)
+
+const (
+	webhookSubscriptionSelect = "SELECT id, url, secret, events, iddist, region5, createdat FROM webhook_subscription"
+	// deliveriesListLimit is how many of the latest deliveries of a
+	// subscription are listed.
+	deliveriesListLimit = 100
+)
+
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db}
+}
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) *domain.Error {
+	q := "INSERT INTO webhook_subscription (id,url,secret,events,iddist,region5) VALUES ($1,$2,$3,$4,$5,$6)"
+
+	events := pq.Array(eventStrings(sub.Events))
+	qr, err := r.db.ExecContext(ctx, q, sub.ID, sub.URL, sub.Secret, events, sub.IDdist, sub.Region5)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	ra, err := qr.RowsAffected()
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if ra < 1 {
+		return &domain.Error{
+			Kind: domain.NothingCreatedErrKd,
+			Msg:  fmt.Sprintf("0 rows affected for id %s", sub.ID),
+		}
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, *domain.Error) {
+	return r.listSubscriptions(ctx, webhookSubscriptionSelect+" ORDER BY createdat")
+}
+
+func (r *WebhookRepository) GetSubscription(
+	ctx context.Context,
+	ID string,
+) (domain.WebhookSubscription, *domain.Error) {
+	subs, err := r.listSubscriptions(ctx, webhookSubscriptionSelect+" WHERE id = $1", ID)
+	if err != nil {
+		return domain.WebhookSubscription{}, err
+	}
+
+	if len(subs) == 0 {
+		return domain.WebhookSubscription{}, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  fmt.Sprintf("0 rows found for id %s", ID),
+		}
+	}
+
+	return subs[0], nil
+}
+
+// ListSubscriptionsByEvent returns the subscriptions to event, whatever their
+// filters.
+func (r *WebhookRepository) ListSubscriptionsByEvent(
+	ctx context.Context,
+	event domain.EventType,
+) ([]domain.WebhookSubscription, *domain.Error) {
+	return r.listSubscriptions(ctx, webhookSubscriptionSelect+" WHERE $1 = ANY(events) ORDER BY createdat", string(event))
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, ID string) *domain.Error {
+	qr, err := r.db.ExecContext(ctx, "DELETE FROM webhook_subscription WHERE id = $1", ID)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	ra, err := qr.RowsAffected()
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if ra < 1 {
+		return &domain.Error{
+			Kind: domain.NothingDeletedErrKd,
+			Msg:  fmt.Sprintf("0 rows affected for id %s", ID),
+		}
+	}
+
+	return nil
+}
+
+// CreateDeliveries queues the deliveries for their first attempt, all of them
+// or none. A delivery already queued is left as it is.
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, ds []domain.WebhookDelivery) *domain.Error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	q := "INSERT INTO webhook_delivery (id,subscriptionid,event,payload) VALUES ($1,$2,$3,$4) " +
+		"ON CONFLICT (id) DO NOTHING"
+	for _, d := range ds {
+		if _, err := tx.ExecContext(ctx, q, d.ID, d.Subscription.ID, string(d.Event), d.Payload); err != nil {
+			return &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+// ClaimDeliveries returns up to limit pending deliveries due at now, with the
+// URL and secret of their subscriptions. Their next attempt is pushed to until,
+// so no other dispatcher claims them meanwhile and they are retried if this one
+// stops before recording the attempt.
+func (r *WebhookRepository) ClaimDeliveries(
+	ctx context.Context,
+	now time.Time,
+	until time.Time,
+	limit int,
+) ([]domain.WebhookDelivery, *domain.Error) {
+	q := "UPDATE webhook_delivery d SET nextattemptat = $2 FROM webhook_subscription s " +
+		"WHERE s.id = d.subscriptionid AND d.id IN (" +
+		"SELECT id FROM webhook_delivery WHERE status = 'pending' AND nextattemptat <= $1 " +
+		"ORDER BY nextattemptat LIMIT $3 FOR UPDATE SKIP LOCKED" +
+		") RETURNING d.id, d.subscriptionid, d.event, d.payload, d.attempts, s.url, s.secret"
+
+	res, err := r.db.QueryContext(ctx, q, now, until, limit)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	ds := []domain.WebhookDelivery{}
+	for res.Next() {
+		d := domain.WebhookDelivery{Status: domain.DeliveryPending, NextAttemptAt: &until}
+		if err := res.Scan(
+			&d.ID,
+			&d.Subscription.ID,
+			&d.Event,
+			&d.Payload,
+			&d.AttemptCount,
+			&d.Subscription.URL,
+			&d.Subscription.Secret,
+		); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+		ds = append(ds, d)
+	}
+
+	return ds, nil
+}
+
+// RecordAttempt saves attempt a of d along with the status, attempt count and
+// next attempt of d it resulted in.
+func (r *WebhookRepository) RecordAttempt(
+	ctx context.Context,
+	d domain.WebhookDelivery,
+	a domain.WebhookAttempt,
+) *domain.Error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	q := "INSERT INTO webhook_delivery_attempt (deliveryid,number,statuscode,error,durationms,attemptedat) " +
+		"VALUES ($1,$2,$3,$4,$5,$6)"
+	if _, err := tx.ExecContext(
+		ctx, q, d.ID, a.Number, a.StatusCode, a.Error, a.Duration.Milliseconds(), a.AttemptedAt,
+	); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	q = "UPDATE webhook_delivery SET status = $1, attempts = $2, nextattemptat = $3 WHERE id = $4"
+	if _, err := tx.ExecContext(ctx, q, string(d.Status), d.AttemptCount, d.NextAttemptAt, d.ID); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries of the subscription, newest
+// first and with their attempts. An empty status lists all of them.
+func (r *WebhookRepository) ListDeliveries(
+	ctx context.Context,
+	subID string,
+	status domain.DeliveryStatus,
+) ([]domain.WebhookDelivery, *domain.Error) {
+	var count int
+	q := "SELECT COUNT(1) FROM webhook_subscription WHERE id = $1"
+	if err := r.db.QueryRowContext(ctx, q, subID).Scan(&count); err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	if count < 1 {
+		return nil, &domain.Error{
+			Kind: domain.NothingFoundErrKd,
+			Msg:  fmt.Sprintf("0 rows found for id %s", subID),
+		}
+	}
+
+	where := "subscriptionid = $1"
+	args := []interface{}{subID}
+	if status != "" {
+		where += " AND status = $2"
+		args = append(args, string(status))
+	}
+
+	q = fmt.Sprintf(
+		"SELECT d.id, d.subscriptionid, d.event, d.payload, d.status, d.attempts, d.nextattemptat, d.createdat, "+
+			"a.number, a.statuscode, a.error, a.durationms, a.attemptedat FROM ("+
+			"SELECT id, subscriptionid, event, payload, status, attempts, nextattemptat, createdat "+
+			"FROM webhook_delivery WHERE %s ORDER BY createdat DESC LIMIT %v"+
+			") d LEFT JOIN webhook_delivery_attempt a ON a.deliveryid = d.id "+
+			"ORDER BY d.createdat DESC, d.id, a.number",
+		where,
+		deliveriesListLimit,
+	)
+
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	ds := []domain.WebhookDelivery{}
+	for res.Next() {
+		d := domain.WebhookDelivery{Attempts: []domain.WebhookAttempt{}}
+		var (
+			number, statusCode, durationMs sql.NullInt64
+			attemptErr                     sql.NullString
+			attemptedAt                    sql.NullTime
+		)
+		if err := res.Scan(
+			&d.ID,
+			&d.Subscription.ID,
+			&d.Event,
+			&d.Payload,
+			&d.Status,
+			&d.AttemptCount,
+			&d.NextAttemptAt,
+			&d.CreatedAt,
+			&number,
+			&statusCode,
+			&attemptErr,
+			&durationMs,
+			&attemptedAt,
+		); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+
+		// Rows of the same delivery are adjacent, one per attempt.
+		if len(ds) == 0 || ds[len(ds)-1].ID != d.ID {
+			ds = append(ds, d)
+		}
+		if number.Valid {
+			at := attemptedAt.Time
+			last := &ds[len(ds)-1]
+			last.Attempts = append(last.Attempts, domain.WebhookAttempt{
+				Number:      int(number.Int64),
+				StatusCode:  int(statusCode.Int64),
+				Error:       attemptErr.String,
+				Duration:    time.Duration(durationMs.Int64) * time.Millisecond,
+				AttemptedAt: &at,
+			})
+		}
+	}
+
+	return ds, nil
+}
+
+func (r *WebhookRepository) listSubscriptions(
+	ctx context.Context,
+	q string,
+	args ...interface{},
+) ([]domain.WebhookSubscription, *domain.Error) {
+	res, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, &domain.Error{
+			Kind:  domain.UnexpectedErrKd,
+			Msg:   err.Error(),
+			Cause: err,
+		}
+	}
+	defer res.Close()
+
+	subs := []domain.WebhookSubscription{}
+	for res.Next() {
+		sub := domain.WebhookSubscription{}
+		events := []string{}
+		if err := res.Scan(
+			&sub.ID,
+			&sub.URL,
+			&sub.Secret,
+			pq.Array(&events),
+			&sub.IDdist,
+			&sub.Region5,
+			&sub.CreatedAt,
+		); err != nil {
+			return nil, &domain.Error{
+				Kind:  domain.UnexpectedErrKd,
+				Msg:   err.Error(),
+				Cause: err,
+			}
+		}
+
+		for _, e := range events {
+			sub.Events = append(sub.Events, domain.EventType(e))
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, nil
+}
+
+func eventStrings(events []domain.EventType) []string {
+	ss := make([]string, 0, len(events))
+	for _, e := range events {
+		ss = append(ss, string(e))
+	}
+
+	return ss
+}
@@ -0,0 +1,407 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lib/pq"
+)
+
+func TestWebhookRepository_CreateSubscription(t *testing.T) {
+	sub := domain.WebhookSubscription{
+		ID:      "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+		URL:     "https://partner.example.com/hooks",
+		Secret:  "0123456789abcdef",
+		Events:  []domain.EventType{domain.MarketCreatedEvent, domain.MarketDeletedEvent},
+		Region5: "Leste",
+	}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO webhook_subscription (id,url,secret,events,iddist,region5) VALUES ($1,$2,$3,$4,$5,$6)").
+		WithArgs(
+			sub.ID,
+			sub.URL,
+			sub.Secret,
+			pq.Array([]string{"street_market.created", "street_market.deleted"}),
+			"",
+			"Leste",
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewWebhookRepository(db)
+
+	if dErr := repo.CreateSubscription(context.TODO(), sub); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWebhookRepository_ListSubscriptionsByEvent(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(webhookSubscriptionSelect + " WHERE $1 = ANY(events) ORDER BY createdat").
+		WithArgs("street_market.updated").
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "url", "secret", "events", "iddist", "region5", "createdat"}).
+				AddRow(
+					"1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+					"https://partner.example.com/hooks",
+					"0123456789abcdef",
+					"{street_market.created,street_market.updated}",
+					"87",
+					"",
+					nil,
+				),
+		)
+
+	repo := NewWebhookRepository(db)
+
+	got, dErr := repo.ListSubscriptionsByEvent(context.TODO(), domain.MarketUpdatedEvent)
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.WebhookSubscription{{
+		ID:     "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+		URL:    "https://partner.example.com/hooks",
+		Secret: "0123456789abcdef",
+		Events: []domain.EventType{domain.MarketCreatedEvent, domain.MarketUpdatedEvent},
+		IDdist: "87",
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected subscriptions (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWebhookRepository_GetSubscription_Error(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(webhookSubscriptionSelect + " WHERE id = $1").
+		WithArgs("1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret", "events", "iddist", "region5", "createdat"}))
+
+	repo := NewWebhookRepository(db)
+
+	_, gErr := repo.GetSubscription(context.TODO(), "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d")
+	if gErr == nil || gErr.Kind != domain.NothingFoundErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.NothingFoundErrKd, gErr)
+	}
+}
+
+func TestWebhookRepository_DeleteSubscription_Error(t *testing.T) {
+	testCases := map[string]struct {
+		result driver.Result
+		err    error
+		wErr   domain.KindError
+	}{
+		"When nothing is deleted": {
+			result: sqlmock.NewResult(0, 0),
+			wErr:   domain.NothingDeletedErrKd,
+		},
+		"When the query fails": {
+			err:  errors.New("connection refused"),
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectExec("DELETE FROM webhook_subscription").WillReturnResult(tc.result).WillReturnError(tc.err)
+
+			repo := NewWebhookRepository(db)
+
+			gErr := repo.DeleteSubscription(context.TODO(), "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d")
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_CreateDeliveries(t *testing.T) {
+	ds := []domain.WebhookDelivery{
+		{
+			ID:           "5a0d7f5e-3b7e-4c1e-9f57-2d1f3c4b5a6e",
+			Subscription: domain.WebhookSubscription{ID: "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"},
+			Event:        domain.MarketCreatedEvent,
+			Payload:      []byte(`{"event":"street_market.created"}`),
+		},
+		{
+			ID:           "6b1e8a6f-4c8f-4d2f-8a68-3e2a4d5c6b7f",
+			Subscription: domain.WebhookSubscription{ID: "2a1d2d3f-9e5c-4a68-8e1f-7b9a7c2f3d4e"},
+			Event:        domain.MarketCreatedEvent,
+			Payload:      []byte(`{"event":"street_market.created"}`),
+		},
+	}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	for _, d := range ds {
+		mock.ExpectExec(
+			"INSERT INTO webhook_delivery (id,subscriptionid,event,payload) VALUES ($1,$2,$3,$4) ON CONFLICT (id) DO NOTHING",
+		).
+			WithArgs(d.ID, d.Subscription.ID, "street_market.created", d.Payload).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	repo := NewWebhookRepository(db)
+
+	if dErr := repo.CreateDeliveries(context.TODO(), ds); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWebhookRepository_CreateDeliveries_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO webhook_delivery").WillReturnError(errors.New("connection refused"))
+	mock.ExpectRollback()
+
+	repo := NewWebhookRepository(db)
+
+	gErr := repo.CreateDeliveries(context.TODO(), []domain.WebhookDelivery{{ID: "5a0d7f5e-3b7e-4c1e-9f57-2d1f3c4b5a6e"}})
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWebhookRepository_ClaimDeliveries(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	until := now.Add(time.Minute)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`UPDATE webhook_delivery d SET nextattemptat = \$2 .+ FOR UPDATE SKIP LOCKED\) RETURNING`).
+		WithArgs(now, until, 10).
+		WillReturnRows(
+			sqlmock.NewRows([]string{"id", "subscriptionid", "event", "payload", "attempts", "url", "secret"}).
+				AddRow(
+					"5a0d7f5e-3b7e-4c1e-9f57-2d1f3c4b5a6e",
+					"1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+					"street_market.deleted",
+					[]byte(`{}`),
+					2,
+					"https://partner.example.com/hooks",
+					"0123456789abcdef",
+				),
+		)
+
+	repo := NewWebhookRepository(db)
+
+	got, dErr := repo.ClaimDeliveries(context.TODO(), now, until, 10)
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.WebhookDelivery{{
+		ID: "5a0d7f5e-3b7e-4c1e-9f57-2d1f3c4b5a6e",
+		Subscription: domain.WebhookSubscription{
+			ID:     "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+			URL:    "https://partner.example.com/hooks",
+			Secret: "0123456789abcdef",
+		},
+		Event:         domain.MarketDeletedEvent,
+		Payload:       []byte(`{}`),
+		Status:        domain.DeliveryPending,
+		AttemptCount:  2,
+		NextAttemptAt: &until,
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected deliveries (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWebhookRepository_RecordAttempt(t *testing.T) {
+	at := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	next := at.Add(time.Minute)
+	d := domain.WebhookDelivery{
+		ID:            "5a0d7f5e-3b7e-4c1e-9f57-2d1f3c4b5a6e",
+		Status:        domain.DeliveryPending,
+		AttemptCount:  1,
+		NextAttemptAt: &next,
+	}
+	a := domain.WebhookAttempt{
+		Number:      1,
+		StatusCode:  503,
+		Error:       "unexpected status 503",
+		Duration:    250 * time.Millisecond,
+		AttemptedAt: &at,
+	}
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(
+		"INSERT INTO webhook_delivery_attempt (deliveryid,number,statuscode,error,durationms,attemptedat) "+
+			"VALUES ($1,$2,$3,$4,$5,$6)",
+	).
+		WithArgs(d.ID, 1, 503, "unexpected status 503", int64(250), &at).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE webhook_delivery SET status = $1, attempts = $2, nextattemptat = $3 WHERE id = $4").
+		WithArgs("pending", 1, &next, d.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	repo := NewWebhookRepository(db)
+
+	if dErr := repo.RecordAttempt(context.TODO(), d, a); dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWebhookRepository_ListDeliveries(t *testing.T) {
+	subID := "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"
+	first := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	second := first.Add(30 * time.Second)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(1\) FROM webhook_subscription WHERE id = \$1`).
+		WithArgs(subID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(`WHERE subscriptionid = \$1 AND status = \$2 ORDER BY createdat DESC LIMIT 100`).
+		WithArgs(subID, "dead").
+		WillReturnRows(
+			sqlmock.NewRows([]string{
+				"id", "subscriptionid", "event", "payload", "status", "attempts", "nextattemptat", "createdat",
+				"number", "statuscode", "error", "durationms", "attemptedat",
+			}).
+				AddRow("d1", subID, "street_market.created", []byte(`{}`), "dead", 2, nil, nil,
+					1, 500, "unexpected status 500", 120, first).
+				AddRow("d1", subID, "street_market.created", []byte(`{}`), "dead", 2, nil, nil,
+					2, 0, "connection refused", 3, second).
+				AddRow("d2", subID, "street_market.deleted", []byte(`{}`), "dead", 0, nil, nil,
+					nil, nil, nil, nil, nil),
+		)
+
+	repo := NewWebhookRepository(db)
+
+	got, dErr := repo.ListDeliveries(context.TODO(), subID, domain.DeliveryDead)
+	if dErr != nil {
+		t.Fatalf("expect return nil, got %v", dErr)
+	}
+
+	want := []domain.WebhookDelivery{
+		{
+			ID:           "d1",
+			Subscription: domain.WebhookSubscription{ID: subID},
+			Event:        domain.MarketCreatedEvent,
+			Payload:      []byte(`{}`),
+			Status:       domain.DeliveryDead,
+			AttemptCount: 2,
+			Attempts: []domain.WebhookAttempt{
+				{
+					Number:      1,
+					StatusCode:  500,
+					Error:       "unexpected status 500",
+					Duration:    120 * time.Millisecond,
+					AttemptedAt: &first,
+				},
+				{Number: 2, Error: "connection refused", Duration: 3 * time.Millisecond, AttemptedAt: &second},
+			},
+		},
+		{
+			ID:           "d2",
+			Subscription: domain.WebhookSubscription{ID: subID},
+			Event:        domain.MarketDeletedEvent,
+			Payload:      []byte(`{}`),
+			Status:       domain.DeliveryDead,
+			Attempts:     []domain.WebhookAttempt{},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected deliveries (-want +got):\n%s", diff)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWebhookRepository_ListDeliveries_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	repo := NewWebhookRepository(db)
+
+	_, gErr := repo.ListDeliveries(context.TODO(), "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d", "")
+	if gErr == nil || gErr.Kind != domain.NothingFoundErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.NothingFoundErrKd, gErr)
+	}
+}
@@ -0,0 +1,110 @@
+package schedule
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+const calendarPageSize = 100
+
+type streetMarketRepository interface {
+	GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error)
+	List(context.Context, domain.Pagination, domain.StreetMarketFilter) ([]domain.StreetMarket, *domain.Error)
+}
+
+type scheduleRepositoryLister interface {
+	ListByStreetMarketIDs(ctx context.Context, IDs []string) (map[string]domain.Schedule, *domain.Error)
+}
+
+type CalendarReader struct {
+	smRepo  streetMarketRepository
+	schRepo scheduleRepositoryLister
+}
+
+func NewCalendarReader(smRepo streetMarketRepository, schRepo scheduleRepositoryLister) *CalendarReader {
+	return &CalendarReader{smRepo, schRepo}
+}
+
+func (s *CalendarReader) Get(ctx context.Context, ID domain.SMID) ([]domain.ScheduledStreetMarket, *domain.Error) {
+	if err := ID.Validate(); err != nil {
+		return nil, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	sm, err := s.smRepo.GetByID(ctx, string(ID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return nil, &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when getting", Previous: err}
+		}
+	}
+
+	return s.scheduled(ctx, []domain.StreetMarket{sm})
+}
+
+// List returns every street market matching the filter that has a schedule,
+// reading all pages since a calendar feed is not paginated.
+func (s *CalendarReader) List(
+	ctx context.Context,
+	query domain.StreetMarketFilter,
+) ([]domain.ScheduledStreetMarket, *domain.Error) {
+	filter := domain.StreetMarketFilter{
+		District:     query.District,
+		Region5:      query.Region5,
+		Name:         query.Name,
+		Neighborhood: query.Neighborhood,
+	}
+
+	sms := []domain.StreetMarket{}
+	pc := domain.Pagination{Limit: calendarPageSize}
+	for {
+		ls, err := s.smRepo.List(ctx, pc, filter)
+		if err != nil {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+		}
+
+		sms = append(sms, ls...)
+		if len(ls) < pc.Limit {
+			break
+		}
+		pc.Offset += pc.Limit
+	}
+
+	return s.scheduled(ctx, sms)
+}
+
+func (s *CalendarReader) scheduled(
+	ctx context.Context,
+	sms []domain.StreetMarket,
+) ([]domain.ScheduledStreetMarket, *domain.Error) {
+	ssms := []domain.ScheduledStreetMarket{}
+	if len(sms) == 0 {
+		return ssms, nil
+	}
+
+	ids := make([]string, 0, len(sms))
+	for _, sm := range sms {
+		ids = append(ids, sm.ID)
+	}
+
+	schs, err := s.schRepo.ListByStreetMarketIDs(ctx, ids)
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing schedules", Previous: err}
+	}
+
+	for _, sm := range sms {
+		sch, ok := schs[sm.ID]
+		if !ok {
+			continue
+		}
+		ssms = append(ssms, domain.ScheduledStreetMarket{StreetMarket: sm, Schedule: sch})
+	}
+
+	return ssms, nil
+}
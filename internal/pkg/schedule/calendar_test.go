@@ -0,0 +1,196 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubStreetMarketRepository struct {
+	listPCInp []domain.Pagination
+	getByID   func(context.Context, string) (domain.StreetMarket, *domain.Error)
+	list      func(context.Context, domain.Pagination, domain.StreetMarketFilter) ([]domain.StreetMarket, *domain.Error)
+}
+
+func (s *stubStreetMarketRepository) GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+	return s.getByID(ctx, ID)
+}
+
+func (s *stubStreetMarketRepository) List(
+	ctx context.Context,
+	pc domain.Pagination,
+	query domain.StreetMarketFilter,
+) ([]domain.StreetMarket, *domain.Error) {
+	s.listPCInp = append(s.listPCInp, pc)
+	return s.list(ctx, pc, query)
+}
+
+type stubScheduleRepositoryLister struct {
+	listInp []string
+	list    func(context.Context, []string) (map[string]domain.Schedule, *domain.Error)
+}
+
+func (s *stubScheduleRepositoryLister) ListByStreetMarketIDs(
+	ctx context.Context,
+	IDs []string,
+) (map[string]domain.Schedule, *domain.Error) {
+	s.listInp = IDs
+	return s.list(ctx, IDs)
+}
+
+func TestCalendarReader_Get(t *testing.T) {
+	sm := domain.StreetMarket{ID: "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10", Name: "VILA FORMOSA"}
+	sch := domain.Schedule{
+		StreetMarketID: sm.ID,
+		Slots:          []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+	}
+
+	smRepoMock := &stubStreetMarketRepository{
+		getByID: func(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+			return sm, nil
+		},
+	}
+	schRepoMock := &stubScheduleRepositoryLister{
+		list: func(ctx context.Context, IDs []string) (map[string]domain.Schedule, *domain.Error) {
+			return map[string]domain.Schedule{sm.ID: sch}, nil
+		},
+	}
+
+	srv := NewCalendarReader(smRepoMock, schRepoMock)
+
+	got, err := srv.Get(context.TODO(), domain.SMID(sm.ID))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	want := []domain.ScheduledStreetMarket{{StreetMarket: sm, Schedule: sch}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+}
+
+func TestCalendarReader_Get_Error(t *testing.T) {
+	testCases := map[string]struct {
+		id     domain.SMID
+		smErr  *domain.Error
+		schErr *domain.Error
+		wErr   domain.KindError
+	}{
+		"When id is invalid": {
+			id:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When street market not exists": {
+			id:    "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10",
+			smErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr:  domain.SMNotFoundErrKd,
+		},
+		"When unexpected error occurs getting street market": {
+			id:    "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10",
+			smErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr:  domain.UnexpectedErrKd,
+		},
+		"When unexpected error occurs listing schedules": {
+			id:     "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10",
+			schErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr:   domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			smRepoMock := &stubStreetMarketRepository{
+				getByID: func(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+					return domain.StreetMarket{ID: ID}, tc.smErr
+				},
+			}
+			schRepoMock := &stubScheduleRepositoryLister{
+				list: func(ctx context.Context, IDs []string) (map[string]domain.Schedule, *domain.Error) {
+					return nil, tc.schErr
+				},
+			}
+
+			srv := NewCalendarReader(smRepoMock, schRepoMock)
+
+			_, gErr := srv.Get(context.TODO(), tc.id)
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+		})
+	}
+}
+
+func TestCalendarReader_List(t *testing.T) {
+	page := make([]domain.StreetMarket, calendarPageSize)
+	for i := range page {
+		page[i] = domain.StreetMarket{ID: "page-1", District: "VILA FORMOSA"}
+	}
+	scheduled := domain.StreetMarket{ID: "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10", District: "VILA FORMOSA"}
+	sch := domain.Schedule{
+		StreetMarketID: scheduled.ID,
+		Slots:          []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+	}
+
+	smRepoMock := &stubStreetMarketRepository{
+		list: func(
+			ctx context.Context,
+			pc domain.Pagination,
+			query domain.StreetMarketFilter,
+		) ([]domain.StreetMarket, *domain.Error) {
+			if pc.Offset == 0 {
+				return page, nil
+			}
+			return []domain.StreetMarket{scheduled}, nil
+		},
+	}
+	schRepoMock := &stubScheduleRepositoryLister{
+		list: func(ctx context.Context, IDs []string) (map[string]domain.Schedule, *domain.Error) {
+			return map[string]domain.Schedule{scheduled.ID: sch}, nil
+		},
+	}
+
+	srv := NewCalendarReader(smRepoMock, schRepoMock)
+
+	got, err := srv.List(context.TODO(), domain.StreetMarketFilter{District: "VILA FORMOSA"})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	want := []domain.ScheduledStreetMarket{{StreetMarket: scheduled, Schedule: sch}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+
+	wPCs := []domain.Pagination{{Offset: 0, Limit: calendarPageSize}, {Offset: calendarPageSize, Limit: calendarPageSize}}
+	if diff := cmp.Diff(wPCs, smRepoMock.listPCInp); diff != "" {
+		t.Errorf("unexpected pages when calls list (-want +got):\n%s", diff)
+	}
+
+	if len(schRepoMock.listInp) != calendarPageSize+1 {
+		t.Errorf("expect schedules of %v street markets, got %v", calendarPageSize+1, len(schRepoMock.listInp))
+	}
+}
+
+func TestCalendarReader_List_Error(t *testing.T) {
+	smRepoMock := &stubStreetMarketRepository{
+		list: func(
+			ctx context.Context,
+			pc domain.Pagination,
+			query domain.StreetMarketFilter,
+		) ([]domain.StreetMarket, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd}
+		},
+	}
+
+	srv := NewCalendarReader(smRepoMock, &stubScheduleRepositoryLister{})
+
+	_, gErr := srv.List(context.TODO(), domain.StreetMarketFilter{})
+
+	if gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr.Kind)
+	}
+}
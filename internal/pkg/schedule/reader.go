@@ -0,0 +1,45 @@
+package schedule
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryReader interface {
+	GetByStreetMarketID(ctx context.Context, ID string) (domain.Schedule, *domain.Error)
+}
+
+type ScheduleReader struct {
+	repo repositoryReader
+}
+
+func NewReader(repo repositoryReader) *ScheduleReader {
+	return &ScheduleReader{repo}
+}
+
+func (s *ScheduleReader) Get(ctx context.Context, ID domain.SMID) (domain.Schedule, *domain.Error) {
+	if err := ID.Validate(); err != nil {
+		return domain.Schedule{}, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	sch, err := s.repo.GetByStreetMarketID(ctx, string(ID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return domain.Schedule{}, &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return domain.Schedule{}, &domain.Error{
+				Kind:     domain.UnexpectedErrKd,
+				Msg:      "Unexpected error when getting schedule",
+				Previous: err,
+			}
+		}
+	}
+
+	return sch, nil
+}
@@ -0,0 +1,91 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRepositoryReader struct {
+	getInp string
+	get    func(context.Context, string) (domain.Schedule, *domain.Error)
+}
+
+func (s *stubRepositoryReader) GetByStreetMarketID(ctx context.Context, ID string) (domain.Schedule, *domain.Error) {
+	s.getInp = ID
+	return s.get(ctx, ID)
+}
+
+func TestScheduleReader_Get(t *testing.T) {
+	id := "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"
+	want := domain.Schedule{
+		StreetMarketID: id,
+		Slots:          []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+		Exceptions:     []domain.ScheduleException{},
+	}
+
+	repoMock := &stubRepositoryReader{
+		get: func(ctx context.Context, ID string) (domain.Schedule, *domain.Error) {
+			return want, nil
+		},
+	}
+
+	srv := NewReader(repoMock)
+
+	got, err := srv.Get(context.TODO(), domain.SMID(id))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+
+	if repoMock.getInp != id {
+		t.Errorf("expect repository receive id %s, got %s", id, repoMock.getInp)
+	}
+}
+
+func TestScheduleReader_Get_Error(t *testing.T) {
+	testCases := map[string]struct {
+		id   domain.SMID
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When id is invalid": {
+			id:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When street market not exists": {
+			id:   "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10",
+			rErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr: domain.SMNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			id:   "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10",
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryReader{
+				get: func(ctx context.Context, ID string) (domain.Schedule, *domain.Error) {
+					return domain.Schedule{}, tc.rErr
+				},
+			}
+
+			srv := NewReader(repoMock)
+
+			_, gErr := srv.Get(context.TODO(), tc.id)
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package schedule
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryWriter interface {
+	Replace(ctx context.Context, sch domain.Schedule) *domain.Error
+}
+
+// marketGetter gets the street market of the schedule, which tells who may
+// change it.
+type marketGetter interface {
+	GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error)
+}
+
+type ScheduleWriter struct {
+	repo    repositoryWriter
+	markets marketGetter
+}
+
+func NewWriter(repo repositoryWriter, markets marketGetter) *ScheduleWriter {
+	return &ScheduleWriter{repo, markets}
+}
+
+func (s *ScheduleWriter) Replace(ctx context.Context, ID domain.SMID, sch domain.Schedule) *domain.Error {
+	if err := ID.Validate(); err != nil {
+		return &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	if err := sch.Validate(); err != nil {
+		return &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid input",
+			Previous: err,
+		}
+	}
+
+	sm, err := s.markets.GetByID(ctx, string(ID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when authorize", Previous: err}
+		}
+	}
+
+	if err := domain.AuthorizeMarketChange(ctx, sm, domain.ScopeMarketsWrite); err != nil {
+		return err
+	}
+
+	sch.StreetMarketID = string(ID)
+
+	if err := s.repo.Replace(ctx, sch); err != nil {
+		switch err.Kind {
+		case domain.NothingUpdatedErrKd:
+			return &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when replacing schedule", Previous: err}
+		}
+	}
+
+	return nil
+}
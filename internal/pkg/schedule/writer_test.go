@@ -0,0 +1,124 @@
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRepositoryWriter struct {
+	replaceInp domain.Schedule
+	replace    func(context.Context, domain.Schedule) *domain.Error
+}
+
+func (s *stubRepositoryWriter) Replace(ctx context.Context, sch domain.Schedule) *domain.Error {
+	s.replaceInp = sch
+	return s.replace(ctx, sch)
+}
+
+// stubMarketGetter gets sm, or fails with err.
+type stubMarketGetter struct {
+	sm  domain.StreetMarket
+	err *domain.Error
+}
+
+func (s stubMarketGetter) GetByID(context.Context, string) (domain.StreetMarket, *domain.Error) {
+	return s.sm, s.err
+}
+
+func editor() context.Context {
+	return domain.WithUser(context.TODO(), domain.User{Subject: "maria", Role: domain.RoleEditor, IDSubTH: "26"})
+}
+
+func TestScheduleWriter_Replace(t *testing.T) {
+	id := "8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10"
+	inp := domain.Schedule{
+		Slots: []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 420, End: 780}},
+	}
+
+	repoMock := &stubRepositoryWriter{
+		replace: func(ctx context.Context, sch domain.Schedule) *domain.Error {
+			return nil
+		},
+	}
+
+	srv := NewWriter(repoMock, stubMarketGetter{sm: domain.StreetMarket{ID: id, IDSubTH: "26"}})
+
+	if err := srv.Replace(editor(), domain.SMID(id), inp); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	want := inp
+	want.StreetMarketID = id
+	if diff := cmp.Diff(want, repoMock.replaceInp); diff != "" {
+		t.Errorf("unexpected schedule when calls replace (-want +got):\n%s", diff)
+	}
+}
+
+func TestScheduleWriter_Replace_Error(t *testing.T) {
+	validID := domain.SMID("8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10")
+
+	testCases := map[string]struct {
+		id      domain.SMID
+		inp     domain.Schedule
+		markets *stubMarketGetter
+		rErr    *domain.Error
+		wErr    domain.KindError
+	}{
+		"When id is invalid": {
+			id:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When schedule is invalid": {
+			id:   validID,
+			inp:  domain.Schedule{Slots: []domain.ScheduleSlot{{Weekday: time.Saturday, Start: 780, End: 420}}},
+			wErr: domain.InpValidationErrKd,
+		},
+		"When street market is not found to authorize": {
+			id:      validID,
+			markets: &stubMarketGetter{err: &domain.Error{Kind: domain.NothingFoundErrKd}},
+			wErr:    domain.SMNotFoundErrKd,
+		},
+		"When street market is of another sub-town hall": {
+			id:      validID,
+			markets: &stubMarketGetter{sm: domain.StreetMarket{ID: string(validID), IDSubTH: "25"}},
+			wErr:    domain.ForbiddenErrKd,
+		},
+		"When street market not exists": {
+			id:   validID,
+			rErr: &domain.Error{Kind: domain.NothingUpdatedErrKd},
+			wErr: domain.SMNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			id:   validID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryWriter{
+				replace: func(ctx context.Context, sch domain.Schedule) *domain.Error {
+					return tc.rErr
+				},
+			}
+
+			markets := stubMarketGetter{sm: domain.StreetMarket{ID: string(validID), IDSubTH: "26"}}
+			if tc.markets != nil {
+				markets = *tc.markets
+			}
+
+			srv := NewWriter(repoMock, markets)
+
+			gErr := srv.Replace(editor(), tc.id, tc.inp)
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryReader interface {
+	ListByEdition(ctx context.Context, edition int) ([]domain.StreetMarketSnapshot, *domain.Error)
+}
+
+type SnapshotDiffer struct {
+	repo repositoryReader
+}
+
+func NewDiffer(repo repositoryReader) *SnapshotDiffer {
+	return &SnapshotDiffer{repo}
+}
+
+func (s *SnapshotDiffer) Diff(ctx context.Context, inp domain.SnapshotDiffInput) (domain.SnapshotDiff, *domain.Error) {
+	if err := inp.Validate(); err != nil {
+		return domain.SnapshotDiff{}, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid input",
+			Previous: err,
+		}
+	}
+
+	from, err := s.edition(ctx, inp.From)
+	if err != nil {
+		return domain.SnapshotDiff{}, err
+	}
+
+	to, err := s.edition(ctx, inp.To)
+	if err != nil {
+		return domain.SnapshotDiff{}, err
+	}
+
+	fromByReg := indexByRegister(from)
+	toByReg := indexByRegister(to)
+
+	diff := domain.SnapshotDiff{
+		From:      inp.From,
+		To:        inp.To,
+		Added:     []domain.StreetMarketSnapshot{},
+		Removed:   []domain.StreetMarketSnapshot{},
+		Relocated: []domain.SnapshotChange{},
+		Renamed:   []domain.SnapshotChange{},
+	}
+
+	for _, before := range from {
+		after, ok := toByReg[before.Register]
+		if !ok {
+			diff.Removed = append(diff.Removed, before)
+			continue
+		}
+		if before.Relocated(after) {
+			diff.Relocated = append(diff.Relocated, domain.SnapshotChange{Before: before, After: after})
+		}
+		if before.Renamed(after) {
+			diff.Renamed = append(diff.Renamed, domain.SnapshotChange{Before: before, After: after})
+		}
+	}
+
+	for _, after := range to {
+		if _, ok := fromByReg[after.Register]; !ok {
+			diff.Added = append(diff.Added, after)
+		}
+	}
+
+	return diff, nil
+}
+
+func (s *SnapshotDiffer) edition(ctx context.Context, edition int) ([]domain.StreetMarketSnapshot, *domain.Error) {
+	ls, err := s.repo.ListByEdition(ctx, edition)
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when reading edition", Previous: err}
+	}
+
+	if len(ls) == 0 {
+		return nil, &domain.Error{Kind: domain.SnapNotFoundErrKd, Msg: fmt.Sprintf("Edition %v not exists", edition)}
+	}
+
+	return ls, nil
+}
+
+func indexByRegister(ls []domain.StreetMarketSnapshot) map[string]domain.StreetMarketSnapshot {
+	idx := map[string]domain.StreetMarketSnapshot{}
+	for _, ss := range ls {
+		idx[ss.Register] = ss
+	}
+
+	return idx
+}
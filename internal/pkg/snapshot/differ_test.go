@@ -0,0 +1,110 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRepositoryReader struct {
+	listByEdition func(context.Context, int) ([]domain.StreetMarketSnapshot, *domain.Error)
+}
+
+func (s *stubRepositoryReader) ListByEdition(
+	ctx context.Context,
+	edition int,
+) ([]domain.StreetMarketSnapshot, *domain.Error) {
+	return s.listByEdition(ctx, edition)
+}
+
+func TestSnapshotDiffer_Diff(t *testing.T) {
+	kept := domain.StreetMarketSnapshot{Edition: 2003, Register: "1129-0", Name: "RAPOSO TAVARES", Street: "RUA NOVE"}
+	removed := domain.StreetMarketSnapshot{Edition: 2003, Register: "7216-8", Name: "PRACA LEAO X", Street: "RUA CODAJ-S"}
+	moved := domain.StreetMarketSnapshot{Edition: 2003, Register: "4041-0", Name: "VILA FORMOSA", Street: "RUA PRETORIA"}
+	renamed := domain.StreetMarketSnapshot{Edition: 2003, Register: "1193-2", Name: "COHAB", Street: "RUA ARPOADOR"}
+
+	keptAfter := kept
+	keptAfter.Edition = 2014
+	movedAfter := moved
+	movedAfter.Edition = 2014
+	movedAfter.Street = "RUA MARAGOJIPE"
+	renamedAfter := renamed
+	renamedAfter.Edition = 2014
+	renamedAfter.Name = "COHAB RAPOSO TAVARES"
+	added := domain.StreetMarketSnapshot{Edition: 2014, Register: "4045-2", Name: "PRACA SANTA HELENA"}
+
+	editions := map[int][]domain.StreetMarketSnapshot{
+		2003: {kept, removed, moved, renamed},
+		2014: {keptAfter, movedAfter, renamedAfter, added},
+	}
+
+	repoMock := &stubRepositoryReader{
+		listByEdition: func(ctx context.Context, edition int) ([]domain.StreetMarketSnapshot, *domain.Error) {
+			return editions[edition], nil
+		},
+	}
+
+	want := domain.SnapshotDiff{
+		From:      2003,
+		To:        2014,
+		Added:     []domain.StreetMarketSnapshot{added},
+		Removed:   []domain.StreetMarketSnapshot{removed},
+		Relocated: []domain.SnapshotChange{{Before: moved, After: movedAfter}},
+		Renamed:   []domain.SnapshotChange{{Before: renamed, After: renamedAfter}},
+	}
+
+	srv := NewDiffer(repoMock)
+
+	got, err := srv.Diff(context.TODO(), domain.SnapshotDiffInput{From: 2003, To: 2014})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+}
+
+func TestSnapshotDiffer_Diff_Error(t *testing.T) {
+	testCases := map[string]struct {
+		inp  domain.SnapshotDiffInput
+		ls   []domain.StreetMarketSnapshot
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When input is invalid": {
+			inp:  domain.SnapshotDiffInput{},
+			wErr: domain.InpValidationErrKd,
+		},
+		"When edition not exists": {
+			inp:  domain.SnapshotDiffInput{From: 2003, To: 2014},
+			ls:   []domain.StreetMarketSnapshot{},
+			wErr: domain.SnapNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			inp:  domain.SnapshotDiffInput{From: 2003, To: 2014},
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryReader{
+				listByEdition: func(ctx context.Context, edition int) ([]domain.StreetMarketSnapshot, *domain.Error) {
+					return tc.ls, tc.rErr
+				},
+			}
+
+			srv := NewDiffer(repoMock)
+
+			_, gErr := srv.Diff(context.TODO(), tc.inp)
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+		})
+	}
+}
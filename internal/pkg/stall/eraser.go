@@ -0,0 +1,41 @@
+package stall
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryEraser interface {
+	DeleteByID(ctx context.Context, smID, ID string) *domain.Error
+}
+
+type StallEraser struct {
+	repo    repositoryEraser
+	markets marketGetter
+}
+
+func NewEraser(repo repositoryEraser, markets marketGetter) *StallEraser {
+	return &StallEraser{repo, markets}
+}
+
+func (s *StallEraser) Delete(ctx context.Context, smID domain.SMID, ID domain.StallID) *domain.Error {
+	if err := validateIDs(smID, ID); err != nil {
+		return err
+	}
+
+	if err := authorizeMarket(ctx, s.markets, smID, domain.ScopeMarketsDelete); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteByID(ctx, string(smID), string(ID)); err != nil {
+		switch err.Kind {
+		case domain.NothingDeletedErrKd:
+			return &domain.Error{Kind: domain.StallNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when delete", Previous: err}
+		}
+	}
+
+	return nil
+}
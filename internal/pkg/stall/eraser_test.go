@@ -0,0 +1,90 @@
+package stall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type stubRepositoryEraser struct {
+	delete func(context.Context, string, string) *domain.Error
+}
+
+func (s *stubRepositoryEraser) DeleteByID(ctx context.Context, smID, ID string) *domain.Error {
+	return s.delete(ctx, smID, ID)
+}
+
+func TestStallEraser_Delete(t *testing.T) {
+	var gotSMID, gotID string
+	repoMock := &stubRepositoryEraser{
+		delete: func(ctx context.Context, smID, ID string) *domain.Error {
+			gotSMID, gotID = smID, ID
+			return nil
+		},
+	}
+
+	srv := NewEraser(repoMock, market())
+
+	if err := srv.Delete(editor(), validSMID, validStallID); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if gotSMID != string(validSMID) || gotID != string(validStallID) {
+		t.Errorf("expect repository receive ids %s %s, got %s %s", validSMID, validStallID, gotSMID, gotID)
+	}
+}
+
+func TestStallEraser_Delete_Error(t *testing.T) {
+	testCases := map[string]struct {
+		id      domain.StallID
+		markets *stubMarketGetter
+		rErr    *domain.Error
+		wErr    domain.KindError
+	}{
+		"When id is invalid": {
+			id:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When street market is of another sub-town hall": {
+			id:      validStallID,
+			markets: &stubMarketGetter{sm: domain.StreetMarket{ID: string(validSMID), IDSubTH: "25"}},
+			wErr:    domain.ForbiddenErrKd,
+		},
+		"When unexpected error occurs getting the street market": {
+			id:      validStallID,
+			markets: &stubMarketGetter{err: &domain.Error{Kind: domain.UnexpectedErrKd}},
+			wErr:    domain.UnexpectedErrKd,
+		},
+		"When stall not exists": {
+			id:   validStallID,
+			rErr: &domain.Error{Kind: domain.NothingDeletedErrKd},
+			wErr: domain.StallNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			id:   validStallID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryEraser{
+				delete: func(context.Context, string, string) *domain.Error { return tc.rErr },
+			}
+
+			markets := market()
+			if tc.markets != nil {
+				markets = *tc.markets
+			}
+
+			srv := NewEraser(repoMock, markets)
+
+			err := srv.Delete(editor(), validSMID, tc.id)
+			if err == nil || err.Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package stall
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryReader interface {
+	ListByStreetMarketID(ctx context.Context, smID string) ([]domain.Stall, *domain.Error)
+	GetByID(ctx context.Context, smID, ID string) (domain.Stall, *domain.Error)
+}
+
+type StallReader struct {
+	repo repositoryReader
+}
+
+func NewReader(repo repositoryReader) *StallReader {
+	return &StallReader{repo}
+}
+
+func (s *StallReader) List(ctx context.Context, smID domain.SMID) ([]domain.Stall, *domain.Error) {
+	if err := smID.Validate(); err != nil {
+		return nil, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	ls, err := s.repo.ListByStreetMarketID(ctx, string(smID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return nil, &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+		}
+	}
+
+	return ls, nil
+}
+
+func (s *StallReader) Get(ctx context.Context, smID domain.SMID, ID domain.StallID) (domain.Stall, *domain.Error) {
+	if err := validateIDs(smID, ID); err != nil {
+		return domain.Stall{}, err
+	}
+
+	st, err := s.repo.GetByID(ctx, string(smID), string(ID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return domain.Stall{}, &domain.Error{Kind: domain.StallNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return domain.Stall{}, &domain.Error{
+				Kind:     domain.UnexpectedErrKd,
+				Msg:      "Unexpected error when getting",
+				Previous: err,
+			}
+		}
+	}
+
+	return st, nil
+}
+
+func validateIDs(smID domain.SMID, ID domain.StallID) *domain.Error {
+	if err := smID.Validate(); err != nil {
+		return &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	if err := ID.Validate(); err != nil {
+		return &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid stall ID",
+			Previous: err,
+		}
+	}
+
+	return nil
+}
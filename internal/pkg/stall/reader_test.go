@@ -0,0 +1,143 @@
+package stall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	validSMID    = domain.SMID("8d4b5a4e-2f2e-4d43-8b8c-4a7b7a5a8f10")
+	validStallID = domain.StallID("12d54a54-bbd7-4e70-8c3c-7e8e424d8ebf")
+)
+
+type stubRepositoryReader struct {
+	list func(context.Context, string) ([]domain.Stall, *domain.Error)
+	get  func(context.Context, string, string) (domain.Stall, *domain.Error)
+}
+
+func (s *stubRepositoryReader) ListByStreetMarketID(ctx context.Context, smID string) ([]domain.Stall, *domain.Error) {
+	return s.list(ctx, smID)
+}
+
+func (s *stubRepositoryReader) GetByID(ctx context.Context, smID, ID string) (domain.Stall, *domain.Error) {
+	return s.get(ctx, smID, ID)
+}
+
+func TestStallReader_List(t *testing.T) {
+	want := []domain.Stall{{ID: string(validStallID), StreetMarketID: string(validSMID), Number: 1}}
+
+	var gotSMID string
+	repoMock := &stubRepositoryReader{
+		list: func(ctx context.Context, smID string) ([]domain.Stall, *domain.Error) {
+			gotSMID = smID
+			return want, nil
+		},
+	}
+
+	srv := NewReader(repoMock)
+
+	got, err := srv.List(context.TODO(), validSMID)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+
+	if gotSMID != string(validSMID) {
+		t.Errorf("expect repository receive id %s, got %s", validSMID, gotSMID)
+	}
+}
+
+func TestStallReader_List_Error(t *testing.T) {
+	testCases := map[string]struct {
+		smID domain.SMID
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When id is invalid": {
+			smID: "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When street market not exists": {
+			smID: validSMID,
+			rErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr: domain.SMNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			smID: validSMID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryReader{
+				list: func(context.Context, string) ([]domain.Stall, *domain.Error) {
+					return nil, tc.rErr
+				},
+			}
+
+			srv := NewReader(repoMock)
+
+			_, err := srv.List(context.TODO(), tc.smID)
+			if err == nil || err.Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, err)
+			}
+		})
+	}
+}
+
+func TestStallReader_Get_Error(t *testing.T) {
+	testCases := map[string]struct {
+		smID domain.SMID
+		id   domain.StallID
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When street market id is invalid": {
+			smID: "invalid",
+			id:   validStallID,
+			wErr: domain.InpValidationErrKd,
+		},
+		"When stall id is invalid": {
+			smID: validSMID,
+			id:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When stall not exists": {
+			smID: validSMID,
+			id:   validStallID,
+			rErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr: domain.StallNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			smID: validSMID,
+			id:   validStallID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryReader{
+				get: func(context.Context, string, string) (domain.Stall, *domain.Error) {
+					return domain.Stall{}, tc.rErr
+				},
+			}
+
+			srv := NewReader(repoMock)
+
+			_, err := srv.Get(context.TODO(), tc.smID, tc.id)
+			if err == nil || err.Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package stall
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryWriter interface {
+	Create(ctx context.Context, st domain.Stall) *domain.Error
+	Update(ctx context.Context, st domain.Stall) *domain.Error
+}
+
+// marketGetter gets the street market of the stalls, which tells who may
+// change them.
+type marketGetter interface {
+	GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error)
+}
+
+type uuidGenerator func() string
+
+type StallWriter struct {
+	repo    repositoryWriter
+	markets marketGetter
+	idGen   uuidGenerator
+}
+
+func NewWriter(repo repositoryWriter, markets marketGetter, idGen uuidGenerator) *StallWriter {
+	return &StallWriter{repo, markets, idGen}
+}
+
+func (s *StallWriter) Create(
+	ctx context.Context,
+	smID domain.SMID,
+	inp domain.StallCreateInput,
+) (string, *domain.Error) {
+	if err := smID.Validate(); err != nil {
+		return "", &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	if err := inp.Validate(); err != nil {
+		return "", &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid input",
+			Previous: err,
+			Fields:   err.Fields,
+		}
+	}
+
+	if err := authorizeMarket(ctx, s.markets, smID, domain.ScopeMarketsWrite); err != nil {
+		return "", err
+	}
+
+	st := domain.Stall{
+		ID:             s.idGen(),
+		StreetMarketID: string(smID),
+		Number:         inp.Number,
+		VendorName:     inp.VendorName,
+		LicenseNumber:  inp.LicenseNumber,
+		Categories:     inp.Categories,
+	}
+
+	if err := s.repo.Create(ctx, st); err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return "", &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		case domain.DuplicatedErrKd:
+			return "", &domain.Error{Kind: domain.StallDupErrKd, Msg: "Stall number already exists", Previous: err}
+		default:
+			return "", &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when create", Previous: err}
+		}
+	}
+
+	return st.ID, nil
+}
+
+func (s *StallWriter) Edit(
+	ctx context.Context,
+	smID domain.SMID,
+	ID domain.StallID,
+	inp domain.StallEditInput,
+) *domain.Error {
+	if err := validateIDs(smID, ID); err != nil {
+		return err
+	}
+
+	if err := inp.Validate(); err != nil {
+		return &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid input",
+			Previous: err,
+			Fields:   err.Fields,
+		}
+	}
+
+	if err := authorizeMarket(ctx, s.markets, smID, domain.ScopeMarketsWrite); err != nil {
+		return err
+	}
+
+	st := domain.Stall{
+		ID:             string(ID),
+		StreetMarketID: string(smID),
+		Number:         inp.Number,
+		VendorName:     inp.VendorName,
+		LicenseNumber:  inp.LicenseNumber,
+		Categories:     inp.Categories,
+	}
+
+	if err := s.repo.Update(ctx, st); err != nil {
+		switch err.Kind {
+		case domain.NothingUpdatedErrKd:
+			return &domain.Error{Kind: domain.StallNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		case domain.DuplicatedErrKd:
+			return &domain.Error{Kind: domain.StallDupErrKd, Msg: "Stall number already exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when edit", Previous: err}
+		}
+	}
+
+	return nil
+}
+
+// authorizeMarket tells whether the caller of ctx may change the stalls of the
+// street market smID, as domain.AuthorizeMarketChange does for the street
+// market itself.
+func authorizeMarket(ctx context.Context, markets marketGetter, smID domain.SMID, scope domain.Scope) *domain.Error {
+	sm, err := markets.GetByID(ctx, string(smID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when authorize", Previous: err}
+		}
+	}
+
+	return domain.AuthorizeMarketChange(ctx, sm, scope)
+}
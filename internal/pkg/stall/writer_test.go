@@ -0,0 +1,241 @@
+package stall
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRepositoryWriter struct {
+	createInp domain.Stall
+	updateInp domain.Stall
+	create    func(context.Context, domain.Stall) *domain.Error
+	update    func(context.Context, domain.Stall) *domain.Error
+}
+
+func (s *stubRepositoryWriter) Create(ctx context.Context, st domain.Stall) *domain.Error {
+	s.createInp = st
+	return s.create(ctx, st)
+}
+
+func (s *stubRepositoryWriter) Update(ctx context.Context, st domain.Stall) *domain.Error {
+	s.updateInp = st
+	return s.update(ctx, st)
+}
+
+// stubMarketGetter gets sm, or fails with err.
+type stubMarketGetter struct {
+	sm  domain.StreetMarket
+	err *domain.Error
+}
+
+func (s stubMarketGetter) GetByID(context.Context, string) (domain.StreetMarket, *domain.Error) {
+	return s.sm, s.err
+}
+
+// market is the street market of the stalls, of the sub-town hall of editor.
+func market() stubMarketGetter {
+	return stubMarketGetter{sm: domain.StreetMarket{ID: string(validSMID), IDSubTH: "26"}}
+}
+
+func editor() context.Context {
+	return domain.WithUser(context.TODO(), domain.User{Subject: "maria", Role: domain.RoleEditor, IDSubTH: "26"})
+}
+
+func TestStallWriter_Create(t *testing.T) {
+	inp := domain.StallCreateInput{
+		Number:        1,
+		VendorName:    "Maria da Silva",
+		LicenseNumber: "TPU-2022-0001",
+		Categories:    []domain.ProductCategory{domain.FishCategory},
+	}
+
+	repoMock := &stubRepositoryWriter{
+		create: func(context.Context, domain.Stall) *domain.Error { return nil },
+	}
+
+	srv := NewWriter(repoMock, market(), func() string { return string(validStallID) })
+
+	id, err := srv.Create(editor(), validSMID, inp)
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if id != string(validStallID) {
+		t.Errorf("expect id %s, got %s", validStallID, id)
+	}
+
+	want := domain.Stall{
+		ID:             string(validStallID),
+		StreetMarketID: string(validSMID),
+		Number:         1,
+		VendorName:     "Maria da Silva",
+		LicenseNumber:  "TPU-2022-0001",
+		Categories:     []domain.ProductCategory{domain.FishCategory},
+	}
+	if diff := cmp.Diff(want, repoMock.createInp); diff != "" {
+		t.Errorf("unexpected stall when calls create (-want +got):\n%s", diff)
+	}
+}
+
+func TestStallWriter_Create_Error(t *testing.T) {
+	validInp := domain.StallCreateInput{
+		Number:        1,
+		VendorName:    "Maria da Silva",
+		LicenseNumber: "TPU-2022-0001",
+		Categories:    []domain.ProductCategory{domain.FishCategory},
+	}
+
+	testCases := map[string]struct {
+		smID    domain.SMID
+		inp     domain.StallCreateInput
+		markets *stubMarketGetter
+		rErr    *domain.Error
+		wErr    domain.KindError
+	}{
+		"When id is invalid": {
+			smID: "invalid",
+			inp:  validInp,
+			wErr: domain.InpValidationErrKd,
+		},
+		"When input is invalid": {
+			smID: validSMID,
+			wErr: domain.InpValidationErrKd,
+		},
+		"When street market not exists": {
+			smID: validSMID,
+			inp:  validInp,
+			rErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr: domain.SMNotFoundErrKd,
+		},
+		"When street market is not found to authorize": {
+			smID:    validSMID,
+			inp:     validInp,
+			markets: &stubMarketGetter{err: &domain.Error{Kind: domain.NothingFoundErrKd}},
+			wErr:    domain.SMNotFoundErrKd,
+		},
+		"When street market is of another sub-town hall": {
+			smID:    validSMID,
+			inp:     validInp,
+			markets: &stubMarketGetter{sm: domain.StreetMarket{ID: string(validSMID), IDSubTH: "25"}},
+			wErr:    domain.ForbiddenErrKd,
+		},
+		"When stall number already exists": {
+			smID: validSMID,
+			inp:  validInp,
+			rErr: &domain.Error{Kind: domain.DuplicatedErrKd},
+			wErr: domain.StallDupErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			smID: validSMID,
+			inp:  validInp,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryWriter{
+				create: func(context.Context, domain.Stall) *domain.Error { return tc.rErr },
+			}
+
+			markets := market()
+			if tc.markets != nil {
+				markets = *tc.markets
+			}
+
+			srv := NewWriter(repoMock, markets, func() string { return string(validStallID) })
+
+			_, err := srv.Create(editor(), tc.smID, tc.inp)
+			if err == nil || err.Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, err)
+			}
+		})
+	}
+}
+
+func TestStallWriter_Edit(t *testing.T) {
+	inp := domain.StallEditInput{VendorName: "Joana"}
+
+	repoMock := &stubRepositoryWriter{
+		update: func(context.Context, domain.Stall) *domain.Error { return nil },
+	}
+
+	srv := NewWriter(repoMock, market(), nil)
+
+	if err := srv.Edit(editor(), validSMID, validStallID, inp); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	want := domain.Stall{ID: string(validStallID), StreetMarketID: string(validSMID), VendorName: "Joana"}
+	if diff := cmp.Diff(want, repoMock.updateInp); diff != "" {
+		t.Errorf("unexpected stall when calls update (-want +got):\n%s", diff)
+	}
+}
+
+func TestStallWriter_Edit_Error(t *testing.T) {
+	testCases := map[string]struct {
+		id   domain.StallID
+		inp  domain.StallEditInput
+		ctx  context.Context
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When id is invalid": {
+			id:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When input is invalid": {
+			id:   validStallID,
+			inp:  domain.StallEditInput{Categories: []domain.ProductCategory{}},
+			wErr: domain.InpValidationErrKd,
+		},
+		"When the editor is of another sub-town hall": {
+			id:   validStallID,
+			ctx:  domain.WithUser(context.TODO(), domain.User{Subject: "joana", Role: domain.RoleEditor, IDSubTH: "25"}),
+			wErr: domain.ForbiddenErrKd,
+		},
+		"When nobody is authenticated": {
+			id:   validStallID,
+			ctx:  context.TODO(),
+			wErr: domain.UnauthenticatedErrKd,
+		},
+		"When stall not exists": {
+			id:   validStallID,
+			rErr: &domain.Error{Kind: domain.NothingUpdatedErrKd},
+			wErr: domain.StallNotFoundErrKd,
+		},
+		"When stall number already exists": {
+			id:   validStallID,
+			rErr: &domain.Error{Kind: domain.DuplicatedErrKd},
+			wErr: domain.StallDupErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			id:   validStallID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryWriter{
+				update: func(context.Context, domain.Stall) *domain.Error { return tc.rErr },
+			}
+
+			srv := NewWriter(repoMock, market(), nil)
+
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = editor()
+			}
+			err := srv.Edit(ctx, validSMID, tc.id, tc.inp)
+			if err == nil || err.Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, err)
+			}
+		})
+	}
+}
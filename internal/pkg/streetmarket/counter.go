@@ -0,0 +1,73 @@
+package streetmarket
+
+import (
+	"context"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryCounter interface {
+	CountBy(context.Context, domain.StatsGroupBy, domain.StreetMarketFilter) ([]domain.StreetMarketCount, *domain.Error)
+}
+
+type snapshotRepositoryCounter interface {
+	CountBy(
+		context.Context,
+		int,
+		domain.StatsGroupBy,
+		domain.StreetMarketFilter,
+	) ([]domain.StreetMarketCount, *domain.Error)
+}
+
+type StreetMarketCounter struct {
+	repo     repositoryCounter
+	snapRepo snapshotRepositoryCounter
+	loc      *time.Location
+}
+
+// NewCounter builds a counter that evaluates schedule filters on the loc time
+// zone, like NewReader.
+func NewCounter(repo repositoryCounter, snapRepo snapshotRepositoryCounter, loc *time.Location) *StreetMarketCounter {
+	return &StreetMarketCounter{repo, snapRepo, loc}
+}
+
+func (s *StreetMarketCounter) Count(
+	ctx context.Context,
+	inp domain.StreetMarketStatsInput,
+) ([]domain.StreetMarketCount, *domain.Error) {
+	if err := inp.Validate(); err != nil {
+		return nil, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid input",
+			Previous: err,
+		}
+	}
+
+	filter := domain.StreetMarketFilter{
+		District:     inp.Filter.District,
+		Region5:      inp.Filter.Region5,
+		Name:         inp.Filter.Name,
+		Neighborhood: inp.Filter.Neighborhood,
+		OpenOn:       inp.Filter.OpenOn,
+		Sells:        inp.Filter.Sells,
+	}
+
+	if inp.Filter.OpenAt != nil {
+		openAt := inp.Filter.OpenAt.In(s.loc)
+		filter.OpenAt = &openAt
+	}
+
+	var cs []domain.StreetMarketCount
+	var err *domain.Error
+	if inp.Snapshot != 0 {
+		cs, err = s.snapRepo.CountBy(ctx, inp.Snapshot, inp.GroupBy, filter)
+	} else {
+		cs, err = s.repo.CountBy(ctx, inp.GroupBy, filter)
+	}
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when counting", Previous: err}
+	}
+
+	return cs, nil
+}
@@ -0,0 +1,160 @@
+package streetmarket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type countFunc func(
+	context.Context,
+	domain.StatsGroupBy,
+	domain.StreetMarketFilter,
+) ([]domain.StreetMarketCount, *domain.Error)
+
+type stubRepositoryCounter struct {
+	countGInp domain.StatsGroupBy
+	countFInp domain.StreetMarketFilter
+	count     countFunc
+}
+
+func (s *stubRepositoryCounter) CountBy(
+	ctx context.Context,
+	groupBy domain.StatsGroupBy,
+	query domain.StreetMarketFilter,
+) ([]domain.StreetMarketCount, *domain.Error) {
+	s.countGInp = groupBy
+	s.countFInp = query
+	return s.count(ctx, groupBy, query)
+}
+
+type stubSnapshotRepositoryCounter struct {
+	countEInp int
+	countFInp domain.StreetMarketFilter
+	count     func(context.Context, int, domain.StatsGroupBy, domain.StreetMarketFilter) (
+		[]domain.StreetMarketCount,
+		*domain.Error,
+	)
+}
+
+func (s *stubSnapshotRepositoryCounter) CountBy(
+	ctx context.Context,
+	edition int,
+	groupBy domain.StatsGroupBy,
+	query domain.StreetMarketFilter,
+) ([]domain.StreetMarketCount, *domain.Error) {
+	s.countEInp = edition
+	s.countFInp = query
+	return s.count(ctx, edition, groupBy, query)
+}
+
+func TestStreetMarketCounter_Count(t *testing.T) {
+	want := []domain.StreetMarketCount{{Group: "Leste", Count: 12}}
+	openAt := time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC)
+	brt := time.FixedZone("BRT", -3*60*60)
+
+	repoMock := &stubRepositoryCounter{
+		count: func(context.Context, domain.StatsGroupBy, domain.StreetMarketFilter) (
+			[]domain.StreetMarketCount,
+			*domain.Error,
+		) {
+			return want, nil
+		},
+	}
+
+	srv := NewCounter(repoMock, &stubSnapshotRepositoryCounter{}, brt)
+
+	got, err := srv.Count(context.TODO(), domain.StreetMarketStatsInput{
+		GroupBy: domain.Region5Group,
+		Filter:  domain.StreetMarketFilter{District: "VILA FORMOSA", OpenAt: &openAt},
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+
+	if repoMock.countGInp != domain.Region5Group {
+		t.Errorf("expect repository receive group %s, got %s", domain.Region5Group, repoMock.countGInp)
+	}
+
+	gotAt := repoMock.countFInp.OpenAt
+	if gotAt == nil || gotAt.Location() != brt || !gotAt.Equal(openAt) {
+		t.Errorf("expect repository receive open at %v on %v, got %v", openAt, brt, gotAt)
+	}
+}
+
+func TestStreetMarketCounter_Count_Snapshot(t *testing.T) {
+	want := []domain.StreetMarketCount{{Group: "ARICANDUVA", Count: 7}}
+
+	snapMock := &stubSnapshotRepositoryCounter{
+		count: func(context.Context, int, domain.StatsGroupBy, domain.StreetMarketFilter) (
+			[]domain.StreetMarketCount,
+			*domain.Error,
+		) {
+			return want, nil
+		},
+	}
+
+	srv := NewCounter(&stubRepositoryCounter{}, snapMock, time.UTC)
+
+	got, err := srv.Count(context.TODO(), domain.StreetMarketStatsInput{
+		GroupBy:  domain.SubTownHallGroup,
+		Filter:   domain.StreetMarketFilter{Region5: "Leste"},
+		Snapshot: 2014,
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+
+	if snapMock.countEInp != 2014 {
+		t.Errorf("expect snapshot repository receive edition 2014, got %v", snapMock.countEInp)
+	}
+}
+
+func TestStreetMarketCounter_Count_Error(t *testing.T) {
+	testCases := map[string]struct {
+		inp  domain.StreetMarketStatsInput
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When group is invalid": {
+			inp:  domain.StreetMarketStatsInput{GroupBy: "street"},
+			wErr: domain.InpValidationErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			inp:  domain.StreetMarketStatsInput{GroupBy: domain.DistrictGroup},
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryCounter{
+				count: func(context.Context, domain.StatsGroupBy, domain.StreetMarketFilter) (
+					[]domain.StreetMarketCount,
+					*domain.Error,
+				) {
+					return nil, tc.rErr
+				},
+			}
+
+			srv := NewCounter(repoMock, &stubSnapshotRepositoryCounter{}, time.UTC)
+
+			_, err := srv.Count(context.TODO(), tc.inp)
+			if err == nil || err.Kind != tc.wErr {
+				t.Errorf("expect error kind %s, got %v", tc.wErr, err)
+			}
+		})
+	}
+}
@@ -6,16 +6,20 @@ import (
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 )
 
+// repositoryEraser deletes a street market along with writing the event of the
+// delete, both or none.
 type repositoryEraser interface {
-	DeleteByID(ctx context.Context, ID string) *domain.Error
+	DeleteByID(ctx context.Context, ID string, ev domain.MarketEvent) *domain.Error
+	GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error)
 }
 
 type StreetMarketEraser struct {
-	repo repositoryEraser
+	repo  repositoryEraser
+	idGen uuidGenerator
 }
 
-func NewEraser(repo repositoryEraser) *StreetMarketEraser {
-	return &StreetMarketEraser{repo}
+func NewEraser(repo repositoryEraser, idGen uuidGenerator) *StreetMarketEraser {
+	return &StreetMarketEraser{repo, idGen}
 }
 
 func (s *StreetMarketEraser) Delete(ctx context.Context, ID domain.SMID) *domain.Error {
@@ -27,7 +31,30 @@ func (s *StreetMarketEraser) Delete(ctx context.Context, ID domain.SMID) *domain
 		}
 	}
 
-	if err := s.repo.DeleteByID(ctx, string(ID)); err != nil {
+	// The street market as it was before the delete is the before of the event.
+	sm, err := s.repo.GetByID(ctx, string(ID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when delete", Previous: err}
+		}
+	}
+
+	if err := domain.AuthorizeMarketChange(ctx, sm, domain.ScopeMarketsDelete); err != nil {
+		return err
+	}
+
+	ev := domain.MarketEvent{
+		ID:       s.idGen(),
+		Type:     domain.MarketDeletedEvent,
+		MarketID: sm.ID,
+		Before:   &sm,
+		Actor:    domain.Actor(ctx),
+	}
+
+	if err := s.repo.DeleteByID(ctx, string(ID), ev); err != nil {
 		switch err.Kind {
 		case domain.NothingDeletedErrKd:
 			return &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
@@ -5,30 +5,43 @@ import (
 	"testing"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
 )
 
 type stubRepositoryEraser struct {
 	deleteInp  string
+	eventInp   domain.MarketEvent
 	deleteByID func(ctx context.Context, ID string) *domain.Error
+	getByID    func(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error)
 }
 
-func (s *stubRepositoryEraser) DeleteByID(ctx context.Context, ID string) *domain.Error {
+func (s *stubRepositoryEraser) DeleteByID(ctx context.Context, ID string, ev domain.MarketEvent) *domain.Error {
 	s.deleteInp = ID
+	s.eventInp = ev
 	return s.deleteByID(ctx, ID)
 }
 
+func (s *stubRepositoryEraser) GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+	return s.getByID(ctx, ID)
+}
+
 func TestStreetMarketEraser_Delete(t *testing.T) {
 	var wID domain.SMID = "662ea609-2ef5-4026-a402-218dd316cc03"
+	sm := domain.StreetMarket{ID: string(wID), IDdist: "87", Region5: "Leste", Name: "RAPOSO TAVARES"}
 
 	repoMock := &stubRepositoryEraser{
 		deleteByID: func(ctx context.Context, ID string) *domain.Error {
 			return nil
 		},
+		getByID: func(context.Context, string) (domain.StreetMarket, *domain.Error) {
+			return sm, nil
+		},
 	}
 
-	srv := NewEraser(repoMock)
+	srv := NewEraser(repoMock, sequence("5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c"))
 
-	err := srv.Delete(context.TODO(), wID)
+	ctx := domain.WithUser(context.TODO(), domain.User{Subject: "maria", Role: domain.RoleAdmin})
+	err := srv.Delete(ctx, wID)
 
 	if err != nil {
 		t.Errorf("want return nil, got %v", err)
@@ -37,28 +50,66 @@ func TestStreetMarketEraser_Delete(t *testing.T) {
 	if string(wID) != repoMock.deleteInp {
 		t.Errorf("unexpected id when call deletebyid, want %s, got %s", wID, repoMock.deleteInp)
 	}
+
+	wEvent := domain.MarketEvent{
+		ID:       "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c",
+		Type:     domain.MarketDeletedEvent,
+		MarketID: string(wID),
+		Before:   &sm,
+		Actor:    "maria",
+	}
+	if diff := cmp.Diff(wEvent, repoMock.eventInp); diff != "" {
+		t.Errorf("unexpected event when calls deletebyid (-want +got):\n%s", diff)
+	}
 }
 
 func TestStreetMarketEraser_Delete_Error(t *testing.T) {
 	testCases := map[string]struct {
-		rErr *domain.Error
-		ID   domain.SMID
-		wErr domain.KindError
+		ctx    context.Context
+		rErr   *domain.Error
+		getErr *domain.Error
+		ID     domain.SMID
+		wErr   domain.KindError
 	}{
 		"When id is invalid": {
 			wErr: domain.InpValidationErrKd,
 			ID:   "invalid",
 		},
 		"When street market not exists": {
+			getErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr:   domain.SMNotFoundErrKd,
+			ID:     "29336645-6243-4279-b7ff-47f1a64aa781",
+		},
+		"When street market is deleted meanwhile": {
 			rErr: &domain.Error{Kind: domain.NothingDeletedErrKd},
 			wErr: domain.SMNotFoundErrKd,
 			ID:   "29336645-6243-4279-b7ff-47f1a64aa781",
 		},
+		"When a unexpected error occurs getting the street market": {
+			getErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr:   domain.UnexpectedErrKd,
+			ID:     "6c34a17f-6330-4625-9184-25eb0a5c6533",
+		},
 		"When a unexpected error occurs in repository": {
 			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
 			wErr: domain.UnexpectedErrKd,
 			ID:   "6c34a17f-6330-4625-9184-25eb0a5c6533",
 		},
+		"When the user is of another sub-town hall": {
+			ctx:  domain.WithUser(context.TODO(), domain.User{Subject: "maria", Role: domain.RoleAdmin, IDSubTH: "25"}),
+			wErr: domain.ForbiddenErrKd,
+			ID:   "6c34a17f-6330-4625-9184-25eb0a5c6533",
+		},
+		"When the API key may not delete": {
+			ctx:  domain.WithAPIKey(context.TODO(), domain.APIKey{Scopes: []domain.Scope{domain.ScopeMarketsWrite}}),
+			wErr: domain.ForbiddenErrKd,
+			ID:   "6c34a17f-6330-4625-9184-25eb0a5c6533",
+		},
+		"When nobody is authenticated": {
+			ctx:  context.TODO(),
+			wErr: domain.UnauthenticatedErrKd,
+			ID:   "6c34a17f-6330-4625-9184-25eb0a5c6533",
+		},
 	}
 
 	for title, tc := range testCases {
@@ -67,15 +118,27 @@ func TestStreetMarketEraser_Delete_Error(t *testing.T) {
 				deleteByID: func(ctx context.Context, ID string) *domain.Error {
 					return tc.rErr
 				},
+				getByID: func(_ context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+					return domain.StreetMarket{ID: ID, IDSubTH: "26"}, tc.getErr
+				},
 			}
 
-			srv := NewEraser(repoMock)
+			srv := NewEraser(repoMock, func() string { return "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c" })
 
-			gErr := srv.Delete(context.TODO(), tc.ID)
+			// Cases without a caller are of a user of the whole city.
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = domain.WithUser(context.TODO(), domain.User{Subject: "maria", Role: domain.RoleAdmin})
+			}
+			gErr := srv.Delete(ctx, tc.ID)
 
 			if gErr.Kind != tc.wErr {
 				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
 			}
+
+			if tc.wErr == domain.ForbiddenErrKd && repoMock.deleteInp != "" {
+				t.Errorf("expect nothing deleted, got %s", repoMock.deleteInp)
+			}
 		})
 	}
 }
@@ -2,20 +2,25 @@ package streetmarket
 
 import (
 	"context"
+	"time"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 )
 
 type repositoryReader interface {
 	List(context.Context, domain.Pagination, domain.StreetMarketFilter) ([]domain.StreetMarket, *domain.Error)
+	GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error)
 }
 
 type StreetMarketReader struct {
 	repo repositoryReader
+	loc  *time.Location
 }
 
-func NewReader(repo repositoryReader) *StreetMarketReader {
-	return &StreetMarketReader{repo}
+// NewReader builds a reader that evaluates schedule filters on the loc time zone,
+// which must be the domain.ScheduleTimeZone location outside tests.
+func NewReader(repo repositoryReader, loc *time.Location) *StreetMarketReader {
+	return &StreetMarketReader{repo, loc}
 }
 
 func (s *StreetMarketReader) List(
@@ -32,17 +37,80 @@ func (s *StreetMarketReader) List(
 		pc.Offset = (page-1)*perPage + 1
 	}
 
+	ls, err := s.repo.List(ctx, pc, s.filter(query))
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+	}
+
+	return ls, nil
+}
+
+// Walk calls fn with every street market matching query, reading them page by
+// page, and stops at the first error of fn.
+func (s *StreetMarketReader) Walk(
+	ctx context.Context,
+	query domain.StreetMarketFilter,
+	fn func(domain.StreetMarket) error,
+) *domain.Error {
+	const perPage = 100
+
+	filter := s.filter(query)
+	for pc := (domain.Pagination{Limit: perPage}); ; pc.Offset += perPage {
+		ls, err := s.repo.List(ctx, pc, filter)
+		if err != nil {
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+		}
+
+		for _, sm := range ls {
+			if err := fn(sm); err != nil {
+				return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: err.Error(), Cause: err}
+			}
+		}
+
+		if len(ls) < perPage {
+			return nil
+		}
+	}
+}
+
+func (s *StreetMarketReader) filter(query domain.StreetMarketFilter) domain.StreetMarketFilter {
 	filter := domain.StreetMarketFilter{
 		District:     query.District,
 		Region5:      query.Region5,
 		Name:         query.Name,
 		Neighborhood: query.Neighborhood,
+		OpenOn:       query.OpenOn,
+		Sells:        query.Sells,
+	}
+
+	if query.OpenAt != nil {
+		openAt := query.OpenAt.In(s.loc)
+		filter.OpenAt = &openAt
+	}
+
+	return filter
+}
+
+func (s *StreetMarketReader) Get(ctx context.Context, ID domain.SMID) (domain.StreetMarket, *domain.Error) {
+	if err := ID.Validate(); err != nil {
+		return domain.StreetMarket{}, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
 	}
 
-	ls, err := s.repo.List(ctx, pc, filter)
+	sm, err := s.repo.GetByID(ctx, string(ID))
 	if err != nil {
-		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when listing", Previous: err}
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			err = &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			err = &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when get", Previous: err}
+		}
+
+		return domain.StreetMarket{}, err
 	}
 
-	return ls, nil
+	return sm, nil
 }
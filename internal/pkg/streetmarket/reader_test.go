@@ -2,7 +2,9 @@ package streetmarket
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 	"github.com/google/go-cmp/cmp"
@@ -13,6 +15,7 @@ type stubRepositoryReader struct {
 	listFInp  domain.StreetMarketFilter
 	listPCInp domain.Pagination
 	list      func(context.Context, domain.Pagination, domain.StreetMarketFilter) ([]domain.StreetMarket, *domain.Error)
+	getByID   func(context.Context, string) (domain.StreetMarket, *domain.Error)
 }
 
 func (s *stubRepositoryReader) List(
@@ -25,6 +28,10 @@ func (s *stubRepositoryReader) List(
 	return s.list(ctx, pc, query)
 }
 
+func (s *stubRepositoryReader) GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+	return s.getByID(ctx, ID)
+}
+
 func TestStreetMarketReader_List(t *testing.T) {
 	want := []domain.StreetMarket{{
 		ID:            uuid.NewString(),
@@ -56,7 +63,7 @@ func TestStreetMarketReader_List(t *testing.T) {
 		},
 	}
 
-	srv := NewReader(repoMock)
+	srv := NewReader(repoMock, time.UTC)
 
 	wInp := domain.StreetMarketFilter{
 		District:     want[0].District,
@@ -127,6 +134,35 @@ func TestStreetMarketReader_List(t *testing.T) {
 	})
 }
 
+func TestStreetMarketReader_List_OpenAt(t *testing.T) {
+	repoMock := &stubRepositoryReader{
+		list: func(
+			ctx context.Context,
+			pc domain.Pagination,
+			query domain.StreetMarketFilter,
+		) ([]domain.StreetMarket, *domain.Error) {
+			return []domain.StreetMarket{}, nil
+		},
+	}
+
+	loc := time.FixedZone("BRT", -3*60*60)
+	srv := NewReader(repoMock, loc)
+
+	openAt := time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC)
+	if _, err := srv.List(context.TODO(), 0, domain.StreetMarketFilter{OpenAt: &openAt}); err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	got := repoMock.listFInp.OpenAt
+	if got == nil {
+		t.Fatal("expect open at, got nil")
+	}
+
+	if got.Location() != loc || got.Hour() != 8 {
+		t.Errorf("expect open at on schedule time zone at 08h, got %v", got)
+	}
+}
+
 func TestStreetMarketReader_List_Error(t *testing.T) {
 	testCases := map[string]struct {
 		wErr domain.KindError
@@ -152,7 +188,7 @@ func TestStreetMarketReader_List_Error(t *testing.T) {
 				},
 			}
 
-			srv := NewReader(repoMock)
+			srv := NewReader(repoMock, time.UTC)
 
 			_, gErr := srv.List(context.TODO(), 0, tc.inp)
 
@@ -162,3 +198,149 @@ func TestStreetMarketReader_List_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestStreetMarketReader_Get(t *testing.T) {
+	want := domain.StreetMarket{ID: uuid.NewString(), Name: "RAPOSO TAVARES"}
+
+	var gotID string
+	repoMock := &stubRepositoryReader{
+		getByID: func(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+			gotID = ID
+			return want, nil
+		},
+	}
+
+	got, err := NewReader(repoMock, time.UTC).Get(context.TODO(), domain.SMID(want.ID))
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected return (-want +got):\n%s", diff)
+	}
+
+	if gotID != want.ID {
+		t.Errorf("expect get by id %s, got %s", want.ID, gotID)
+	}
+}
+
+func TestStreetMarketReader_Get_Error(t *testing.T) {
+	testCases := map[string]struct {
+		wErr domain.KindError
+		inp  domain.SMID
+		rErr *domain.Error
+	}{
+		"When ID is invalid": {
+			wErr: domain.InpValidationErrKd,
+			inp:  "invalid",
+		},
+		"When street market is not found": {
+			wErr: domain.SMNotFoundErrKd,
+			inp:  domain.SMID(uuid.NewString()),
+			rErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+		},
+		"When a unexpected error occurs in reader repository": {
+			wErr: domain.UnexpectedErrKd,
+			inp:  domain.SMID(uuid.NewString()),
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryReader{
+				getByID: func(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+					return domain.StreetMarket{}, tc.rErr
+				},
+			}
+
+			_, gErr := NewReader(repoMock, time.UTC).Get(context.TODO(), tc.inp)
+
+			if gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
+			}
+		})
+	}
+}
+
+func TestStreetMarketReader_Walk(t *testing.T) {
+	pages := [][]domain.StreetMarket{make([]domain.StreetMarket, 100), {{ID: uuid.NewString()}}}
+
+	gotPc := []domain.Pagination{}
+	repoMock := &stubRepositoryReader{
+		list: func(
+			ctx context.Context,
+			pc domain.Pagination,
+			query domain.StreetMarketFilter,
+		) ([]domain.StreetMarket, *domain.Error) {
+			gotPc = append(gotPc, pc)
+			return pages[len(gotPc)-1], nil
+		},
+	}
+
+	filter := domain.StreetMarketFilter{District: "VILA FORMOSA"}
+
+	got := 0
+	err := NewReader(repoMock, time.UTC).Walk(context.TODO(), filter, func(sm domain.StreetMarket) error {
+		got++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expect nil, got %v", err)
+	}
+
+	if got != 101 {
+		t.Errorf("expect 101 street markets, got %d", got)
+	}
+
+	wPc := []domain.Pagination{{Offset: 0, Limit: 100}, {Offset: 100, Limit: 100}}
+	if diff := cmp.Diff(wPc, gotPc); diff != "" {
+		t.Errorf("unexpected page chain when calls list (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(filter, repoMock.listFInp); diff != "" {
+		t.Errorf("unexpected filter when calls list (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreetMarketReader_Walk_Error(t *testing.T) {
+	testCases := map[string]struct {
+		rErr  *domain.Error
+		fnErr error
+	}{
+		"When a unexpected error occurs in reader repository": {
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+		},
+		"When fn fails": {
+			fnErr: context.Canceled,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryReader{
+				list: func(
+					ctx context.Context,
+					pc domain.Pagination,
+					query domain.StreetMarketFilter,
+				) ([]domain.StreetMarket, *domain.Error) {
+					return []domain.StreetMarket{{}}, tc.rErr
+				},
+			}
+
+			gErr := NewReader(repoMock, time.UTC).Walk(
+				context.TODO(),
+				domain.StreetMarketFilter{},
+				func(domain.StreetMarket) error { return tc.fnErr },
+			)
+
+			if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+				t.Fatalf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+			}
+
+			if tc.fnErr != nil && !errors.Is(gErr, tc.fnErr) {
+				t.Errorf("expect error to wrap %v", tc.fnErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package streetmarket
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+// syncPageSize is how many street markets a sync responds at a time.
+const syncPageSize = 500
+
+type repositorySyncer interface {
+	ListChanges(ctx context.Context, cur domain.SyncCursor, limit int) (domain.StreetMarketChanges, *domain.Error)
+}
+
+// StreetMarketSyncer tells offline clients what changed since they last synced.
+type StreetMarketSyncer struct {
+	repo repositorySyncer
+}
+
+func NewSyncer(repo repositorySyncer) *StreetMarketSyncer {
+	return &StreetMarketSyncer{repo}
+}
+
+// Sync returns a page of the street markets created or updated since the sync
+// or page that issued since, and of the ones deleted. Without since every
+// street market is returned, with no tombstones.
+func (s *StreetMarketSyncer) Sync(
+	ctx context.Context,
+	since domain.SyncToken,
+) (domain.StreetMarketChanges, *domain.Error) {
+	cur, err := since.Cursor()
+	if err != nil {
+		return domain.StreetMarketChanges{}, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      err.Msg,
+			Previous: err,
+			Fields:   []domain.FieldError{{Field: "since", Code: domain.InvalidFormatFieldCd, Msg: err.Msg}},
+		}
+	}
+
+	chs, err := s.repo.ListChanges(ctx, cur, syncPageSize)
+	if err != nil {
+		return domain.StreetMarketChanges{}, &domain.Error{
+			Kind:     domain.UnexpectedErrKd,
+			Msg:      "Unexpected error when sync",
+			Previous: err,
+		}
+	}
+
+	return chs, nil
+}
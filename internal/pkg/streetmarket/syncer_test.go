@@ -0,0 +1,106 @@
+package streetmarket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRepositorySyncer struct {
+	curInp      domain.SyncCursor
+	limitInp    int
+	listChanges func(context.Context, domain.SyncCursor, int) (domain.StreetMarketChanges, *domain.Error)
+}
+
+func (s *stubRepositorySyncer) ListChanges(
+	ctx context.Context,
+	cur domain.SyncCursor,
+	limit int,
+) (domain.StreetMarketChanges, *domain.Error) {
+	s.curInp = cur
+	s.limitInp = limit
+	return s.listChanges(ctx, cur, limit)
+}
+
+func TestStreetMarketSyncer_Sync(t *testing.T) {
+	want := domain.StreetMarketChanges{
+		Markets: []domain.StreetMarket{{ID: "1966d99f-20e8-4e5e-8f68-eb88ca67f95f"}},
+		Deleted: []domain.Tombstone{{ID: "84713a81-0e31-4c14-a62f-7e1f67bc526d"}},
+		Cursor:  domain.SyncCursor{Position: 740213},
+	}
+	page := domain.SyncCursor{Position: 740100, Next: 740213, AfterTxID: 740160, AfterID: "1966d99f"}
+
+	testCases := map[string]struct {
+		since domain.SyncToken
+		wCur  domain.SyncCursor
+	}{
+		"When it is the first sync": {},
+		"When it syncs since a token": {
+			since: domain.NewSyncToken(740100),
+			wCur:  domain.SyncCursor{Position: 740100},
+		},
+		"When it pages a sync": {
+			since: page.Token(),
+			wCur:  page,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositorySyncer{
+				listChanges: func(context.Context, domain.SyncCursor, int) (domain.StreetMarketChanges, *domain.Error) {
+					return want, nil
+				},
+			}
+
+			got, err := NewSyncer(repoMock).Sync(context.TODO(), tc.since)
+			if err != nil {
+				t.Fatalf("expect return nil, got %v", err)
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("unexpected changes (-want +got):\n%s", diff)
+			}
+
+			if repoMock.curInp != tc.wCur || repoMock.limitInp != syncPageSize {
+				t.Errorf("expect repository receive %v and %d, got %v and %d",
+					tc.wCur, syncPageSize, repoMock.curInp, repoMock.limitInp)
+			}
+		})
+	}
+}
+
+func TestStreetMarketSyncer_Sync_Error(t *testing.T) {
+	testCases := map[string]struct {
+		since domain.SyncToken
+		rErr  *domain.Error
+		wErr  domain.KindError
+	}{
+		"When the token is invalid": {
+			since: "not a token!",
+			wErr:  domain.InpValidationErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			since: domain.NewSyncToken(740100),
+			rErr:  &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr:  domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositorySyncer{
+				listChanges: func(context.Context, domain.SyncCursor, int) (domain.StreetMarketChanges, *domain.Error) {
+					return domain.StreetMarketChanges{}, tc.rErr
+				},
+			}
+
+			_, gErr := NewSyncer(repoMock).Sync(context.TODO(), tc.since)
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
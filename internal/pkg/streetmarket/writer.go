@@ -2,24 +2,33 @@ package streetmarket
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 )
 
+// repositoryWriter writes a street market along with the event of the change,
+// both or none.
 type repositoryWriter interface {
-	Create(ctx context.Context, streetMarket domain.StreetMarket) *domain.Error
-	Update(ctx context.Context, sm domain.StreetMarket) *domain.Error
+	Create(ctx context.Context, streetMarket domain.StreetMarket, ev domain.MarketEvent) *domain.Error
+	Update(ctx context.Context, sm domain.StreetMarket, ev domain.MarketEvent) *domain.Error
+	GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error)
+}
+
+type hierarchyGetter interface {
+	GetHierarchy(ctx context.Context, IDdist string) (domain.Hierarchy, *domain.Error)
 }
 
 type uuidGenerator func() string
 
 type StreetMarketWriter struct {
-	repo  repositoryWriter
-	idGen uuidGenerator
+	repo    repositoryWriter
+	refRepo hierarchyGetter
+	idGen   uuidGenerator
 }
 
-func NewWriter(repo repositoryWriter, idGen uuidGenerator) *StreetMarketWriter {
-	return &StreetMarketWriter{repo, idGen}
+func NewWriter(repo repositoryWriter, refRepo hierarchyGetter, idGen uuidGenerator) *StreetMarketWriter {
+	return &StreetMarketWriter{repo, refRepo, idGen}
 }
 
 func (s *StreetMarketWriter) Create(ctx context.Context, inp domain.StreetMarketCreateInput) (string, *domain.Error) {
@@ -28,9 +37,14 @@ func (s *StreetMarketWriter) Create(ctx context.Context, inp domain.StreetMarket
 			Kind:     domain.InpValidationErrKd,
 			Msg:      "Invalid input",
 			Previous: err,
+			Fields:   err.Fields,
 		}
 	}
 
+	if err := s.checkHierarchy(ctx, inp.Hierarchy()); err != nil {
+		return "", err
+	}
+
 	sm := domain.StreetMarket{
 		ID:            s.idGen(),
 		Long:          inp.Long,
@@ -51,9 +65,22 @@ func (s *StreetMarketWriter) Create(ctx context.Context, inp domain.StreetMarket
 		AddrExtraInfo: inp.AddrExtraInfo,
 	}
 
-	err := s.repo.Create(ctx, sm)
+	ev := domain.MarketEvent{
+		ID:       s.idGen(),
+		Type:     domain.MarketCreatedEvent,
+		MarketID: sm.ID,
+		After:    &sm,
+		Actor:    domain.Actor(ctx),
+	}
+
+	err := s.repo.Create(ctx, sm, ev)
 	if err != nil {
-		return "", &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when create", Previous: err}
+		switch err.Kind {
+		case domain.MissingRefErrKd:
+			return "", missingReference(err)
+		default:
+			return "", &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when create", Previous: err}
+		}
 	}
 
 	return sm.ID, nil
@@ -68,6 +95,42 @@ func (s *StreetMarketWriter) Edit(ctx context.Context, ID domain.SMID, inp domai
 		}
 	}
 
+	if err := inp.Validate(); err != nil {
+		return &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid input",
+			Previous: err,
+			Fields:   err.Fields,
+		}
+	}
+
+	// The current street market is needed to check the edited hierarchy and is
+	// the before of the event.
+	cur, err := s.repo.GetByID(ctx, string(ID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when edit", Previous: err}
+		}
+	}
+
+	// Moving the street market to another sub-town hall is checked as well, so
+	// it is not moved out of the one of the user.
+	after := inp.Apply(cur)
+	for _, sm := range []domain.StreetMarket{cur, after} {
+		if err := domain.AuthorizeMarketChange(ctx, sm, domain.ScopeMarketsWrite); err != nil {
+			return err
+		}
+	}
+
+	if inp.ChangesHierarchy() {
+		if err := s.checkHierarchy(ctx, inp.Hierarchy(cur)); err != nil {
+			return err
+		}
+	}
+
 	sm := domain.StreetMarket{
 		ID:            string(ID),
 		Long:          inp.Long,
@@ -88,11 +151,21 @@ func (s *StreetMarketWriter) Edit(ctx context.Context, ID domain.SMID, inp domai
 		AddrExtraInfo: inp.AddrExtraInfo,
 	}
 
-	err := s.repo.Update(ctx, sm)
-	if err != nil {
+	ev := domain.MarketEvent{
+		ID:       s.idGen(),
+		Type:     domain.MarketUpdatedEvent,
+		MarketID: string(ID),
+		Before:   &cur,
+		After:    &after,
+		Actor:    domain.Actor(ctx),
+	}
+
+	if err := s.repo.Update(ctx, sm, ev); err != nil {
 		switch err.Kind {
 		case domain.NothingUpdatedErrKd:
 			return &domain.Error{Kind: domain.SMNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		case domain.MissingRefErrKd:
+			return missingReference(err)
 		default:
 			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when edit", Previous: err}
 		}
@@ -100,3 +173,31 @@ func (s *StreetMarketWriter) Edit(ctx context.Context, ID domain.SMID, inp domai
 
 	return nil
 }
+
+// checkHierarchy validates h against the reference chain of its district.
+func (s *StreetMarketWriter) checkHierarchy(ctx context.Context, h domain.Hierarchy) *domain.Error {
+	ref, err := s.refRepo.GetHierarchy(ctx, h.IDdist)
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return missingReference(&domain.Error{Kind: domain.MissingRefErrKd, Msg: "IDdist", Previous: err})
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when check hierarchy", Previous: err}
+		}
+	}
+
+	return h.Check(ref)
+}
+
+// missingReference reports a code unknown to the reference data, the repository
+// error message being the field name.
+func missingReference(err *domain.Error) *domain.Error {
+	msg := fmt.Sprintf("%s does not exist in the reference data", err.Msg)
+
+	return &domain.Error{
+		Kind:     domain.InpValidationErrKd,
+		Msg:      msg,
+		Previous: err,
+		Fields:   []domain.FieldError{{Field: err.Msg, Code: domain.UnknownFieldCd, Msg: msg}},
+	}
+}
@@ -2,6 +2,7 @@ package streetmarket
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
@@ -10,21 +11,67 @@ import (
 
 type stubRepositoryWriter struct {
 	createSMInp domain.StreetMarket
+	eventInp    domain.MarketEvent
 	create      func(ctx context.Context, sm domain.StreetMarket) *domain.Error
 	updateInp   domain.StreetMarket
 	update      func(ctx context.Context, sm domain.StreetMarket) *domain.Error
+	getByID     func(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error)
 }
 
-func (s *stubRepositoryWriter) Create(ctx context.Context, sm domain.StreetMarket) *domain.Error {
+func (s *stubRepositoryWriter) Create(
+	ctx context.Context,
+	sm domain.StreetMarket,
+	ev domain.MarketEvent,
+) *domain.Error {
 	s.createSMInp = sm
+	s.eventInp = ev
 	return s.create(ctx, sm)
 }
 
-func (s *stubRepositoryWriter) Update(ctx context.Context, sm domain.StreetMarket) *domain.Error {
+func (s *stubRepositoryWriter) Update(
+	ctx context.Context,
+	sm domain.StreetMarket,
+	ev domain.MarketEvent,
+) *domain.Error {
 	s.updateInp = sm
+	s.eventInp = ev
 	return s.update(ctx, sm)
 }
 
+func (s *stubRepositoryWriter) GetByID(ctx context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+	return s.getByID(ctx, ID)
+}
+
+type stubHierarchyGetter struct {
+	getInp string
+	get    func(ctx context.Context, IDdist string) (domain.Hierarchy, *domain.Error)
+}
+
+func (s *stubHierarchyGetter) GetHierarchy(ctx context.Context, IDdist string) (domain.Hierarchy, *domain.Error) {
+	s.getInp = IDdist
+	return s.get(ctx, IDdist)
+}
+
+// sequence generates ids in turn.
+func sequence(ids ...string) uuidGenerator {
+	return func() string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	}
+}
+
+func referenceHierarchy() domain.Hierarchy {
+	return domain.Hierarchy{
+		IDdist:      "87",
+		District:    "VILA FORMOSA",
+		IDSubTH:     "26",
+		SubTownHall: "ARICANDUVA",
+		Region8:     "Leste 1",
+		Region5:     "Leste",
+	}
+}
+
 func TestStreetMarketWriter_Create(t *testing.T) {
 	want := "d00443e8-160d-4099-8a93-442a183be369"
 
@@ -34,9 +81,7 @@ func TestStreetMarketWriter_Create(t *testing.T) {
 		},
 	}
 
-	idGenMock := func() string {
-		return want
-	}
+	idGenMock := sequence(want, "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c")
 
 	inp := domain.StreetMarketCreateInput{
 		Long:          -46548146,
@@ -77,9 +122,19 @@ func TestStreetMarketWriter_Create(t *testing.T) {
 		AddrExtraInfo: inp.AddrExtraInfo,
 	}
 
-	srv := NewWriter(repoMock, idGenMock)
+	refMock := &stubHierarchyGetter{
+		get: func(context.Context, string) (domain.Hierarchy, *domain.Error) {
+			return referenceHierarchy(), nil
+		},
+	}
 
-	got, _ := srv.Create(context.TODO(), inp)
+	srv := NewWriter(repoMock, refMock, idGenMock)
+
+	ctx := domain.WithUser(context.TODO(), domain.User{Subject: "maria", Role: domain.RoleEditor})
+	got, err := srv.Create(ctx, inp)
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
 
 	if got != want {
 		t.Errorf("unexpected return want id %s, got %s", want, got)
@@ -88,6 +143,21 @@ func TestStreetMarketWriter_Create(t *testing.T) {
 	if diff := cmp.Diff(wSM, repoMock.createSMInp); diff != "" {
 		t.Errorf("unexpected street market when calls create (-want +got):\n%s", diff)
 	}
+
+	if refMock.getInp != inp.IDdist {
+		t.Errorf("expect hierarchy of district %s, got %s", inp.IDdist, refMock.getInp)
+	}
+
+	wEvent := domain.MarketEvent{
+		ID:       "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c",
+		Type:     domain.MarketCreatedEvent,
+		MarketID: want,
+		After:    &wSM,
+		Actor:    "maria",
+	}
+	if diff := cmp.Diff(wEvent, repoMock.eventInp); diff != "" {
+		t.Errorf("unexpected event when calls create (-want +got):\n%s", diff)
+	}
 }
 
 func TestStreetMarketWriter_Create_Error(t *testing.T) {
@@ -110,11 +180,17 @@ func TestStreetMarketWriter_Create_Error(t *testing.T) {
 		AddrExtraInfo: "Loren ipsum",
 	}
 
+	conflictRef := referenceHierarchy()
+	conflictRef.Region8 = "Leste 2"
+
 	testCases := map[string]struct {
 		rErr  *domain.Error
+		hRef  domain.Hierarchy
+		hErr  *domain.Error
 		IDGen string
 		inp   domain.StreetMarketCreateInput
 		wErr  domain.KindError
+		wMsg  string
 	}{
 		"When unexpected erro occurs in writer repository": {
 			wErr:  domain.UnexpectedErrKd,
@@ -126,6 +202,29 @@ func TestStreetMarketWriter_Create_Error(t *testing.T) {
 			wErr: domain.InpValidationErrKd,
 			inp:  domain.StreetMarketCreateInput{},
 		},
+		"When a code does not exist in the reference data": {
+			wErr:  domain.InpValidationErrKd,
+			rErr:  &domain.Error{Kind: domain.MissingRefErrKd, Msg: "IDdist"},
+			inp:   validInp,
+			IDGen: "70bb2026-9e6a-4dad-9f86-99dbddf3a087",
+		},
+		"When the district does not exist in the reference data": {
+			wErr: domain.InpValidationErrKd,
+			hErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			inp:  validInp,
+			wMsg: "IDdist does not exist in the reference data",
+		},
+		"When the hierarchy is inconsistent": {
+			wErr: domain.InpValidationErrKd,
+			hRef: conflictRef,
+			inp:  validInp,
+			wMsg: "SubTownHall and Region8 conflict: sub-town hall ARICANDUVA belongs to region Leste 2",
+		},
+		"When unexpected error occurs in reference repository": {
+			wErr: domain.UnexpectedErrKd,
+			hErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			inp:  validInp,
+		},
 	}
 	for title, tc := range testCases {
 		t.Run(title, func(t *testing.T) {
@@ -139,13 +238,26 @@ func TestStreetMarketWriter_Create_Error(t *testing.T) {
 				return tc.IDGen
 			}
 
-			srv := NewWriter(repoMock, idGenMock)
+			refMock := &stubHierarchyGetter{
+				get: func(context.Context, string) (domain.Hierarchy, *domain.Error) {
+					if tc.hRef != (domain.Hierarchy{}) {
+						return tc.hRef, tc.hErr
+					}
+					return referenceHierarchy(), tc.hErr
+				},
+			}
+
+			srv := NewWriter(repoMock, refMock, idGenMock)
 
 			_, gErr := srv.Create(context.TODO(), tc.inp)
 
 			if gErr.Kind != tc.wErr {
 				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
 			}
+
+			if tc.wMsg != "" && gErr.Msg != tc.wMsg {
+				t.Errorf("Want error message %q, got %q", tc.wMsg, gErr.Msg)
+			}
 		})
 	}
 }
@@ -155,11 +267,20 @@ func TestStreetMarketWriter_Edit(t *testing.T) {
 		update: func(ctx context.Context, sm domain.StreetMarket) *domain.Error {
 			return nil
 		},
+		getByID: func(_ context.Context, ID string) (domain.StreetMarket, *domain.Error) {
+			return domain.StreetMarket{ID: ID, Name: "VILA FORMOSA"}, nil
+		},
 	}
 
-	idGenMock := func() string { return "" }
+	idGenMock := sequence("5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c")
 
-	srv := NewWriter(repoMock, idGenMock)
+	refMock := &stubHierarchyGetter{
+		get: func(context.Context, string) (domain.Hierarchy, *domain.Error) {
+			return referenceHierarchy(), nil
+		},
+	}
+
+	srv := NewWriter(repoMock, refMock, idGenMock)
 
 	var id domain.SMID = "07468c29-cd01-414d-adcb-68282eb94d9a"
 	editInp := domain.StreetMarketEditInput{
@@ -181,7 +302,11 @@ func TestStreetMarketWriter_Edit(t *testing.T) {
 		AddrExtraInfo: "Loren ipsum",
 	}
 
-	err := srv.Edit(context.TODO(), id, editInp)
+	ctx := domain.WithAPIKey(context.TODO(), domain.APIKey{
+		ID:     "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+		Scopes: []domain.Scope{domain.ScopeMarketsWrite},
+	})
+	err := srv.Edit(ctx, id, editInp)
 
 	if err != nil {
 		t.Errorf("expect return nil, got %v", err)
@@ -210,13 +335,41 @@ func TestStreetMarketWriter_Edit(t *testing.T) {
 	if diff := cmp.Diff(want, repoMock.updateInp); diff != "" {
 		t.Errorf("unexpected street market when calls edit (-want +got):\n%s", diff)
 	}
+
+	wEvent := domain.MarketEvent{
+		ID:       "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c",
+		Type:     domain.MarketUpdatedEvent,
+		MarketID: string(id),
+		Before:   &domain.StreetMarket{ID: string(id), Name: "VILA FORMOSA"},
+		After:    &want,
+		Actor:    "api-key:1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d",
+	}
+	if diff := cmp.Diff(wEvent, repoMock.eventInp); diff != "" {
+		t.Errorf("unexpected event when calls edit (-want +got):\n%s", diff)
+	}
 }
 
 func TestStreetMarketWriter_Edit_Error(t *testing.T) {
+	current := domain.StreetMarket{
+		ID:          "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		IDdist:      "87",
+		District:    "VILA FORMOSA",
+		IDSubTH:     "26",
+		SubTownHall: "ARICANDUVA",
+		Region5:     "Leste",
+		Region8:     "Leste 1",
+	}
+
+	editor := domain.User{Subject: "maria", Role: domain.RoleEditor, IDSubTH: "26"}
+
 	testCases := map[string]struct {
-		rErr *domain.Error
-		wErr domain.KindError
-		id   domain.SMID
+		ctx    context.Context
+		rErr   *domain.Error
+		getErr *domain.Error
+		inp    domain.StreetMarketEditInput
+		wErr   domain.KindError
+		wMsg   string
+		id     domain.SMID
 	}{
 		"When entity not exists": {
 			rErr: &domain.Error{Kind: domain.NothingUpdatedErrKd},
@@ -232,6 +385,63 @@ func TestStreetMarketWriter_Edit_Error(t *testing.T) {
 			wErr: domain.InpValidationErrKd,
 			id:   "invalid",
 		},
+		"When a code does not exist in the reference data": {
+			rErr: &domain.Error{Kind: domain.MissingRefErrKd, Msg: "Region8"},
+			wErr: domain.InpValidationErrKd,
+			id:   "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		},
+		"When the edited field conflicts with the current hierarchy": {
+			inp:  domain.StreetMarketEditInput{Region5: "Oeste"},
+			wErr: domain.InpValidationErrKd,
+			wMsg: "Region8 and Region5 conflict: region Leste 1 belongs to region Leste",
+			id:   "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		},
+		"When a field is too long": {
+			inp:  domain.StreetMarketEditInput{Name: strings.Repeat("a", 51)},
+			wErr: domain.InpValidationErrKd,
+			id:   "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		},
+		"When entity not exists before the edit": {
+			inp:    domain.StreetMarketEditInput{Name: "RAPOSO TAVARES"},
+			getErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr:   domain.SMNotFoundErrKd,
+			id:     "51557ef2-dfe8-485d-90e0-c7adf4e59581",
+		},
+		"When a unexpected error occurs getting the current entity": {
+			getErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr:   domain.UnexpectedErrKd,
+			id:     "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		},
+		"When entity not exists and the hierarchy is edited": {
+			inp:    domain.StreetMarketEditInput{District: "VILA FORMOSA"},
+			getErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr:   domain.SMNotFoundErrKd,
+			id:     "51557ef2-dfe8-485d-90e0-c7adf4e59581",
+		},
+		"When nobody is authenticated": {
+			ctx:  context.TODO(),
+			inp:  domain.StreetMarketEditInput{Name: "RAPOSO TAVARES"},
+			wErr: domain.UnauthenticatedErrKd,
+			id:   "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		},
+		"When the user is of another sub-town hall": {
+			ctx:  domain.WithUser(context.TODO(), domain.User{Subject: "joao", Role: domain.RoleEditor, IDSubTH: "25"}),
+			inp:  domain.StreetMarketEditInput{Name: "RAPOSO TAVARES"},
+			wErr: domain.ForbiddenErrKd,
+			id:   "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		},
+		"When the user moves the street market out of their sub-town hall": {
+			ctx:  domain.WithUser(context.TODO(), editor),
+			inp:  domain.StreetMarketEditInput{IDSubTH: "25"},
+			wErr: domain.ForbiddenErrKd,
+			id:   "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		},
+		"When the user of the sub-town hall edits a field that conflicts": {
+			ctx:  domain.WithUser(context.TODO(), editor),
+			inp:  domain.StreetMarketEditInput{Region5: "Oeste"},
+			wErr: domain.InpValidationErrKd,
+			id:   "c882edc1-c1f3-4b20-b8f6-36156d99bc48",
+		},
 	}
 
 	for title, tc := range testCases {
@@ -240,17 +450,35 @@ func TestStreetMarketWriter_Edit_Error(t *testing.T) {
 				update: func(ctx context.Context, sm domain.StreetMarket) *domain.Error {
 					return tc.rErr
 				},
+				getByID: func(context.Context, string) (domain.StreetMarket, *domain.Error) {
+					return current, tc.getErr
+				},
 			}
 
 			idGenMock := func() string { return "" }
 
-			srv := NewWriter(repoMock, idGenMock)
+			refMock := &stubHierarchyGetter{
+				get: func(context.Context, string) (domain.Hierarchy, *domain.Error) {
+					return referenceHierarchy(), nil
+				},
+			}
 
-			gErr := srv.Edit(context.TODO(), tc.id, domain.StreetMarketEditInput{})
+			srv := NewWriter(repoMock, refMock, idGenMock)
+
+			// Cases without a caller are of a user of the whole city.
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = domain.WithUser(context.TODO(), domain.User{Subject: "maria", Role: domain.RoleEditor, IDSubTH: domain.AllSubTHs})
+			}
+			gErr := srv.Edit(ctx, tc.id, tc.inp)
 
 			if gErr.Kind != tc.wErr {
 				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr.Kind)
 			}
+
+			if tc.wMsg != "" && gErr.Msg != tc.wMsg {
+				t.Errorf("Want error message %q, got %q", tc.wMsg, gErr.Msg)
+			}
 		})
 	}
 }
@@ -0,0 +1,230 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+const (
+	// dispatchBatch is how many deliveries are claimed at a time. They are sent
+	// concurrently, so claimLease only has to outlast the slowest of them.
+	dispatchBatch = 20
+	claimLease    = 5 * time.Minute
+	// responseDrainLimit bounds what is read of a response before closing it,
+	// so the connection can be reused.
+	responseDrainLimit = 64 << 10
+)
+
+type repositoryDispatcher interface {
+	ClaimDeliveries(ctx context.Context, now, until time.Time, limit int) ([]domain.WebhookDelivery, *domain.Error)
+	RecordAttempt(ctx context.Context, d domain.WebhookDelivery, a domain.WebhookAttempt) *domain.Error
+}
+
+type httpDoer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+type dispatcherLogger interface {
+	Infof(ctx context.Context, msg string, md map[string]interface{})
+	Warnf(ctx context.Context, msg string, md map[string]interface{})
+	Error(context.Context, domain.Error)
+}
+
+// Retry is how failed deliveries are retried. The n-th retry waits Backoff
+// doubled n-1 times, up to MaxBackoff, and a delivery is dead once MaxAttempts
+// attempts failed.
+type Retry struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+func (r Retry) delay(attempt int) time.Duration {
+	d := r.Backoff
+	for i := 1; i < attempt && d < r.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+
+	return d
+}
+
+// WebhookDispatcher posts the queued deliveries to their subscriptions, signed
+// with the secret of each, and records every attempt.
+type WebhookDispatcher struct {
+	repo   repositoryDispatcher
+	client httpDoer
+	retry  Retry
+	now    func() time.Time
+	logger dispatcherLogger
+}
+
+func NewDispatcher(
+	repo repositoryDispatcher,
+	client httpDoer,
+	retry Retry,
+	now func() time.Time,
+	logger dispatcherLogger,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{repo, client, retry, now, logger}
+}
+
+// Run dispatches the due deliveries every interval until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		for {
+			n, err := d.Dispatch(ctx)
+			if err != nil {
+				d.logger.Error(ctx, *err)
+			}
+			// A full batch may have left more due deliveries behind.
+			if err != nil || n < dispatchBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Dispatch attempts a batch of the deliveries due now and returns how many were
+// attempted.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) (int, *domain.Error) {
+	now := d.now()
+	ds, err := d.repo.ClaimDeliveries(ctx, now, now.Add(claimLease), dispatchBatch)
+	if err != nil {
+		return 0, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when claim deliveries", Previous: err}
+	}
+
+	var wg sync.WaitGroup
+	for _, dl := range ds {
+		wg.Add(1)
+		go func(dl domain.WebhookDelivery) {
+			defer wg.Done()
+			d.attempt(ctx, dl)
+		}(dl)
+	}
+	wg.Wait()
+
+	return len(ds), nil
+}
+
+// attempt posts dl once and records how it went, scheduling the next attempt
+// or moving dl to the dead letters when it failed.
+func (d *WebhookDispatcher) attempt(ctx context.Context, dl domain.WebhookDelivery) {
+	start := d.now()
+	status, sErr := d.send(ctx, dl, start)
+	end := d.now()
+
+	a := domain.WebhookAttempt{
+		Number:      dl.AttemptCount + 1,
+		StatusCode:  status,
+		Duration:    end.Sub(start),
+		AttemptedAt: &start,
+	}
+	if sErr != nil {
+		a.Error = sErr.Error()
+	}
+
+	dl.AttemptCount = a.Number
+	next := end
+	switch {
+	case sErr == nil:
+		dl.Status = domain.DeliveryDelivered
+	case a.Number >= d.retry.MaxAttempts:
+		dl.Status = domain.DeliveryDead
+	default:
+		dl.Status = domain.DeliveryPending
+		next = end.Add(d.retry.delay(a.Number))
+	}
+	dl.NextAttemptAt = &next
+
+	md := map[string]interface{}{
+		"delivery_id":     dl.ID,
+		"subscription_id": dl.Subscription.ID,
+		"event":           dl.Event,
+		"attempt":         a.Number,
+		"status_code":     a.StatusCode,
+		"duration_ms":     a.Duration.Milliseconds(),
+		"delivery_status": dl.Status,
+	}
+	switch {
+	case sErr == nil:
+		d.logger.Infof(ctx, "Webhook delivered", md)
+	case dl.Status == domain.DeliveryDead:
+		md["error"] = a.Error
+		d.logger.Warnf(ctx, "Webhook delivery is dead", md)
+	default:
+		md["error"] = a.Error
+		md["next_attempt_at"] = next
+		d.logger.Warnf(ctx, "Webhook delivery failed", md)
+	}
+
+	if err := d.repo.RecordAttempt(ctx, dl, a); err != nil {
+		d.logger.Error(ctx, domain.Error{
+			Kind:     domain.UnexpectedErrKd,
+			Msg:      "Unexpected error when record webhook attempt",
+			Previous: err,
+		})
+	}
+}
+
+// send posts the payload of dl, returning the response status, zero when there
+// was no response, and an error unless it is a 2xx.
+func (d *WebhookDispatcher) send(ctx context.Context, dl domain.WebhookDelivery, at time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dl.Subscription.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+
+	ts := at.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "unicoAPITest-Webhook/1.0")
+	req.Header.Set("X-Webhook-ID", dl.ID)
+	req.Header.Set("X-Webhook-Event", string(dl.Event))
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(ts, 10))
+	req.Header.Set("X-Webhook-Signature", "sha256="+sign(dl.Subscription.Secret, ts, dl.Payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%w", err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, responseDrainLimit)) //nolint:errcheck
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// sign is the hex HMAC-SHA256, keyed by secret, of the timestamp and the body
+// joined by a dot. Signing the timestamp lets partners reject replays.
+func sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + ".")) //nolint:errcheck
+	mac.Write(body)                                           //nolint:errcheck
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
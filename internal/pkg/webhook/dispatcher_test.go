@@ -0,0 +1,254 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+type recordedAttempt struct {
+	delivery domain.WebhookDelivery
+	attempt  domain.WebhookAttempt
+}
+
+type stubRepositoryDispatcher struct {
+	mu       sync.Mutex
+	claim    func(ctx context.Context, now, until time.Time, limit int) ([]domain.WebhookDelivery, *domain.Error)
+	recorded []recordedAttempt
+}
+
+func (s *stubRepositoryDispatcher) ClaimDeliveries(
+	ctx context.Context,
+	now, until time.Time,
+	limit int,
+) ([]domain.WebhookDelivery, *domain.Error) {
+	return s.claim(ctx, now, until, limit)
+}
+
+func (s *stubRepositoryDispatcher) RecordAttempt(
+	_ context.Context,
+	d domain.WebhookDelivery,
+	a domain.WebhookAttempt,
+) *domain.Error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recorded = append(s.recorded, recordedAttempt{d, a})
+	return nil
+}
+
+func TestRetry_delay(t *testing.T) {
+	r := Retry{MaxAttempts: 8, Backoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+
+	want := []time.Duration{
+		30 * time.Second,
+		time.Minute,
+		2 * time.Minute,
+		4 * time.Minute,
+		5 * time.Minute,
+		5 * time.Minute,
+	}
+	for i, w := range want {
+		if got := r.delay(i + 1); got != w {
+			t.Errorf("expect delay %v after attempt %d, got %v", w, i+1, got)
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	// printf '1760868000.{"id":"1"}' | openssl dgst -sha256 -hmac 0123456789abcdef
+	want := "a9382e1d0338d32cc2584bc45afafa13cead30e77628364feaad884817b5b7f5"
+
+	if got := sign("0123456789abcdef", 1760868000, []byte(`{"id":"1"}`)); got != want {
+		t.Errorf("expect signature %s, got %s", want, got)
+	}
+}
+
+func TestWebhookDispatcher_Dispatch(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	payload := []byte(`{"id":"event-1"}`)
+
+	var (
+		mu   sync.Mutex
+		reqs = map[string]*http.Request{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != string(payload) {
+			t.Errorf("unexpected body %s", body)
+		}
+
+		mu.Lock()
+		reqs[r.URL.Path] = r
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	delivery := func(ID, path string, attempts int) domain.WebhookDelivery {
+		return domain.WebhookDelivery{
+			ID:           ID,
+			Subscription: domain.WebhookSubscription{ID: "sub", URL: srv.URL + path, Secret: "0123456789abcdef"},
+			Event:        domain.MarketCreatedEvent,
+			Payload:      payload,
+			Status:       domain.DeliveryPending,
+			AttemptCount: attempts,
+		}
+	}
+
+	repoMock := &stubRepositoryDispatcher{
+		claim: func(_ context.Context, gNow, until time.Time, limit int) ([]domain.WebhookDelivery, *domain.Error) {
+			if !gNow.Equal(now) || !until.Equal(now.Add(claimLease)) || limit != dispatchBatch {
+				t.Errorf("unexpected claim %v %v %d", gNow, until, limit)
+			}
+			return []domain.WebhookDelivery{
+				delivery("delivered", "/ok", 0),
+				delivery("retried", "/down", 1),
+				delivery("dead", "/down-too", 2),
+			}, nil
+		},
+	}
+	logMock := &stubLogger{}
+
+	retry := Retry{MaxAttempts: 3, Backoff: 30 * time.Second, MaxBackoff: time.Hour}
+	d := NewDispatcher(repoMock, srv.Client(), retry, func() time.Time { return now }, logMock)
+
+	n, err := d.Dispatch(context.TODO())
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expect 3 deliveries attempted, got %d", n)
+	}
+
+	got := map[string]recordedAttempt{}
+	for _, r := range repoMock.recorded {
+		r.attempt.AttemptedAt = nil
+		r.delivery.Payload = nil
+		r.delivery.Subscription = domain.WebhookSubscription{}
+		got[r.delivery.ID] = r
+	}
+
+	retryAt := now.Add(time.Minute)
+	want := map[string]recordedAttempt{
+		"delivered": {
+			delivery: domain.WebhookDelivery{
+				ID:            "delivered",
+				Event:         domain.MarketCreatedEvent,
+				Status:        domain.DeliveryDelivered,
+				AttemptCount:  1,
+				NextAttemptAt: &now,
+			},
+			attempt: domain.WebhookAttempt{Number: 1, StatusCode: http.StatusNoContent},
+		},
+		"retried": {
+			delivery: domain.WebhookDelivery{
+				ID:            "retried",
+				Event:         domain.MarketCreatedEvent,
+				Status:        domain.DeliveryPending,
+				AttemptCount:  2,
+				NextAttemptAt: &retryAt,
+			},
+			attempt: domain.WebhookAttempt{
+				Number:     2,
+				StatusCode: http.StatusServiceUnavailable,
+				Error:      "unexpected status 503",
+			},
+		},
+		"dead": {
+			delivery: domain.WebhookDelivery{
+				ID:            "dead",
+				Event:         domain.MarketCreatedEvent,
+				Status:        domain.DeliveryDead,
+				AttemptCount:  3,
+				NextAttemptAt: &now,
+			},
+			attempt: domain.WebhookAttempt{
+				Number:     3,
+				StatusCode: http.StatusServiceUnavailable,
+				Error:      "unexpected status 503",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(recordedAttempt{})); diff != "" {
+		t.Errorf("unexpected attempts recorded (-want +got):\n%s", diff)
+	}
+
+	r := reqs["/ok"]
+	if r == nil {
+		t.Fatal("expect the delivery posted")
+	}
+	wHeaders := map[string]string{
+		"Content-Type":        "application/json",
+		"X-Webhook-Id":        "delivered",
+		"X-Webhook-Event":     "street_market.created",
+		"X-Webhook-Timestamp": "1792404000",
+		"X-Webhook-Signature": "sha256=" + sign("0123456789abcdef", now.Unix(), payload),
+	}
+	for k, v := range wHeaders {
+		if got := r.Header.Get(k); got != v {
+			t.Errorf("expect header %s %q, got %q", k, v, got)
+		}
+	}
+
+	if len(logMock.infos) != 1 || len(logMock.warns) != 2 {
+		t.Errorf("expect every attempt logged, got %v and %v", logMock.infos, logMock.warns)
+	}
+}
+
+func TestWebhookDispatcher_Dispatch_Error(t *testing.T) {
+	repoMock := &stubRepositoryDispatcher{
+		claim: func(context.Context, time.Time, time.Time, int) ([]domain.WebhookDelivery, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd}
+		},
+	}
+
+	d := NewDispatcher(repoMock, http.DefaultClient, Retry{}, time.Now, &stubLogger{})
+
+	_, gErr := d.Dispatch(context.TODO())
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+}
+
+func TestWebhookDispatcher_Dispatch_Unreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	repoMock := &stubRepositoryDispatcher{
+		claim: func(context.Context, time.Time, time.Time, int) ([]domain.WebhookDelivery, *domain.Error) {
+			return []domain.WebhookDelivery{{ID: "d", Subscription: domain.WebhookSubscription{URL: url}}}, nil
+		},
+	}
+
+	retry := Retry{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: time.Minute}
+	d := NewDispatcher(repoMock, http.DefaultClient, retry, time.Now, &stubLogger{})
+
+	if _, err := d.Dispatch(context.TODO()); err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	if len(repoMock.recorded) != 1 {
+		t.Fatalf("expect one attempt recorded, got %d", len(repoMock.recorded))
+	}
+	a := repoMock.recorded[0].attempt
+	if a.StatusCode != 0 || a.Error == "" {
+		t.Errorf("expect no status and an error, got %d %q", a.StatusCode, a.Error)
+	}
+	if s := repoMock.recorded[0].delivery.Status; s != domain.DeliveryPending {
+		t.Errorf("expect status %s, got %s", domain.DeliveryPending, s)
+	}
+}
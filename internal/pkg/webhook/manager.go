@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+)
+
+type repositoryManager interface {
+	CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) *domain.Error
+	GetSubscription(ctx context.Context, ID string) (domain.WebhookSubscription, *domain.Error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, *domain.Error)
+	DeleteSubscription(ctx context.Context, ID string) *domain.Error
+	ListDeliveries(
+		ctx context.Context,
+		subID string,
+		status domain.DeliveryStatus,
+	) ([]domain.WebhookDelivery, *domain.Error)
+}
+
+type uuidGenerator func() string
+
+// WebhookManager manages the subscriptions of the partners and shows how
+// their deliveries went.
+type WebhookManager struct {
+	repo  repositoryManager
+	idGen uuidGenerator
+}
+
+func NewManager(repo repositoryManager, idGen uuidGenerator) *WebhookManager {
+	return &WebhookManager{repo, idGen}
+}
+
+func (s *WebhookManager) Create(ctx context.Context, inp domain.WebhookSubscriptionInput) (string, *domain.Error) {
+	if err := inp.Validate(); err != nil {
+		return "", &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid input",
+			Previous: err,
+			Fields:   err.Fields,
+		}
+	}
+
+	sub := domain.WebhookSubscription{
+		ID:      s.idGen(),
+		URL:     inp.URL,
+		Secret:  inp.Secret,
+		Events:  inp.Events,
+		IDdist:  inp.IDdist,
+		Region5: inp.Region5,
+	}
+
+	if err := s.repo.CreateSubscription(ctx, sub); err != nil {
+		return "", &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when create", Previous: err}
+	}
+
+	return sub.ID, nil
+}
+
+func (s *WebhookManager) Get(ctx context.Context, ID domain.WebhookID) (domain.WebhookSubscription, *domain.Error) {
+	if err := ID.Validate(); err != nil {
+		return domain.WebhookSubscription{}, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	sub, err := s.repo.GetSubscription(ctx, string(ID))
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return domain.WebhookSubscription{}, &domain.Error{
+				Kind:     domain.WebhookNotFoundErrKd,
+				Msg:      "Entity not exists",
+				Previous: err,
+			}
+		default:
+			return domain.WebhookSubscription{}, &domain.Error{
+				Kind:     domain.UnexpectedErrKd,
+				Msg:      "Unexpected error when get",
+				Previous: err,
+			}
+		}
+	}
+
+	return sub, nil
+}
+
+func (s *WebhookManager) List(ctx context.Context) ([]domain.WebhookSubscription, *domain.Error) {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when list", Previous: err}
+	}
+
+	return subs, nil
+}
+
+func (s *WebhookManager) Delete(ctx context.Context, ID domain.WebhookID) *domain.Error {
+	if err := ID.Validate(); err != nil {
+		return &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	if err := s.repo.DeleteSubscription(ctx, string(ID)); err != nil {
+		switch err.Kind {
+		case domain.NothingDeletedErrKd:
+			return &domain.Error{Kind: domain.WebhookNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when delete", Previous: err}
+		}
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries of the subscription with their
+// attempts, only the ones in status unless it is empty.
+func (s *WebhookManager) ListDeliveries(
+	ctx context.Context,
+	ID domain.WebhookID,
+	status domain.DeliveryStatus,
+) ([]domain.WebhookDelivery, *domain.Error) {
+	if err := ID.Validate(); err != nil {
+		return nil, &domain.Error{
+			Kind:     domain.InpValidationErrKd,
+			Msg:      "Invalid ID",
+			Previous: err,
+		}
+	}
+
+	if status != "" {
+		if err := status.Validate(); err != nil {
+			return nil, &domain.Error{
+				Kind:     domain.InpValidationErrKd,
+				Msg:      err.Msg,
+				Previous: err,
+				Fields:   []domain.FieldError{{Field: "status", Code: domain.NotAllowedFieldCd, Msg: err.Msg}},
+			}
+		}
+	}
+
+	ds, err := s.repo.ListDeliveries(ctx, string(ID), status)
+	if err != nil {
+		switch err.Kind {
+		case domain.NothingFoundErrKd:
+			return nil, &domain.Error{Kind: domain.WebhookNotFoundErrKd, Msg: "Entity not exists", Previous: err}
+		default:
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd, Msg: "Unexpected error when list", Previous: err}
+		}
+	}
+
+	return ds, nil
+}
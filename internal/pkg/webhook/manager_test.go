@@ -0,0 +1,291 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/google/go-cmp/cmp"
+)
+
+const validWebhookID domain.WebhookID = "1f0c1c2e-8d4b-4f57-9d0e-6a8f6b1e2c3d"
+
+type stubRepositoryManager struct {
+	createInp          domain.WebhookSubscription
+	createSubscription func(context.Context, domain.WebhookSubscription) *domain.Error
+	getSubscription    func(context.Context, string) (domain.WebhookSubscription, *domain.Error)
+	listSubscriptions  func(context.Context) ([]domain.WebhookSubscription, *domain.Error)
+	deleteSubscription func(context.Context, string) *domain.Error
+	listDeliveriesInp  domain.DeliveryStatus
+	listDeliveries     func(context.Context, string, domain.DeliveryStatus) ([]domain.WebhookDelivery, *domain.Error)
+}
+
+func (s *stubRepositoryManager) CreateSubscription(ctx context.Context, sub domain.WebhookSubscription) *domain.Error {
+	s.createInp = sub
+	return s.createSubscription(ctx, sub)
+}
+
+func (s *stubRepositoryManager) GetSubscription(
+	ctx context.Context,
+	ID string,
+) (domain.WebhookSubscription, *domain.Error) {
+	return s.getSubscription(ctx, ID)
+}
+
+func (s *stubRepositoryManager) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, *domain.Error) {
+	return s.listSubscriptions(ctx)
+}
+
+func (s *stubRepositoryManager) DeleteSubscription(ctx context.Context, ID string) *domain.Error {
+	return s.deleteSubscription(ctx, ID)
+}
+
+func (s *stubRepositoryManager) ListDeliveries(
+	ctx context.Context,
+	subID string,
+	status domain.DeliveryStatus,
+) ([]domain.WebhookDelivery, *domain.Error) {
+	s.listDeliveriesInp = status
+	return s.listDeliveries(ctx, subID, status)
+}
+
+func TestWebhookManager_Create(t *testing.T) {
+	repoMock := &stubRepositoryManager{
+		createSubscription: func(context.Context, domain.WebhookSubscription) *domain.Error {
+			return nil
+		},
+	}
+
+	srv := NewManager(repoMock, func() string { return string(validWebhookID) })
+
+	inp := domain.WebhookSubscriptionInput{
+		URL:    "https://partner.example.com/hooks",
+		Secret: "0123456789abcdef",
+		Events: []domain.EventType{domain.MarketCreatedEvent},
+		IDdist: "87",
+	}
+
+	got, err := srv.Create(context.TODO(), inp)
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	if got != string(validWebhookID) {
+		t.Errorf("unexpected return want id %s, got %s", validWebhookID, got)
+	}
+
+	want := domain.WebhookSubscription{
+		ID:     string(validWebhookID),
+		URL:    inp.URL,
+		Secret: inp.Secret,
+		Events: inp.Events,
+		IDdist: inp.IDdist,
+	}
+	if diff := cmp.Diff(want, repoMock.createInp); diff != "" {
+		t.Errorf("unexpected subscription when calls create (-want +got):\n%s", diff)
+	}
+}
+
+func TestWebhookManager_Create_Error(t *testing.T) {
+	testCases := map[string]struct {
+		inp  domain.WebhookSubscriptionInput
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When input is invalid": {
+			wErr: domain.InpValidationErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			inp: domain.WebhookSubscriptionInput{
+				URL:    "https://partner.example.com/hooks",
+				Secret: "0123456789abcdef",
+				Events: domain.EventTypes(),
+			},
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryManager{
+				createSubscription: func(context.Context, domain.WebhookSubscription) *domain.Error {
+					return tc.rErr
+				},
+			}
+
+			srv := NewManager(repoMock, func() string { return string(validWebhookID) })
+
+			_, gErr := srv.Create(context.TODO(), tc.inp)
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestWebhookManager_Get_Error(t *testing.T) {
+	testCases := map[string]struct {
+		ID   domain.WebhookID
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When id is invalid": {
+			ID:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When subscription not exists": {
+			ID:   validWebhookID,
+			rErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr: domain.WebhookNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			ID:   validWebhookID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryManager{
+				getSubscription: func(context.Context, string) (domain.WebhookSubscription, *domain.Error) {
+					return domain.WebhookSubscription{}, tc.rErr
+				},
+			}
+
+			srv := NewManager(repoMock, nil)
+
+			_, gErr := srv.Get(context.TODO(), tc.ID)
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestWebhookManager_List_Error(t *testing.T) {
+	repoMock := &stubRepositoryManager{
+		listSubscriptions: func(context.Context) ([]domain.WebhookSubscription, *domain.Error) {
+			return nil, &domain.Error{Kind: domain.UnexpectedErrKd}
+		},
+	}
+
+	srv := NewManager(repoMock, nil)
+
+	_, gErr := srv.List(context.TODO())
+	if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+		t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+	}
+}
+
+func TestWebhookManager_Delete_Error(t *testing.T) {
+	testCases := map[string]struct {
+		ID   domain.WebhookID
+		rErr *domain.Error
+		wErr domain.KindError
+	}{
+		"When id is invalid": {
+			ID:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When subscription not exists": {
+			ID:   validWebhookID,
+			rErr: &domain.Error{Kind: domain.NothingDeletedErrKd},
+			wErr: domain.WebhookNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			ID:   validWebhookID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryManager{
+				deleteSubscription: func(context.Context, string) *domain.Error {
+					return tc.rErr
+				},
+			}
+
+			srv := NewManager(repoMock, nil)
+
+			gErr := srv.Delete(context.TODO(), tc.ID)
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
+
+func TestWebhookManager_ListDeliveries(t *testing.T) {
+	want := []domain.WebhookDelivery{{ID: "5a0d7f5e-3b7e-4c1e-9f57-2d1f3c4b5a6e", Status: domain.DeliveryDead}}
+
+	repoMock := &stubRepositoryManager{
+		listDeliveries: func(context.Context, string, domain.DeliveryStatus) ([]domain.WebhookDelivery, *domain.Error) {
+			return want, nil
+		},
+	}
+
+	srv := NewManager(repoMock, nil)
+
+	got, err := srv.ListDeliveries(context.TODO(), validWebhookID, domain.DeliveryDead)
+	if err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected deliveries (-want +got):\n%s", diff)
+	}
+
+	if repoMock.listDeliveriesInp != domain.DeliveryDead {
+		t.Errorf("expect status %s, got %s", domain.DeliveryDead, repoMock.listDeliveriesInp)
+	}
+}
+
+func TestWebhookManager_ListDeliveries_Error(t *testing.T) {
+	testCases := map[string]struct {
+		ID     domain.WebhookID
+		status domain.DeliveryStatus
+		rErr   *domain.Error
+		wErr   domain.KindError
+	}{
+		"When id is invalid": {
+			ID:   "invalid",
+			wErr: domain.InpValidationErrKd,
+		},
+		"When status is invalid": {
+			ID:     validWebhookID,
+			status: "lost",
+			wErr:   domain.InpValidationErrKd,
+		},
+		"When subscription not exists": {
+			ID:   validWebhookID,
+			rErr: &domain.Error{Kind: domain.NothingFoundErrKd},
+			wErr: domain.WebhookNotFoundErrKd,
+		},
+		"When unexpected error occurs in repository": {
+			ID:   validWebhookID,
+			rErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+			wErr: domain.UnexpectedErrKd,
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryManager{
+				listDeliveries: func(context.Context, string, domain.DeliveryStatus) ([]domain.WebhookDelivery, *domain.Error) {
+					return nil, tc.rErr
+				},
+			}
+
+			srv := NewManager(repoMock, nil)
+
+			_, gErr := srv.ListDeliveries(context.TODO(), tc.ID, tc.status)
+			if gErr == nil || gErr.Kind != tc.wErr {
+				t.Errorf("Want error kind %v, got error %v", tc.wErr, gErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/outbox"
+	"github.com/google/uuid"
+)
+
+type repositoryPublisher interface {
+	ListSubscriptionsByEvent(ctx context.Context, event domain.EventType) ([]domain.WebhookSubscription, *domain.Error)
+	CreateDeliveries(ctx context.Context, ds []domain.WebhookDelivery) *domain.Error
+}
+
+// Payload is the body posted to the subscriptions. ID identifies the event,
+// the same for every subscription notified of it.
+type Payload struct {
+	ID         string            `json:"id"`
+	Event      domain.EventType  `json:"event"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	Data       outbox.MarketData `json:"data"`
+}
+
+// WebhookPublisher queues a delivery of every street market event to the
+// subscriptions it matches. The deliveries are sent by the WebhookDispatcher.
+type WebhookPublisher struct {
+	repo repositoryPublisher
+}
+
+func NewPublisher(repo repositoryPublisher) *WebhookPublisher {
+	return &WebhookPublisher{repo}
+}
+
+// Publish queues the deliveries of ev. A subscription filtering by district or
+// region matches an update moving the street market out of them too. The
+// deliveries of an event published again are queued only once, as their IDs
+// derive from the event and the subscription.
+func (p *WebhookPublisher) Publish(ctx context.Context, ev domain.MarketEvent) *domain.Error {
+	subs, err := p.repo.ListSubscriptionsByEvent(ctx, ev.Type)
+	if err != nil {
+		return &domain.Error{
+			Kind:     domain.UnexpectedErrKd,
+			Msg:      "Unexpected error when list webhook subscriptions",
+			Previous: err,
+		}
+	}
+
+	pl := Payload{ID: ev.ID, Event: ev.Type, Data: outbox.NewMarketData(ev.Market())}
+	if ev.OccurredAt != nil {
+		pl.OccurredAt = ev.OccurredAt.UTC()
+	}
+	body, jErr := json.Marshal(pl)
+	if jErr != nil {
+		return &domain.Error{Kind: domain.UnexpectedErrKd, Msg: jErr.Error(), Cause: jErr}
+	}
+
+	ds := []domain.WebhookDelivery{}
+	for _, sub := range subs {
+		if !matches(sub, ev) {
+			continue
+		}
+		ds = append(ds, domain.WebhookDelivery{
+			ID:           deliveryID(ev.ID, sub.ID),
+			Subscription: domain.WebhookSubscription{ID: sub.ID},
+			Event:        ev.Type,
+			Payload:      body,
+		})
+	}
+
+	if len(ds) == 0 {
+		return nil
+	}
+
+	if err := p.repo.CreateDeliveries(ctx, ds); err != nil {
+		return &domain.Error{
+			Kind:     domain.UnexpectedErrKd,
+			Msg:      "Unexpected error when queue webhook deliveries",
+			Previous: err,
+		}
+	}
+
+	return nil
+}
+
+func matches(sub domain.WebhookSubscription, ev domain.MarketEvent) bool {
+	if ev.Before != nil && sub.Matches(ev.Type, *ev.Before) {
+		return true
+	}
+
+	return sub.Matches(ev.Type, ev.Market())
+}
+
+func deliveryID(eventID, subID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(eventID+"/"+subID)).String()
+}
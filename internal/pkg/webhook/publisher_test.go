@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
+	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/outbox"
+	"github.com/google/go-cmp/cmp"
+)
+
+type stubRepositoryPublisher struct {
+	listByEvent      func(context.Context, domain.EventType) ([]domain.WebhookSubscription, *domain.Error)
+	createInp        []domain.WebhookDelivery
+	createDeliveries func(context.Context, []domain.WebhookDelivery) *domain.Error
+}
+
+func (s *stubRepositoryPublisher) ListSubscriptionsByEvent(
+	ctx context.Context,
+	event domain.EventType,
+) ([]domain.WebhookSubscription, *domain.Error) {
+	return s.listByEvent(ctx, event)
+}
+
+func (s *stubRepositoryPublisher) CreateDeliveries(ctx context.Context, ds []domain.WebhookDelivery) *domain.Error {
+	s.createInp = ds
+	return s.createDeliveries(ctx, ds)
+}
+
+// stubLogger is safe for the concurrent attempts of the dispatcher.
+type stubLogger struct {
+	mu     sync.Mutex
+	errors []domain.Error
+	infos  []string
+	warns  []map[string]interface{}
+}
+
+func (s *stubLogger) Error(_ context.Context, err domain.Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = append(s.errors, err)
+}
+
+func (s *stubLogger) Infof(_ context.Context, msg string, _ map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.infos = append(s.infos, msg)
+}
+
+func (s *stubLogger) Warnf(_ context.Context, _ string, md map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.warns = append(s.warns, md)
+}
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	before := domain.StreetMarket{ID: "c882edc1-c1f3-4b20-b8f6-36156d99bc48", IDdist: "12", Region5: "Oeste"}
+	after := domain.StreetMarket{ID: before.ID, IDdist: "87", Region5: "Leste", Name: "VILA"}
+	ev := domain.MarketEvent{
+		ID:         "5c1b3e1a-6f0e-4a57-9d2c-3b8e7f6a5d4c",
+		Type:       domain.MarketUpdatedEvent,
+		MarketID:   before.ID,
+		Before:     &before,
+		After:      &after,
+		OccurredAt: &now,
+	}
+
+	repoMock := &stubRepositoryPublisher{
+		listByEvent: func(context.Context, domain.EventType) ([]domain.WebhookSubscription, *domain.Error) {
+			return []domain.WebhookSubscription{
+				{ID: "sub-all", Events: []domain.EventType{domain.MarketUpdatedEvent}},
+				{ID: "sub-oeste", Events: []domain.EventType{domain.MarketUpdatedEvent}, Region5: "Oeste"},
+				{ID: "sub-sul", Events: []domain.EventType{domain.MarketUpdatedEvent}, Region5: "Sul"},
+				{ID: "sub-87", Events: domain.EventTypes(), IDdist: "87"},
+			}, nil
+		},
+		createDeliveries: func(context.Context, []domain.WebhookDelivery) *domain.Error {
+			return nil
+		},
+	}
+
+	p := NewPublisher(repoMock)
+
+	if err := p.Publish(context.TODO(), ev); err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+
+	body, err := json.Marshal(Payload{
+		ID:         ev.ID,
+		Event:      domain.MarketUpdatedEvent,
+		OccurredAt: now,
+		Data:       outbox.MarketData{ID: after.ID, IDdist: "87", Region5: "Leste", Name: "VILA"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []domain.WebhookDelivery{}
+	for _, sub := range []string{"sub-all", "sub-oeste", "sub-87"} {
+		want = append(want, domain.WebhookDelivery{
+			ID:           deliveryID(ev.ID, sub),
+			Subscription: domain.WebhookSubscription{ID: sub},
+			Event:        domain.MarketUpdatedEvent,
+			Payload:      body,
+		})
+	}
+	if diff := cmp.Diff(want, repoMock.createInp); diff != "" {
+		t.Errorf("unexpected deliveries (-want +got):\n%s", diff)
+	}
+}
+
+func TestWebhookPublisher_Publish_Error(t *testing.T) {
+	testCases := map[string]struct {
+		listErr   *domain.Error
+		createErr *domain.Error
+	}{
+		"When unexpected error occurs listing subscriptions": {
+			listErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+		},
+		"When unexpected error occurs queueing deliveries": {
+			createErr: &domain.Error{Kind: domain.UnexpectedErrKd},
+		},
+	}
+
+	for title, tc := range testCases {
+		t.Run(title, func(t *testing.T) {
+			repoMock := &stubRepositoryPublisher{
+				listByEvent: func(context.Context, domain.EventType) ([]domain.WebhookSubscription, *domain.Error) {
+					return []domain.WebhookSubscription{{ID: "sub", Events: domain.EventTypes()}}, tc.listErr
+				},
+				createDeliveries: func(context.Context, []domain.WebhookDelivery) *domain.Error {
+					return tc.createErr
+				},
+			}
+
+			p := NewPublisher(repoMock)
+
+			gErr := p.Publish(context.TODO(), domain.MarketEvent{ID: "e1", Type: domain.MarketDeletedEvent})
+			if gErr == nil || gErr.Kind != domain.UnexpectedErrKd {
+				t.Errorf("Want error kind %v, got error %v", domain.UnexpectedErrKd, gErr)
+			}
+		})
+	}
+}
+
+func TestWebhookPublisher_Publish_NoMatch(t *testing.T) {
+	repoMock := &stubRepositoryPublisher{
+		listByEvent: func(context.Context, domain.EventType) ([]domain.WebhookSubscription, *domain.Error) {
+			return []domain.WebhookSubscription{{ID: "sub", Events: domain.EventTypes(), IDdist: "12"}}, nil
+		},
+		createDeliveries: func(context.Context, []domain.WebhookDelivery) *domain.Error {
+			t.Error("expect no delivery queued")
+			return nil
+		},
+	}
+
+	p := NewPublisher(repoMock)
+
+	ev := domain.MarketEvent{ID: "e1", Type: domain.MarketCreatedEvent, After: &domain.StreetMarket{IDdist: "87"}}
+	if err := p.Publish(context.TODO(), ev); err != nil {
+		t.Fatalf("expect return nil, got %v", err)
+	}
+}
+
+func TestDeliveryID(t *testing.T) {
+	if deliveryID("e1", "sub") != deliveryID("e1", "sub") {
+		t.Error("expect the same delivery id for the same event and subscription")
+	}
+
+	if deliveryID("e1", "sub") == deliveryID("e1", "other") {
+		t.Error("expect a delivery id per subscription")
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/Danielsilveira98/unicoAPITest/internal/domain"
 	"github.com/Danielsilveira98/unicoAPITest/internal/pkg/repository"
@@ -19,6 +21,8 @@ import (
 	"github.com/pressly/goose/v3"
 )
 
+var editionRgx = regexp.MustCompile(`_(\d{4})\.csv$`)
+
 func main() {
 	ctx := context.Background()
 
@@ -53,7 +57,9 @@ func main() {
 	}
 
 	repo := repository.NewStreetMarketRepository(db)
-	srv := streetmarket.NewWriter(repo, uuid.NewString)
+	refRepo := repository.NewReferenceRepository(db)
+	srv := streetmarket.NewWriter(repo, refRepo, uuid.NewString)
+	snapRepo := repository.NewSnapshotRepository(db)
 
 	dataPath := os.Getenv("DATA_PATH")
 	files, err := ioutil.ReadDir(dataPath)
@@ -69,6 +75,17 @@ func main() {
 			continue
 		}
 
+		if m := editionRgx.FindStringSubmatch(file.Name()); m != nil {
+			edition, _ := strconv.Atoi(m[1])
+			// A register the file repeats, or that a previous run loaded, is
+			// kept as first loaded.
+			for _, sm := range sms {
+				if err := snapRepo.Create(ctx, toSnapshot(edition, sm)); err != nil {
+					fmt.Println(err)
+				}
+			}
+		}
+
 		for _, sm := range sms {
 			if err := sm.Validate(); err != nil {
 				fmt.Println(err)
@@ -104,6 +121,10 @@ func processFile(path string) ([]domain.StreetMarketCreateInput, error) {
 
 	reader := csv.NewReader(csvFile)
 	reader.LazyQuotes = true
+	// The 2013 edition is published with ";" as separator
+	if strings.Contains(string(row1), ";") {
+		reader.Comma = ';'
+	}
 
 	csvLines, err := reader.ReadAll()
 	if err != nil {
@@ -136,7 +157,7 @@ func processFile(path string) ([]domain.StreetMarketCreateInput, error) {
 			Lat:           lat,
 			SectCens:      line[3],
 			Area:          line[4],
-			IDdist:        line[6],
+			IDdist:        line[5],
 			District:      line[6],
 			IDSubTH:       line[7],
 			SubTownHall:   line[8],
@@ -154,3 +175,25 @@ func processFile(path string) ([]domain.StreetMarketCreateInput, error) {
 
 	return sms, nil
 }
+
+func toSnapshot(edition int, sm domain.StreetMarketCreateInput) domain.StreetMarketSnapshot {
+	return domain.StreetMarketSnapshot{
+		Edition:       edition,
+		Long:          sm.Long,
+		Lat:           sm.Lat,
+		SectCens:      sm.SectCens,
+		Area:          sm.Area,
+		IDdist:        sm.IDdist,
+		District:      sm.District,
+		IDSubTH:       sm.IDSubTH,
+		SubTownHall:   sm.SubTownHall,
+		Region5:       sm.Region5,
+		Region8:       sm.Region8,
+		Name:          sm.Name,
+		Register:      sm.Register,
+		Street:        sm.Street,
+		Number:        sm.Number,
+		Neighborhood:  sm.Neighborhood,
+		AddrExtraInfo: sm.AddrExtraInfo,
+	}
+}